@@ -12,6 +12,7 @@ import (
 
 	"gestalt/internal/cli"
 	"gestalt/internal/client"
+	"gestalt/internal/flow"
 )
 
 const defaultServerHost = "127.0.0.1"
@@ -42,6 +43,7 @@ func parseArgs(args []string, errOut io.Writer) (Config, error) {
 	timeoutFlag := fs.Duration("timeout", defaultNotifyTimeout, "Request timeout")
 	verboseFlag := fs.Bool("verbose", false, "Verbose output")
 	debugFlag := fs.Bool("debug", false, "Debug output (implies --verbose)")
+	allowUnknownTypeFlag := fs.Bool("allow-unknown-type", false, "Send payload types not in the known list")
 	helpVersion := cli.AddHelpVersionFlags(fs, "Show this help message", "Print version and exit")
 	fs.Usage = func() {
 		printNotifyHelp(fs.Output())
@@ -112,6 +114,9 @@ func parseArgs(args []string, errOut io.Writer) (Config, error) {
 	if err != nil {
 		return Config{}, notifyErr(exitCodeInvalidPayload, err.Error())
 	}
+	if typeText, _ := payloadMap["type"].(string); !*allowUnknownTypeFlag && !flow.IsKnownNotifyType(typeText) {
+		return Config{}, notifyErrf(exitCodeUsage, "unknown payload type %q (allowed: %s; use --allow-unknown-type to send anyway)", strings.TrimSpace(typeText), strings.Join(flow.KnownNotifyTypes(), ", "))
+	}
 
 	occurredAt := (*time.Time)(nil)
 	if payloadMap != nil {
@@ -186,12 +191,16 @@ func printNotifyHelp(out io.Writer) {
 	writeNotifyOption(out, "--timeout DURATION", "Request timeout (default: 2s)")
 	writeNotifyOption(out, "--verbose", "Verbose output")
 	writeNotifyOption(out, "--debug", "Debug output (implies --verbose)")
+	writeNotifyOption(out, "--allow-unknown-type", "Send payload types not in the known list")
 	writeNotifyOption(out, "--help", "Show this help message")
 	writeNotifyOption(out, "--version", "Print version and exit")
 	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Modes:")
 	fmt.Fprintln(out, "  Manual: pass JSON payload as the final argument (use '-' for stdin)")
 	fmt.Fprintln(out, "")
+	fmt.Fprintln(out, "Payload types:")
+	fmt.Fprintf(out, "  %s\n", strings.Join(flow.KnownNotifyTypes(), ", "))
+	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Examples:")
 	fmt.Fprintln(out, "  gestalt-notify --session-id 'Coder 1' '{\"type\":\"agent-turn-complete\"}'")
 	fmt.Fprintln(out, "  echo '{\"type\":\"plan-L1-wip\",\"plan_file\":\"plan.org\"}' | gestalt-notify --session-id 'Coder 1' -")
@@ -206,7 +215,7 @@ func printNotifyHelp(out io.Writer) {
 }
 
 func writeNotifyOption(out io.Writer, name, desc string) {
-	fmt.Fprintf(out, "  %-18s %s\n", name, desc)
+	fmt.Fprintf(out, "  %-20s %s\n", name, desc)
 }
 
 func buildServerURL(host string, port int) string {
@@ -2,10 +2,13 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"strings"
 	"testing"
 	"time"
+
+	"gestalt/internal/flow"
 )
 
 func TestParseArgsMissingSessionID(t *testing.T) {
@@ -183,3 +186,52 @@ func TestParseArgsPayloadTypeRequired(t *testing.T) {
 		t.Fatalf("expected payload type error, got %v", err)
 	}
 }
+
+func TestParseArgsRejectsUnknownType(t *testing.T) {
+	var stderr bytes.Buffer
+	_, err := parseArgs([]string{"--session-id", "term-1", `{"type":"plna-update"}`}, &stderr)
+	var notifyErr *notifyError
+	if !errors.As(err, &notifyErr) || notifyErr.Code != exitCodeUsage {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+	if !strings.Contains(notifyErr.Message, "--allow-unknown-type") || !strings.Contains(notifyErr.Message, "plan-update") {
+		t.Fatalf("expected allowed types in message, got %q", notifyErr.Message)
+	}
+}
+
+func TestParseArgsAllowUnknownType(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseArgs([]string{"--session-id", "term-1", "--allow-unknown-type", `{"type":"future-event"}`}, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Payload) == 0 {
+		t.Fatalf("expected payload to be set")
+	}
+}
+
+func TestIsKnownNotifyType(t *testing.T) {
+	for _, value := range []string{"agent-turn-complete", "Progress", "plan-L1-wip", "work_start", "commit", "prompt-text"} {
+		if !flow.IsKnownNotifyType(value) {
+			t.Fatalf("expected %q to be known", value)
+		}
+	}
+	for _, value := range []string{"", "progres", "deploy", "work-later"} {
+		if flow.IsKnownNotifyType(value) {
+			t.Fatalf("expected %q to be unknown", value)
+		}
+	}
+}
+
+func TestNotifyHelpListsPayloadTypes(t *testing.T) {
+	var out bytes.Buffer
+	printNotifyHelp(&out)
+	if !strings.Contains(out.String(), "Payload types:") || !strings.Contains(out.String(), "--allow-unknown-type") {
+		t.Fatalf("expected payload types in help, got %q", out.String())
+	}
+	for _, name := range flow.KnownNotifyTypes() {
+		if !strings.Contains(out.String(), name) {
+			t.Fatalf("expected help to list %q, got %q", name, out.String())
+		}
+	}
+}
@@ -7,7 +7,7 @@ import (
 
 func runCompletion(args []string, out io.Writer, errOut io.Writer) int {
 	if len(args) != 1 {
-		fmt.Fprintln(errOut, "usage: gestalt-send completion [bash|zsh]")
+		fmt.Fprintln(errOut, "usage: gestalt-send completion [bash|zsh|fish|powershell]")
 		return 1
 	}
 	switch args[0] {
@@ -17,8 +17,14 @@ func runCompletion(args []string, out io.Writer, errOut io.Writer) int {
 	case "zsh":
 		_, _ = io.WriteString(out, zshCompletionScript)
 		return 0
+	case "fish":
+		_, _ = io.WriteString(out, fishCompletionScript)
+		return 0
+	case "powershell":
+		_, _ = io.WriteString(out, powershellCompletionScript)
+		return 0
 	default:
-		fmt.Fprintln(errOut, "usage: gestalt-send completion [bash|zsh]")
+		fmt.Fprintln(errOut, "usage: gestalt-send completion [bash|zsh|fish|powershell]")
 		return 1
 	}
 }
@@ -29,17 +35,17 @@ _gestalt_send_complete() {
   _init_completion || return
 
   if [[ "$cword" -eq 1 ]]; then
-    COMPREPLY=( $(compgen -W "completion --help --version --host --port --token --verbose --debug" -- "$cur") )
+    COMPREPLY=( $(compgen -W "completion --help --version --host --port --token --id --content-type --verbose --debug" -- "$cur") )
     return
   fi
 
   if [[ "$prev" == "completion" ]]; then
-    COMPREPLY=( $(compgen -W "bash zsh" -- "$cur") )
+    COMPREPLY=( $(compgen -W "bash zsh fish powershell" -- "$cur") )
     return
   fi
 
   if [[ "$cur" == -* ]]; then
-    COMPREPLY=( $(compgen -W "--help --version --host --port --token --verbose --debug" -- "$cur") )
+    COMPREPLY=( $(compgen -W "--help --version --host --port --token --id --content-type --verbose --debug" -- "$cur") )
     return
   fi
 }
@@ -55,6 +61,8 @@ _gestalt_send() {
     '--host[Gestalt server host]:HOST'
     '--port[Gestalt server port]:PORT'
     '--token[Auth token]:TOKEN'
+    '--id[Treat the argument as an exact session id]'
+    '--content-type[Content-Type of the payload]:TYPE'
     '--verbose[Verbose output]'
     '--debug[Debug output]'
     '--help[Show help]'
@@ -70,10 +78,43 @@ _gestalt_send() {
       _values 'subcommand' completion
       ;;
     args)
-      _values 'shell' bash zsh
+      _values 'shell' bash zsh fish powershell
       ;;
   esac
 }
 
 _gestalt_send "$@"
 `
+
+const fishCompletionScript = `# Fish completion for gestalt-send
+complete -c gestalt-send -f
+complete -c gestalt-send -n '__fish_use_subcommand' -a completion -d 'Print shell completion script'
+complete -c gestalt-send -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish powershell'
+complete -c gestalt-send -l host -r -d 'Gestalt server host'
+complete -c gestalt-send -l port -r -d 'Gestalt server port'
+complete -c gestalt-send -l token -r -d 'Auth token'
+complete -c gestalt-send -l id -d 'Treat the argument as an exact session id'
+complete -c gestalt-send -l content-type -r -d 'Content-Type of the payload'
+complete -c gestalt-send -l verbose -d 'Verbose output'
+complete -c gestalt-send -l debug -d 'Debug output'
+complete -c gestalt-send -l help -d 'Show help'
+complete -c gestalt-send -l version -d 'Print version'
+`
+
+const powershellCompletionScript = `# PowerShell completion for gestalt-send
+Register-ArgumentCompleter -Native -CommandName gestalt-send -ScriptBlock {
+  param($wordToComplete, $commandAst, $cursorPosition)
+
+  $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })
+  $candidates = @('completion', '--help', '--version', '--host', '--port', '--token', '--id', '--content-type', '--verbose', '--debug')
+  if ($words.Count -ge 2 -and $words[1] -eq 'completion') {
+    $candidates = @('bash', 'zsh', 'fish', 'powershell')
+  } elseif ($wordToComplete -like '-*') {
+    $candidates = @('--help', '--version', '--host', '--port', '--token', '--id', '--content-type', '--verbose', '--debug')
+  }
+
+  $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+    [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+  }
+}
+`
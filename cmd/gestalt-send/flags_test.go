@@ -29,6 +29,26 @@ func TestParseArgsDefaults(t *testing.T) {
 	if cfg.Debug {
 		t.Fatalf("expected debug false")
 	}
+	if cfg.URLSource != "default" || cfg.TokenSource != "none" {
+		t.Fatalf("expected default sources, got url=%q token=%q", cfg.URLSource, cfg.TokenSource)
+	}
+	if cfg.ContentType != "application/octet-stream" {
+		t.Fatalf("expected octet-stream content type, got %q", cfg.ContentType)
+	}
+}
+
+func TestParseArgsContentType(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg, err := parseArgs([]string{"--content-type", "application/json", "s-1"}, &stderr)
+	if err != nil {
+		t.Fatalf("parse args: %v", err)
+	}
+	if cfg.ContentType != "application/json" {
+		t.Fatalf("expected application/json, got %q", cfg.ContentType)
+	}
+	if _, err := parseArgs([]string{"--content-type", "not a type", "s-1"}, &stderr); err == nil {
+		t.Fatalf("expected invalid content type to be rejected")
+	}
 }
 
 func TestParseArgsFlagOverridesEnv(t *testing.T) {
@@ -52,6 +72,9 @@ func TestParseArgsFlagOverridesEnv(t *testing.T) {
 	if cfg.Token != "override-token" {
 		t.Fatalf("expected override token, got %q", cfg.Token)
 	}
+	if cfg.URLSource != "flag" || cfg.TokenSource != "flag" {
+		t.Fatalf("expected flag sources, got url=%q token=%q", cfg.URLSource, cfg.TokenSource)
+	}
 	if !cfg.Verbose {
 		t.Fatalf("expected verbose true")
 	}
@@ -67,7 +90,7 @@ func TestParseArgsHelp(t *testing.T) {
 		t.Fatalf("expected ErrHelp, got %v", err)
 	}
 	help := stderr.String()
-	if !strings.Contains(help, "Usage: gestalt-send [options] <session-ref>") {
+	if !strings.Contains(help, "Usage: gestalt-send [options] [session-ref]") {
 		t.Fatalf("expected positional usage, got %q", help)
 	}
 	if strings.Contains(help, "--session-id ID") {
@@ -118,10 +141,17 @@ func TestParseArgsInvalidFlag(t *testing.T) {
 	}
 }
 
-func TestParseArgsRejectsMissingPositionalArgument(t *testing.T) {
+func TestParseArgsMissingSessionRefUsesDefaultAgent(t *testing.T) {
 	var stderr bytes.Buffer
-	if _, err := parseArgs([]string{}, &stderr); err == nil {
-		t.Fatalf("expected error")
+	cfg, err := parseArgs([]string{}, &stderr)
+	if err != nil {
+		t.Fatalf("parse args: %v", err)
+	}
+	if cfg.SessionRef != "" {
+		t.Fatalf("expected empty session ref, got %q", cfg.SessionRef)
+	}
+	if _, err := parseArgs([]string{"--id"}, &stderr); err == nil {
+		t.Fatalf("expected --id without a session ref to fail")
 	}
 }
 
@@ -157,4 +187,51 @@ func TestCompletionScriptsDoNotExposeLegacySessionIDFlag(t *testing.T) {
 	if strings.Contains(zshCompletionScript, "--session-id") {
 		t.Fatalf("zsh completion must not include --session-id")
 	}
+	if strings.Contains(fishCompletionScript, "session-id") {
+		t.Fatalf("fish completion must not include --session-id")
+	}
+	if strings.Contains(powershellCompletionScript, "--session-id") {
+		t.Fatalf("powershell completion must not include --session-id")
+	}
+}
+
+func TestRunCompletionShells(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		var stdout, stderr bytes.Buffer
+		if code := runCompletion([]string{shell}, &stdout, &stderr); code != 0 {
+			t.Fatalf("%s: expected exit 0, got %d (%s)", shell, code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "gestalt-send") {
+			t.Fatalf("%s: expected completion script, got %q", shell, stdout.String())
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runCompletion([]string{"tcsh"}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit 1 for unsupported shell, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "bash|zsh|fish|powershell") {
+		t.Fatalf("expected usage to list all shells, got %q", stderr.String())
+	}
+}
+
+func TestRunWithSenderDebugLogsResolvedConfig(t *testing.T) {
+	t.Setenv("GESTALT_TOKEN", "env-secret")
+	var stderr bytes.Buffer
+	code := runWithSender([]string{"--debug", "s-1"}, strings.NewReader(""), &stderr, func(Config, []byte) error {
+		return nil
+	})
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	output := stderr.String()
+	if !strings.Contains(output, "server url: http://127.0.0.1:57417 (source: default)") {
+		t.Fatalf("expected resolved url in debug output, got %q", output)
+	}
+	if !strings.Contains(output, "auth token: en******et (source: env)") {
+		t.Fatalf("expected masked token source in debug output, got %q", output)
+	}
+	if strings.Contains(output, "env-secret") {
+		t.Fatalf("expected token to be masked, got %q", output)
+	}
 }
@@ -47,27 +47,23 @@ func sendInput(cfg Config, payload []byte) error {
 }
 
 func sendSessionInput(cfg Config, payload []byte) error {
+	baseURL := strings.TrimRight(cfg.URL, "/")
 	sessionRef := strings.TrimSpace(cfg.SessionRef)
 	if sessionRef == "" {
-		return sendErr(2, "session reference is required")
-	}
-	baseURL := strings.TrimRight(cfg.URL, "/")
-	sessions, err := client.FetchSessions(httpClient, baseURL, cfg.Token)
-	if err != nil {
-		var httpErr *client.HTTPError
-		if errors.As(err, &httpErr) {
-			return sendErr(3, httpErr.Message)
+		defaultRef, err := resolveDefaultAgentRef(cfg, baseURL)
+		if err != nil {
+			return err
 		}
-		return sendErrf(3, "%v", err)
+		sessionRef = defaultRef
 	}
-	sessionID, err := client.ResolveSessionRefAgainstSessions(sessionRef, sessions)
+	sessionID, err := resolveSessionID(cfg, baseURL, sessionRef)
 	if err != nil {
-		return sendErr(2, err.Error())
+		return err
 	}
 
 	target := fmt.Sprintf("%s/api/sessions/%s/input", baseURL, sessionID)
 	if cfg.Verbose {
-		logf(cfg, "sending %d bytes to session %q (from %q) at %s", len(payload), sessionID, sessionRef, target)
+		logf(cfg, "sending %d bytes (%s) to session %q (from %q) at %s", len(payload), cfg.ContentType, sessionID, sessionRef, target)
 		if strings.TrimSpace(cfg.Token) != "" {
 			logf(cfg, "token: %s", maskToken(cfg.Token, cfg.Debug))
 		}
@@ -80,7 +76,7 @@ func sendSessionInput(cfg Config, payload []byte) error {
 		logf(cfg, "payload preview: %q", string(preview))
 	}
 
-	if err := client.SendSessionInput(httpClient, baseURL, cfg.Token, sessionID, payload); err != nil {
+	if err := client.SendSessionInputAs(httpClient, baseURL, cfg.Token, sessionID, cfg.ContentType, payload); err != nil {
 		var httpErr *client.HTTPError
 		if errors.As(err, &httpErr) {
 			if cfg.Verbose && httpErr.StatusCode != 0 {
@@ -99,6 +95,48 @@ func sendSessionInput(cfg Config, payload []byte) error {
 	return nil
 }
 
+// resolveDefaultAgentRef returns the name of the server's default agent, to
+// be resolved like any other short session ref.
+func resolveDefaultAgentRef(cfg Config, baseURL string) (string, error) {
+	defaultAgent, err := client.FetchDefaultAgent(httpClient, baseURL, cfg.Token)
+	if err != nil {
+		var httpErr *client.HTTPError
+		if errors.As(err, &httpErr) {
+			return "", sendErr(3, httpErr.Message)
+		}
+		return "", sendErrf(3, "%v", err)
+	}
+	if defaultAgent.AgentID == "" {
+		return "", sendErr(1, "no session-ref given and no default agent set (PUT /api/agents/default)")
+	}
+	if defaultAgent.Missing || defaultAgent.Name == "" {
+		return "", sendErrf(2, "default agent %q not found", defaultAgent.AgentID)
+	}
+	logf(cfg, "using default agent %q (%s)", defaultAgent.Name, defaultAgent.AgentID)
+	return defaultAgent.Name, nil
+}
+
+// resolveSessionID maps a session ref to a session id. With --id the ref is
+// used as is; otherwise the session list is fetched to resolve short names.
+func resolveSessionID(cfg Config, baseURL, sessionRef string) (string, error) {
+	if cfg.DirectID {
+		return sessionRef, nil
+	}
+	sessions, err := client.FetchSessions(httpClient, baseURL, cfg.Token)
+	if err != nil {
+		var httpErr *client.HTTPError
+		if errors.As(err, &httpErr) {
+			return "", sendErr(3, httpErr.Message)
+		}
+		return "", sendErrf(3, "%v", err)
+	}
+	sessionID, err := client.ResolveSessionRefAgainstSessions(sessionRef, sessions)
+	if err != nil {
+		return "", sendErr(2, err.Error())
+	}
+	return sessionID, nil
+}
+
 func logf(cfg Config, format string, args ...any) {
 	if cfg.LogWriter == nil || !(cfg.Verbose || cfg.Debug) {
 		return
@@ -106,6 +144,17 @@ func logf(cfg Config, format string, args ...any) {
 	fmt.Fprintf(cfg.LogWriter, format+"\n", args...)
 }
 
+// logResolvedConfig reports which URL and token won, and where each came
+// from, so a send that hits the wrong server is easy to explain.
+func logResolvedConfig(cfg Config) {
+	logf(cfg, "server url: %s (source: %s)", cfg.URL, cfg.URLSource)
+	if strings.TrimSpace(cfg.Token) == "" {
+		logf(cfg, "auth token: none")
+		return
+	}
+	logf(cfg, "auth token: %s (source: %s)", maskToken(cfg.Token, false), cfg.TokenSource)
+}
+
 func maskToken(token string, debug bool) string {
 	if debug {
 		return token
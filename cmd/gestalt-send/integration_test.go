@@ -136,6 +136,44 @@ func TestGestaltSendEndToEnd(t *testing.T) {
 	})
 }
 
+func TestGestaltSendJSONContentTypeDeliveredUnchanged(t *testing.T) {
+	manager := terminal.NewManager(terminal.ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	session := terminal.NewExternalSession("mcp-1", "MCP", "", time.Now(), 10, 0, terminal.OutputBackpressureBlock, 0, nil, nil, nil)
+	writes := make(chan []byte, 1)
+	if err := session.AttachExternalRunner(func(data []byte) error {
+		writes <- append([]byte(nil), data...)
+		return nil
+	}, nil, nil); err != nil {
+		t.Fatalf("attach external runner: %v", err)
+	}
+	manager.RegisterSession(session)
+
+	mux := http.NewServeMux()
+	api.RegisterRoutes(mux, manager, "", api.StatusConfig{}, "", nil, nil, nil, nil)
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, req)
+		return recorder.Result(), nil
+	})
+
+	payload := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`
+	withMockClient(t, transport, func() {
+		var stderr bytes.Buffer
+		code := runWithSender([]string{"--id", "--content-type", "application/json", session.ID}, strings.NewReader(payload), &stderr, sendInput)
+		if code != 0 {
+			t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+	})
+	select {
+	case got := <-writes:
+		if string(got) != payload {
+			t.Fatalf("expected payload %q, got %q", payload, got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for session input")
+	}
+}
+
 func TestTmuxMockCodexFixtureEchoesInput(t *testing.T) {
 	tmpDir := t.TempDir()
 	binPath := installMockCodexBinary(t, tmpDir)
@@ -44,6 +44,9 @@ func runWithSender(args []string, in io.Reader, errOut io.Writer, send func(Conf
 		cfg.Verbose = true
 	}
 	cfg.LogWriter = errOut
+	if cfg.Debug {
+		logResolvedConfig(cfg)
+	}
 
 	payload, err := io.ReadAll(in)
 	if err != nil {
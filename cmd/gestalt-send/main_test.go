@@ -183,6 +183,56 @@ func TestRunWithSenderNameResolvesToCanonicalSession(t *testing.T) {
 	})
 }
 
+func TestRunWithSenderDefaultAgent(t *testing.T) {
+	defaultAgent := `{"agent_id":"fixer","name":"Fixer"}`
+	sawInput := false
+	withMockClient(t, func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/api/agents/default":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(defaultAgent)),
+				Header:     make(http.Header),
+				Request:    r,
+			}, nil
+		case "/api/sessions":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`[{"id":"Fixer 1"}]`)),
+				Header:     make(http.Header),
+				Request:    r,
+			}, nil
+		case "/api/sessions/Fixer 1/input":
+			sawInput = true
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     make(http.Header),
+				Request:    r,
+			}, nil
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		return nil, nil
+	}, func() {
+		var stderr bytes.Buffer
+		code := runWithSender([]string{}, strings.NewReader("hi"), &stderr, sendInput)
+		if code != 0 {
+			t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		if !sawInput {
+			t.Fatalf("expected input call")
+		}
+
+		defaultAgent = `{"agent_id":""}`
+		stderr.Reset()
+		code = runWithSender([]string{}, strings.NewReader("hi"), &stderr, sendInput)
+		if code != 1 || !strings.Contains(stderr.String(), "no default agent set") {
+			t.Fatalf("expected usage error without a default agent, got %d: %s", code, stderr.String())
+		}
+	})
+}
+
 func TestHandleSendErrorMapping(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -210,7 +260,7 @@ func TestHandleSendErrorMapping(t *testing.T) {
 func TestRunWithSenderNonZeroWritesStderr(t *testing.T) {
 	t.Run("usage error", func(t *testing.T) {
 		var stderr bytes.Buffer
-		code := runWithSender([]string{}, strings.NewReader(""), &stderr, nil)
+		code := runWithSender([]string{"s-1", "s-2"}, strings.NewReader(""), &stderr, nil)
 		if code != 1 {
 			t.Fatalf("expected exit code 1, got %d", code)
 		}
@@ -264,3 +314,66 @@ func TestRunWithSenderNonZeroWritesStderr(t *testing.T) {
 		})
 	})
 }
+
+func TestRunWithSenderDirectIDSkipsSessionList(t *testing.T) {
+	sawInput := false
+	withMockClient(t, func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/api/sessions/Fixer 1/input" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		sawInput = true
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+			Request:    r,
+		}, nil
+	}, func() {
+		var stderr bytes.Buffer
+		code := runWithSender([]string{"--id", "Fixer 1"}, strings.NewReader("hi"), &stderr, sendInput)
+		if code != 0 {
+			t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		if !sawInput {
+			t.Fatalf("expected input call")
+		}
+	})
+}
+
+func TestRunWithSenderContentType(t *testing.T) {
+	contentType := ""
+	withMockClient(t, func(r *http.Request) (*http.Response, error) {
+		contentType = r.Header.Get("Content-Type")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+			Request:    r,
+		}, nil
+	}, func() {
+		var stderr bytes.Buffer
+		code := runWithSender([]string{"--id", "--content-type", "application/json", "Fixer 1"}, strings.NewReader(`{"jsonrpc":"2.0","method":"ping"}`), &stderr, sendInput)
+		if code != 0 {
+			t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		if contentType != "application/json" {
+			t.Fatalf("expected application/json, got %q", contentType)
+		}
+	})
+}
+
+func TestRunWithSenderDirectIDMissingSession(t *testing.T) {
+	withMockClient(t, func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"message":"terminal not found"}`)),
+			Header:     make(http.Header),
+			Request:    r,
+		}, nil
+	}, func() {
+		var stderr bytes.Buffer
+		if code := runWithSender([]string{"--id", "Fixer"}, strings.NewReader("hi"), &stderr, sendInput); code != 2 {
+			t.Fatalf("expected exit code 2, got %d: %s", code, stderr.String())
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"mime"
 	"os"
 	"strconv"
 	"strings"
@@ -18,7 +19,15 @@ const defaultServerPort = 57417
 type Config struct {
 	URL         string
 	Token       string
+	URLSource   string
+	TokenSource string
 	SessionRef  string
+	// DirectID sends to SessionRef as an exact session id, skipping the
+	// session list fetch used to resolve names.
+	DirectID bool
+	// ContentType labels the payload, e.g. application/json for structured
+	// input to MCP sessions.
+	ContentType string
 	Verbose     bool
 	Debug       bool
 	ShowVersion bool
@@ -33,6 +42,8 @@ func parseArgs(args []string, errOut io.Writer) (Config, error) {
 	tokenFlag := fs.String("token", "", "Auth token (env: GESTALT_TOKEN, default: none)")
 	verboseFlag := fs.Bool("verbose", false, "Verbose output")
 	debugFlag := fs.Bool("debug", false, "Debug output (implies --verbose)")
+	idFlag := fs.Bool("id", false, "Treat <session-ref> as an exact session id (skips name resolution)")
+	contentTypeFlag := fs.String("content-type", client.DefaultInputContentType, "Content-Type of the sent payload")
 	helpVersion := cli.AddHelpVersionFlags(fs, "Show this help message", "Print version and exit")
 	fs.Usage = func() {
 		printSendHelp(fs.Output())
@@ -51,23 +62,43 @@ func parseArgs(args []string, errOut io.Writer) (Config, error) {
 		return Config{ShowVersion: true}, nil
 	}
 
-	if fs.NArg() != 1 {
+	if fs.NArg() > 1 {
 		fs.Usage()
-		return Config{}, fmt.Errorf("expected exactly one positional argument: <session-ref>")
+		return Config{}, fmt.Errorf("expected at most one positional argument: <session-ref>")
 	}
 
-	sessionRef := strings.TrimSpace(fs.Arg(0))
-	normalizedSessionRef, err := client.NormalizeSessionRef(sessionRef)
-	if err != nil {
+	// Without a session ref, the send goes to the server's default agent.
+	sessionRef := ""
+	if fs.NArg() == 1 {
+		normalizedSessionRef, err := client.NormalizeSessionRef(fs.Arg(0))
+		if err != nil {
+			fs.Usage()
+			return Config{}, err
+		}
+		sessionRef = normalizedSessionRef
+	} else if *idFlag {
 		fs.Usage()
-		return Config{}, err
+		return Config{}, fmt.Errorf("--id requires <session-ref>")
 	}
-	sessionRef = normalizedSessionRef
 	if *portFlag <= 0 || *portFlag > 65535 {
 		fs.Usage()
 		return Config{}, fmt.Errorf("port must be between 1 and 65535")
 	}
+	contentType := strings.TrimSpace(*contentTypeFlag)
+	if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		fs.Usage()
+		return Config{}, fmt.Errorf("invalid --content-type %q", contentType)
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
 
+	urlSource := "default"
+	if set["host"] || set["port"] {
+		urlSource = "flag"
+	}
 	host := strings.TrimSpace(*hostFlag)
 	if host == "" {
 		host = defaultServerHost
@@ -75,28 +106,40 @@ func parseArgs(args []string, errOut io.Writer) (Config, error) {
 	baseURL := buildServerURL(host, *portFlag)
 
 	token := strings.TrimSpace(*tokenFlag)
+	tokenSource := "flag"
 	if token == "" {
 		token = strings.TrimSpace(os.Getenv("GESTALT_TOKEN"))
+		tokenSource = "env"
+	}
+	if token == "" {
+		tokenSource = "none"
 	}
 
 	return Config{
-		URL:       baseURL,
-		Token:     token,
-		SessionRef: sessionRef,
-		Verbose:   *verboseFlag,
-		Debug:     *debugFlag,
+		URL:         baseURL,
+		Token:       token,
+		URLSource:   urlSource,
+		TokenSource: tokenSource,
+		SessionRef:  sessionRef,
+		DirectID:    *idFlag,
+		ContentType: contentType,
+		Verbose:     *verboseFlag,
+		Debug:       *debugFlag,
 	}, nil
 }
 
 func printSendHelp(out io.Writer) {
-	fmt.Fprintln(out, "Usage: gestalt-send [options] <session-ref>")
+	fmt.Fprintln(out, "Usage: gestalt-send [options] [session-ref]")
 	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Send stdin to a running Gestalt session")
+	fmt.Fprintln(out, "Without a session-ref, stdin goes to the server's default agent.")
 	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Options:")
 	writeSendOption(out, "--host HOST", "Gestalt server host (default: 127.0.0.1)")
 	writeSendOption(out, "--port PORT", "Gestalt server port (default: 57417)")
 	writeSendOption(out, "--token TOKEN", "Auth token (env: GESTALT_TOKEN, default: none)")
+	writeSendOption(out, "--id", "Treat <session-ref> as an exact session id (skips name resolution)")
+	writeSendOption(out, "--content-type TYPE", "Content-Type of the payload (default: application/octet-stream)")
 	writeSendOption(out, "--verbose", "Show request/response details")
 	writeSendOption(out, "--debug", "Show detailed debug info (implies --verbose)")
 	writeSendOption(out, "--help", "Show this help message")
@@ -105,6 +148,8 @@ func printSendHelp(out io.Writer) {
 	fmt.Fprintln(out, "Examples:")
 	fmt.Fprintln(out, "  echo \"status\" | gestalt-send \"Fixer\"")
 	fmt.Fprintln(out, "  cat file.txt | gestalt-send --host remote --port 57417 --token abc123 \"Fixer 1\"")
+	fmt.Fprintln(out, "  echo \"status\" | gestalt-send --id \"Fixer 1\"")
+	fmt.Fprintln(out, "  echo '{\"jsonrpc\": \"2.0\", \"method\": \"ping\"}' | gestalt-send --content-type application/json \"Fixer\"")
 	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Migration:")
 	fmt.Fprintln(out, "  gestalt-send --session-id \"Fixer 1\"   ->   gestalt-send \"Fixer 1\"")
@@ -14,6 +14,9 @@ type commandDeps struct {
 	Stderr            io.Writer
 	RunServer         func(args []string) int
 	RunValidateSkill  func(args []string) int
+	RunLintSkills     func(args []string) int
+	RunConfigVerify   func(args []string) int
+	RunNew            func(args []string) int
 	RunValidateConfig func(args []string) int
 	RunCompletion     func(args []string, out io.Writer, errOut io.Writer) int
 	RunExtractConfig  func() int
@@ -25,6 +28,9 @@ func defaultCommandDeps() commandDeps {
 		Stderr:            os.Stderr,
 		RunServer:         runServer,
 		RunValidateSkill:  runValidateSkill,
+		RunLintSkills:     runLintSkills,
+		RunConfigVerify:   runConfigVerify,
+		RunNew:            runNew,
 		RunValidateConfig: runValidateConfig,
 		RunCompletion:     runCompletion,
 		RunExtractConfig:  runExtractConfig,
@@ -47,6 +53,30 @@ func (c validateSkillCommand) Run(args []string) int {
 	return c.deps.RunValidateSkill(args)
 }
 
+type lintSkillsCommand struct {
+	deps commandDeps
+}
+
+func (c lintSkillsCommand) Run(args []string) int {
+	return c.deps.RunLintSkills(args)
+}
+
+type configVerifyCommand struct {
+	deps commandDeps
+}
+
+func (c configVerifyCommand) Run(args []string) int {
+	return c.deps.RunConfigVerify(args)
+}
+
+type newCommand struct {
+	deps commandDeps
+}
+
+func (c newCommand) Run(args []string) int {
+	return c.deps.RunNew(args)
+}
+
 type validateConfigCommand struct {
 	deps commandDeps
 }
@@ -75,6 +105,15 @@ func resolveCommand(args []string, deps commandDeps) (command, []string) {
 	if len(args) > 0 && args[0] == "validate-skill" {
 		return validateSkillCommand{deps: deps}, args[1:]
 	}
+	if len(args) > 0 && args[0] == "lint-skills" {
+		return lintSkillsCommand{deps: deps}, args[1:]
+	}
+	if len(args) > 0 && args[0] == "config-verify" {
+		return configVerifyCommand{deps: deps}, args[1:]
+	}
+	if len(args) > 0 && args[0] == "new" {
+		return newCommand{deps: deps}, args[1:]
+	}
 	if len(args) > 1 && args[0] == "config" && args[1] == "validate" {
 		return validateConfigCommand{deps: deps}, args[2:]
 	}
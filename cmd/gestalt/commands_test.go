@@ -13,6 +13,9 @@ func stubCommandDeps() commandDeps {
 		Stderr:            io.Discard,
 		RunServer:         func(args []string) int { return 0 },
 		RunValidateSkill:  func(args []string) int { return 0 },
+		RunLintSkills:     func(args []string) int { return 0 },
+		RunConfigVerify:   func(args []string) int { return 0 },
+		RunNew:            func(args []string) int { return 0 },
 		RunValidateConfig: func(args []string) int { return 0 },
 		RunCompletion:     func(args []string, out io.Writer, errOut io.Writer) int { return 0 },
 		RunExtractConfig:  func() int { return 0 },
@@ -36,6 +39,57 @@ func TestResolveCommandValidateSkill(t *testing.T) {
 	}
 }
 
+func TestResolveCommandLintSkills(t *testing.T) {
+	deps := stubCommandDeps()
+	var gotArgs []string
+	deps.RunLintSkills = func(args []string) int {
+		gotArgs = append([]string(nil), args...)
+		return 3
+	}
+
+	cmd, cmdArgs := resolveCommand([]string{"lint-skills", "--json", "skills"}, deps)
+	if code := cmd.Run(cmdArgs); code != 3 {
+		t.Fatalf("expected code 3, got %d", code)
+	}
+	if !reflect.DeepEqual(gotArgs, []string{"--json", "skills"}) {
+		t.Fatalf("expected args to be forwarded, got %v", gotArgs)
+	}
+}
+
+func TestResolveCommandConfigVerify(t *testing.T) {
+	deps := stubCommandDeps()
+	var gotArgs []string
+	deps.RunConfigVerify = func(args []string) int {
+		gotArgs = append([]string(nil), args...)
+		return 4
+	}
+
+	cmd, cmdArgs := resolveCommand([]string{"config-verify", "--json"}, deps)
+	if code := cmd.Run(cmdArgs); code != 4 {
+		t.Fatalf("expected code 4, got %d", code)
+	}
+	if !reflect.DeepEqual(gotArgs, []string{"--json"}) {
+		t.Fatalf("expected args to be forwarded, got %v", gotArgs)
+	}
+}
+
+func TestResolveCommandNew(t *testing.T) {
+	deps := stubCommandDeps()
+	var gotArgs []string
+	deps.RunNew = func(args []string) int {
+		gotArgs = append([]string(nil), args...)
+		return 5
+	}
+
+	cmd, cmdArgs := resolveCommand([]string{"new", "skill", "triage"}, deps)
+	if code := cmd.Run(cmdArgs); code != 5 {
+		t.Fatalf("expected code 5, got %d", code)
+	}
+	if !reflect.DeepEqual(gotArgs, []string{"skill", "triage"}) {
+		t.Fatalf("expected args to be forwarded, got %v", gotArgs)
+	}
+}
+
 func TestResolveCommandValidateConfig(t *testing.T) {
 	deps := stubCommandDeps()
 	var gotArgs []string
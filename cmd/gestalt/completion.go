@@ -33,7 +33,7 @@ _gestalt_complete() {
     return
   fi
 
-  if [[ "$prev" == "validate-skill" ]]; then
+  if [[ "$prev" == "validate-skill" || "$prev" == "lint-skills" ]]; then
     COMPREPLY=( $(compgen -f -- "$cur") )
     return
   fi
@@ -43,18 +43,28 @@ _gestalt_complete() {
     return
   fi
 
+  if [[ "$prev" == "new" ]]; then
+    COMPREPLY=( $(compgen -W "agent skill" -- "$cur") )
+    return
+  fi
+
+  if [[ "$prev" == "config-verify" ]]; then
+    COMPREPLY=( $(compgen -W "--config-dir --json" -- "$cur") )
+    return
+  fi
+
   if [[ "$prev" == "--shell" ]]; then
     COMPREPLY=( $(compgen -W "/bin/bash /bin/zsh /bin/sh" -- "$cur") )
     return
   fi
 
   if [[ "$cur" == -* ]]; then
-    COMPREPLY=( $(compgen -W "--port --backend-port --shell --token --session-persist --session-dir --session-buffer-lines --session-retention-days --input-history-persist --input-history-dir --max-watches --verbose --quiet --force-upgrade --dev --help --version --extract-config --agents-dir" -- "$cur") )
+    COMPREPLY=( $(compgen -W "--port --backend-port --shell --token --session-persist --session-dir --session-buffer-lines --session-retention-days --session-dir-max-bytes --input-history-persist --input-history-dir --max-watches --verbose --quiet --force-upgrade --config-file --config-freeze --allow-metrics-reset --input-file-root --dev --help --version --extract-config --agents-dir" -- "$cur") )
     return
   fi
 
   if [[ $COMP_CWORD -eq 1 ]]; then
-    COMPREPLY=( $(compgen -W "validate-skill config completion" -- "$cur") )
+    COMPREPLY=( $(compgen -W "validate-skill lint-skills config config-verify new completion" -- "$cur") )
   fi
 }
 
@@ -73,12 +83,17 @@ _gestalt_complete() {
     '--session-dir[Session log directory]'
     '--session-buffer-lines[Session buffer lines]'
     '--session-retention-days[Session retention days]'
+    '--session-dir-max-bytes[Session log directory quota in bytes]'
     '--input-history-persist[Persist input history]'
     '--input-history-dir[Input history directory]'
     '--max-watches[Max active watches]'
     '--verbose[Enable verbose logging]'
     '--quiet[Reduce logging to warnings]'
     '--force-upgrade[Bypass config version compatibility checks]'
+    '--config-file[Merged config file with agents and skills]'
+    '--config-freeze[Keep installed config on version mismatch]'
+    '--allow-metrics-reset[Enable the metrics reset endpoint]'
+    '--input-file-root[Directory session input files may be read from]'
     '--dev[Enable developer mode]'
     '--help[Show help]'
     '--version[Print version and exit]'
@@ -91,7 +106,7 @@ _gestalt_complete() {
       _values 'shells' bash zsh
       return
       ;;
-    validate-skill)
+    validate-skill|lint-skills)
       _files
       return
       ;;
@@ -99,9 +114,17 @@ _gestalt_complete() {
       _values 'config commands' validate
       return
       ;;
+    new)
+      _arguments '--dir[Config directory]:dir:_files -/' '1:kind:(agent skill)' '2:name:'
+      return
+      ;;
+    config-verify)
+      _arguments '--config-dir[Config directory]:dir:_files -/' '--json[Print the report as JSON]'
+      return
+      ;;
   esac
 
-  _arguments -s $flags '1:subcommand:(validate-skill config completion)' '*::arg:->args'
+  _arguments -s $flags '1:subcommand:(validate-skill lint-skills config config-verify new completion)' '*::arg:->args'
 }
 
 _gestalt_complete "$@"
@@ -13,6 +13,7 @@ import (
 	"gestalt/internal/config"
 	"gestalt/internal/logging"
 	"gestalt/internal/plan"
+	"gestalt/internal/version"
 )
 
 func TestPrepareConfigWarmStartSkipsExtraction(t *testing.T) {
@@ -269,3 +270,52 @@ func logContains(buffer *logging.LogBuffer, message string) bool {
 	}
 	return false
 }
+
+func TestPrepareConfigFreezeSkipsExtractionOnVersionMismatch(t *testing.T) {
+	root := withTempWorkdir(t)
+
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if _, err := prepareConfig(cfg, newTestLogger(logging.LevelInfo)); err != nil {
+		t.Fatalf("prepare config: %v", err)
+	}
+
+	agentPath := filepath.Join(root, cfg.ConfigDir, "agents", "coder.toml")
+	if err := os.Chmod(agentPath, 0o644); err != nil {
+		t.Fatalf("chmod agent: %v", err)
+	}
+	if err := os.WriteFile(agentPath, []byte("custom"), 0o644); err != nil {
+		t.Fatalf("write custom agent: %v", err)
+	}
+	versionPath := filepath.Join(root, filepath.Dir(cfg.ConfigDir), "version.json")
+	installed := version.GetVersionInfo()
+	installed.Major += 1
+	if err := config.WriteVersionFile(versionPath, installed); err != nil {
+		t.Fatalf("write version file: %v", err)
+	}
+
+	cfg.ConfigFreeze = true
+	logger := newTestLogger(logging.LevelInfo)
+	if _, err := prepareConfig(cfg, logger); err != nil {
+		t.Fatalf("expected frozen config to skip version check, got %v", err)
+	}
+	data, err := os.ReadFile(agentPath)
+	if err != nil {
+		t.Fatalf("read agent: %v", err)
+	}
+	if string(data) != "custom" {
+		t.Fatalf("expected frozen config to be untouched, got %q", string(data))
+	}
+	if !logContains(logger.Buffer(), "config frozen") {
+		t.Fatalf("expected config frozen warning")
+	}
+	stillInstalled, err := config.LoadVersionFile(versionPath)
+	if err != nil {
+		t.Fatalf("load version file: %v", err)
+	}
+	if stillInstalled.Major != installed.Major {
+		t.Fatalf("expected version file to be left alone")
+	}
+}
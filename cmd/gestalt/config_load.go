@@ -32,9 +32,12 @@ type Config struct {
 	SessionPersist       bool
 	SessionLogDir        string
 	SessionBufferLines   int
+	SessionDirMaxBytes   int64
 	InputHistoryPersist  bool
 	InputHistoryDir      string
 	ConfigDir            string
+	ConfigLayers         []string
+	ConfigFile           string
 	ConfigBackupLimit    int
 	ConfigOverrides      map[string]any
 	DevMode              bool
@@ -44,6 +47,9 @@ type Config struct {
 	Quiet                bool
 	ShowVersion          bool
 	ForceUpgrade         bool
+	ConfigFreeze         bool
+	AllowMetricsReset    bool
+	InputFileRoot        string
 	Sources              map[string]configSource
 }
 
@@ -64,14 +70,19 @@ type configDefaults struct {
 	SessionPersist       bool
 	SessionLogDir        string
 	SessionBufferLines   int
+	SessionDirMaxBytes   int64
 	InputHistoryPersist  bool
 	InputHistoryDir      string
 	ConfigDir            string
+	ConfigFile           string
 	ConfigBackupLimit    int
 	DevMode              bool
 	MaxWatches           int
 	PprofEnabled         bool
 	ForceUpgrade         bool
+	ConfigFreeze         bool
+	AllowMetricsReset    bool
+	InputFileRoot        string
 }
 
 type flagValues struct {
@@ -83,9 +94,11 @@ type flagValues struct {
 	SessionPersist       bool
 	SessionLogDir        string
 	SessionBufferLines   int
+	SessionDirMaxBytes   int64
 	InputHistoryPersist  bool
 	InputHistoryDir      string
 	ConfigDir            string
+	ConfigFile           string
 	ConfigBackupLimit    int
 	ConfigOverrides      []string
 	MaxWatches           int
@@ -95,6 +108,9 @@ type flagValues struct {
 	Help                 bool
 	Version              bool
 	ForceUpgrade         bool
+	ConfigFreeze         bool
+	AllowMetricsReset    bool
+	InputFileRoot        string
 	DevMode              bool
 	Set                  map[string]bool
 }
@@ -255,6 +271,24 @@ func loadConfig(args []string) (Config, error) {
 	cfg.SessionBufferLines = sessionBufferLines
 	cfg.Sources["session-buffer-lines"] = sessionBufferLinesSource
 
+	sessionDirMaxBytes := defaults.SessionDirMaxBytes
+	sessionDirMaxBytesSource := sourceDefault
+	if rawQuota := strings.TrimSpace(os.Getenv("GESTALT_SESSION_DIR_MAX_BYTES")); rawQuota != "" {
+		if parsed, err := strconv.ParseInt(rawQuota, 10, 64); err == nil && parsed >= 0 {
+			sessionDirMaxBytes = parsed
+			sessionDirMaxBytesSource = sourceEnv
+		}
+	}
+	if flags.Set["session-dir-max-bytes"] {
+		if flags.SessionDirMaxBytes < 0 {
+			return Config{}, fmt.Errorf("invalid --session-dir-max-bytes: must be >= 0")
+		}
+		sessionDirMaxBytes = flags.SessionDirMaxBytes
+		sessionDirMaxBytesSource = sourceFlag
+	}
+	cfg.SessionDirMaxBytes = sessionDirMaxBytes
+	cfg.Sources["session-dir-max-bytes"] = sessionDirMaxBytesSource
+
 	historyPersist := defaults.InputHistoryPersist
 	historyPersistSource := sourceDefault
 	if rawPersist := strings.TrimSpace(os.Getenv("GESTALT_INPUT_HISTORY_PERSIST")); rawPersist != "" {
@@ -287,19 +321,42 @@ func loadConfig(args []string) (Config, error) {
 	cfg.InputHistoryDir = historyDir
 	cfg.Sources["input-history-dir"] = historyDirSource
 
-	configDir := defaults.ConfigDir
+	configDirs := []string{defaults.ConfigDir}
 	configDirSource := sourceDefault
+	// GESTALT_CONFIG_DIR names a single dir, so a path containing the list
+	// separator still works.
 	if rawDir := strings.TrimSpace(os.Getenv("GESTALT_CONFIG_DIR")); rawDir != "" {
-		configDir = rawDir
+		configDirs = []string{rawDir}
+		configDirSource = sourceEnv
+	}
+	if rawDirs := strings.TrimSpace(os.Getenv("GESTALT_CONFIG_DIRS")); rawDirs != "" {
+		configDirs = splitConfigDirs(rawDirs)
 		configDirSource = sourceEnv
 	}
 	if flags.Set["config-dir"] {
-		configDir = flags.ConfigDir
+		configDirs = splitConfigDirs(flags.ConfigDir)
 		configDirSource = sourceFlag
 	}
-	cfg.ConfigDir = configDir
+	if len(configDirs) == 0 {
+		return Config{}, fmt.Errorf("invalid --config-dir: value cannot be empty")
+	}
+	cfg.ConfigDir = configDirs[len(configDirs)-1]
+	cfg.ConfigLayers = configDirs[:len(configDirs)-1]
 	cfg.Sources["config-dir"] = configDirSource
 
+	configFile := defaults.ConfigFile
+	configFileSource := sourceDefault
+	if rawFile := strings.TrimSpace(os.Getenv("GESTALT_CONFIG_FILE")); rawFile != "" {
+		configFile = rawFile
+		configFileSource = sourceEnv
+	}
+	if flags.Set["config-file"] {
+		configFile = strings.TrimSpace(flags.ConfigFile)
+		configFileSource = sourceFlag
+	}
+	cfg.ConfigFile = configFile
+	cfg.Sources["config-file"] = configFileSource
+
 	backupLimit := defaults.ConfigBackupLimit
 	backupSource := sourceDefault
 	if rawLimit := strings.TrimSpace(os.Getenv("GESTALT_CONFIG_BACKUP_LIMIT")); rawLimit != "" {
@@ -406,6 +463,46 @@ func loadConfig(args []string) (Config, error) {
 	}
 	cfg.Sources["force-upgrade"] = forceUpgradeSource
 
+	configFreezeSource := sourceDefault
+	cfg.ConfigFreeze = defaults.ConfigFreeze
+	if rawFreeze := strings.TrimSpace(os.Getenv("GESTALT_CONFIG_FREEZE")); rawFreeze != "" {
+		if parsed, err := strconv.ParseBool(rawFreeze); err == nil {
+			cfg.ConfigFreeze = parsed
+			configFreezeSource = sourceEnv
+		}
+	}
+	if flags.Set["config-freeze"] {
+		cfg.ConfigFreeze = flags.ConfigFreeze
+		configFreezeSource = sourceFlag
+	}
+	cfg.Sources["config-freeze"] = configFreezeSource
+
+	metricsResetSource := sourceDefault
+	cfg.AllowMetricsReset = defaults.AllowMetricsReset
+	if rawReset := strings.TrimSpace(os.Getenv("GESTALT_ALLOW_METRICS_RESET")); rawReset != "" {
+		if parsed, err := strconv.ParseBool(rawReset); err == nil {
+			cfg.AllowMetricsReset = parsed
+			metricsResetSource = sourceEnv
+		}
+	}
+	if flags.Set["allow-metrics-reset"] {
+		cfg.AllowMetricsReset = flags.AllowMetricsReset
+		metricsResetSource = sourceFlag
+	}
+	cfg.Sources["allow-metrics-reset"] = metricsResetSource
+
+	inputFileRootSource := sourceDefault
+	cfg.InputFileRoot = defaults.InputFileRoot
+	if rawRoot := strings.TrimSpace(os.Getenv("GESTALT_INPUT_FILE_ROOT")); rawRoot != "" {
+		cfg.InputFileRoot = rawRoot
+		inputFileRootSource = sourceEnv
+	}
+	if flags.Set["input-file-root"] {
+		cfg.InputFileRoot = strings.TrimSpace(flags.InputFileRoot)
+		inputFileRootSource = sourceFlag
+	}
+	cfg.Sources["input-file-root"] = inputFileRootSource
+
 	envOverrides, err := parseConfigOverridesEnv(os.Getenv("GESTALT_CONFIG_OVERRIDES"))
 	if err != nil {
 		return Config{}, err
@@ -445,6 +542,8 @@ func defaultConfigValues() configDefaults {
 		MaxWatches:           100,
 		PprofEnabled:         false,
 		ForceUpgrade:         false,
+		ConfigFreeze:         false,
+		AllowMetricsReset:    false,
 	}
 }
 
@@ -462,15 +561,20 @@ func parseFlags(args []string, defaults configDefaults) (flagValues, error) {
 	sessionDir := fs.String("session-dir", defaults.SessionLogDir, "Session log directory")
 	sessionRetentionDays := fs.Int("session-retention-days", defaults.SessionRetentionDays, "Session retention days")
 	sessionBufferLines := fs.Int("session-buffer-lines", defaults.SessionBufferLines, "Session buffer lines")
+	sessionDirMaxBytes := fs.Int64("session-dir-max-bytes", defaults.SessionDirMaxBytes, "Session log directory quota in bytes (0 disables)")
 	inputHistoryPersist := fs.Bool("input-history-persist", defaults.InputHistoryPersist, "Persist input history")
 	inputHistoryDir := fs.String("input-history-dir", defaults.InputHistoryDir, "Input history directory")
 	configDir := fs.String("config-dir", defaults.ConfigDir, "Config directory")
+	configFile := fs.String("config-file", defaults.ConfigFile, "Merged gestalt.yaml/json with agents and skills")
 	configBackupLimit := fs.Int("config-backup-limit", defaults.ConfigBackupLimit, "Config backup limit")
 	var configOverrides overrideList
 	fs.Var(&configOverrides, "c", "Override gestalt.toml settings (key=value)")
 	maxWatches := fs.Int("max-watches", defaults.MaxWatches, "Max active watches")
 	pprofEnabled := fs.Bool("pprof", defaults.PprofEnabled, "Enable pprof debug endpoints")
 	forceUpgrade := fs.Bool("force-upgrade", defaults.ForceUpgrade, "Bypass config version compatibility checks")
+	configFreeze := fs.Bool("config-freeze", defaults.ConfigFreeze, "Never extract config over a different installed version")
+	allowMetricsReset := fs.Bool("allow-metrics-reset", defaults.AllowMetricsReset, "Enable POST /api/metrics/reset")
+	inputFileRoot := fs.String("input-file-root", defaults.InputFileRoot, "Directory session input files may be read from")
 	devMode := fs.Bool("dev", defaults.DevMode, "Enable developer mode (skip config extraction)")
 	verbose := fs.Bool("verbose", false, "Enable verbose logging")
 	quiet := fs.Bool("quiet", false, "Reduce logging to warnings")
@@ -498,14 +602,19 @@ func parseFlags(args []string, defaults configDefaults) (flagValues, error) {
 		SessionPersist:       *sessionPersist,
 		SessionLogDir:        *sessionDir,
 		SessionBufferLines:   *sessionBufferLines,
+		SessionDirMaxBytes:   *sessionDirMaxBytes,
 		InputHistoryPersist:  *inputHistoryPersist,
 		InputHistoryDir:      *inputHistoryDir,
 		ConfigDir:            *configDir,
+		ConfigFile:           *configFile,
 		ConfigBackupLimit:    *configBackupLimit,
 		ConfigOverrides:      configOverrides,
 		MaxWatches:           *maxWatches,
 		PprofEnabled:         *pprofEnabled,
 		ForceUpgrade:         *forceUpgrade,
+		ConfigFreeze:         *configFreeze,
+		AllowMetricsReset:    *allowMetricsReset,
+		InputFileRoot:        *inputFileRoot,
 		DevMode:              *devMode,
 		Verbose:              *verbose,
 		Quiet:                *quiet,
@@ -575,6 +684,10 @@ func printHelp(out io.Writer, defaults configDefaults) {
 			Name: "--session-retention-days N",
 			Desc: fmt.Sprintf("Session retention days (env: GESTALT_SESSION_RETENTION_DAYS, default: %d)", defaults.SessionRetentionDays),
 		},
+		{
+			Name: "--session-dir-max-bytes N",
+			Desc: "Session log directory quota; evicts oldest logs when exceeded (env: GESTALT_SESSION_DIR_MAX_BYTES, default: 0 = unlimited)",
+		},
 		{
 			Name: "--input-history-persist",
 			Desc: fmt.Sprintf("Persist input history (env: GESTALT_INPUT_HISTORY_PERSIST, default: %t)", defaults.InputHistoryPersist),
@@ -588,7 +701,11 @@ func printHelp(out io.Writer, defaults configDefaults) {
 	writeOptionGroup(out, "Config", []helpOption{
 		{
 			Name: "--config-dir DIR",
-			Desc: fmt.Sprintf("Config directory (env: GESTALT_CONFIG_DIR, default: %s)", defaults.ConfigDir),
+			Desc: fmt.Sprintf("Config directory, or a %q-separated list layered in order; the last is written to and wins (env: GESTALT_CONFIG_DIR, GESTALT_CONFIG_DIRS, default: %s)", string(os.PathListSeparator), defaults.ConfigDir),
+		},
+		{
+			Name: "--config-file FILE",
+			Desc: "Merged gestalt.yaml/json with agents and skills; overrides same-ID entries from the config directory (env: GESTALT_CONFIG_FILE)",
 		},
 		{
 			Name: "--config-backup-limit N",
@@ -602,6 +719,18 @@ func printHelp(out io.Writer, defaults configDefaults) {
 			Name: "--force-upgrade",
 			Desc: fmt.Sprintf("Bypass version checks (env: GESTALT_FORCE_UPGRADE, default: %t)", defaults.ForceUpgrade),
 		},
+		{
+			Name: "--config-freeze",
+			Desc: fmt.Sprintf("Keep installed config on version mismatch (env: GESTALT_CONFIG_FREEZE, default: %t)", defaults.ConfigFreeze),
+		},
+		{
+			Name: "--allow-metrics-reset",
+			Desc: fmt.Sprintf("Enable POST /api/metrics/reset (env: GESTALT_ALLOW_METRICS_RESET, default: %t)", defaults.AllowMetricsReset),
+		},
+		{
+			Name: "--input-file-root DIR",
+			Desc: "Allow session input from files under DIR (env: GESTALT_INPUT_FILE_ROOT, default: disabled)",
+		},
 		{
 			Name: "-c key=value",
 			Desc: "Override gestalt.toml settings (repeatable, env: GESTALT_CONFIG_OVERRIDES)",
@@ -678,6 +807,9 @@ func logStartupFlags(logger *logging.Logger, cfg Config) {
 	if cfg.Sources["session-buffer-lines"] == sourceFlag {
 		flags = append(flags, formatBoolFlag("--session-buffer-lines", cfg.SessionBufferLines != 0))
 	}
+	if cfg.Sources["session-dir-max-bytes"] == sourceFlag {
+		flags = append(flags, formatBoolFlag("--session-dir-max-bytes", cfg.SessionDirMaxBytes != 0))
+	}
 	if cfg.Sources["input-history-persist"] == sourceFlag {
 		flags = append(flags, formatBoolFlag("--input-history-persist", cfg.InputHistoryPersist))
 	}
@@ -685,7 +817,11 @@ func logStartupFlags(logger *logging.Logger, cfg Config) {
 		flags = append(flags, formatStringFlag("--input-history-dir", cfg.InputHistoryDir))
 	}
 	if cfg.Sources["config-dir"] == sourceFlag {
-		flags = append(flags, formatStringFlag("--config-dir", cfg.ConfigDir))
+		dirs := append(append([]string(nil), cfg.ConfigLayers...), cfg.ConfigDir)
+		flags = append(flags, formatStringFlag("--config-dir", strings.Join(dirs, string(os.PathListSeparator))))
+	}
+	if cfg.Sources["config-file"] == sourceFlag {
+		flags = append(flags, formatStringFlag("--config-file", cfg.ConfigFile))
 	}
 	if cfg.Sources["config-backup-limit"] == sourceFlag {
 		flags = append(flags, formatBoolFlag("--config-backup-limit", cfg.ConfigBackupLimit != 0))
@@ -708,6 +844,15 @@ func logStartupFlags(logger *logging.Logger, cfg Config) {
 	if cfg.Sources["force-upgrade"] == sourceFlag {
 		flags = append(flags, formatBoolFlag("--force-upgrade", cfg.ForceUpgrade))
 	}
+	if cfg.Sources["config-freeze"] == sourceFlag {
+		flags = append(flags, formatBoolFlag("--config-freeze", cfg.ConfigFreeze))
+	}
+	if cfg.Sources["allow-metrics-reset"] == sourceFlag {
+		flags = append(flags, formatBoolFlag("--allow-metrics-reset", cfg.AllowMetricsReset))
+	}
+	if cfg.Sources["input-file-root"] == sourceFlag {
+		flags = append(flags, formatStringFlag("--input-file-root", cfg.InputFileRoot))
+	}
 	if len(flags) > 0 {
 		logger.Debug("startup flags", map[string]string{
 			"flags": strings.Join(flags, " "),
@@ -804,29 +949,37 @@ func runValidateSkill(args []string) int {
 	if entry.Description != "" {
 		fmt.Fprintf(os.Stdout, "description: %s\n", entry.Description)
 	}
+	for _, note := range skillNotes(entry, filepath.Dir(skillPath)) {
+		fmt.Fprintf(os.Stdout, "note: %s\n", note)
+	}
+
+	return 0
+}
+
+// skillNotes lists the optional fields and directories a skill leaves out,
+// as validate-skill and lint-skills report them. fallbackDir is used when
+// the skill has no path of its own.
+func skillNotes(entry *skill.Skill, fallbackDir string) []string {
+	var notes []string
 	if strings.TrimSpace(entry.License) == "" {
-		fmt.Fprintln(os.Stdout, "note: license is empty")
+		notes = append(notes, "license is empty")
 	}
 	if strings.TrimSpace(entry.Compatibility) == "" {
-		fmt.Fprintln(os.Stdout, "note: compatibility is empty")
+		notes = append(notes, "compatibility is empty")
 	}
 	if len(entry.AllowedTools) == 0 {
-		fmt.Fprintln(os.Stdout, "note: allowed_tools not set")
+		notes = append(notes, "allowed_tools not set")
 	}
-
 	base := entry.Path
 	if strings.TrimSpace(base) == "" {
-		base = filepath.Dir(skillPath)
+		base = fallbackDir
 	}
 	for _, dir := range []string{"scripts", "references", "assets"} {
-		if hasOptionalSkillDir(base, dir) {
-			fmt.Fprintf(os.Stdout, "ok: %s/ directory present\n", dir)
-		} else {
-			fmt.Fprintf(os.Stdout, "note: %s/ directory missing\n", dir)
+		if !hasOptionalSkillDir(base, dir) {
+			notes = append(notes, dir+"/ directory missing")
 		}
 	}
-
-	return 0
+	return notes
 }
 
 func hasOptionalSkillDir(base, name string) bool {
@@ -899,6 +1052,18 @@ func prepareConfig(cfg Config, logger *logging.Logger) (configPaths, error) {
 			lastVersionWrite = info.ModTime()
 		}
 	}
+	if hadInstalled && cfg.ConfigFreeze && configVersionDiffers(installed, current) {
+		if logger != nil {
+			logger.Warn("config frozen: installed config version differs from binary, skipping extraction", map[string]string{
+				"gestalt.category": "config",
+				"gestalt.source":   "backend",
+				"config_dir":       paths.ConfigDir,
+				"installed":        formatVersionInfo(installed),
+				"current":          formatVersionInfo(current),
+			})
+		}
+		return paths, nil
+	}
 	if err == nil {
 		if compatibilityErr := config.CheckVersionCompatibility(installed, current, logger); compatibilityErr != nil {
 			if cfg.ForceUpgrade {
@@ -914,9 +1079,10 @@ func prepareConfig(cfg Config, logger *logging.Logger) (configPaths, error) {
 	}
 
 	extractor := config.Extractor{
-		Logger:      logger,
-		BackupLimit: cfg.ConfigBackupLimit,
-		LastUpdated: lastVersionWrite,
+		Logger:       logger,
+		BackupLimit:  cfg.ConfigBackupLimit,
+		LastUpdated:  lastVersionWrite,
+		ChecksumPath: config.ChecksumManifestPath(paths.ConfigDir),
 		Resolver: &config.ConffileResolver{
 			Interactive: stdinIsInteractive(),
 			In:          os.Stdin,
@@ -945,6 +1111,10 @@ func prepareConfig(cfg Config, logger *logging.Logger) (configPaths, error) {
 	return paths, nil
 }
 
+func configVersionDiffers(installed, current version.VersionInfo) bool {
+	return installed.Major != current.Major || installed.Minor != current.Minor || installed.Patch != current.Patch
+}
+
 func preparePlanFile(logger *logging.Logger) string {
 	plansDir := plan.DefaultPlansDir()
 	if err := os.MkdirAll(plansDir, 0o755); err != nil && logger != nil {
@@ -1037,3 +1207,17 @@ func formatVersionInfo(info version.VersionInfo) string {
 	}
 	return info.Version
 }
+
+// splitConfigDirs splits a --config-dir value into its dirs, lowest
+// precedence first. The last is the primary config dir, which defaults are
+// extracted into and state is written to; the earlier ones are layered
+// below it.
+func splitConfigDirs(raw string) []string {
+	var dirs []string
+	for _, dir := range filepath.SplitList(raw) {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
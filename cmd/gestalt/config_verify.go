@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gestalt/internal/config"
+)
+
+type configVerifyReport struct {
+	ConfigDir string `json:"config_dir"`
+	Manifest  string `json:"manifest"`
+	config.ChecksumReport
+}
+
+func runConfigVerify(args []string) int {
+	return runConfigVerifyWithOutput(args, os.Stdout, os.Stderr)
+}
+
+// runConfigVerifyWithOutput compares the extracted config against the
+// checksum manifest written at extraction time. It exits non-zero when any
+// file is modified, missing, or extra.
+func runConfigVerifyWithOutput(args []string, out, errOut io.Writer) int {
+	fs := flag.NewFlagSet("gestalt config-verify", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	configDir := fs.String("config-dir", filepath.Join(".gestalt", "config"), "Config directory")
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() != 0 || strings.TrimSpace(*configDir) == "" {
+		fmt.Fprintln(errOut, "usage: gestalt config-verify [--config-dir DIR] [--json]")
+		return 1
+	}
+
+	dir := filepath.Clean(strings.TrimSpace(*configDir))
+	manifestPath := config.ChecksumManifestPath(dir)
+	manifest, err := config.LoadChecksumManifest(manifestPath)
+	if err != nil {
+		if errors.Is(err, config.ErrChecksumManifestMissing) {
+			fmt.Fprintf(errOut, "no checksum manifest at %s; start gestalt once to extract the config\n", manifestPath)
+			return 1
+		}
+		fmt.Fprintf(errOut, "load checksum manifest: %v\n", err)
+		return 1
+	}
+	checksums, err := config.VerifyChecksums(dir, manifest)
+	if err != nil {
+		fmt.Fprintf(errOut, "verify config: %v\n", err)
+		return 1
+	}
+	report := configVerifyReport{ConfigDir: dir, Manifest: manifestPath, ChecksumReport: checksums}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(errOut, "encode report: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, path := range report.Modified {
+			fmt.Fprintf(out, "MODIFIED %s\n", path)
+		}
+		for _, path := range report.Missing {
+			fmt.Fprintf(out, "MISSING %s\n", path)
+		}
+		for _, path := range report.Extra {
+			fmt.Fprintf(out, "EXTRA %s\n", path)
+		}
+		fmt.Fprintf(out, "Summary: %d modified, %d missing, %d extra\n", len(report.Modified), len(report.Missing), len(report.Extra))
+	}
+	if !report.Clean() {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gestalt/internal/config"
+)
+
+func writeVerifiedConfig(t *testing.T) string {
+	t.Helper()
+	configDir := filepath.Join(t.TempDir(), "config")
+	agentsDir := filepath.Join(configDir, "agents")
+	if err := os.MkdirAll(agentsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(agentsDir, "coder.toml"), []byte("name = \"coder\"\n"), 0o644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	sum := sha256.Sum256([]byte("name = \"coder\"\n"))
+	manifest := config.ChecksumManifest{Files: map[string]string{"agents/coder.toml": hex.EncodeToString(sum[:])}}
+	if err := config.WriteChecksumManifest(config.ChecksumManifestPath(configDir), manifest); err != nil {
+		t.Fatalf("write checksum manifest: %v", err)
+	}
+	return configDir
+}
+
+func TestConfigVerifyClean(t *testing.T) {
+	configDir := writeVerifiedConfig(t)
+
+	var out, errOut bytes.Buffer
+	code := runConfigVerifyWithOutput([]string{"--config-dir", configDir}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d (stderr %q)", code, errOut.String())
+	}
+	if !strings.Contains(out.String(), "Summary: 0 modified, 0 missing, 0 extra") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestConfigVerifyReportsChanges(t *testing.T) {
+	configDir := writeVerifiedConfig(t)
+	if err := os.WriteFile(filepath.Join(configDir, "agents", "coder.toml"), []byte("name = \"edited\"\n"), 0o644); err != nil {
+		t.Fatalf("edit agent: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "agents", "extra.toml"), []byte("name = \"extra\"\n"), 0o644); err != nil {
+		t.Fatalf("write extra: %v", err)
+	}
+
+	var out, errOut bytes.Buffer
+	code := runConfigVerifyWithOutput([]string{"--config-dir", configDir}, &out, &errOut)
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	text := out.String()
+	if !strings.Contains(text, "MODIFIED agents/coder.toml") || !strings.Contains(text, "EXTRA agents/extra.toml") {
+		t.Fatalf("unexpected output: %q", text)
+	}
+
+	out.Reset()
+	code = runConfigVerifyWithOutput([]string{"--config-dir", configDir, "--json"}, &out, &errOut)
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	var report configVerifyReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if len(report.Modified) != 1 || len(report.Extra) != 1 || len(report.Missing) != 0 {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+}
+
+func TestConfigVerifyMissingManifest(t *testing.T) {
+	var out, errOut bytes.Buffer
+	code := runConfigVerifyWithOutput([]string{"--config-dir", filepath.Join(t.TempDir(), "config")}, &out, &errOut)
+	if code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "no checksum manifest") {
+		t.Fatalf("unexpected stderr: %q", errOut.String())
+	}
+}
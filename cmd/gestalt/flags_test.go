@@ -3,7 +3,9 @@ package main
 import (
 	"errors"
 	"flag"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -148,6 +150,7 @@ func TestLoadConfigFlagOverridesEnv(t *testing.T) {
 		"--session-persist=true",
 		"--session-dir", "/tmp/flag-sessions",
 		"--session-buffer-lines", "900",
+		"--session-dir-max-bytes", "1048576",
 		"--max-watches", "200",
 		"--pprof",
 		"--verbose",
@@ -174,6 +177,9 @@ func TestLoadConfigFlagOverridesEnv(t *testing.T) {
 	if cfg.SessionBufferLines != 900 {
 		t.Fatalf("expected session buffer lines 900, got %d", cfg.SessionBufferLines)
 	}
+	if cfg.SessionDirMaxBytes != 1048576 {
+		t.Fatalf("expected session dir quota 1048576, got %d", cfg.SessionDirMaxBytes)
+	}
 	if cfg.MaxWatches != 200 {
 		t.Fatalf("expected max watches 200, got %d", cfg.MaxWatches)
 	}
@@ -215,6 +221,7 @@ func TestLoadConfigInvalidFlags(t *testing.T) {
 		{name: "backend-port", args: []string{"--backend-port", "0"}},
 		{name: "buffer", args: []string{"--session-buffer-lines", "0"}},
 		{name: "retention", args: []string{"--session-retention-days", "0"}},
+		{name: "session-dir-quota", args: []string{"--session-dir-max-bytes", "-1"}},
 		{name: "max-watches", args: []string{"--max-watches", "0"}},
 		{name: "shell-empty", args: []string{"--shell="}},
 		{name: "session-dir-empty", args: []string{"--session-dir="}},
@@ -276,3 +283,118 @@ func TestLoadConfigForceUpgradeFlag(t *testing.T) {
 		t.Fatalf("expected force upgrade source flag, got %q", cfg.Sources["force-upgrade"])
 	}
 }
+
+func TestLoadConfigConfigFreezeEnvAndFlag(t *testing.T) {
+	t.Setenv("GESTALT_CONFIG_FREEZE", "true")
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.ConfigFreeze || cfg.Sources["config-freeze"] != sourceEnv {
+		t.Fatalf("expected config freeze from env, got %t (%q)", cfg.ConfigFreeze, cfg.Sources["config-freeze"])
+	}
+
+	cfg, err = loadConfig([]string{"--config-freeze=false"})
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ConfigFreeze || cfg.Sources["config-freeze"] != sourceFlag {
+		t.Fatalf("expected flag to override env, got %t (%q)", cfg.ConfigFreeze, cfg.Sources["config-freeze"])
+	}
+}
+
+func TestLoadConfigAllowMetricsReset(t *testing.T) {
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.AllowMetricsReset {
+		t.Fatalf("expected metrics reset to be disabled by default")
+	}
+
+	cfg, err = loadConfig([]string{"--allow-metrics-reset"})
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !cfg.AllowMetricsReset || cfg.Sources["allow-metrics-reset"] != sourceFlag {
+		t.Fatalf("expected metrics reset from flag, got %t (%q)", cfg.AllowMetricsReset, cfg.Sources["allow-metrics-reset"])
+	}
+}
+
+func TestLoadConfigInputFileRoot(t *testing.T) {
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.InputFileRoot != "" {
+		t.Fatalf("expected file input to be disabled by default, got %q", cfg.InputFileRoot)
+	}
+
+	t.Setenv("GESTALT_INPUT_FILE_ROOT", "/srv/prompts")
+	cfg, err = loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.InputFileRoot != "/srv/prompts" || cfg.Sources["input-file-root"] != sourceEnv {
+		t.Fatalf("expected input root from env, got %q (%q)", cfg.InputFileRoot, cfg.Sources["input-file-root"])
+	}
+
+	cfg, err = loadConfig([]string{"--input-file-root", "/tmp/prompts"})
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.InputFileRoot != "/tmp/prompts" || cfg.Sources["input-file-root"] != sourceFlag {
+		t.Fatalf("expected flag to override env, got %q (%q)", cfg.InputFileRoot, cfg.Sources["input-file-root"])
+	}
+}
+
+func TestLoadConfigConfigFileEnvAndFlag(t *testing.T) {
+	t.Setenv("GESTALT_CONFIG_FILE", "/tmp/env-gestalt.yaml")
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ConfigFile != "/tmp/env-gestalt.yaml" || cfg.Sources["config-file"] != sourceEnv {
+		t.Fatalf("expected config file from env, got %q (%q)", cfg.ConfigFile, cfg.Sources["config-file"])
+	}
+
+	cfg, err = loadConfig([]string{"--config-file", "/tmp/gestalt.json"})
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ConfigFile != "/tmp/gestalt.json" || cfg.Sources["config-file"] != sourceFlag {
+		t.Fatalf("expected flag to override env, got %q (%q)", cfg.ConfigFile, cfg.Sources["config-file"])
+	}
+}
+
+func TestLoadConfigConfigDirList(t *testing.T) {
+	t.Setenv("GESTALT_CONFIG_DIRS", strings.Join([]string{"/tmp/base", "/tmp/team"}, string(os.PathListSeparator)))
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ConfigDir != "/tmp/team" || len(cfg.ConfigLayers) != 1 || cfg.ConfigLayers[0] != "/tmp/base" {
+		t.Fatalf("expected layered config dirs from env, got %q %v", cfg.ConfigDir, cfg.ConfigLayers)
+	}
+
+	value := strings.Join([]string{"/tmp/flag", "", "/tmp/user"}, string(os.PathListSeparator))
+	cfg, err = loadConfig([]string{"--config-dir", value})
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ConfigDir != "/tmp/user" || len(cfg.ConfigLayers) != 1 || cfg.ConfigLayers[0] != "/tmp/flag" {
+		t.Fatalf("expected flag to override env, got %q %v", cfg.ConfigDir, cfg.ConfigLayers)
+	}
+}
+
+func TestLoadConfigSingleConfigDirNotSplit(t *testing.T) {
+	dir := strings.Join([]string{"/tmp/a", "b"}, string(os.PathListSeparator))
+	t.Setenv("GESTALT_CONFIG_DIR", dir)
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if cfg.ConfigDir != dir || len(cfg.ConfigLayers) != 0 {
+		t.Fatalf("expected GESTALT_CONFIG_DIR as one dir, got %q %v", cfg.ConfigDir, cfg.ConfigLayers)
+	}
+}
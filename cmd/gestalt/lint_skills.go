@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gestalt/internal/skill"
+)
+
+type lintSkillResult struct {
+	Path   string   `json:"path"`
+	Name   string   `json:"name,omitempty"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"`
+	Notes  []string `json:"notes,omitempty"`
+}
+
+type lintSkillsReport struct {
+	Dir     string            `json:"dir"`
+	Valid   int               `json:"valid"`
+	Invalid int               `json:"invalid"`
+	Skills  []lintSkillResult `json:"skills"`
+}
+
+func runLintSkills(args []string) int {
+	return runLintSkillsWithOutput(args, os.Stdout, os.Stderr)
+}
+
+func runLintSkillsWithOutput(args []string, out, errOut io.Writer) int {
+	fs := flag.NewFlagSet("gestalt lint-skills", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	jsonOutput := fs.Bool("json", false, "Print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() != 1 || strings.TrimSpace(fs.Arg(0)) == "" {
+		fmt.Fprintln(errOut, "usage: gestalt lint-skills [--json] <dir>")
+		return 1
+	}
+
+	report, err := lintSkillsDir(strings.TrimSpace(fs.Arg(0)))
+	if err != nil {
+		fmt.Fprintf(errOut, "lint skills: %v\n", err)
+		return 1
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(errOut, "encode report: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, result := range report.Skills {
+			if result.Valid {
+				fmt.Fprintf(out, "OK %s (%s)\n", result.Path, result.Name)
+			} else {
+				fmt.Fprintf(out, "ERROR %s: %s\n", result.Path, strings.Join(result.Errors, "; "))
+			}
+			for _, note := range result.Notes {
+				fmt.Fprintf(out, "  note: %s\n", note)
+			}
+		}
+		fmt.Fprintf(out, "Summary: %d valid, %d invalid\n", report.Valid, report.Invalid)
+	}
+	if len(report.Skills) == 0 {
+		fmt.Fprintf(errOut, "no SKILL.md files found under %s\n", report.Dir)
+		return 1
+	}
+	if report.Invalid > 0 {
+		return 1
+	}
+	return 0
+}
+
+// lintSkillsDir validates every SKILL.md under dir the way validate-skill
+// does, then checks that extends references resolve across the set and that
+// skill names are unique.
+func lintSkillsDir(dir string) (lintSkillsReport, error) {
+	report := lintSkillsReport{Dir: dir, Skills: []lintSkillResult{}}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return report, err
+	}
+	if !info.IsDir() {
+		return report, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var paths []string
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && entry.Name() == "SKILL.md" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+	sort.Strings(paths)
+
+	byName := make(map[string]*skill.Skill, len(paths))
+	indexByName := make(map[string]int, len(paths))
+	for _, path := range paths {
+		result := lintSkillResult{Path: path}
+		entry, err := skill.ParseFile(path)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			report.Skills = append(report.Skills, result)
+			continue
+		}
+		result.Name = entry.Name
+		result.Notes = skillNotes(entry, filepath.Dir(path))
+		if first, ok := indexByName[entry.Name]; ok {
+			result.Errors = append(result.Errors, fmt.Sprintf("duplicate skill name %q (also %s)", entry.Name, report.Skills[first].Path))
+		} else {
+			byName[entry.Name] = entry
+			indexByName[entry.Name] = len(report.Skills)
+		}
+		report.Skills = append(report.Skills, result)
+	}
+
+	for name, err := range skill.ResolveExtends(byName) {
+		index := indexByName[name]
+		report.Skills[index].Errors = append(report.Skills[index].Errors, err.Error())
+	}
+
+	for i := range report.Skills {
+		report.Skills[i].Valid = len(report.Skills[i].Errors) == 0
+		if report.Skills[i].Valid {
+			report.Valid++
+		} else {
+			report.Invalid++
+		}
+	}
+	return report, nil
+}
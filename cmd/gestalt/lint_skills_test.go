@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeLintSkill(t *testing.T, root, name, frontmatter string) string {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	path := filepath.Join(dir, "SKILL.md")
+	data := []byte("---\n" + frontmatter + "---\n\nBody.\n")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write skill: %v", err)
+	}
+	return path
+}
+
+func TestLintSkillsValidDir(t *testing.T) {
+	root := t.TempDir()
+	writeLintSkill(t, root, "git-workflows", "name: git-workflows\ndescription: Git helpers\n")
+	writeLintSkill(t, root, "git-review", "name: git-review\ndescription: Review helpers\nextends: git-workflows\n")
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	code := runLintSkillsWithOutput([]string{root}, &out, &errOut)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stdout: %s, stderr: %s)", code, out.String(), errOut.String())
+	}
+	if !strings.Contains(out.String(), "Summary: 2 valid, 0 invalid") {
+		t.Fatalf("unexpected summary: %q", out.String())
+	}
+}
+
+func TestLintSkillsReportsInvalidSkills(t *testing.T) {
+	root := t.TempDir()
+	writeLintSkill(t, root, "ok-skill", "name: ok-skill\ndescription: Fine\n")
+	badPath := writeLintSkill(t, root, "no-description", "name: no-description\n")
+	orphanPath := writeLintSkill(t, root, "orphan", "name: orphan\ndescription: Orphan\nextends: missing-base\n")
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	code := runLintSkillsWithOutput([]string{"--json", root}, &out, &errOut)
+	if code == 0 {
+		t.Fatalf("expected non-zero exit code")
+	}
+
+	var report lintSkillsReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v (%s)", err, out.String())
+	}
+	if report.Valid != 1 || report.Invalid != 2 {
+		t.Fatalf("expected 1 valid and 2 invalid, got %+v", report)
+	}
+	invalid := map[string]bool{}
+	for _, result := range report.Skills {
+		if !result.Valid {
+			if len(result.Errors) == 0 {
+				t.Fatalf("expected errors for %s", result.Path)
+			}
+			invalid[result.Path] = true
+		}
+	}
+	if !invalid[badPath] || !invalid[orphanPath] {
+		t.Fatalf("expected %s and %s to be invalid, got %v", badPath, orphanPath, invalid)
+	}
+}
+
+func TestLintSkillsEmptyDirFails(t *testing.T) {
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	code := runLintSkillsWithOutput([]string{t.TempDir()}, &out, &errOut)
+	if code == 0 {
+		t.Fatalf("expected non-zero exit code for a directory without skills")
+	}
+	if !strings.Contains(out.String(), "Summary: 0 valid, 0 invalid") {
+		t.Fatalf("unexpected summary: %q", out.String())
+	}
+}
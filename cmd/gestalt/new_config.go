@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gestalt/internal/agent"
+	"gestalt/internal/skill"
+)
+
+// scaffoldNamePattern matches the names skills accept; agent IDs use it too
+// so that the file name doubles as a readable ID.
+var scaffoldNamePattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+var skillScaffoldDirs = []string{"scripts", "references", "assets"}
+
+const agentScaffoldTemplate = `name = %q
+# Command started for each session. To launch Codex with options set in this
+# file instead, replace it with cli_type = "codex" and add model and friends.
+shell = "/bin/bash"
+
+# Prompt files from the prompts/ dir, sent once the session is up.
+# prompt = ["%s"]
+
+# Skills from the skills/ dir offered to the agent.
+# skills = []
+
+# Text in the output that means the agent is ready for its prompts.
+# onair_string = "$ "
+`
+
+const skillScaffoldTemplate = `---
+name: %s
+description: TODO describe what this skill does and when to use it.
+# license: MIT
+# allowed_tools:
+#   - bash
+---
+
+# %s
+
+Describe the steps the agent should follow when this skill applies.
+
+Put helper scripts in scripts/, longer reference material in references/,
+and templates or other files in assets/.
+`
+
+func runNew(args []string) int {
+	return runNewWithOutput(args, os.Stdout, os.Stderr)
+}
+
+// runNewWithOutput scaffolds an agent or skill in the config dir, then
+// validates what it wrote the same way config validate and validate-skill
+// would. Existing files are never overwritten.
+func runNewWithOutput(args []string, out, errOut io.Writer) int {
+	if len(args) == 0 || (args[0] != "agent" && args[0] != "skill") {
+		fmt.Fprintln(errOut, "usage: gestalt new agent|skill [--dir DIR] <name>")
+		return 1
+	}
+	kind := args[0]
+	fs := flag.NewFlagSet("gestalt new "+kind, flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	configDir := fs.String("dir", filepath.Join(".gestalt", "config"), "Config directory")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 1
+	}
+	if fs.NArg() != 1 || strings.TrimSpace(*configDir) == "" {
+		fmt.Fprintf(errOut, "usage: gestalt new %s [--dir DIR] <name>\n", kind)
+		return 1
+	}
+	name := strings.TrimSpace(fs.Arg(0))
+	if len(name) > 64 || !scaffoldNamePattern.MatchString(name) {
+		fmt.Fprintf(errOut, "invalid %s name %q: use lowercase letters, digits, and single hyphens\n", kind, name)
+		return 1
+	}
+
+	dir := filepath.Clean(strings.TrimSpace(*configDir))
+	var path string
+	var err error
+	if kind == "agent" {
+		path, err = scaffoldAgent(dir, name)
+	} else {
+		path, err = scaffoldSkill(dir, name)
+	}
+	if err != nil {
+		fmt.Fprintf(errOut, "new %s: %v\n", kind, err)
+		return 1
+	}
+	fmt.Fprintf(out, "created %s\n", path)
+	return 0
+}
+
+// validateScaffoldAgent and validateScaffoldSkill check a freshly written
+// scaffold; tests swap them to exercise the cleanup path.
+var (
+	validateScaffoldAgent = func(name, agentsDir string) error {
+		_, err := agent.LoadAgentByID(name, agentsDir)
+		return err
+	}
+	validateScaffoldSkill = func(path string) error {
+		_, err := skill.ParseFile(path)
+		return err
+	}
+)
+
+// scaffoldAgent writes agents/<name>.toml. If writing or validation fails,
+// the file and any directories created for it are removed again.
+func scaffoldAgent(configDir, name string) (string, error) {
+	agentsDir := filepath.Join(configDir, "agents")
+	path := filepath.Join(agentsDir, name+".toml")
+	createdDir, err := createDirs(agentsDir)
+	if err != nil {
+		return "", err
+	}
+	if err := writeNewFile(path, fmt.Sprintf(agentScaffoldTemplate, name, name)); err != nil {
+		removeCreatedDir(createdDir)
+		return "", err
+	}
+	if err := validateScaffoldAgent(name, agentsDir); err != nil {
+		_ = os.Remove(path)
+		removeCreatedDir(createdDir)
+		return "", fmt.Errorf("scaffold does not validate: %w", err)
+	}
+	return path, nil
+}
+
+// scaffoldSkill writes skills/<name>/ with SKILL.md and the standard
+// subdirectories. The skill dir must not exist yet, so on failure everything
+// created for it is removed again.
+func scaffoldSkill(configDir, name string) (string, error) {
+	skillDir := filepath.Join(configDir, "skills", name)
+	if _, err := os.Stat(skillDir); err == nil {
+		return "", fmt.Errorf("%s already exists", skillDir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	createdDir, err := createDirs(skillDir)
+	if err != nil {
+		return "", err
+	}
+	path, err := writeSkillScaffold(skillDir, name)
+	if err != nil {
+		removeCreatedDir(createdDir)
+		return "", err
+	}
+	return path, nil
+}
+
+func writeSkillScaffold(skillDir, name string) (string, error) {
+	for _, sub := range skillScaffoldDirs {
+		if err := os.MkdirAll(filepath.Join(skillDir, sub), 0o755); err != nil {
+			return "", err
+		}
+	}
+	path := filepath.Join(skillDir, "SKILL.md")
+	if err := writeNewFile(path, fmt.Sprintf(skillScaffoldTemplate, name, name)); err != nil {
+		return "", err
+	}
+	if err := validateScaffoldSkill(path); err != nil {
+		return "", fmt.Errorf("scaffold does not validate: %w", err)
+	}
+	return path, nil
+}
+
+// createDirs makes dir and any missing parents, returning the topmost
+// directory it created, or "" when dir already existed.
+func createDirs(dir string) (string, error) {
+	created := ""
+	for current := dir; ; {
+		if _, err := os.Stat(current); err == nil {
+			break
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		created = current
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return created, nil
+}
+
+// removeCreatedDir undoes createDirs after a failed scaffold.
+func removeCreatedDir(dir string) {
+	if dir != "" {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// writeNewFile creates path with content, failing if it already exists.
+func writeNewFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%s already exists", path)
+		}
+		return err
+	}
+	if _, err := io.WriteString(file, content); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewAgentScaffoldValidates(t *testing.T) {
+	configDir := t.TempDir()
+	var out, errOut bytes.Buffer
+	if code := runNewWithOutput([]string{"agent", "--dir", configDir, "reviewer"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+	}
+	path := filepath.Join(configDir, "agents", "reviewer.toml")
+	if !strings.Contains(out.String(), path) {
+		t.Fatalf("expected created path in output, got %q", out.String())
+	}
+
+	out.Reset()
+	if code := validateAgentsDir(filepath.Join(configDir, "agents"), &out, &errOut); code != 0 {
+		t.Fatalf("expected scaffold to pass config validate, got %d: %s", code, out.String())
+	}
+}
+
+func TestNewSkillScaffoldValidates(t *testing.T) {
+	configDir := t.TempDir()
+	var out, errOut bytes.Buffer
+	if code := runNewWithOutput([]string{"skill", "--dir", configDir, "triage"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+	}
+	skillDir := filepath.Join(configDir, "skills", "triage")
+	for _, sub := range []string{"scripts", "references", "assets"} {
+		if info, err := os.Stat(filepath.Join(skillDir, sub)); err != nil || !info.IsDir() {
+			t.Fatalf("expected %s dir in scaffold: %v", sub, err)
+		}
+	}
+
+	report, err := lintSkillsDir(filepath.Join(configDir, "skills"))
+	if err != nil {
+		t.Fatalf("lint skills: %v", err)
+	}
+	if report.Valid != 1 || report.Invalid != 0 {
+		t.Fatalf("expected scaffold to lint clean, got %+v", report)
+	}
+}
+
+func TestNewRefusesExistingAndInvalidNames(t *testing.T) {
+	configDir := t.TempDir()
+	var out, errOut bytes.Buffer
+	if code := runNewWithOutput([]string{"agent", "--dir", configDir, "reviewer"}, &out, &errOut); code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, errOut.String())
+	}
+	errOut.Reset()
+	if code := runNewWithOutput([]string{"agent", "--dir", configDir, "reviewer"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "already exists") {
+		t.Fatalf("expected existing agent to be refused, got %d: %s", code, errOut.String())
+	}
+	errOut.Reset()
+	if code := runNewWithOutput([]string{"skill", "--dir", configDir, "Bad Name"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "invalid skill name") {
+		t.Fatalf("expected invalid name to be refused, got %d: %s", code, errOut.String())
+	}
+	errOut.Reset()
+	if code := runNewWithOutput([]string{"prompt", "x"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "usage:") {
+		t.Fatalf("expected usage for unknown kind, got %d: %s", code, errOut.String())
+	}
+}
+
+func TestNewRemovesScaffoldThatFailsValidation(t *testing.T) {
+	restoreAgent, restoreSkill := validateScaffoldAgent, validateScaffoldSkill
+	t.Cleanup(func() {
+		validateScaffoldAgent, validateScaffoldSkill = restoreAgent, restoreSkill
+	})
+	validateScaffoldAgent = func(string, string) error { return errors.New("broken") }
+	validateScaffoldSkill = func(string) error { return errors.New("broken") }
+
+	configDir := filepath.Join(t.TempDir(), "config")
+	var out, errOut bytes.Buffer
+	if code := runNewWithOutput([]string{"agent", "--dir", configDir, "reviewer"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "does not validate") {
+		t.Fatalf("expected validation failure, got %d: %s", code, errOut.String())
+	}
+	if _, err := os.Stat(configDir); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected created config dir to be removed, got %v", err)
+	}
+
+	skillsDir := filepath.Join(configDir, "skills")
+	if err := os.MkdirAll(skillsDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	errOut.Reset()
+	if code := runNewWithOutput([]string{"skill", "--dir", configDir, "triage"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "does not validate") {
+		t.Fatalf("expected validation failure, got %d: %s", code, errOut.String())
+	}
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		t.Fatalf("read skills dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected failed skill scaffold to be removed, found %d entries", len(entries))
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"gestalt"
+	"gestalt/internal/agent"
 	"gestalt/internal/api"
 	"gestalt/internal/app"
 	"gestalt/internal/config"
@@ -247,10 +248,13 @@ func runServer(args []string) int {
 		ConfigOverlay:        configOverlay,
 		ConfigRoot:           configPaths.SubDir,
 		AgentsDir:            filepath.Join(configPaths.ConfigDir, "agents"),
+		ConfigLayers:         cfg.ConfigLayers,
+		ConfigFile:           cfg.ConfigFile,
 		ProcessRegistry:      processRegistry,
 		SessionLogDir:        cfg.SessionLogDir,
 		InputHistoryDir:      cfg.InputHistoryDir,
 		SessionRetentionDays: cfg.SessionRetentionDays,
+		SessionDirMaxBytes:   cfg.SessionDirMaxBytes,
 		BufferLines:          cfg.SessionBufferLines,
 		SessionLogMaxBytes:   settings.Session.LogMaxBytes,
 		HistoryScanMaxBytes:  settings.Session.HistoryScanMaxBytes,
@@ -258,6 +262,10 @@ func runServer(args []string) int {
 		TUIMode:              settings.Session.TUIMode,
 		TUISnapshotInterval:  tuiSnapshotInterval,
 		PortResolver:         portRegistry,
+		RedactPatterns:       settings.Session.RedactPatterns,
+		OutputRateLimit:      settings.Session.OutputRateLimit,
+		LauncherCheckWindow:  time.Duration(settings.Session.LauncherCheckWindowMS) * time.Millisecond,
+		BellDebounce:         time.Duration(settings.Session.BellDebounceMS) * time.Millisecond,
 	})
 	if err != nil {
 		var buildErr app.BuildError
@@ -273,6 +281,16 @@ func runServer(args []string) int {
 					"error": buildErr.Err.Error(),
 				})
 				return 1
+			case app.StageConfigFile:
+				logger.Error("load config file failed", map[string]string{
+					"error": buildErr.Err.Error(),
+				})
+				return 1
+			case app.StageRedact:
+				logger.Error("invalid session redact patterns", map[string]string{
+					"error": buildErr.Err.Error(),
+				})
+				return 1
 			}
 		}
 		logger.Error("app build failed", map[string]string{
@@ -390,6 +408,10 @@ func runServer(args []string) int {
 		SessionFontSize:        settings.Session.FontSize,
 		SessionInputFontFamily: settings.Session.InputFontFamily,
 		SessionInputFontSize:   settings.Session.InputFontSize,
+		AllowMetricsReset:      cfg.AllowMetricsReset,
+		InputFileRoot:          cfg.InputFileRoot,
+		Watcher:                fsWatcher,
+		DefaultAgentPath:       agent.DefaultAgentPath(configPaths.ConfigDir),
 	}, "", nil, logger, eventBus, flowService)
 	backendListener, backendPort, err := listenOnPort(cfg.BackendPort)
 	if err != nil {
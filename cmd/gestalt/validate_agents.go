@@ -40,6 +40,12 @@ func validateAgentsDir(agentsDir string, out, errOut io.Writer) int {
 		return 1
 	}
 
+	promptsDir := filepath.Join(filepath.Dir(filepath.Clean(agentsDir)), "prompts")
+	missingPrompts, err := agentsMissingPrompts(agentsDir, promptsDir)
+	if err != nil {
+		fmt.Fprintf(errOut, "load agents: %v\n", err)
+		return 1
+	}
 	validCount := 0
 	invalidCount := 0
 	for _, entry := range entries {
@@ -62,6 +68,11 @@ func validateAgentsDir(agentsDir string, out, errOut io.Writer) int {
 			fmt.Fprintf(out, "ERROR %s: %v\n", name, err)
 			continue
 		}
+		if missing := missingPrompts[agentID]; len(missing) > 0 {
+			invalidCount++
+			fmt.Fprintf(out, "ERROR %s: missing prompt files in %s: %s\n", name, promptsDir, strings.Join(missing, ", "))
+			continue
+		}
 		validCount++
 		if profile != nil && strings.TrimSpace(profile.Name) != "" {
 			fmt.Fprintf(out, "OK %s (%s)\n", agentID, profile.Name)
@@ -77,6 +88,22 @@ func validateAgentsDir(agentsDir string, out, errOut io.Writer) int {
 	return 0
 }
 
+// agentsMissingPrompts loads agentsDir with strict prompt checking and
+// returns the unresolved prompt names by agent ID.
+func agentsMissingPrompts(agentsDir, promptsDir string) (map[string][]string, error) {
+	loader := agent.Loader{StrictPrompts: true}
+	_, err := loader.Load(nil, agentsDir, promptsDir, nil)
+	var missingErr *agent.MissingPromptsError
+	if !errors.As(err, &missingErr) {
+		return nil, err
+	}
+	missing := make(map[string][]string)
+	for _, entry := range missingErr.Missing {
+		missing[entry.AgentID] = append(missing[entry.AgentID], entry.Prompt)
+	}
+	return missing, nil
+}
+
 const legacyMemoScanLimit = 2 * 1024 * 1024
 
 var legacyMemoPatterns = [][]byte{
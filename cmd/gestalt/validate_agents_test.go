@@ -52,6 +52,34 @@ func TestValidateConfigInvalidDir(t *testing.T) {
 	}
 }
 
+func TestValidateConfigReportsMissingPrompt(t *testing.T) {
+	dir := t.TempDir()
+	agentsDir := filepath.Join(dir, "agents")
+	promptsDir := filepath.Join(dir, "prompts")
+	for _, path := range []string{agentsDir, promptsDir} {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "present.md"), []byte("hello"), 0o644); err != nil {
+		t.Fatalf("write prompt: %v", err)
+	}
+	data := []byte("name = \"Codex\"\nshell = \"/bin/bash\"\nprompt = [\"present\", \"absent\"]\n")
+	if err := os.WriteFile(filepath.Join(agentsDir, "codex.toml"), data, 0o644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	code := runValidateConfigWithOutput([]string{"--agents-dir", agentsDir}, &out, &errOut)
+	if code == 0 {
+		t.Fatalf("expected non-zero exit code")
+	}
+	if !strings.Contains(out.String(), "ERROR codex.toml: missing prompt files") || !strings.Contains(out.String(), "absent") || strings.Contains(out.String(), "present,") {
+		t.Fatalf("expected missing prompt error, got %q", out.String())
+	}
+}
+
 func TestValidateConfigEmptyDir(t *testing.T) {
 	dir := t.TempDir()
 	agentsDir := filepath.Join(dir, "agents")
@@ -103,11 +103,16 @@ func watchPlanFile(bus *event.Bus[watcher.Event], watch watcher.Watch, logger *l
 	}
 
 	events, _ := bus.SubscribeFiltered(func(event watcher.Event) bool {
-		return event.Type == watcher.EventTypeFileChanged && event.Path == planPath
+		if event.Path != planPath {
+			return false
+		}
+		return event.Type == watcher.EventTypeFileChanged || event.Type == watcher.EventTypeOverflow
 	})
 	go func() {
 		for event := range events {
-			if event.Op&(fsnotify.Remove|fsnotify.Rename) == 0 {
+			// After an overflow the plans path may have been removed or
+			// replaced without us seeing it, so re-arm the watch as if it had.
+			if event.Type != watcher.EventTypeOverflow && event.Op&(fsnotify.Remove|fsnotify.Rename) == 0 {
 				continue
 			}
 			stopWatch()
@@ -57,6 +57,7 @@ const normalizeTerminal = (terminal) => {
     id: String(id),
     title: terminal?.title ? String(terminal.title) : '',
     interface: interfaceValue,
+    mcp: Boolean(terminal?.mcp),
     runner: normalizeRunner(terminal?.runner),
     gui_modules: normalizeStringArray(terminal?.gui_modules),
     model: terminal?.model ? String(terminal.model) : '',
@@ -206,6 +207,13 @@ export const fetchAgents = async () => {
   return normalizeArray(payload, normalizeAgent)
 }
 
+export const fetchDefaultAgent = async () => {
+  const response = await apiFetch('/api/agents/default')
+  const payload = normalizeObject(await response.json())
+  if (payload.missing) return ''
+  return payload.agent_id ? String(payload.agent_id) : ''
+}
+
 export const fetchAgentSkills = async (agentId) => {
   if (!agentId) return []
   const response = await apiFetch(`/api/skills${buildQuery({ agent: agentId })}`)
@@ -2,7 +2,7 @@ import { get, writable } from 'svelte/store'
 import { subscribe as subscribeAgentEvents } from './agentEventStore.js'
 import { subscribe as subscribeConfigEvents } from './configEventStore.js'
 import { subscribe as subscribeEvents } from './eventStore.js'
-import { fetchAgents, fetchGitLog } from './apiClient.js'
+import { fetchAgents, fetchDefaultAgent, fetchGitLog } from './apiClient.js'
 import { getErrorMessage } from './errorUtils.js'
 import { notificationStore } from './notificationStore.js'
 import { createLogStream } from './logStream.js'
@@ -16,6 +16,7 @@ const logFlushDelayMs = 16
 export const createDashboardStore = () => {
   const state = writable({
     agents: [],
+    defaultAgentId: '',
     agentsLoading: false,
     agentsError: '',
     logs: [],
@@ -162,7 +163,10 @@ export const createDashboardStore = () => {
   const loadAgents = async () => {
     state.update((current) => ({ ...current, agentsLoading: true, agentsError: '' }))
     try {
-      const fetched = await fetchAgents()
+      const [fetched, defaultAgentId] = await Promise.all([
+        fetchAgents(),
+        fetchDefaultAgent().catch(() => ''),
+      ])
       const fetchedWithBackendState = fetched.map((agent) => ({
         ...agent,
         _backend_running: Boolean(agent?.running),
@@ -171,6 +175,7 @@ export const createDashboardStore = () => {
       state.update((current) => ({
         ...current,
         agents: nextAgents,
+        defaultAgentId,
         agentsLoading: false,
       }))
     } catch (err) {
@@ -19,6 +19,7 @@ import {
   createTerminal,
   fetchAgentSkills,
   fetchAgents,
+  fetchDefaultAgent,
   fetchFlowActivities,
   fetchFlowConfig,
   fetchGitLog,
@@ -119,6 +120,23 @@ describe('apiClient', () => {
     expect(result).toEqual([{ id: 'hidden', name: 'Hidden', hidden: true, model: '' }])
   })
 
+  it('fetches the default agent id', async () => {
+    apiFetch.mockResolvedValue({ json: vi.fn().mockResolvedValue({ agent_id: 'codex', name: 'Codex' }) })
+
+    const result = await fetchDefaultAgent()
+
+    expect(apiFetch).toHaveBeenCalledWith('/api/agents/default')
+    expect(result).toBe('codex')
+  })
+
+  it('ignores a missing default agent', async () => {
+    apiFetch.mockResolvedValue({ json: vi.fn().mockResolvedValue({ agent_id: 'gone', missing: true }) })
+
+    const result = await fetchDefaultAgent()
+
+    expect(result).toBe('')
+  })
+
   it('normalizes malformed agent skills payloads', async () => {
     apiFetch.mockResolvedValue({ json: vi.fn().mockResolvedValue([null, { name: '' }, { name: 'Skill' }]) })
 
@@ -21,6 +21,7 @@ import Dashboard from '../src/views/Dashboard.svelte'
 const buildDashboardStore = (stateOverrides = {}) => {
   const store = writable({
     agents: [],
+    defaultAgentId: '',
     agentsLoading: false,
     agentsError: '',
     logs: [],
@@ -111,6 +112,28 @@ describe('Dashboard', () => {
     expect(queryByText('Hidden')).toBeNull()
   })
 
+  it('marks the default agent in the dashboard grid', async () => {
+    const dashboardStore = buildDashboardStore({
+      agents: [
+        { id: 'codex', name: 'Codex' },
+        { id: 'claude', name: 'Claude' },
+      ],
+      defaultAgentId: 'codex',
+    })
+    createDashboardStore.mockReturnValue(dashboardStore)
+
+    const { findByText, getAllByText } = render(Dashboard, {
+      props: {
+        terminals: [],
+        status: { session_count: 0 },
+      },
+    })
+
+    const button = await findByText('Codex')
+    expect(getAllByText('Default')).toHaveLength(1)
+    expect(button.closest('.agent-card').classList.contains('agent-card--default')).toBe(true)
+  })
+
   it('expands log details from recent logs', async () => {
     Object.defineProperty(window, 'isSecureContext', {
       value: true,
@@ -3,6 +3,7 @@ import { get } from 'svelte/store'
 import { createLogStreamStub } from './helpers/appApiMocks.js'
 
 const fetchAgents = vi.hoisted(() => vi.fn())
+const fetchDefaultAgent = vi.hoisted(() => vi.fn(() => Promise.resolve('')))
 const fetchGitLog = vi.hoisted(() => vi.fn())
 const addNotification = vi.hoisted(() => vi.fn())
 const subscribeAgentEvents = vi.hoisted(() => vi.fn())
@@ -12,6 +13,7 @@ const createLogStream = vi.hoisted(() => vi.fn())
 
 vi.mock('../src/lib/apiClient.js', () => ({
   fetchAgents,
+  fetchDefaultAgent,
   fetchGitLog,
 }))
 
@@ -96,6 +98,29 @@ describe('dashboardStore', () => {
     expect(value.agentsLoading).toBe(false)
   })
 
+  it('loads the default agent alongside agents', async () => {
+    fetchAgents.mockResolvedValue([{ id: 'a1', name: 'Agent 1' }])
+    fetchDefaultAgent.mockResolvedValueOnce('a1')
+
+    const store = createDashboardStore()
+    await store.loadAgents()
+
+    expect(get(store).defaultAgentId).toBe('a1')
+  })
+
+  it('keeps agents when the default agent fails to load', async () => {
+    fetchAgents.mockResolvedValue([{ id: 'a1', name: 'Agent 1' }])
+    fetchDefaultAgent.mockRejectedValueOnce(new Error('boom'))
+
+    const store = createDashboardStore()
+    await store.loadAgents()
+
+    const value = get(store)
+    expect(value.agents).toHaveLength(1)
+    expect(value.defaultAgentId).toBe('')
+    expect(value.agentsError).toBe('')
+  })
+
   it('captures agent load errors', async () => {
     fetchAgents.mockRejectedValue(new Error('boom'))
 
@@ -3,6 +3,8 @@ package agent
 import (
 	"fmt"
 	"strings"
+
+	"golang.org/x/text/encoding/ianaindex"
 )
 
 // PromptList supports "prompt" as a string or array in TOML.
@@ -61,6 +63,8 @@ func (p *PromptList) setPromptList(values []string) error {
 type Agent struct {
 	Name        string                 `json:"name" toml:"name"`
 	Shell       string                 `json:"shell,omitempty" toml:"shell,omitempty"`
+	Command     string                 `json:"command,omitempty" toml:"command,omitempty"`
+	Args        []string               `json:"args,omitempty" toml:"args,omitempty"`
 	Prompts     PromptList             `json:"prompt,omitempty" toml:"prompt,omitempty"`
 	Skills      []string               `json:"skills,omitempty" toml:"skills,omitempty"`
 	OnAirString string                 `json:"onair_string,omitempty" toml:"onair_string,omitempty"`
@@ -71,8 +75,13 @@ type Agent struct {
 	CodexMode   string                 `json:"codex_mode,omitempty" toml:"codex_mode,omitempty"`
 	Model       string                 `json:"model,omitempty" toml:"model,omitempty"`
 	Hidden      bool                   `json:"hidden" toml:"hidden,omitempty"`
+	PtyFactory  string                 `json:"pty_factory,omitempty" toml:"pty_factory,omitempty"`
+	Encoding    string                 `json:"encoding,omitempty" toml:"encoding,omitempty"`
 	ConfigHash  string                 `json:"-" toml:"-"`
 	warnings    []string               `json:"-" toml:"-"`
+	inline      bool
+	// Extends names the agent ID this profile inherits unset fields from.
+	Extends string `json:"extends,omitempty" toml:"extends,omitempty"`
 }
 
 const (
@@ -90,9 +99,15 @@ func (a *Agent) Validate() error {
 			Message: "codex_mode is no longer supported",
 		}
 	}
+	if err := a.validateCommand(); err != nil {
+		return err
+	}
 	if _, err := a.resolveShell(); err != nil {
 		return err
 	}
+	if err := validateEncoding(a.Encoding); err != nil {
+		return err
+	}
 
 	for i, prompt := range a.Prompts {
 		if strings.TrimSpace(prompt) == "" {
@@ -103,6 +118,23 @@ func (a *Agent) Validate() error {
 	return nil
 }
 
+// validateEncoding accepts the IANA charset names sessions can transcode,
+// e.g. "latin1"; empty and UTF-8 mean no transcoding.
+func validateEncoding(name string) error {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", "utf-8", "utf8":
+		return nil
+	}
+	if enc, err := ianaindex.IANA.Encoding(name); err == nil && enc != nil {
+		return nil
+	}
+	return &ValidationError{
+		Path:    "encoding",
+		Message: fmt.Sprintf("unsupported encoding %q", name),
+	}
+}
+
 func (a *Agent) RuntimeInterface(forceTUI bool) (string, error) {
 	_ = forceTUI
 	return AgentInterfaceCLI, nil
@@ -130,6 +162,9 @@ func (a *Agent) resolveShell() (string, error) {
 		}
 		return command, nil
 	}
+	if a.HasCommand() {
+		return strings.TrimSpace(strings.Join(append([]string{a.Command}, a.Args...), " ")), nil
+	}
 	command := strings.TrimSpace(a.Shell)
 	if command == "" {
 		return "", fmt.Errorf("agent shell is required")
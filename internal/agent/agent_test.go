@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -281,3 +282,57 @@ func TestAgentInterfacePrecedence(t *testing.T) {
 		})
 	}
 }
+
+func TestAgentValidateEncoding(t *testing.T) {
+	for _, name := range []string{"", "utf-8", "latin1", "windows-1252"} {
+		agent := Agent{Name: "Legacy", Shell: "/bin/sh", Encoding: name}
+		if err := agent.Validate(); err != nil {
+			t.Fatalf("expected encoding %q to validate, got %v", name, err)
+		}
+	}
+	agent := Agent{Name: "Legacy", Shell: "/bin/sh", Encoding: "klingon"}
+	err := agent.Validate()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Path != "encoding" {
+		t.Fatalf("expected encoding validation error, got %v", err)
+	}
+}
+
+func TestAgentCommandTemplate(t *testing.T) {
+	loaded, err := loadAgentFromBytes("config/agents/wrapped.toml", []byte(`
+name = "Wrapped"
+command = "llm-wrapper"
+args = ["--model", "{model}", "--skills={skills}", "{prompt}"]
+model = "large"
+`))
+	if err != nil {
+		t.Fatalf("load agent: %v", err)
+	}
+	if len(loaded.CLIConfig) != 0 {
+		t.Fatalf("expected command and args kept out of cli_config, got %v", loaded.CLIConfig)
+	}
+	if !loaded.CommandUses(PlaceholderPrompt) || loaded.CommandUses("{missing}") {
+		t.Fatalf("unexpected placeholder detection")
+	}
+	command, args := loaded.ExpandCommand(CommandValues{Prompt: "two\nlines", Model: loaded.Model, Skills: []string{"git", "review"}})
+	if command != "llm-wrapper" {
+		t.Fatalf("command mismatch: %q", command)
+	}
+	if want := []string{"--model", "large", "--skills=git,review", "two\nlines"}; strings.Join(args, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected args %q, got %q", want, args)
+	}
+
+	for _, tc := range []struct {
+		agent Agent
+		path  string
+	}{
+		{Agent{Name: "Args", Shell: "/bin/sh", Args: []string{"-x"}}, "args"},
+		{Agent{Name: "Both", Command: "llm-wrapper", CLIType: "codex"}, "command"},
+	} {
+		err := tc.agent.Validate()
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Path != tc.path {
+			t.Fatalf("expected %s validation error for %q, got %v", tc.path, tc.agent.Name, err)
+		}
+	}
+}
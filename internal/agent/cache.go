@@ -24,10 +24,16 @@ func NewAgentCache(initial map[string]Agent) *AgentCache {
 }
 
 func (c *AgentCache) LoadOrReload(agentID string, agentsDir string) (*Agent, bool, error) {
+	return c.LoadOrReloadLayered(agentID, []string{agentsDir})
+}
+
+// LoadOrReloadLayered is LoadOrReload for an agent in the last of
+// agentsDirs; see LoadLayeredAgentByID.
+func (c *AgentCache) LoadOrReloadLayered(agentID string, agentsDirs []string) (*Agent, bool, error) {
 	if c == nil {
 		return nil, false, nil
 	}
-	latest, err := LoadAgentByID(agentID, agentsDir)
+	latest, err := LoadLayeredAgentByID(agentID, agentsDirs)
 	if err != nil {
 		return nil, false, err
 	}
@@ -0,0 +1,81 @@
+package agent
+
+import "strings"
+
+// Placeholders expanded in an agent's command and args at session creation.
+const (
+	PlaceholderPrompt = "{prompt}"
+	PlaceholderModel  = "{model}"
+	PlaceholderSkills = "{skills}"
+)
+
+// CommandValues holds what the command placeholders expand to for one
+// session: the rendered prompt text, the model, and the agent's skills,
+// which {skills} joins with commas.
+type CommandValues struct {
+	Prompt string
+	Model  string
+	Skills []string
+}
+
+// HasCommand reports whether the agent launches through a command template
+// instead of shell or cli_config.
+func (a *Agent) HasCommand() bool {
+	return a != nil && strings.TrimSpace(a.Command) != ""
+}
+
+// CommandUses reports whether the command or any arg contains placeholder.
+func (a *Agent) CommandUses(placeholder string) bool {
+	if !a.HasCommand() {
+		return false
+	}
+	if strings.Contains(a.Command, placeholder) {
+		return true
+	}
+	for _, arg := range a.Args {
+		if strings.Contains(arg, placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandCommand returns the command and args with placeholders replaced.
+// Each arg stays a single argument whatever its value expands to, so a
+// multi-line prompt is passed intact.
+func (a *Agent) ExpandCommand(values CommandValues) (string, []string) {
+	if !a.HasCommand() {
+		return "", nil
+	}
+	replacer := strings.NewReplacer(
+		PlaceholderPrompt, values.Prompt,
+		PlaceholderModel, values.Model,
+		PlaceholderSkills, strings.Join(values.Skills, ","),
+	)
+	args := make([]string, 0, len(a.Args))
+	for _, arg := range a.Args {
+		args = append(args, replacer.Replace(arg))
+	}
+	return replacer.Replace(strings.TrimSpace(a.Command)), args
+}
+
+// validateCommand rejects args without a command, and a command alongside
+// cli_type or cli_config, which build their own launch command.
+func (a *Agent) validateCommand() error {
+	if !a.HasCommand() {
+		if len(a.Args) > 0 {
+			return &ValidationError{
+				Path:    "args",
+				Message: "args requires command",
+			}
+		}
+		return nil
+	}
+	if strings.TrimSpace(a.CLIType) != "" || len(a.CLIConfig) > 0 {
+		return &ValidationError{
+			Path:    "command",
+			Message: "command cannot be combined with cli_type or cli_config",
+		}
+	}
+	return nil
+}
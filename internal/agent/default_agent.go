@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultAgentFile holds the agent ID chosen for quick launch. It lives next
+// to version.json in the config root, outside the extracted config dir.
+const DefaultAgentFile = "default-agent.json"
+
+type defaultAgentRecord struct {
+	AgentID string `json:"agent_id"`
+}
+
+// DefaultAgentPath returns where the default agent is stored for configDir.
+func DefaultAgentPath(configDir string) string {
+	return filepath.Join(filepath.Dir(filepath.Clean(configDir)), DefaultAgentFile)
+}
+
+// LoadDefaultAgent returns the stored default agent ID, or "" when none is
+// set.
+func LoadDefaultAgent(path string) (string, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	var record defaultAgentRecord
+	if err := json.Unmarshal(payload, &record); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(record.AgentID), nil
+}
+
+// SaveDefaultAgent stores agentID as the default agent; an empty ID clears
+// it. Callers are expected to have checked that the agent exists.
+func SaveDefaultAgent(path, agentID string) error {
+	agentID = strings.TrimSpace(agentID)
+	if agentID == "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	payload, err := json.MarshalIndent(defaultAgentRecord{AgentID: agentID}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	payload = append(payload, '\n')
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, payload, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,162 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// pendingAgent is a parsed agent that declares extends. It is validated only
+// after its parent's fields are merged in.
+type pendingAgent struct {
+	agent  Agent
+	source string
+	data   []byte
+}
+
+const (
+	extendsVisiting = iota + 1
+	extendsResolved
+)
+
+type extendsCycleError struct {
+	chain []string
+}
+
+func (e extendsCycleError) Error() string {
+	return fmt.Sprintf("agent extends cycle: %s", strings.Join(e.chain, " -> "))
+}
+
+// resolveExtends finishes each pending agent by merging it over its parent,
+// looked up by agent ID among the loaded agents, the other pending ones, and
+// then parents. An agent that extends its own ID takes its parent from
+// parents. It returns the finished agents, plus an error for every agent
+// whose parent is missing, invalid, or part of a cycle.
+func resolveExtends(loaded map[string]Agent, pending map[string]pendingAgent, parents map[string]Agent) (map[string]Agent, map[string]error) {
+	resolver := extendsResolver{
+		loaded:   loaded,
+		pending:  pending,
+		parents:  parents,
+		state:    make(map[string]int, len(pending)),
+		resolved: make(map[string]Agent, len(pending)),
+		errs:     make(map[string]error),
+	}
+	for id := range pending {
+		_, _ = resolver.resolve(id, nil)
+	}
+	return resolver.resolved, resolver.errs
+}
+
+type extendsResolver struct {
+	loaded   map[string]Agent
+	pending  map[string]pendingAgent
+	parents  map[string]Agent
+	state    map[string]int
+	resolved map[string]Agent
+	errs     map[string]error
+}
+
+func (r *extendsResolver) resolve(id string, chain []string) (Agent, error) {
+	switch r.state[id] {
+	case extendsResolved:
+		return r.resolved[id], r.errs[id]
+	case extendsVisiting:
+		return Agent{}, extendsCycleError{chain: append(chain, id)}
+	}
+
+	r.state[id] = extendsVisiting
+	child := r.pending[id]
+	parentID := child.agent.Extends
+	var parent Agent
+	var err error
+	if inherited, ok := r.parents[parentID]; ok && parentID == id {
+		parent = inherited
+	} else if loaded, ok := r.loaded[parentID]; ok {
+		parent = loaded
+	} else if _, ok := r.pending[parentID]; ok {
+		parent, err = r.resolve(parentID, append(chain, id))
+		var cycle extendsCycleError
+		if err != nil && !errors.As(err, &cycle) {
+			err = fmt.Errorf("parent agent %q: %w", parentID, err)
+		}
+	} else if inherited, ok := r.parents[parentID]; ok {
+		parent = inherited
+	} else {
+		err = fmt.Errorf("parent agent %q not found", parentID)
+	}
+
+	var agent Agent
+	if err == nil {
+		agent, err = finishExtendedAgent(child, parent)
+	}
+	r.state[id] = extendsResolved
+	if err != nil {
+		r.errs[id] = err
+		return Agent{}, err
+	}
+	r.resolved[id] = agent
+	return agent, nil
+}
+
+// finishExtendedAgent merges parent into child, then validates the result as
+// if it had been written out in full.
+func finishExtendedAgent(child pendingAgent, parent Agent) (Agent, error) {
+	merged := inheritFrom(child.agent, parent)
+	if err := ValidateAgentConfig(merged.CLIType, merged.CLIConfig); err != nil {
+		return Agent{}, formatParseError(child.source, err)
+	}
+	return finishAgent(merged, child.source, child.data)
+}
+
+// inheritFrom fills the fields child leaves unset from parent. A child that
+// sets shell or command without cli_type or cli_config replaces the parent's
+// command outright; otherwise cli_config keys merge, the child's winning, and a child
+// model overrides the parent's. Name and hidden are never inherited.
+func inheritFrom(child, parent Agent) Agent {
+	merged := child
+	ownShell := (strings.TrimSpace(child.Shell) != "" || child.HasCommand()) && child.CLIType == "" && len(child.CLIConfig) == 0
+	if !ownShell {
+		if merged.CLIType == "" {
+			merged.CLIType = parent.CLIType
+		}
+		if len(parent.CLIConfig) > 0 {
+			cliConfig := make(map[string]interface{}, len(parent.CLIConfig)+len(child.CLIConfig))
+			for key, value := range parent.CLIConfig {
+				cliConfig[key] = value
+			}
+			for key, value := range child.CLIConfig {
+				cliConfig[key] = value
+			}
+			if _, ok := child.CLIConfig["model"]; !ok && child.Model != "" {
+				cliConfig["model"] = child.Model
+			}
+			merged.CLIConfig = cliConfig
+		}
+		if strings.TrimSpace(merged.Shell) == "" {
+			merged.Shell = parent.Shell
+		}
+		if !merged.HasCommand() && parent.HasCommand() && child.CLIType == "" && len(child.CLIConfig) == 0 {
+			merged.Command = parent.Command
+			merged.Args = append([]string(nil), parent.Args...)
+		}
+	}
+	if len(merged.Prompts) == 0 && len(parent.Prompts) > 0 {
+		merged.Prompts = append(PromptList(nil), parent.Prompts...)
+	}
+	if len(merged.Skills) == 0 && len(parent.Skills) > 0 {
+		merged.Skills = append([]string(nil), parent.Skills...)
+	}
+	if merged.OnAirString == "" {
+		merged.OnAirString = parent.OnAirString
+	}
+	if merged.Model == "" {
+		merged.Model = parent.Model
+	}
+	if merged.PtyFactory == "" {
+		merged.PtyFactory = parent.PtyFactory
+	}
+	if merged.Encoding == "" {
+		merged.Encoding = parent.Encoding
+	}
+	return merged
+}
@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeAgentFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoaderResolvesExtends(t *testing.T) {
+	dir := writeAgentFiles(t, map[string]string{
+		"base.toml": `
+name = "Base"
+cli_type = "codex"
+model = "gpt-5"
+prompt = ["coder"]
+skills = ["git"]
+onair_string = "ready"
+hidden = true
+`,
+		"fast.toml": `
+name = "Fast"
+extends = "base"
+model = "gpt-5-mini"
+`,
+		"plain.toml": `
+name = "Plain"
+extends = "base"
+shell = "/bin/bash"
+`,
+	})
+
+	agents, err := Loader{}.Load(nil, dir, "", map[string]struct{}{"git": {}})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	fast, ok := agents["fast"]
+	if !ok {
+		t.Fatalf("expected fast agent, got %v", agents)
+	}
+	if fast.Model != "gpt-5-mini" || fast.CLIType != "codex" {
+		t.Fatalf("unexpected fast model/cli: %q %q", fast.Model, fast.CLIType)
+	}
+	if fast.CLIConfig["model"] != "gpt-5-mini" || !strings.Contains(fast.Shell, "gpt-5-mini") {
+		t.Fatalf("expected child model in cli config and shell, got %v %q", fast.CLIConfig, fast.Shell)
+	}
+	if !reflect.DeepEqual([]string(fast.Prompts), []string{"coder"}) || !reflect.DeepEqual(fast.Skills, []string{"git"}) {
+		t.Fatalf("expected inherited prompts and skills, got %v %v", fast.Prompts, fast.Skills)
+	}
+	if fast.OnAirString != "ready" || fast.Hidden {
+		t.Fatalf("expected onair inherited and hidden not, got %q %v", fast.OnAirString, fast.Hidden)
+	}
+	if fast.ConfigHash == "" || fast.ConfigHash == agents["base"].ConfigHash {
+		t.Fatalf("expected distinct config hash")
+	}
+
+	plain := agents["plain"]
+	if plain.Shell != "/bin/bash" || len(plain.CLIConfig) != 0 {
+		t.Fatalf("expected own shell to replace parent command, got %q %v", plain.Shell, plain.CLIConfig)
+	}
+}
+
+func TestLoaderExtendsErrors(t *testing.T) {
+	dir := writeAgentFiles(t, map[string]string{
+		"ok.toml":     "name = \"OK\"\nshell = \"/bin/sh\"\n",
+		"orphan.toml": "name = \"Orphan\"\nextends = \"missing\"\n",
+		"a.toml":      "name = \"A\"\nextends = \"b\"\n",
+		"b.toml":      "name = \"B\"\nextends = \"a\"\n",
+		"self.toml":   "name = \"Self\"\nextends = \"self\"\n",
+	})
+
+	agents, err := Loader{}.Load(nil, dir, "", nil)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(agents) != 1 {
+		t.Fatalf("expected only ok agent, got %v", agents)
+	}
+
+	pending := map[string]pendingAgent{
+		"orphan": {agent: Agent{Name: "Orphan", Extends: "missing"}},
+		"a":      {agent: Agent{Name: "A", Extends: "b"}},
+		"b":      {agent: Agent{Name: "B", Extends: "a"}},
+	}
+	_, errs := resolveExtends(nil, pending, nil)
+	if err := errs["orphan"]; err == nil || !strings.Contains(err.Error(), `parent agent "missing" not found`) {
+		t.Fatalf("expected missing parent error, got %v", err)
+	}
+	if err := errs["a"]; err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestLoadAgentByIDResolvesExtends(t *testing.T) {
+	dir := writeAgentFiles(t, map[string]string{
+		"base.toml":  "name = \"Base\"\nshell = \"/bin/sh\"\nonair_string = \"$\"\n",
+		"child.toml": "name = \"Child\"\nextends = \"base\"\n",
+		"loop.toml":  "name = \"Loop\"\nextends = \"loop\"\n",
+	})
+
+	child, err := LoadAgentByID("child", dir)
+	if err != nil {
+		t.Fatalf("load child: %v", err)
+	}
+	if child.Shell != "/bin/sh" || child.OnAirString != "$" {
+		t.Fatalf("expected inherited fields, got %q %q", child.Shell, child.OnAirString)
+	}
+	if _, err := LoadAgentByID("loop", dir); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
+
+func TestLoadInlineExtendsParents(t *testing.T) {
+	loader := Loader{Parents: map[string]Agent{
+		"base": {Name: "Base", Shell: "/bin/sh", OnAirString: "$"},
+	}}
+	agents := loader.LoadInline("gestalt.yaml", []map[string]any{
+		{"id": "child", "name": "Child", "extends": "base"},
+		{"id": "grandchild", "name": "Grandchild", "extends": "child", "model": "small"},
+	}, nil)
+
+	grandchild, ok := agents["grandchild"]
+	if !ok {
+		t.Fatalf("expected grandchild agent, got %v", agents)
+	}
+	if grandchild.Shell != "/bin/sh" || grandchild.OnAirString != "$" || grandchild.Model != "small" {
+		t.Fatalf("unexpected merged agent: %+v", grandchild)
+	}
+	if !grandchild.IsInline() {
+		t.Fatalf("expected inline agent")
+	}
+}
+
+func TestLoaderExtendsCommandTemplate(t *testing.T) {
+	dir := writeAgentFiles(t, map[string]string{
+		"base.toml": `
+name = "Base"
+command = "llm-wrapper"
+args = ["--model", "{model}"]
+model = "large"
+`,
+		"small.toml": `
+name = "Small"
+extends = "base"
+model = "small"
+`,
+		"plain.toml": `
+name = "Plain"
+extends = "base"
+shell = "/bin/bash"
+`,
+	})
+
+	agents, err := Loader{}.Load(nil, dir, "", nil)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	small := agents["small"]
+	if small.Command != "llm-wrapper" || !reflect.DeepEqual(small.Args, []string{"--model", "{model}"}) || small.Model != "small" {
+		t.Fatalf("expected command template inherited, got %q %q %q", small.Command, small.Args, small.Model)
+	}
+	plain := agents["plain"]
+	if plain.HasCommand() || plain.Shell != "/bin/bash" {
+		t.Fatalf("expected own shell to replace the command template, got %q %q", plain.Command, plain.Shell)
+	}
+}
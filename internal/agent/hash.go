@@ -23,6 +23,15 @@ func ComputeConfigHash(agent *Agent) string {
 		"singleton":    agent.Singleton,
 		"model":        agent.Model,
 		"hidden":       agent.Hidden,
+		"pty_factory":  agent.PtyFactory,
+	}
+	// Only hashed when set, so agents without one keep their hash.
+	if agent.Encoding != "" {
+		payload["encoding"] = agent.Encoding
+	}
+	if agent.Command != "" {
+		payload["command"] = agent.Command
+		payload["args"] = agent.Args
 	}
 
 	jsonPayload, err := json.Marshal(payload)
@@ -19,6 +19,37 @@ import (
 // Loader reads agent profiles from TOML files.
 type Loader struct {
 	Logger *logging.Logger
+	// StrictPrompts makes Load fail with a *MissingPromptsError when any
+	// agent references a prompt that does not resolve in the prompts dir.
+	// Otherwise each missing prompt is logged and the agent still loads.
+	StrictPrompts bool
+	// Parents are agents that loaded agents may extend when the parent is
+	// not among them, typically those from the config directory or a lower
+	// config layer. An agent that extends its own ID builds on its parent.
+	Parents map[string]Agent
+	// PromptFS, when set, is where prompt references are checked instead of
+	// the agent FS, so agents can use prompts from another config dir.
+	PromptFS fs.FS
+}
+
+// MissingPrompt is a prompt reference that did not resolve.
+type MissingPrompt struct {
+	AgentID string
+	Prompt  string
+}
+
+// MissingPromptsError lists every unresolved prompt reference across the
+// agents passed to Load.
+type MissingPromptsError struct {
+	Missing []MissingPrompt
+}
+
+func (e *MissingPromptsError) Error() string {
+	refs := make([]string, 0, len(e.Missing))
+	for _, missing := range e.Missing {
+		refs = append(refs, fmt.Sprintf("%s: %s", missing.AgentID, missing.Prompt))
+	}
+	return "missing prompt files: " + strings.Join(refs, ", ")
 }
 
 // Load scans dir for *.toml files and returns a map keyed by agent ID.
@@ -33,6 +64,10 @@ func (l Loader) Load(agentFS fs.FS, dir, promptsDir string, skillIndex map[strin
 		}
 		return nil, err
 	}
+	promptFS := agentFS
+	if l.PromptFS != nil {
+		promptFS = l.PromptFS
+	}
 
 	entries, err := fsutil.ReadDirOrEmpty(agentFS, dir)
 	if err != nil {
@@ -41,27 +76,8 @@ func (l Loader) Load(agentFS fs.FS, dir, promptsDir string, skillIndex map[strin
 
 	agents := make(map[string]Agent)
 	agentNames := make(map[string]string)
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		ext := strings.ToLower(filepath.Ext(name))
-		if ext != ".toml" {
-			if ext == ".json" {
-				agentID := strings.TrimSuffix(name, ext)
-				filePath := path.Join(dir, name)
-				l.warnLoadError(agentID, filePath, fmt.Errorf("only TOML agent configs are supported"))
-			}
-			continue
-		}
-		agentID := strings.TrimSuffix(name, ".toml")
-		filePath := path.Join(dir, name)
-		agent, err := readAgentFile(agentFS, filePath)
-		if err != nil {
-			l.warnLoadError(agentID, filePath, err)
-			continue
-		}
+	var missingPrompts []MissingPrompt
+	admit := func(agentID, filePath string, agent Agent) {
 		for _, warning := range agent.warnings {
 			if l.Logger != nil {
 				l.Logger.Warn("agent config warning", map[string]string{
@@ -83,42 +99,123 @@ func (l Loader) Load(agentFS fs.FS, dir, promptsDir string, skillIndex map[strin
 		}
 		if _, exists := agents[agentID]; exists {
 			l.warnDuplicateID(agentID, filePath)
-			continue
+			return
 		}
 		normalizedName := normalizeAgentName(agent.Name)
 		if prior, ok := agentNames[normalizedName]; ok {
 			l.warnDuplicateName(agent.Name, prior, filePath)
-			continue
+			return
+		}
+		for _, promptName := range missingPromptNames(promptFS, agent, promptsDir) {
+			missingPrompts = append(missingPrompts, MissingPrompt{AgentID: agentID, Prompt: promptName})
+			if l.Logger != nil && !l.StrictPrompts {
+				l.Logger.Warn("agent prompt file missing", map[string]string{
+					"agent_id": agentID,
+					"prompt":   promptName,
+				})
+			}
 		}
-		validatePromptNames(l.Logger, agentFS, agentID, agent, promptsDir)
 		agent.Skills = resolveSkills(l.Logger, agentID, agent.Skills, skillIndex)
 		agents[agentID] = agent
 		agentNames[normalizedName] = filePath
 	}
 
+	// Agents that extend another are finished after every file is read, so
+	// a parent may sort after its children.
+	pending := make(map[string]pendingAgent)
+	var pendingIDs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".toml" {
+			if ext == ".json" {
+				agentID := strings.TrimSuffix(name, ext)
+				filePath := path.Join(dir, name)
+				l.warnLoadError(agentID, filePath, fmt.Errorf("only TOML agent configs are supported"))
+			}
+			continue
+		}
+		agentID := strings.TrimSuffix(name, ".toml")
+		filePath := path.Join(dir, name)
+		agent, data, err := readAgentFile(agentFS, filePath)
+		if err != nil {
+			l.warnLoadError(agentID, filePath, err)
+			continue
+		}
+		if agent.Extends != "" {
+			pending[agentID] = pendingAgent{agent: agent, source: filePath, data: data}
+			pendingIDs = append(pendingIDs, agentID)
+			continue
+		}
+		agent, err = finishAgent(agent, filePath, data)
+		if err != nil {
+			emitConfigValidationError(filePath, err)
+			l.warnLoadError(agentID, filePath, err)
+			continue
+		}
+		admit(agentID, filePath, agent)
+	}
+
+	resolved, errs := resolveExtends(agents, pending, l.Parents)
+	for _, agentID := range pendingIDs {
+		filePath := pending[agentID].source
+		if err := errs[agentID]; err != nil {
+			emitConfigValidationError(filePath, err)
+			l.warnLoadError(agentID, filePath, err)
+			continue
+		}
+		admit(agentID, filePath, resolved[agentID])
+	}
+
+	if l.StrictPrompts && len(missingPrompts) > 0 {
+		return nil, &MissingPromptsError{Missing: missingPrompts}
+	}
 	return agents, nil
 }
 
-// LoadAgentByID loads a single agent config by ID from the filesystem.
+// LoadAgentByID loads a single agent config by ID from the filesystem. An
+// agent that extends another is merged with its parent, loaded from the same
+// directory.
 func LoadAgentByID(agentID string, agentsDir string) (*Agent, error) {
+	return LoadLayeredAgentByID(agentID, []string{agentsDir})
+}
+
+// LoadLayeredAgentByID loads agentID from the last of agentsDirs, which are
+// ordered from lowest to highest precedence as with layered config dirs. A
+// parent it extends is looked up in the same dir and then the earlier ones;
+// extending its own ID builds on the copy from an earlier dir.
+func LoadLayeredAgentByID(agentID string, agentsDirs []string) (*Agent, error) {
+	if len(agentsDirs) == 0 {
+		agentsDirs = []string{""}
+	}
+	return loadAgentByID(agentID, agentsDirs, nil)
+}
+
+func loadAgentByID(agentID string, agentsDirs []string, chain []string) (*Agent, error) {
 	agentID = strings.TrimSpace(agentID)
 	if agentID == "" {
 		return nil, fmt.Errorf("agent id is required")
 	}
-	agentsDir = strings.TrimSpace(agentsDir)
-	if agentsDir == "" {
-		agentsDir = filepath.Join("config", "agents")
-	}
 	if strings.HasSuffix(strings.ToLower(agentID), ".toml") {
 		agentID = strings.TrimSuffix(agentID, filepath.Ext(agentID))
 	}
-	filePath := filepath.Join(agentsDir, agentID+".toml")
+	filePath := agentFilePath(agentsDirs[len(agentsDirs)-1], agentID)
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		emitConfigValidationError(filePath, err)
 		return nil, fmt.Errorf("read agent file %s: %w", filePath, err)
 	}
-	agent, err := loadAgentFromBytes(filePath, data)
+	agent, err := parseAgentFile(filePath, data)
+	if err == nil {
+		if agent.Extends == "" {
+			agent, err = finishAgent(agent, filePath, data)
+		} else {
+			agent, err = finishAgentByID(agentID, agentsDirs, chain, pendingAgent{agent: agent, source: filePath, data: data})
+		}
+	}
 	if err != nil {
 		emitConfigValidationError(filePath, err)
 		return nil, err
@@ -126,18 +223,71 @@ func LoadAgentByID(agentID string, agentsDir string) (*Agent, error) {
 	return &agent, nil
 }
 
-func readAgentFile(agentFS fs.FS, filePath string) (Agent, error) {
+func agentFilePath(agentsDir, agentID string) string {
+	agentsDir = strings.TrimSpace(agentsDir)
+	if agentsDir == "" {
+		agentsDir = filepath.Join("config", "agents")
+	}
+	return filepath.Join(agentsDir, agentID+".toml")
+}
+
+// finishAgentByID loads child's parent from agentsDirs and merges it in;
+// chain holds the files already being loaded, to catch cycles.
+func finishAgentByID(agentID string, agentsDirs []string, chain []string, child pendingAgent) (Agent, error) {
+	chain = append(chain, child.source)
+	parentID := child.agent.Extends
+	searchDirs := agentsDirs
+	if parentID == agentID {
+		searchDirs = agentsDirs[:len(agentsDirs)-1]
+	}
+	for i := len(searchDirs) - 1; i >= 0; i-- {
+		parentPath := agentFilePath(searchDirs[i], parentID)
+		for _, seen := range chain {
+			if seen == parentPath {
+				return Agent{}, extendsCycleError{chain: agentIDsFromPaths(append(chain, parentPath))}
+			}
+		}
+		if _, err := os.Stat(parentPath); err != nil {
+			continue
+		}
+		parent, err := loadAgentByID(parentID, searchDirs[:i+1], chain)
+		if err != nil {
+			var cycle extendsCycleError
+			if errors.As(err, &cycle) {
+				return Agent{}, err
+			}
+			return Agent{}, fmt.Errorf("parent agent %q: %w", parentID, err)
+		}
+		return finishExtendedAgent(child, *parent)
+	}
+	if parentID == agentID {
+		return Agent{}, extendsCycleError{chain: []string{agentID, parentID}}
+	}
+	return Agent{}, fmt.Errorf("parent agent %q not found", parentID)
+}
+
+func agentIDsFromPaths(paths []string) []string {
+	ids := make([]string, 0, len(paths))
+	for _, filePath := range paths {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath)))
+	}
+	return ids
+}
+
+// readAgentFile reads and parses an agent file; callers finish it with
+// finishAgent or, when it declares extends, resolveExtends.
+func readAgentFile(agentFS fs.FS, filePath string) (Agent, []byte, error) {
 	data, err := fs.ReadFile(agentFS, filePath)
 	if err != nil {
 		emitConfigValidationError(filePath, err)
-		return Agent{}, fmt.Errorf("read agent file %s: %w", filePath, err)
+		return Agent{}, nil, fmt.Errorf("read agent file %s: %w", filePath, err)
 	}
-	agent, err := loadAgentFromBytes(filePath, data)
+	agent, err := parseAgentFile(filePath, data)
 	if err != nil {
 		emitConfigValidationError(filePath, err)
-		return Agent{}, err
+		return Agent{}, nil, err
 	}
-	return agent, nil
+	return agent, data, nil
 }
 
 func emitConfigValidationErrorWithMessage(filePath string, message string) {
@@ -184,25 +334,20 @@ func (l Loader) warnDuplicateName(name, firstPath, secondPath string) {
 	})
 }
 
-func validatePromptNames(logger *logging.Logger, agentFS fs.FS, agentID string, agent Agent, promptsDir string) {
-	if len(agent.Prompts) == 0 {
-		return
-	}
+// missingPromptNames returns the agent's prompt references that do not
+// resolve to a file under promptsDir.
+func missingPromptNames(agentFS fs.FS, agent Agent, promptsDir string) []string {
+	var missing []string
 	for _, promptName := range agent.Prompts {
 		promptName = strings.TrimSpace(promptName)
 		if promptName == "" {
 			continue
 		}
 		if _, err := prompt.ResolvePromptPath(agentFS, promptsDir, promptName); err != nil {
-			if logger != nil {
-				logger.Warn("agent prompt file missing", map[string]string{
-					"agent_id": agentID,
-					"prompt":   promptName,
-					"error":    err.Error(),
-				})
-			}
+			missing = append(missing, promptName)
 		}
 	}
+	return missing
 }
 
 func normalizeAgentName(name string) string {
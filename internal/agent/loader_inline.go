@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// LoadInline builds agents from entries of a merged config file. Each entry
+// carries the same keys as an agent TOML file plus an "id" key; source names
+// the file in logs and validation errors. Entries may extend each other or,
+// failing that, an agent in l.Parents.
+func (l Loader) LoadInline(source string, entries []map[string]any, skillIndex map[string]struct{}) map[string]Agent {
+	agents := make(map[string]Agent)
+	agentNames := make(map[string]string)
+	admit := func(agentID, entrySource string, agent Agent) {
+		for _, warning := range agent.warnings {
+			if l.Logger != nil {
+				l.Logger.Warn("agent config warning", map[string]string{
+					"agent_id": agentID,
+					"path":     entrySource,
+					"warning":  warning,
+				})
+			}
+		}
+		if _, exists := agents[agentID]; exists {
+			l.warnDuplicateID(agentID, entrySource)
+			return
+		}
+		normalizedName := normalizeAgentName(agent.Name)
+		if prior, ok := agentNames[normalizedName]; ok {
+			l.warnDuplicateName(agent.Name, prior, entrySource)
+			return
+		}
+		agent.Skills = resolveSkills(l.Logger, agentID, agent.Skills, skillIndex)
+		agent.inline = true
+		agents[agentID] = agent
+		agentNames[normalizedName] = entrySource
+	}
+
+	pending := make(map[string]pendingAgent)
+	var pendingIDs []string
+	for index, entry := range entries {
+		agentID, fields, err := splitInlineEntry(entry)
+		if err != nil {
+			l.warnLoadError("", fmt.Sprintf("%s#%d", source, index), err)
+			continue
+		}
+		entrySource := source + "#" + agentID
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(fields); err != nil {
+			l.warnLoadError(agentID, entrySource, fmt.Errorf("encode agent entry: %w", err))
+			continue
+		}
+		agent, err := parseAgentTOML(entrySource, buf.Bytes())
+		if err != nil {
+			err = formatParseError(entrySource, err)
+		} else if agent.Extends != "" {
+			if _, exists := pending[agentID]; exists {
+				l.warnDuplicateID(agentID, entrySource)
+				continue
+			}
+			pending[agentID] = pendingAgent{agent: agent, source: entrySource, data: buf.Bytes()}
+			pendingIDs = append(pendingIDs, agentID)
+			continue
+		} else {
+			agent, err = finishAgent(agent, entrySource, buf.Bytes())
+		}
+		if err != nil {
+			emitConfigValidationError(entrySource, err)
+			l.warnLoadError(agentID, entrySource, err)
+			continue
+		}
+		admit(agentID, entrySource, agent)
+	}
+
+	if len(pending) > 0 {
+		resolved, errs := resolveExtends(agents, pending, l.Parents)
+		for _, agentID := range pendingIDs {
+			entrySource := pending[agentID].source
+			if err := errs[agentID]; err != nil {
+				emitConfigValidationError(entrySource, err)
+				l.warnLoadError(agentID, entrySource, err)
+				continue
+			}
+			admit(agentID, entrySource, resolved[agentID])
+		}
+	}
+	return agents
+}
+
+// IsInline reports whether the agent came from a merged config file rather
+// than its own TOML file.
+func (a Agent) IsInline() bool {
+	return a.inline
+}
+
+func splitInlineEntry(entry map[string]any) (string, map[string]any, error) {
+	rawID, ok := entry["id"]
+	if !ok {
+		return "", nil, fmt.Errorf("agent entry is missing id")
+	}
+	agentID, ok := rawID.(string)
+	agentID = strings.TrimSpace(agentID)
+	if !ok || agentID == "" {
+		return "", nil, fmt.Errorf("agent entry id must be a non-empty string")
+	}
+	if strings.ContainsAny(agentID, `/\`) {
+		return "", nil, fmt.Errorf("agent entry id %q must not contain path separators", agentID)
+	}
+	fields := make(map[string]any, len(entry)-1)
+	for key, value := range entry {
+		if key == "id" {
+			continue
+		}
+		fields[key] = value
+	}
+	return agentID, fields, nil
+}
@@ -16,10 +16,24 @@ import (
 )
 
 func loadAgentFromBytes(filePath string, data []byte) (Agent, error) {
+	agent, err := parseAgentFile(filePath, data)
+	if err != nil {
+		return Agent{}, err
+	}
+	return finishAgent(agent, filePath, data)
+}
+
+// parseAgentFile parses an agent file without validating it, so agents that
+// declare extends can be finished once their parent is known.
+func parseAgentFile(filePath string, data []byte) (Agent, error) {
 	agent, err := parseAgentData(filePath, data)
 	if err != nil {
 		return Agent{}, formatParseError(filePath, err)
 	}
+	return agent, nil
+}
+
+func finishAgent(agent Agent, filePath string, data []byte) (Agent, error) {
 	if err := agent.Validate(); err != nil {
 		return Agent{}, formatValidationError(agent, filePath, data, err)
 	}
@@ -32,11 +46,14 @@ func loadAgentFromBytes(filePath string, data []byte) (Agent, error) {
 
 func parseAgentData(filePath string, data []byte) (Agent, error) {
 	ext := strings.ToLower(filepath.Ext(filePath))
-	var agent Agent
-
 	if ext != ".toml" {
 		return Agent{}, fmt.Errorf("unsupported agent config extension %q", ext)
 	}
+	return parseAgentTOML(filePath, data)
+}
+
+func parseAgentTOML(filePath string, data []byte) (Agent, error) {
+	var agent Agent
 	raw, err := tomlkeys.DecodeMap(data)
 	if err != nil {
 		return Agent{}, err
@@ -44,10 +61,14 @@ func parseAgentData(filePath string, data []byte) (Agent, error) {
 	if _, err := toml.Decode(string(data), &agent); err != nil {
 		return Agent{}, err
 	}
+	agent.Extends = strings.TrimSpace(agent.Extends)
 	applyCLIConfig(&agent, raw)
 	applyModelAlias(&agent, raw, filePath)
-	if err := ValidateAgentConfig(agent.CLIType, agent.CLIConfig); err != nil {
-		return Agent{}, err
+	// A child's cli_config is validated after it is merged with its parent's.
+	if agent.Extends == "" {
+		if err := ValidateAgentConfig(agent.CLIType, agent.CLIConfig); err != nil {
+			return Agent{}, err
+		}
 	}
 	if agent.Singleton == nil {
 		defaultSingleton := true
@@ -135,6 +156,8 @@ func lineForKey(data []byte, keyPath string) int {
 var reservedAgentKeys = []string{
 	"name",
 	"shell",
+	"command",
+	"args",
 	"prompt",
 	"skills",
 	"onair_string",
@@ -146,6 +169,9 @@ var reservedAgentKeys = []string{
 	"model",
 	"llm_model",
 	"hidden",
+	"pty_factory",
+	"encoding",
+	"extends",
 }
 
 func applyCLIConfig(agent *Agent, raw map[string]interface{}) {
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -107,6 +108,28 @@ shell = "/bin/zsh"
 	}
 }
 
+func TestLoaderAcceptsDefaultAgentID(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "default.toml"), []byte(`
+name = "Default"
+shell = "/bin/bash"
+`), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	loader := Loader{}
+	agents, err := loader.Load(nil, dir, "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := agents["default"]; !ok {
+		t.Fatalf("expected default agent to load, got %v", agents)
+	}
+	if _, err := LoadAgentByID("default", dir); err != nil {
+		t.Fatalf("expected LoadAgentByID to load default: %v", err)
+	}
+}
+
 func TestLoaderInvalidAgent(t *testing.T) {
 	dir := t.TempDir()
 	if err := os.WriteFile(filepath.Join(dir, "bad.toml"), []byte(`
@@ -168,6 +191,40 @@ prompt = ["missing"]
 	}
 }
 
+func TestLoaderStrictPromptsAggregatesMissing(t *testing.T) {
+	dir := t.TempDir()
+	for id, prompts := range map[string]string{"codex": `["missing", "present"]`, "review": `["gone"]`} {
+		data := "name = \"" + id + "\"\nshell = \"/bin/bash\"\nprompt = " + prompts + "\n"
+		if err := os.WriteFile(filepath.Join(dir, id+".toml"), []byte(data), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	promptsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(promptsDir, "present.md"), []byte("present"), 0644); err != nil {
+		t.Fatalf("write prompt: %v", err)
+	}
+
+	loader := Loader{StrictPrompts: true}
+	agents, err := loader.Load(nil, dir, promptsDir, nil)
+	if agents != nil {
+		t.Fatalf("expected no agents on strict failure, got %v", agents)
+	}
+	var missingErr *MissingPromptsError
+	if !errors.As(err, &missingErr) {
+		t.Fatalf("expected MissingPromptsError, got %v", err)
+	}
+	got := make(map[string]string)
+	for _, missing := range missingErr.Missing {
+		got[missing.AgentID] = missing.Prompt
+	}
+	if len(missingErr.Missing) != 2 || got["codex"] != "missing" || got["review"] != "gone" {
+		t.Fatalf("unexpected missing prompts: %+v", missingErr.Missing)
+	}
+	if !strings.Contains(err.Error(), "codex: missing") || !strings.Contains(err.Error(), "review: gone") {
+		t.Fatalf("expected error to list each missing prompt, got %q", err.Error())
+	}
+}
+
 func TestLoaderPromptResolutionSupportsMarkdown(t *testing.T) {
 	dir := t.TempDir()
 	if err := os.WriteFile(filepath.Join(dir, "codex.toml"), []byte(`
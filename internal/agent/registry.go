@@ -13,12 +13,21 @@ type Registry struct {
 	agents    map[string]Agent
 	cache     *AgentCache
 	agentsDir string
+	agentDirs map[string]string
+	layerDirs []string
 }
 
 // RegistryOptions configures a Registry.
 type RegistryOptions struct {
 	Agents    map[string]Agent
 	AgentsDir string
+	// AgentDirs maps agent IDs to the directory their TOML file is reloaded
+	// from when it is not AgentsDir, as for agents from layered config dirs.
+	AgentDirs map[string]string
+	// LayerDirs lists the agents dirs of layered config dirs in precedence
+	// order, all below AgentsDir. A reloaded agent resolves extends through
+	// the dirs below its own.
+	LayerDirs []string
 }
 
 // NewRegistry builds a registry backed by an AgentCache when available.
@@ -27,11 +36,17 @@ func NewRegistry(options RegistryOptions) *Registry {
 	for id, profile := range options.Agents {
 		agents[id] = profile
 	}
+	agentDirs := make(map[string]string, len(options.AgentDirs))
+	for id, dir := range options.AgentDirs {
+		agentDirs[id] = strings.TrimSpace(dir)
+	}
 	cache := NewAgentCache(agents)
 	return &Registry{
 		agents:    agents,
 		cache:     cache,
 		agentsDir: strings.TrimSpace(options.AgentsDir),
+		agentDirs: agentDirs,
+		layerDirs: append([]string(nil), options.LayerDirs...),
 	}
 }
 
@@ -77,8 +92,16 @@ func (r *Registry) LoadOrReload(agentID string) (*Agent, bool, error) {
 		profileCopy := profile
 		return &profileCopy, false, nil
 	}
+	// Agents from a merged config file have no TOML file to reload from.
+	if profile, ok := r.Get(agentID); ok && profile.inline {
+		return &profile, false, nil
+	}
 
-	profile, reloaded, err := r.cache.LoadOrReload(agentID, r.agentsDir)
+	agentsDirs := append(append([]string(nil), r.layerDirs...), r.agentsDir)
+	if dir, ok := r.agentDirs[agentID]; ok && dir != "" {
+		agentsDirs = r.layerStack(dir)
+	}
+	profile, reloaded, err := r.cache.LoadOrReloadLayered(agentID, agentsDirs)
 	if err != nil {
 		return nil, false, err
 	}
@@ -90,3 +113,15 @@ func (r *Registry) LoadOrReload(agentID string) (*Agent, bool, error) {
 	r.mu.Unlock()
 	return profile, reloaded, nil
 }
+
+// layerStack returns the layer dirs up to and including dir.
+func (r *Registry) layerStack(dir string) []string {
+	var stack []string
+	for _, layer := range r.layerDirs {
+		stack = append(stack, layer)
+		if layer == dir {
+			return stack
+		}
+	}
+	return []string{dir}
+}
@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"github.com/invopop/jsonschema"
+
+	internalschema "gestalt/internal/schema"
+)
+
+// SchemaAgentConfig is the schema registry key for agent config files.
+const SchemaAgentConfig = "agent"
+
+func init() {
+	_ = internalschema.Register(SchemaAgentConfig, agentConfigSchema)
+}
+
+// agentConfigFile lists the keys an agent config file accepts. Keys outside
+// this set are passed through to the CLI as cli_config entries, so the schema
+// allows additional properties.
+type agentConfigFile struct {
+	Name        string         `json:"name" jsonschema:"required,minLength=1"`
+	Shell       string         `json:"shell,omitempty"`
+	Command     string         `json:"command,omitempty"`
+	Args        []string       `json:"args,omitempty"`
+	Prompt      PromptList     `json:"prompt,omitempty"`
+	Skills      []string       `json:"skills,omitempty"`
+	OnAirString string         `json:"onair_string,omitempty"`
+	Singleton   *bool          `json:"singleton,omitempty"`
+	Interface   string         `json:"interface,omitempty" jsonschema:"enum=cli"`
+	CLIType     string         `json:"cli_type,omitempty"`
+	CLIConfig   map[string]any `json:"cli_config,omitempty"`
+	Model       string         `json:"model,omitempty"`
+	LLMModel    string         `json:"llm_model,omitempty"`
+	Hidden      bool           `json:"hidden,omitempty"`
+	PtyFactory  string         `json:"pty_factory,omitempty"`
+	Encoding    string         `json:"encoding,omitempty"`
+	Extends     string         `json:"extends,omitempty"`
+}
+
+// JSONSchema describes prompt as either a single prompt name or a list.
+func (PromptList) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		},
+	}
+}
+
+func agentConfigSchema() *jsonschema.Schema {
+	reflector := &jsonschema.Reflector{
+		AllowAdditionalProperties: true,
+		DoNotReference:            true,
+		ExpandedStruct:            true,
+	}
+	s := reflector.Reflect(agentConfigFile{})
+	if s.Version == "" {
+		s.Version = jsonschema.Version
+	}
+	s.Title = "Gestalt agent config"
+	return s
+}
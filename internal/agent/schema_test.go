@@ -0,0 +1,41 @@
+package agent
+
+import (
+	"testing"
+
+	internalschema "gestalt/internal/schema"
+)
+
+func TestAgentConfigSchemaCoversReservedKeys(t *testing.T) {
+	s, err := internalschema.Resolve(SchemaAgentConfig)
+	if err != nil {
+		t.Fatalf("resolve schema: %v", err)
+	}
+	for _, key := range reservedAgentKeys {
+		if key == "codex_mode" {
+			continue
+		}
+		if _, ok := s.Properties.Get(key); !ok {
+			t.Fatalf("schema missing agent key %q", key)
+		}
+	}
+}
+
+func TestAgentConfigSchemaValidatesPrompt(t *testing.T) {
+	s, err := internalschema.Resolve(SchemaAgentConfig)
+	if err != nil {
+		t.Fatalf("resolve schema: %v", err)
+	}
+	for _, prompt := range []any{"review", []any{"review", "summary"}} {
+		if err := internalschema.ValidateObject(s, map[string]any{
+			"name":           "Coder",
+			"prompt":         prompt,
+			"approval_modes": "never",
+		}); err != nil {
+			t.Fatalf("expected prompt %v to validate: %v", prompt, err)
+		}
+	}
+	if err := internalschema.ValidateObject(s, map[string]any{"shell": "bash"}); err == nil {
+		t.Fatal("expected missing name to fail")
+	}
+}
@@ -2,27 +2,89 @@ package api
 
 import "net/http"
 
+// Stable machine-readable error codes returned in the "code" field of error
+// responses. Clients should branch on these instead of matching messages.
+// Errors without a specific code fall back to errorCodeForStatus.
+const (
+	errorCodeInvalidRequest       = "invalid_request"
+	errorCodeUnauthorized         = "unauthorized"
+	errorCodeForbidden            = "forbidden"
+	errorCodeNotFound             = "not_found"
+	errorCodeMethodNotAllowed     = "method_not_allowed"
+	errorCodeConflict             = "conflict"
+	errorCodePayloadTooLarge      = "payload_too_large"
+	errorCodeUnsupportedMediaType = "unsupported_media_type"
+	errorCodeValidationFailed     = "validation_failed"
+	errorCodeRateLimited          = "rate_limited"
+	errorCodeInternal             = "internal_error"
+	errorCodeServiceUnavailable   = "service_unavailable"
+
+	errorCodeInvalidBody      = "invalid_body"
+	errorCodeInvalidParameter = "invalid_parameter"
+	errorCodeInvalidLabels    = "invalid_labels"
+	errorCodeInvalidAttribute = "invalid_attribute"
+
+	errorCodeTerminalNotFound       = "terminal_not_found"
+	errorCodeTerminalIDRequired     = "terminal_id_required"
+	errorCodeTerminalIDMismatch     = "terminal_id_mismatch"
+	errorCodeNotAgentSession        = "not_agent_session"
+	errorCodeAgentUnknown           = "agent_unknown"
+	errorCodeAgentRequired          = "agent_required"
+	errorCodeAgentAlreadyRunning    = "agent_already_running"
+	errorCodeTmuxUnavailable        = "tmux_unavailable"
+	errorCodeTmuxSessionNotFound    = "tmux_session_not_found"
+	errorCodeTmuxWindowNotFound     = "tmux_window_not_found"
+	errorCodeNotTmuxManaged         = "not_tmux_managed"
+	errorCodeInputBridgeUnavailable = "input_bridge_unavailable"
+	errorCodeNoInputHistory         = "no_input_history"
+	errorCodeLogEntryNotFound       = "log_entry_not_found"
+	errorCodeSessionLogNotFound     = "session_log_not_found"
+	errorCodeAttachUnsupported      = "attach_unsupported"
+	errorCodeNotAttachable          = "not_attachable"
+	errorCodeSessionReadOnly        = "session_read_only"
+	errorCodeSessionPaused          = "session_paused"
+	errorCodePauseUnsupported       = "pause_unsupported"
+	errorCodeNotPausable            = "not_pausable"
+	errorCodeWebhookNotFound        = "webhook_not_found"
+	errorCodeWebhookLimit           = "webhook_limit"
+
+	errorCodeManagerUnavailable       = "manager_unavailable"
+	errorCodeLogsUnavailable          = "logs_unavailable"
+	errorCodeFlowUnavailable          = "flow_unavailable"
+	errorCodeFlowConfigInvalid        = "flow_config_invalid"
+	errorCodeGitUnavailable           = "git_unavailable"
+	errorCodeOTelUnavailable          = "otel_unavailable"
+	errorCodeNotificationsUnavailable = "notifications_unavailable"
+	errorCodeWatcherUnavailable       = "watcher_unavailable"
+)
+
 func errorCodeForStatus(status int) string {
 	switch status {
 	case http.StatusBadRequest:
-		return "invalid_request"
+		return errorCodeInvalidRequest
 	case http.StatusUnauthorized:
-		return "unauthorized"
+		return errorCodeUnauthorized
 	case http.StatusForbidden:
-		return "forbidden"
+		return errorCodeForbidden
 	case http.StatusNotFound:
-		return "not_found"
+		return errorCodeNotFound
 	case http.StatusMethodNotAllowed:
-		return "method_not_allowed"
+		return errorCodeMethodNotAllowed
 	case http.StatusConflict:
-		return "conflict"
+		return errorCodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return errorCodePayloadTooLarge
+	case http.StatusUnsupportedMediaType:
+		return errorCodeUnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return errorCodeValidationFailed
 	case http.StatusTooManyRequests:
-		return "rate_limited"
+		return errorCodeRateLimited
 	case http.StatusServiceUnavailable:
-		return "service_unavailable"
+		return errorCodeServiceUnavailable
 	default:
 		if status >= http.StatusInternalServerError {
-			return "internal_error"
+			return errorCodeInternal
 		}
 	}
 	return ""
@@ -29,6 +29,7 @@ type eventSubscribeMessage struct {
 type eventPayload struct {
 	Type      string    `json:"type"`
 	Path      string    `json:"path"`
+	OldPath   string    `json:"old_path,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -103,6 +104,7 @@ func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		watcher.EventTypeFileChanged:      {},
 		watcher.EventTypeGitBranchChanged: {},
 		watcher.EventTypeWatchError:       {},
+		watcher.EventTypeOverflow:         {},
 	}
 
 	filter := newEventFilter(allowed)
@@ -160,6 +162,7 @@ func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			payload := eventPayload{
 				Type:      event.Type,
 				Path:      event.Path,
+				OldPath:   event.OldPath,
 				Timestamp: event.Timestamp,
 			}
 			if payload.Timestamp.IsZero() {
@@ -102,6 +102,7 @@ func (h *EventsSSEHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		watcher.EventTypeFileChanged:      {},
 		watcher.EventTypeGitBranchChanged: {},
 		watcher.EventTypeWatchError:       {},
+		watcher.EventTypeOverflow:         {},
 	}
 
 	watcherEvents, cancelWatcher := watcherBus.SubscribeFiltered(func(event watcher.Event) bool {
@@ -169,6 +170,7 @@ func (h *EventsSSEHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		payload := eventPayload{
 			Type:      event.Type,
 			Path:      event.Path,
+			OldPath:   event.OldPath,
 			Timestamp: event.Timestamp,
 		}
 		if payload.Timestamp.IsZero() {
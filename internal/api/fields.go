@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fieldSelector is the set of top-level JSON keys requested with ?fields=.
+// A nil selector keeps every field.
+type fieldSelector map[string]struct{}
+
+func parseFieldSelector(r *http.Request) fieldSelector {
+	raw := strings.TrimSpace(r.URL.Query().Get("fields"))
+	if raw == "" {
+		return nil
+	}
+	selector := make(fieldSelector)
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selector[name] = struct{}{}
+		}
+	}
+	if len(selector) == 0 {
+		return nil
+	}
+	return selector
+}
+
+// writeJSONFields writes payload like writeJSON, trimming each object (or
+// each element of a list of objects) to the keys named in ?fields=. Unknown
+// names are ignored unless ?fields_strict=true, which rejects them.
+func writeJSONFields(w http.ResponseWriter, r *http.Request, status int, payload any) *apiError {
+	selector := parseFieldSelector(r)
+	if selector == nil {
+		writeJSON(w, status, payload)
+		return nil
+	}
+	if strict, _ := strconv.ParseBool(r.URL.Query().Get("fields_strict")); strict {
+		if unknown := selector.unknown(payload); len(unknown) > 0 {
+			return fieldValidationError(http.StatusBadRequest, "fields", "unknown fields: "+strings.Join(unknown, ", "))
+		}
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to encode response"}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to encode response"}
+	}
+	writeJSON(w, status, selector.apply(generic))
+	return nil
+}
+
+func (s fieldSelector) apply(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		trimmed := make(map[string]any, len(s))
+		for key, field := range typed {
+			if _, ok := s[key]; ok {
+				trimmed[key] = field
+			}
+		}
+		return trimmed
+	case []any:
+		for i, item := range typed {
+			typed[i] = s.apply(item)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// unknown lists selected names that the payload's type never encodes.
+func (s fieldSelector) unknown(payload any) []string {
+	known := jsonFieldNames(reflect.TypeOf(payload))
+	if known == nil {
+		return nil
+	}
+	var unknown []string
+	for name := range s {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// jsonFieldNames returns the JSON keys of a struct type, or of the element
+// type of a slice of structs, including promoted fields of embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			for embedded := range jsonFieldNames(field.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
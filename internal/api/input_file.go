@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxInputFileBytes caps how much of a file is written to a session at once.
+const maxInputFileBytes = 4 << 20
+
+// inputFileContentType marks a session input request whose body is a
+// {"file": ...} reference rather than bytes for the session. Other content
+// types, application/json included, are written to the session verbatim.
+const inputFileContentType = "application/vnd.gestalt.input-file+json"
+
+var (
+	errInputFileOutsideRoot = errors.New("input file is outside the allowed root")
+	errInputFileNotRegular  = errors.New("input file is not a regular file")
+)
+
+type terminalInputFileRequest struct {
+	File string `json:"file"`
+}
+
+// isFileInput reports whether an input request names a file to send instead
+// of carrying the input itself.
+func isFileInput(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == inputFileContentType
+}
+
+// readInputFile loads the payload for a {"file": ...} input request. The
+// path is resolved relative to h.InputFileRoot, and symlinks may not lead
+// outside of it.
+func (h *RestHandler) readInputFile(r *http.Request) ([]byte, *apiError) {
+	var request terminalInputFileRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return nil, invalidBodyError(err)
+	}
+	if strings.TrimSpace(request.File) == "" {
+		return nil, fieldValidationError(http.StatusBadRequest, "file", "file is required")
+	}
+	if strings.TrimSpace(h.InputFileRoot) == "" {
+		return nil, withField(&apiError{Status: http.StatusForbidden, Message: "file input is disabled; start the server with --input-file-root", Code: errorCodeForbidden}, "file")
+	}
+
+	path, err := resolveInputFile(h.InputFileRoot, request.File)
+	if err != nil {
+		if errors.Is(err, errInputFileOutsideRoot) {
+			return nil, withField(&apiError{Status: http.StatusForbidden, Message: "file is outside the allowed input root", Code: errorCodeForbidden}, "file")
+		}
+		return nil, fieldValidationError(http.StatusBadRequest, "file", "file not readable")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fieldValidationError(http.StatusBadRequest, "file", "file not readable")
+	}
+	defer file.Close()
+	payload, err := io.ReadAll(io.LimitReader(file, maxInputFileBytes+1))
+	if err != nil {
+		return nil, fieldValidationError(http.StatusBadRequest, "file", "file not readable")
+	}
+	if len(payload) > maxInputFileBytes {
+		return nil, withField(&apiError{Status: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf("file exceeds %d bytes", maxInputFileBytes), Code: errorCodePayloadTooLarge}, "file")
+	}
+	if len(payload) == 0 {
+		return nil, fieldValidationError(http.StatusBadRequest, "file", "file is empty")
+	}
+	return payload, nil
+}
+
+// resolveInputFile maps a relative name onto root and returns the real path
+// of the regular file it names.
+func resolveInputFile(root, name string) (string, error) {
+	name = filepath.Clean(filepath.FromSlash(strings.TrimSpace(name)))
+	if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return "", errInputFileOutsideRoot
+	}
+	rootPath, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	rootPath, err = filepath.EvalSymlinks(rootPath)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Join(rootPath, name))
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(rootPath, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errInputFileOutsideRoot
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", errInputFileNotRegular
+	}
+	return resolved, nil
+}
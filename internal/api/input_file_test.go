@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gestalt/internal/terminal"
+)
+
+func TestResolveInputFileRejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "prompt.md"), []byte("hi"), 0o644); err != nil {
+		t.Fatalf("write prompt: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("no"), 0o644); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(root, "link.txt")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	if _, err := resolveInputFile(root, "prompt.md"); err != nil {
+		t.Fatalf("expected prompt to resolve, got %v", err)
+	}
+	for _, name := range []string{"../secret.txt", "a/../../secret.txt", filepath.Join(outside, "secret.txt"), "link.txt"} {
+		if _, err := resolveInputFile(root, name); !errors.Is(err, errInputFileOutsideRoot) {
+			t.Fatalf("expected %q to be rejected, got %v", name, err)
+		}
+	}
+	if _, err := resolveInputFile(root, "."); !errors.Is(err, errInputFileNotRegular) {
+		t.Fatalf("expected directory to be rejected, got %v", err)
+	}
+}
+
+func TestTerminalInputFromFile(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "task.md"), []byte("build the thing\n"), 0o644); err != nil {
+		t.Fatalf("write task: %v", err)
+	}
+	post := func(handler *RestHandler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/input", strings.NewReader(body))
+		req.Header.Set("Content-Type", inputFileContentType)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+
+	disabled := post(&RestHandler{Manager: manager}, `{"file":"task.md"}`)
+	if disabled.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without an input root, got %d", disabled.Code)
+	}
+
+	handler := &RestHandler{Manager: manager, InputFileRoot: root}
+	if res := post(handler, `{"file":"../task.md"}`); res.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for traversal, got %d", res.Code)
+	}
+	if res := post(handler, `{"file":"missing.md"}`); res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a missing file, got %d", res.Code)
+	}
+	if res := post(handler, `{"path":"task.md"}`); res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown field, got %d", res.Code)
+	}
+
+	res := post(handler, `{"file":"task.md"}`)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var payload agentInputResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Bytes != len("build the thing\n") {
+		t.Fatalf("unexpected byte count %d", payload.Bytes)
+	}
+}
+
+func TestTerminalInputWritesJSONVerbatim(t *testing.T) {
+	manager := newTerminalTestManager(terminal.ManagerOptions{})
+	session := terminal.NewExternalSession("mcp-1", "title", "role", time.Now(), 10, 0, terminal.OutputBackpressureBlock, 0, nil, nil, nil)
+	writes := make(chan []byte, 1)
+	if err := session.AttachExternalRunner(func(data []byte) error {
+		writes <- append([]byte(nil), data...)
+		return nil
+	}, nil, nil); err != nil {
+		t.Fatalf("attach external runner: %v", err)
+	}
+	manager.RegisterSession(session)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/list","file":"x"}`
+	req := httptest.NewRequest(http.MethodPost, terminalPath(session.ID)+"/input", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	res := httptest.NewRecorder()
+	handler := &RestHandler{Manager: manager, InputFileRoot: t.TempDir()}
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	select {
+	case got := <-writes:
+		if string(got) != body {
+			t.Fatalf("expected JSON body written unchanged, got %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for session input")
+	}
+}
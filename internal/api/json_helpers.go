@@ -2,7 +2,11 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 func writeJSON(w http.ResponseWriter, status int, payload any) {
@@ -20,9 +24,50 @@ func writeJSONError(w http.ResponseWriter, err *apiError) {
 		code = errorCodeForStatus(err.Status)
 	}
 	writeJSON(w, err.Status, errorResponse{
-		Message:    err.Message,
-		Error:      err.Message,
-		Code:       code,
-		SessionID:  err.SessionID,
+		Message:   err.Message,
+		Error:     err.Message,
+		Code:      code,
+		SessionID: err.SessionID,
+		Fields:    err.Fields,
 	})
 }
+
+// invalidBodyError reports a request body that failed to decode. Type
+// mismatches and unknown fields are named in Fields when the decoder says
+// which field was at fault.
+func invalidBodyError(err error) *apiError {
+	apiErr := &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		apiErr.Fields = []fieldError{{Field: typeErr.Field, Error: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)}}
+		return apiErr
+	}
+	if err != nil {
+		if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			if unquoted, unquoteErr := strconv.Unquote(name); unquoteErr == nil {
+				name = unquoted
+			}
+			apiErr.Fields = []fieldError{{Field: name, Error: "unknown field"}}
+		}
+	}
+	return apiErr
+}
+
+// withField attributes err to field, using err's message as the field error.
+func withField(err *apiError, field string) *apiError {
+	if err == nil || len(err.Fields) > 0 {
+		return err
+	}
+	err.Fields = []fieldError{{Field: field, Error: err.Message}}
+	return err
+}
+
+// fieldValidationError is a validation_failed error for a single field.
+func fieldValidationError(status int, field, message string) *apiError {
+	return &apiError{
+		Status:  status,
+		Message: message,
+		Code:    errorCodeValidationFailed,
+		Fields:  []fieldError{{Field: field, Error: message}},
+	}
+}
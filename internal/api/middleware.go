@@ -3,16 +3,20 @@ package api
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"gestalt/internal/logging"
+	"gestalt/internal/metrics"
 	"gestalt/internal/otel"
 )
 
 type apiError struct {
-	Status     int
-	Message    string
-	Code       string
-	SessionID  string
+	Status    int
+	Message   string
+	Code      string
+	SessionID string
+	// Fields names the request fields that failed validation.
+	Fields []fieldError
 }
 
 type apiHandler func(http.ResponseWriter, *http.Request) *apiError
@@ -55,7 +59,7 @@ func authMiddleware(token string, next apiHandler) apiHandler {
 	return func(w http.ResponseWriter, r *http.Request) *apiError {
 		if !validateToken(r, token) {
 			otel.RecordSpanEvent(r.Context(), "auth.token_rejected")
-			return &apiError{Status: http.StatusUnauthorized, Message: "unauthorized"}
+			return &apiError{Status: http.StatusUnauthorized, Message: "unauthorized", Code: errorCodeUnauthorized}
 		}
 		otel.RecordSpanEvent(r.Context(), "auth.token_validated")
 		return next(w, r)
@@ -115,9 +119,46 @@ func loggingMiddleware(logger *logging.Logger, next http.Handler) http.Handler {
 	})
 }
 
+// routeMetricsMiddleware records request count, errors, and latency in
+// metrics.Default, keyed by the route template set by otel.WithRouteInfo.
+func routeMetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &routeStatusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.Default.RecordHTTPRequest(otel.RouteTemplate(r), r.Method, status, time.Since(start))
+	})
+}
+
+type routeStatusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (recorder *routeStatusRecorder) WriteHeader(statusCode int) {
+	if recorder.status == 0 {
+		recorder.status = statusCode
+	}
+	recorder.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (recorder *routeStatusRecorder) Flush() {
+	if flusher, ok := recorder.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (recorder *routeStatusRecorder) Unwrap() http.ResponseWriter {
+	return recorder.ResponseWriter
+}
+
 func methodNotAllowed(w http.ResponseWriter, allow string) *apiError {
 	w.Header().Set("Allow", allow)
-	return &apiError{Status: http.StatusMethodNotAllowed, Message: "method not allowed"}
+	return &apiError{Status: http.StatusMethodNotAllowed, Message: "method not allowed", Code: errorCodeMethodNotAllowed}
 }
 
 func restHandler(token string, logger *logging.Logger, handler apiHandler) http.HandlerFunc {
@@ -10,13 +10,13 @@ import (
 func decodeNotifyRequest(r *http.Request) (notifyRequest, *apiError) {
 	var request notifyRequest
 	if r.Body == nil {
-		return request, &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return request, &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&request); err != nil && err != io.EOF {
-		return request, &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return request, invalidBodyError(err)
 	}
 	if err := validateNotifyRequest(&request); err != nil {
 		return request, err
@@ -26,13 +26,13 @@ func decodeNotifyRequest(r *http.Request) (notifyRequest, *apiError) {
 
 func validateNotifyRequest(request *notifyRequest) *apiError {
 	if request == nil {
-		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 	if strings.TrimSpace(request.SessionID) == "" {
-		return &apiError{Status: http.StatusBadRequest, Message: "missing session id"}
+		return withField(&apiError{Status: http.StatusBadRequest, Message: "missing session id", Code: errorCodeTerminalIDRequired}, "session_id")
 	}
 	if len(request.Payload) == 0 {
-		return &apiError{Status: http.StatusUnprocessableEntity, Message: "missing payload"}
+		return fieldValidationError(http.StatusUnprocessableEntity, "payload", "missing payload")
 	}
 	payloadType, err := extractNotifyPayloadType(request.Payload)
 	if err != nil {
@@ -45,15 +45,15 @@ func validateNotifyRequest(request *notifyRequest) *apiError {
 func extractNotifyPayloadType(payload json.RawMessage) (string, *apiError) {
 	var payloadMap map[string]any
 	if err := json.Unmarshal(payload, &payloadMap); err != nil || payloadMap == nil {
-		return "", &apiError{Status: http.StatusUnprocessableEntity, Message: "payload must be a JSON object"}
+		return "", fieldValidationError(http.StatusUnprocessableEntity, "payload", "payload must be a JSON object")
 	}
 	rawType, ok := payloadMap["type"]
 	if !ok {
-		return "", &apiError{Status: http.StatusUnprocessableEntity, Message: "missing payload type"}
+		return "", fieldValidationError(http.StatusUnprocessableEntity, "payload.type", "missing payload type")
 	}
 	typeText, ok := rawType.(string)
 	if !ok || strings.TrimSpace(typeText) == "" {
-		return "", &apiError{Status: http.StatusUnprocessableEntity, Message: "missing payload type"}
+		return "", fieldValidationError(http.StatusUnprocessableEntity, "payload.type", "missing payload type")
 	}
 	return strings.TrimSpace(typeText), nil
 }
@@ -12,11 +12,11 @@ import (
 
 func buildNotifyFlowFields(session *terminal.Session, request notifyRequest, now time.Time) (map[string]string, *apiError) {
 	if session == nil {
-		return nil, &apiError{Status: http.StatusBadRequest, Message: "session not found"}
+		return nil, &apiError{Status: http.StatusBadRequest, Message: "session not found", Code: errorCodeTerminalNotFound}
 	}
 	var payload map[string]any
 	if err := json.Unmarshal(request.Payload, &payload); err != nil || payload == nil {
-		return nil, &apiError{Status: http.StatusUnprocessableEntity, Message: "payload must be a JSON object"}
+		return nil, &apiError{Status: http.StatusUnprocessableEntity, Message: "payload must be a JSON object", Code: errorCodeValidationFailed}
 	}
 
 	timestamp := now.UTC()
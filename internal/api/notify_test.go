@@ -135,3 +135,26 @@ func TestDecodeNotifyRequestRejectsEventTypeField(t *testing.T) {
 		t.Fatalf("expected invalid request body, got %q", err.Message)
 	}
 }
+
+func TestDecodeNotifyRequestReportsFields(t *testing.T) {
+	cases := []struct {
+		body  string
+		field string
+	}{
+		{body: `{"session_id":"abc"}`, field: "payload"},
+		{body: `{"session_id":"abc","payload":{}}`, field: "payload.type"},
+		{body: `{"session_id":"","payload":{"type":"x"}}`, field: "session_id"},
+		{body: `{"session_id":"abc","payload":{"type":"x"},"extra":1}`, field: "extra"},
+		{body: `{"session_id":7,"payload":{"type":"x"}}`, field: "session_id"},
+	}
+	for _, tc := range cases {
+		request := httptest.NewRequest(http.MethodPost, "/api/sessions/abc/notify", strings.NewReader(tc.body))
+		_, err := decodeNotifyRequest(request)
+		if err == nil {
+			t.Fatalf("expected error for %s", tc.body)
+		}
+		if len(err.Fields) != 1 || err.Fields[0].Field != tc.field || err.Fields[0].Error == "" {
+			t.Fatalf("expected field %q for %s, got %+v", tc.field, tc.body, err.Fields)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// replacementChar stands in for each run of invalid bytes in output lines.
+const replacementChar = "�"
+
+// sanitizeOutputLines returns lines with invalid UTF-8 replaced so they
+// survive JSON encoding predictably. The boolean reports whether any line
+// was changed; callers that need the original bytes should use the ansi
+// transcript, which streams output without re-encoding.
+func sanitizeOutputLines(lines []string) ([]string, bool) {
+	var sanitized []string
+	for i, line := range lines {
+		if utf8.ValidString(line) {
+			if sanitized != nil {
+				sanitized[i] = line
+			}
+			continue
+		}
+		if sanitized == nil {
+			sanitized = make([]string, len(lines))
+			copy(sanitized, lines[:i])
+		}
+		sanitized[i] = strings.ToValidUTF8(line, replacementChar)
+	}
+	if sanitized == nil {
+		return lines, false
+	}
+	return sanitized, true
+}
+
+// newTerminalOutputResponse builds an output payload with lines and the
+// partial line sanitized, flagging the response when bytes were replaced.
+func newTerminalOutputResponse(id string, lines []string, cursor *int64, partial string) terminalOutputResponse {
+	lines, sanitized := sanitizeOutputLines(lines)
+	if !utf8.ValidString(partial) {
+		partial = strings.ToValidUTF8(partial, replacementChar)
+		sanitized = true
+	}
+	return terminalOutputResponse{
+		ID:        id,
+		Lines:     lines,
+		Cursor:    cursor,
+		Partial:   partial,
+		Sanitized: sanitized,
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSanitizeOutputLinesKeepsValidLines(t *testing.T) {
+	lines := []string{"hello", "\x1b[31mred\x1b[0m", "héllo"}
+	got, sanitized := sanitizeOutputLines(lines)
+	if sanitized {
+		t.Fatalf("expected valid lines to be left alone")
+	}
+	if &got[0] != &lines[0] {
+		t.Fatalf("expected the original slice to be returned")
+	}
+}
+
+func TestSanitizeOutputLinesReplacesInvalidBytes(t *testing.T) {
+	lines := []string{"ok", "bin\xff\xfe\x00data", "tail"}
+	got, sanitized := sanitizeOutputLines(lines)
+	if !sanitized {
+		t.Fatalf("expected invalid line to be flagged")
+	}
+	if got[0] != "ok" || got[2] != "tail" {
+		t.Fatalf("expected valid lines preserved, got %q", got)
+	}
+	if got[1] != "bin�\x00data" {
+		t.Fatalf("unexpected sanitized line %q", got[1])
+	}
+	if lines[1] != "bin\xff\xfe\x00data" {
+		t.Fatalf("expected input slice to be untouched")
+	}
+}
+
+func TestTerminalOutputResponseFlagsSanitizedPartial(t *testing.T) {
+	cursor := int64(2)
+	response := newTerminalOutputResponse("1", []string{"a"}, &cursor, "\xc3")
+	if !response.Sanitized || response.Partial != "�" {
+		t.Fatalf("expected sanitized partial, got %+v", response)
+	}
+	payload, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if decoded["sanitized"] != true {
+		t.Fatalf("expected sanitized flag in payload, got %s", payload)
+	}
+}
@@ -19,12 +19,12 @@ type planProgressPayload struct {
 func normalizePlanProgressPayload(payload json.RawMessage) (planProgressPayload, json.RawMessage, *apiError) {
 	var raw map[string]any
 	if err := json.Unmarshal(payload, &raw); err != nil || raw == nil {
-		return planProgressPayload{}, nil, &apiError{Status: http.StatusUnprocessableEntity, Message: "payload must be a JSON object"}
+		return planProgressPayload{}, nil, &apiError{Status: http.StatusUnprocessableEntity, Message: "payload must be a JSON object", Code: errorCodeValidationFailed}
 	}
 
 	planFile, ok := raw["plan_file"].(string)
 	if !ok || strings.TrimSpace(planFile) == "" {
-		return planProgressPayload{}, nil, &apiError{Status: http.StatusUnprocessableEntity, Message: "missing plan_file"}
+		return planProgressPayload{}, nil, &apiError{Status: http.StatusUnprocessableEntity, Message: "missing plan_file", Code: errorCodeValidationFailed}
 	}
 
 	progress := planProgressPayload{
@@ -1,7 +1,14 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+
+	"gestalt/internal/agent"
+	"gestalt/internal/terminal"
 )
 
 func (h *RestHandler) handleAgents(w http.ResponseWriter, r *http.Request) *apiError {
@@ -12,6 +19,7 @@ func (h *RestHandler) handleAgents(w http.ResponseWriter, r *http.Request) *apiE
 		return methodNotAllowed(w, "GET")
 	}
 
+	defaultAgentID := h.loadDefaultAgent()
 	infos := h.Manager.ListAgents()
 	response := make([]agentSummary, 0, len(infos))
 	for _, info := range infos {
@@ -25,8 +33,157 @@ func (h *RestHandler) handleAgents(w http.ResponseWriter, r *http.Request) *apiE
 			SessionID: sessionID,
 			Running:   running,
 			Hidden:    info.Hidden,
+			Default:   defaultAgentID != "" && info.ID == defaultAgentID,
 		})
 	}
-	writeJSON(w, http.StatusOK, response)
+	return writeJSONFields(w, r, http.StatusOK, response)
+}
+
+// handleAgent serves /api/agents/:name/terminal, which returns the live
+// session for an agent resolved by name or id, and /api/agents/:id/prompt,
+// which previews the prompt a new session would receive.
+func (h *RestHandler) handleAgent(w http.ResponseWriter, r *http.Request) *apiError {
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/agents/"), "/")
+	name, action, ok := strings.Cut(rest, "/")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || (action != "terminal" && action != "prompt") {
+		return &apiError{Status: http.StatusNotFound, Message: "not found"}
+	}
+	if action == "prompt" {
+		return h.handleAgentPrompt(w, r, name)
+	}
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+
+	session, ok := h.Manager.GetByAgentName(name)
+	if !ok {
+		if _, known := h.Manager.GetAgent(name); !known && !h.agentNameKnown(name) {
+			return &apiError{Status: http.StatusNotFound, Message: "agent not found", Code: errorCodeAgentUnknown}
+		}
+		return &apiError{Status: http.StatusNotFound, Message: "agent is not running", Code: errorCodeTerminalNotFound}
+	}
+	return writeJSONFields(w, r, http.StatusOK, newTerminalSummary(session.Info()))
+}
+
+func (h *RestHandler) handleAgentPrompt(w http.ResponseWriter, r *http.Request, agentID string) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	preview, err := h.Manager.PreviewAgentPrompt(agentID)
+	if err != nil {
+		if errors.Is(err, terminal.ErrAgentNotFound) {
+			return &apiError{Status: http.StatusNotFound, Message: "agent not found", Code: errorCodeAgentUnknown}
+		}
+		return &apiError{Status: http.StatusInternalServerError, Message: fmt.Sprintf("failed to render agent prompt: %s", err.Error())}
+	}
+	files := preview.Files
+	if files == nil {
+		files = []string{}
+	}
+	writeJSON(w, http.StatusOK, agentPromptResponse{
+		AgentID:        preview.AgentID,
+		SessionID:      preview.SessionID,
+		Mode:           preview.Mode,
+		Prompt:         preview.Prompt,
+		PromptFiles:    files,
+		SkippedPrompts: skippedPromptEntries(preview.Skipped),
+	})
 	return nil
 }
+
+func skippedPromptEntries(skipped []terminal.SkippedPrompt) []skippedPromptEntry {
+	if len(skipped) == 0 {
+		return nil
+	}
+	entries := make([]skippedPromptEntry, 0, len(skipped))
+	for _, entry := range skipped {
+		entries = append(entries, skippedPromptEntry{Name: entry.Name, Error: entry.Error})
+	}
+	return entries
+}
+
+func (h *RestHandler) agentNameKnown(name string) bool {
+	for _, info := range h.Manager.ListAgents() {
+		if strings.EqualFold(info.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleDefaultAgent serves GET and PUT /api/agents/default, the agent the UI
+// pre-selects and gestalt-send falls back to without a session ref.
+func (h *RestHandler) handleDefaultAgent(w http.ResponseWriter, r *http.Request) *apiError {
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, h.defaultAgentResponse(h.loadDefaultAgent()))
+		return nil
+	case http.MethodPut:
+		return h.handleDefaultAgentPut(w, r)
+	default:
+		return methodNotAllowed(w, "GET, PUT")
+	}
+}
+
+func (h *RestHandler) handleDefaultAgentPut(w http.ResponseWriter, r *http.Request) *apiError {
+	if strings.TrimSpace(h.DefaultAgentPath) == "" {
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "default agent storage unavailable", Code: errorCodeServiceUnavailable}
+	}
+	var request defaultAgentRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return invalidBodyError(err)
+	}
+	agentID := strings.TrimSpace(request.AgentID)
+	if agentID != "" {
+		if _, ok := h.Manager.GetAgent(agentID); !ok {
+			return withField(&apiError{Status: http.StatusBadRequest, Message: "agent not found", Code: errorCodeAgentUnknown}, "agent_id")
+		}
+	}
+	if err := agent.SaveDefaultAgent(h.DefaultAgentPath, agentID); err != nil {
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to save default agent"}
+	}
+	writeJSON(w, http.StatusOK, h.defaultAgentResponse(agentID))
+	return nil
+}
+
+// loadDefaultAgent returns the stored default agent ID. A missing or
+// unreadable file means no default.
+func (h *RestHandler) loadDefaultAgent() string {
+	if strings.TrimSpace(h.DefaultAgentPath) == "" {
+		return ""
+	}
+	agentID, err := agent.LoadDefaultAgent(h.DefaultAgentPath)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Warn("default agent load failed", map[string]string{
+				"path":  h.DefaultAgentPath,
+				"error": err.Error(),
+			})
+		}
+		return ""
+	}
+	return agentID
+}
+
+func (h *RestHandler) defaultAgentResponse(agentID string) defaultAgentResponse {
+	response := defaultAgentResponse{AgentID: agentID}
+	if agentID == "" {
+		return response
+	}
+	if profile, ok := h.Manager.GetAgent(agentID); ok {
+		response.Name = profile.Name
+	} else {
+		response.Missing = true
+	}
+	return response
+}
@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"gestalt/internal/agent"
 	"gestalt/internal/terminal"
@@ -89,3 +92,200 @@ func TestAgentsEndpointIncludesInterface(t *testing.T) {
 		t.Fatalf("expected interface %q, got %q", agent.AgentInterfaceCLI, payload[0].Interface)
 	}
 }
+
+func TestAgentTerminalEndpoint(t *testing.T) {
+	manager := terminal.NewManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &recordFactory{},
+		Agents: map[string]agent.Agent{
+			"coder": {
+				Name:  "Coder",
+				Shell: "/bin/bash",
+			},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleAgent)(res, req)
+		return res
+	}
+
+	res := get("/api/agents/Coder/terminal")
+	if res.Code != http.StatusNotFound || decodeErrorCode(t, res) != errorCodeTerminalNotFound {
+		t.Fatalf("expected terminal_not_found before start, got %d %s", res.Code, res.Body.String())
+	}
+	res = get("/api/agents/Ghost/terminal")
+	if res.Code != http.StatusNotFound || decodeErrorCode(t, res) != errorCodeAgentUnknown {
+		t.Fatalf("expected agent_unknown, got %d %s", res.Code, res.Body.String())
+	}
+
+	session, err := manager.Create("coder", "build", "first")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	for _, path := range []string{"/api/agents/Coder/terminal", "/api/agents/coder/terminal"} {
+		res = get(path)
+		if res.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d", path, res.Code)
+		}
+		var payload terminalSummary
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if payload.ID != session.ID {
+			t.Fatalf("expected session %q, got %q", session.ID, payload.ID)
+		}
+	}
+
+	if res = get("/api/agents/Coder/other"); res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown sub-resource, got %d", res.Code)
+	}
+}
+
+func decodeErrorCode(t *testing.T, res *httptest.ResponseRecorder) string {
+	t.Helper()
+	var payload errorResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	return payload.Code
+}
+
+func TestAgentPromptEndpoint(t *testing.T) {
+	manager := terminal.NewManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &recordFactory{},
+		PromptFS: fstest.MapFS{
+			"prompts/greet.tmpl": &fstest.MapFile{Data: []byte("hello from\n{{session id}}\n")},
+		},
+		PromptDir: "prompts",
+		Agents: map[string]agent.Agent{
+			"coder": {
+				Name:    "Coder",
+				Shell:   "/bin/bash",
+				Prompts: agent.PromptList{"greet"},
+			},
+			"drafter": {
+				Name:    "Drafter",
+				Shell:   "/bin/bash",
+				Prompts: agent.PromptList{"missing", "greet"},
+			},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleAgent)(res, req)
+		return res
+	}
+
+	res := get("/api/agents/coder/prompt")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", res.Code, res.Body.String())
+	}
+	var payload agentPromptResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Mode != terminal.PromptModeStdin {
+		t.Fatalf("expected stdin mode, got %q", payload.Mode)
+	}
+	if payload.SessionID != "Coder 1" {
+		t.Fatalf("expected session id Coder 1, got %q", payload.SessionID)
+	}
+	if !strings.Contains(payload.Prompt, "hello from\nCoder 1") {
+		t.Fatalf("expected rendered prompt, got %q", payload.Prompt)
+	}
+	if len(payload.PromptFiles) != 1 {
+		t.Fatalf("expected one prompt file, got %v", payload.PromptFiles)
+	}
+	if len(manager.List()) != 0 {
+		t.Fatalf("expected preview not to start a session")
+	}
+
+	if len(payload.SkippedPrompts) != 0 {
+		t.Fatalf("expected no skipped prompts, got %v", payload.SkippedPrompts)
+	}
+
+	res = get("/api/agents/drafter/prompt")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200 with a missing prompt file, got %d %s", res.Code, res.Body.String())
+	}
+	payload = agentPromptResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !strings.Contains(payload.Prompt, "hello from\nDrafter 1") || len(payload.PromptFiles) != 1 {
+		t.Fatalf("expected the remaining prompt to render, got %+v", payload)
+	}
+	if len(payload.SkippedPrompts) != 1 || payload.SkippedPrompts[0].Name != "missing" || payload.SkippedPrompts[0].Error == "" {
+		t.Fatalf("expected the missing prompt to be reported, got %+v", payload.SkippedPrompts)
+	}
+
+	res = get("/api/agents/ghost/prompt")
+	if res.Code != http.StatusNotFound || decodeErrorCode(t, res) != errorCodeAgentUnknown {
+		t.Fatalf("expected agent_unknown, got %d %s", res.Code, res.Body.String())
+	}
+}
+
+func TestDefaultAgentEndpoint(t *testing.T) {
+	manager := terminal.NewManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &recordFactory{},
+		Agents: map[string]agent.Agent{
+			"coder": {Name: "Coder", Shell: "/bin/bash"},
+		},
+	})
+	handler := &RestHandler{Manager: manager, DefaultAgentPath: filepath.Join(t.TempDir(), agent.DefaultAgentFile)}
+	call := func(method, body string) (*httptest.ResponseRecorder, defaultAgentResponse) {
+		t.Helper()
+		req := httptest.NewRequest(method, "/api/agents/default", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleDefaultAgent)(res, req)
+		var payload defaultAgentResponse
+		if res.Code == http.StatusOK {
+			if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+		}
+		return res, payload
+	}
+
+	if res, payload := call(http.MethodGet, ""); res.Code != http.StatusOK || payload.AgentID != "" {
+		t.Fatalf("expected no default, got %d %+v", res.Code, payload)
+	}
+
+	res, _ := call(http.MethodPut, `{"agent_id":"missing"}`)
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeAgentUnknown {
+		t.Fatalf("expected 400 agent_unknown, got %d", res.Code)
+	}
+
+	if res, payload := call(http.MethodPut, `{"agent_id":"coder"}`); res.Code != http.StatusOK || payload.Name != "Coder" {
+		t.Fatalf("expected default set, got %d %+v", res.Code, payload)
+	}
+	if res, payload := call(http.MethodGet, ""); res.Code != http.StatusOK || payload.AgentID != "coder" || payload.Missing {
+		t.Fatalf("expected stored default, got %d %+v", res.Code, payload)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/agents", nil)
+	listRes := httptest.NewRecorder()
+	restHandler("", nil, handler.handleAgents)(listRes, req)
+	var agents []agentSummary
+	if err := json.NewDecoder(listRes.Body).Decode(&agents); err != nil {
+		t.Fatalf("decode agents: %v", err)
+	}
+	if len(agents) != 1 || !agents[0].Default {
+		t.Fatalf("expected coder flagged as default, got %+v", agents)
+	}
+
+	if res, payload := call(http.MethodPut, `{"agent_id":""}`); res.Code != http.StatusOK || payload.AgentID != "" {
+		t.Fatalf("expected default cleared, got %d %+v", res.Code, payload)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"gestalt/internal/terminal"
+)
+
+const castContentType = "application/x-asciicast"
+
+// handleTerminalCast serves a session's recorded output as an asciinema v2
+// cast. Event times are relative to session creation, or to the oldest kept
+// chunk once earlier output has been dropped to bound memory.
+func (h *RestHandler) handleTerminalCast(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	session, ok := h.Manager.Get(id)
+	if !ok {
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	}
+
+	chunks, truncated, err := session.OutputTimeline()
+	if err != nil {
+		return &apiError{Status: http.StatusInternalServerError, Message: fmt.Sprintf("failed to read session timing: %s", err.Error())}
+	}
+	start := session.CreatedAt
+	if truncated && len(chunks) > 0 {
+		start = chunks[0].At
+	}
+	cols, rows := session.Size()
+	title := session.Title
+	if title == "" {
+		title = session.ID
+	}
+	header := terminal.CastHeader{
+		Width:     int(cols),
+		Height:    int(rows),
+		Timestamp: start.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	}
+
+	w.Header().Set("Content-Type", castContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", downloadFilename(id, "session", "cast")))
+	w.WriteHeader(http.StatusOK)
+	_ = terminal.WriteCast(w, header, start, chunks)
+	return nil
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gestalt/internal/flow"
+	"gestalt/internal/plan"
+	"gestalt/internal/version"
+)
+
+const (
+	diagnosticsOutputLines = 200
+	diagnosticsRedacted    = "[redacted]"
+)
+
+// secretKeyMarkers flag label and config keys whose values are dropped from
+// diagnostics snapshots.
+var secretKeyMarkers = []string{"secret", "token", "password", "passwd", "apikey", "api_key", "credential", "private_key"}
+
+type diagnosticsStatus struct {
+	GeneratedAt    time.Time `json:"generated_at"`
+	Version        string    `json:"version"`
+	GitCommit      string    `json:"git_commit,omitempty"`
+	GitOrigin      string    `json:"git_origin,omitempty"`
+	GitBranch      string    `json:"git_branch,omitempty"`
+	WorkingDir     string    `json:"working_dir"`
+	SessionCount   int       `json:"session_count"`
+	SessionPersist bool      `json:"session_persist"`
+}
+
+type diagnosticsConfig struct {
+	SessionScrollbackLines int    `json:"session_scrollback_lines"`
+	SessionFontFamily      string `json:"session_font_family,omitempty"`
+	SessionFontSize        string `json:"session_font_size,omitempty"`
+	SessionInputFontFamily string `json:"session_input_font_family,omitempty"`
+	SessionInputFontSize   string `json:"session_input_font_size,omitempty"`
+	AuthEnabled            bool   `json:"auth_enabled"`
+	FlowConfigPath         string `json:"flow_config_path,omitempty"`
+	FlowConfig             any    `json:"flow_config,omitempty"`
+	FlowConfigError        string `json:"flow_config_error,omitempty"`
+}
+
+// handleDiagnosticsSnapshot streams a tar.gz with server status, effective
+// config, sessions with their recent output, the flow triggers, and plan
+// files. The auth token and values under secret-looking keys are redacted.
+func (h *RestHandler) handleDiagnosticsSnapshot(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	filename := fmt.Sprintf("gestalt-snapshot-%s.tar.gz", now.Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	gz := gzip.NewWriter(w)
+	archive := &diagnosticsArchive{tw: tar.NewWriter(gz), modTime: now, authToken: h.AuthToken}
+	h.writeDiagnostics(archive, now)
+	if archive.err != nil && h.Logger != nil {
+		h.Logger.Warn("diagnostics snapshot incomplete", map[string]string{
+			"error": archive.err.Error(),
+		})
+	}
+	_ = archive.tw.Close()
+	_ = gz.Close()
+	return nil
+}
+
+func (h *RestHandler) writeDiagnostics(archive *diagnosticsArchive, now time.Time) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		workDir = "unknown"
+	}
+	sessions := h.Manager.List()
+	gitOrigin, gitBranch := h.gitInfo()
+	versionInfo := version.GetVersionInfo()
+	archive.writeJSON("status.json", diagnosticsStatus{
+		GeneratedAt:    now,
+		Version:        versionInfo.Version,
+		GitCommit:      versionInfo.GitCommit,
+		GitOrigin:      gitOrigin,
+		GitBranch:      gitBranch,
+		WorkingDir:     workDir,
+		SessionCount:   len(sessions),
+		SessionPersist: h.Manager.SessionPersistenceEnabled(),
+	})
+
+	config := diagnosticsConfig{
+		SessionScrollbackLines: h.SessionScrollbackLines,
+		SessionFontFamily:      h.SessionFontFamily,
+		SessionFontSize:        h.SessionFontSize,
+		SessionInputFontFamily: h.SessionInputFontFamily,
+		SessionInputFontSize:   h.SessionInputFontSize,
+		AuthEnabled:            h.AuthToken != "",
+	}
+	if h.FlowService != nil {
+		config.FlowConfigPath = h.FlowService.ConfigPath()
+		if flowConfig, err := h.FlowService.LoadConfig(); err != nil {
+			config.FlowConfigError = err.Error()
+		} else {
+			config.FlowConfig = redactFlowConfig(flowConfig)
+		}
+	}
+	archive.writeJSON("config.json", config)
+
+	summaries := make([]terminalSummary, 0, len(sessions))
+	for _, info := range sessions {
+		summary := newTerminalSummary(info)
+		summary.Labels = redactSecretLabels(summary.Labels)
+		summaries = append(summaries, summary)
+	}
+	archive.writeJSON("sessions.json", summaries)
+	for _, info := range sessions {
+		lines, err := h.Manager.HistoryLines(info.ID, diagnosticsOutputLines)
+		if err != nil {
+			archive.writeFile(path.Join("sessions", diagnosticsEntryName(info.ID)+".error"), []byte(err.Error()+"\n"))
+			continue
+		}
+		archive.writeFile(path.Join("sessions", diagnosticsEntryName(info.ID)+".txt"), []byte(strings.Join(lines, "\n")))
+	}
+
+	plansDir := plan.DefaultPlansDir()
+	entries, err := os.ReadDir(plansDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".org") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(plansDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		archive.writeFile(path.Join("plans", entry.Name()), data)
+	}
+}
+
+// diagnosticsArchive writes redacted entries into a tar stream and keeps the
+// first error so a partial archive still closes cleanly.
+type diagnosticsArchive struct {
+	tw        *tar.Writer
+	modTime   time.Time
+	authToken string
+	err       error
+}
+
+func (a *diagnosticsArchive) writeJSON(name string, value any) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		a.fail(err)
+		return
+	}
+	a.writeFile(name, append(data, '\n'))
+}
+
+func (a *diagnosticsArchive) writeFile(name string, data []byte) {
+	if a.err != nil {
+		return
+	}
+	if a.authToken != "" {
+		data = []byte(strings.ReplaceAll(string(data), a.authToken, diagnosticsRedacted))
+	}
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: a.modTime,
+	}
+	if err := a.tw.WriteHeader(header); err != nil {
+		a.fail(err)
+		return
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		a.fail(err)
+	}
+}
+
+func (a *diagnosticsArchive) fail(err error) {
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+func redactSecretLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return labels
+	}
+	redacted := make(map[string]string, len(labels))
+	for key, value := range labels {
+		if isSecretKey(key) {
+			value = diagnosticsRedacted
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+func redactFlowConfig(cfg flow.Config) flow.Config {
+	bindings := make(map[string][]flow.ActivityBinding, len(cfg.BindingsByTriggerID))
+	for triggerID, list := range cfg.BindingsByTriggerID {
+		redacted := make([]flow.ActivityBinding, 0, len(list))
+		for _, binding := range list {
+			config := make(map[string]any, len(binding.Config))
+			for key, value := range binding.Config {
+				if isSecretKey(key) {
+					value = diagnosticsRedacted
+				}
+				config[key] = value
+			}
+			binding.Config = config
+			redacted = append(redacted, binding)
+		}
+		bindings[triggerID] = redacted
+	}
+	cfg.BindingsByTriggerID = bindings
+	return cfg
+}
+
+func isSecretKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, marker := range secretKeyMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func diagnosticsEntryName(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < 0x20 {
+			return '-'
+		}
+		return r
+	}, id)
+}
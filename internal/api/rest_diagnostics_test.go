@@ -0,0 +1,118 @@
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gestalt/internal/terminal"
+)
+
+func TestDiagnosticsSnapshotEndpoint(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+	})
+	created, err := manager.CreateWithOptions(terminal.CreateOptions{
+		AgentID: testAgentID,
+		Labels:  map[string]string{"team": "core", "api_token": "hunter2"},
+	})
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+	created.PublishOutputChunk([]byte("export TOKEN=secret\nbuild ok\n"))
+	if !waitForOutputLines(created, 2, time.Second) {
+		t.Fatalf("expected output buffer to receive data")
+	}
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, manager, "secret", StatusConfig{}, "", nil, nil, nil, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/diagnostics/snapshot", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	if got := res.Header().Get("Content-Type"); got != "application/gzip" {
+		t.Fatalf("unexpected content type %q", got)
+	}
+
+	entries := readSnapshotEntries(t, res.Body)
+	for _, name := range []string{"status.json", "config.json", "sessions.json"} {
+		if _, ok := entries[name]; !ok {
+			t.Fatalf("expected %s in snapshot, got %v", name, snapshotNames(entries))
+		}
+	}
+	for name, body := range entries {
+		if strings.Contains(body, "secret") || strings.Contains(body, "hunter2") {
+			t.Fatalf("expected %s to be redacted, got %q", name, body)
+		}
+	}
+
+	output, ok := entries["sessions/"+created.ID+".txt"]
+	if !ok {
+		t.Fatalf("expected session output in snapshot, got %v", snapshotNames(entries))
+	}
+	if !strings.Contains(output, "export TOKEN=[redacted]") || !strings.Contains(output, "build ok") {
+		t.Fatalf("unexpected session output %q", output)
+	}
+
+	var sessions []terminalSummary
+	if err := json.Unmarshal([]byte(entries["sessions.json"]), &sessions); err != nil {
+		t.Fatalf("decode sessions: %v", err)
+	}
+	for _, session := range sessions {
+		if session.ID != created.ID {
+			continue
+		}
+		if session.Labels["team"] != "core" || session.Labels["api_token"] != "[redacted]" {
+			t.Fatalf("unexpected labels %v", session.Labels)
+		}
+		return
+	}
+	t.Fatalf("expected session %s in sessions.json", created.ID)
+}
+
+func readSnapshotEntries(t *testing.T, body io.Reader) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatalf("open gzip: %v", err)
+	}
+	defer gz.Close()
+	reader := tar.NewReader(gz)
+	entries := map[string]string{}
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("read tar: %v", err)
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("read %s: %v", header.Name, err)
+		}
+		entries[header.Name] = string(data)
+	}
+}
+
+func snapshotNames(entries map[string]string) []string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	return names
+}
@@ -119,29 +119,29 @@ func (h *RestHandler) handleFlowConfigImport(w http.ResponseWriter, r *http.Requ
 func decodeFlowImportPayload(r *http.Request, defs []flow.ActivityDef) (flow.Config, *apiError) {
 	contentType := strings.TrimSpace(r.Header.Get("Content-Type"))
 	if contentType == "" {
-		return flow.Config{}, &apiError{Status: http.StatusUnsupportedMediaType, Message: "unsupported content type"}
+		return flow.Config{}, &apiError{Status: http.StatusUnsupportedMediaType, Message: "unsupported content type", Code: errorCodeUnsupportedMediaType}
 	}
 	mediaType, _, err := mime.ParseMediaType(contentType)
 	if err != nil || !isSupportedFlowYAMLMediaType(mediaType) {
-		return flow.Config{}, &apiError{Status: http.StatusUnsupportedMediaType, Message: "unsupported content type"}
+		return flow.Config{}, &apiError{Status: http.StatusUnsupportedMediaType, Message: "unsupported content type", Code: errorCodeUnsupportedMediaType}
 	}
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 	body = bytes.TrimSpace(body)
 	if len(body) == 0 {
-		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 	cfg, err := flow.DecodeFlowBundleYAML(body, defs)
 	if err != nil {
 		var validationErr *flow.ValidationError
 		if errors.As(err, &validationErr) {
 			if validationErr.Kind == flow.ValidationConflict {
-				return flow.Config{}, &apiError{Status: http.StatusConflict, Message: validationErr.Message}
+				return flow.Config{}, &apiError{Status: http.StatusConflict, Message: validationErr.Message, Code: errorCodeFlowConfigInvalid}
 			}
 		}
-		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 	return cfg, nil
 }
@@ -175,7 +175,7 @@ func decodeFlowConfigPayload(r *http.Request) (flow.Config, *apiError) {
 	decoder.DisallowUnknownFields()
 	decoder.UseNumber()
 	if err := decoder.Decode(&payload); err != nil {
-		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return flow.Config{}, &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 	return payload, nil
 }
@@ -188,13 +188,13 @@ func mapFlowError(err error) *apiError {
 	if errors.As(err, &validation) {
 		switch validation.Kind {
 		case flow.ValidationConflict:
-			return &apiError{Status: http.StatusConflict, Message: validation.Message}
+			return &apiError{Status: http.StatusConflict, Message: validation.Message, Code: errorCodeFlowConfigInvalid}
 		default:
-			return &apiError{Status: http.StatusBadRequest, Message: validation.Message}
+			return &apiError{Status: http.StatusBadRequest, Message: validation.Message, Code: errorCodeFlowConfigInvalid}
 		}
 	}
 	if errors.Is(err, flow.ErrDispatcherUnavailable) {
-		return &apiError{Status: http.StatusServiceUnavailable, Message: "flow dispatcher unavailable"}
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "flow dispatcher unavailable", Code: errorCodeFlowUnavailable}
 	}
 	return &apiError{Status: http.StatusInternalServerError, Message: "failed to save flow config"}
 }
@@ -205,22 +205,7 @@ func flowEventTypes() []string {
 		"git-branch",
 		"git-commit",
 	}
-	notifyTypes := flowNotifyTypeList()
-	return append(coreTypes, notifyTypes...)
-}
-
-func flowNotifyTypeList() []string {
-	values := []string{
-		"plan-new",
-		"plan-update",
-		"work-start",
-		"work-progress",
-		"work-finish",
-		"agent-turn-complete",
-		"prompt-voice",
-		"prompt-text",
-	}
-	return uniqueStrings(values)
+	return uniqueStrings(append(coreTypes, flow.NotifyEventTypes()...))
 }
 
 func uniqueStrings(values []string) []string {
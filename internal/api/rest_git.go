@@ -19,7 +19,7 @@ func (h *RestHandler) handleGitLog(w http.ResponseWriter, r *http.Request) *apiE
 		return methodNotAllowed(w, "GET")
 	}
 	if h.GitLogReader == nil {
-		return &apiError{Status: http.StatusServiceUnavailable, Message: "git log unavailable"}
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "git log unavailable", Code: errorCodeGitUnavailable}
 	}
 
 	limit := gitlog.DefaultLimit
@@ -27,10 +27,10 @@ func (h *RestHandler) handleGitLog(w http.ResponseWriter, r *http.Request) *apiE
 	if limitParam != "" {
 		parsed, err := strconv.Atoi(limitParam)
 		if err != nil {
-			return &apiError{Status: http.StatusBadRequest, Message: "invalid limit"}
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid limit", Code: errorCodeInvalidParameter}
 		}
 		if parsed <= 0 || parsed > gitlog.MaxLimit {
-			return &apiError{Status: http.StatusBadRequest, Message: "invalid limit"}
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid limit", Code: errorCodeInvalidParameter}
 		}
 		limit = parsed
 	}
@@ -42,7 +42,7 @@ func (h *RestHandler) handleGitLog(w http.ResponseWriter, r *http.Request) *apiE
 				"error": err.Error(),
 			})
 		}
-		return &apiError{Status: http.StatusServiceUnavailable, Message: "git log unavailable"}
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "git log unavailable", Code: errorCodeGitUnavailable}
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), gitLogTimeout)
@@ -55,12 +55,12 @@ func (h *RestHandler) handleGitLog(w http.ResponseWriter, r *http.Request) *apiE
 	if err != nil {
 		switch {
 		case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
-			return &apiError{Status: http.StatusServiceUnavailable, Message: "git log timed out"}
+			return &apiError{Status: http.StatusServiceUnavailable, Message: "git log timed out", Code: errorCodeGitUnavailable}
 		case errors.Is(err, gitlog.ErrNotGitRepo), errors.Is(err, gitlog.ErrEmptyRepo):
 			writeJSON(w, http.StatusOK, gitLogResponse{Branch: "", Commits: []gitLogCommit{}})
 			return nil
 		default:
-			return &apiError{Status: http.StatusServiceUnavailable, Message: "git log unavailable"}
+			return &apiError{Status: http.StatusServiceUnavailable, Message: "git log unavailable", Code: errorCodeGitUnavailable}
 		}
 	}
 
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const inputHistoryExportFormatShell = "sh"
+
+// handleTerminalInputHistoryExport serves a session's recorded input as a
+// shell script, one command per entry preceded by its timestamp, so an
+// exploratory session can be replayed elsewhere. It accepts the same since
+// and limit filters as the input history endpoint, but exports everything
+// recorded when no limit is given.
+func (h *RestHandler) handleTerminalInputHistoryExport(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = inputHistoryExportFormatShell
+	}
+	if format != inputHistoryExportFormatShell {
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid format", Code: errorCodeInvalidParameter}
+	}
+	limit, since, err := parseInputHistoryQuery(r)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(r.URL.Query().Get("limit")) == "" {
+		limit = 0
+	}
+
+	session, ok := h.Manager.Get(id)
+	if !ok {
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	}
+	entries := filterInputHistory(session.GetInputHistory(), limit, since)
+
+	var script strings.Builder
+	script.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&script, "# Input history of gestalt session %s\n", commentSafe(id))
+	if session.Title != "" {
+		fmt.Fprintf(&script, "# Title: %s\n", commentSafe(session.Title))
+	}
+	fmt.Fprintf(&script, "# Exported %s, %d commands\n", time.Now().UTC().Format(time.RFC3339), len(entries))
+	for _, entry := range entries {
+		fmt.Fprintf(&script, "\n# %s\n", entry.Timestamp.UTC().Format(time.RFC3339))
+		script.WriteString(strings.TrimRight(entry.Command, "\r\n"))
+		script.WriteString("\n")
+	}
+
+	w.Header().Set("Content-Type", "text/x-shellscript; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", downloadFilename(id, "input-history", format)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(script.String()))
+	return nil
+}
+
+// commentSafe keeps a value on a single comment line.
+func commentSafe(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
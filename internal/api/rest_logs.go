@@ -0,0 +1,33 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// handleLogEntry returns a single buffered log entry by ID so clients can
+// link to it, e.g. from a toast.
+func (h *RestHandler) handleLogEntry(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	if err := h.requireLogger(); err != nil {
+		return err
+	}
+
+	rawID := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/logs/"), "/")
+	if rawID == "" || strings.Contains(rawID, "/") {
+		return &apiError{Status: http.StatusNotFound, Message: "not found"}
+	}
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil || id == 0 {
+		return fieldValidationError(http.StatusBadRequest, "id", "log id must be a positive integer")
+	}
+	entry, ok := h.Logger.Buffer().Get(id)
+	if !ok {
+		return &apiError{Status: http.StatusNotFound, Message: "log entry not found", Code: errorCodeLogEntryNotFound}
+	}
+	writeJSON(w, http.StatusOK, entry)
+	return nil
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"gestalt/internal/logging"
+)
+
+func TestLogEntryEndpoint(t *testing.T) {
+	logger := logging.NewLoggerWithOutput(logging.NewLogBuffer(2), logging.LevelInfo, nil)
+	logger.Info("first", nil)
+	logger.Info("second", map[string]string{"session_id": "s1"})
+	handler := &RestHandler{Logger: logger}
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		restHandler("", nil, handler.handleLogEntry)(rec, req)
+		return rec
+	}
+
+	entries := logger.Buffer().List()
+	rec := get("/api/logs/" + strconv.FormatUint(entries[1].ID, 10))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var entry logging.LogEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entry); err != nil {
+		t.Fatalf("decode entry: %v", err)
+	}
+	if entry.Message != "second" || entry.Context["session_id"] != "s1" {
+		t.Fatalf("unexpected entry: %#v", entry)
+	}
+
+	logger.Info("third", nil)
+	rec = get("/api/logs/" + strconv.FormatUint(entries[0].ID, 10))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for aged-out entry, got %d", rec.Code)
+	}
+	var payload errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if payload.Code != errorCodeLogEntryNotFound {
+		t.Fatalf("expected %s, got %q", errorCodeLogEntryNotFound, payload.Code)
+	}
+
+	if rec := get("/api/logs/abc"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid id, got %d", rec.Code)
+	}
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"net/http"
 	"time"
+
+	"gestalt/internal/metrics"
 )
 
 func (h *RestHandler) handleMetricsSummary(w http.ResponseWriter, r *http.Request) *apiError {
@@ -10,9 +12,67 @@ func (h *RestHandler) handleMetricsSummary(w http.ResponseWriter, r *http.Reques
 		return methodNotAllowed(w, "GET")
 	}
 	if h.MetricsSummary == nil {
-		return &apiError{Status: http.StatusServiceUnavailable, Message: "metrics summary unavailable"}
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "metrics summary unavailable", Code: errorCodeOTelUnavailable}
 	}
 	summary := h.MetricsSummary.Summary(time.Now().UTC())
 	writeJSON(w, http.StatusOK, summary)
 	return nil
 }
+
+type routeMetricsResponse struct {
+	Routes []routeMetrics `json:"routes"`
+}
+
+type routeMetrics struct {
+	Route  string  `json:"route"`
+	Method string  `json:"method"`
+	Count  int64   `json:"count"`
+	Errors int64   `json:"errors"`
+	P50Ms  float64 `json:"p50_ms"`
+	P90Ms  float64 `json:"p90_ms"`
+	P99Ms  float64 `json:"p99_ms"`
+}
+
+// handleMetricsRoutes reports request count, error count, and latency
+// percentiles per API route template.
+func (h *RestHandler) handleMetricsRoutes(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	snapshots := metrics.Default.HTTPRouteSnapshots()
+	response := routeMetricsResponse{Routes: make([]routeMetrics, 0, len(snapshots))}
+	for _, snapshot := range snapshots {
+		response.Routes = append(response.Routes, routeMetrics{
+			Route:  snapshot.Route,
+			Method: snapshot.Method,
+			Count:  snapshot.Count,
+			Errors: snapshot.Errors,
+			P50Ms:  durationMillis(snapshot.P50),
+			P90Ms:  durationMillis(snapshot.P90),
+			P99Ms:  durationMillis(snapshot.P99),
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+	return nil
+}
+
+func durationMillis(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+// handleMetricsReset zeroes the in-process metrics registry between load test
+// runs. It is only routed when the server runs with --allow-metrics-reset.
+func (h *RestHandler) handleMetricsReset(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(w, "POST")
+	}
+	metrics.Default.Reset()
+	if h.Logger != nil {
+		h.Logger.Info("metrics reset", map[string]string{
+			"gestalt.category": "metrics",
+			"gestalt.source":   "backend",
+		})
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
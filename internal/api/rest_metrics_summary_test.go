@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"gestalt/internal/metrics"
 	"gestalt/internal/otel"
 )
 
@@ -68,3 +70,73 @@ func TestMetricsSummaryCacheTTL(t *testing.T) {
 		t.Fatalf("expected cached summary within TTL")
 	}
 }
+
+func TestMetricsResetEndpointRequiresFlag(t *testing.T) {
+	post := func(config StatusConfig) *httptest.ResponseRecorder {
+		mux := http.NewServeMux()
+		RegisterRoutes(mux, nil, "secret", config, "", nil, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodPost, "/api/metrics/reset", nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post(StatusConfig{}); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without flag, got %d", rec.Code)
+	}
+
+	metrics.Default.IncWorkflowStarted()
+	metrics.Default.RecordActivity("load-test", time.Millisecond, nil, 1)
+	if rec := post(StatusConfig{AllowMetricsReset: true}); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+
+	var out strings.Builder
+	if err := metrics.Default.WritePrometheus(&out); err != nil {
+		t.Fatalf("write prometheus: %v", err)
+	}
+	if !strings.Contains(out.String(), "gestalt_workflows_started_total 0") || strings.Contains(out.String(), "load-test") {
+		t.Fatalf("expected metrics to be reset:\n%s", out.String())
+	}
+}
+
+func TestMetricsRoutesEndpointUsesRouteTemplates(t *testing.T) {
+	metrics.Default.Reset()
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, nil, "secret", StatusConfig{}, "", nil, nil, nil, nil)
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, id := range []string{"one", "two", "three"} {
+		get("/api/sessions/" + id + "/output")
+	}
+	rec := get("/api/metrics/routes")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var response routeMetricsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode routes: %v", err)
+	}
+	var found *routeMetrics
+	for i := range response.Routes {
+		if strings.Contains(response.Routes[i].Route, "one") {
+			t.Fatalf("expected raw session ids to be normalized: %#v", response.Routes)
+		}
+		if response.Routes[i].Route == "/api/sessions/:id/output" && response.Routes[i].Method == http.MethodGet {
+			found = &response.Routes[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected output route in %#v", response.Routes)
+	}
+	if found.Count != 3 || found.Errors != 3 {
+		t.Fatalf("expected 3 requests and 3 errors, got %#v", found)
+	}
+}
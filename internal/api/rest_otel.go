@@ -1,7 +1,10 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -21,6 +24,9 @@ const (
 	maxOTelLogKeyLength   = 256
 	maxOTelLogValueLength = 2048
 	otlpUILoggerName      = "gestalt/ui"
+
+	maxOTelLogBatchEntries   = 500
+	maxOTelLogBatchBodyBytes = 4 * 1024 * 1024
 )
 
 type otelTraceQuery struct {
@@ -38,6 +44,9 @@ type otelMetricQuery struct {
 	Until *time.Time
 	Name  string
 	Query string
+	// Self, when set, keeps only (true) or drops (false) the collector's
+	// own metrics.
+	Self *bool
 }
 
 func (h *RestHandler) handleOTelLogs(w http.ResponseWriter, r *http.Request) *apiError {
@@ -49,6 +58,13 @@ func (h *RestHandler) handleOTelLogs(w http.ResponseWriter, r *http.Request) *ap
 	}
 }
 
+func (h *RestHandler) handleOTelLogsBatch(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(w, "POST")
+	}
+	return ingestOTelLogBatch(w, r)
+}
+
 func (h *RestHandler) handleOTelTraces(w http.ResponseWriter, r *http.Request) *apiError {
 	if r.Method != http.MethodGet {
 		return methodNotAllowed(w, "GET")
@@ -59,7 +75,7 @@ func (h *RestHandler) handleOTelTraces(w http.ResponseWriter, r *http.Request) *
 	}
 	dataPath, ok := activeOTelDataPath()
 	if !ok {
-		return &apiError{Status: http.StatusServiceUnavailable, Message: "otel traces unavailable"}
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "otel traces unavailable", Code: errorCodeOTelUnavailable}
 	}
 	records, readErr := otel.ReadTraceRecords(dataPath)
 	if readErr != nil {
@@ -80,7 +96,12 @@ func (h *RestHandler) handleOTelMetrics(w http.ResponseWriter, r *http.Request)
 	}
 	dataPath, ok := activeOTelDataPath()
 	if !ok {
-		return &apiError{Status: http.StatusServiceUnavailable, Message: "otel metrics unavailable"}
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "otel metrics unavailable", Code: errorCodeOTelUnavailable}
+	}
+	if query.Self != nil && *query.Self {
+		if info, _ := otel.ActiveCollector(); !info.SelfMetricsEnabled {
+			return &apiError{Status: http.StatusServiceUnavailable, Message: "otel self-metrics disabled; set GESTALT_OTEL_SELF_METRICS=true", Code: errorCodeOTelUnavailable}
+		}
 	}
 	records, readErr := otel.ReadMetricRecords(dataPath)
 	if readErr != nil {
@@ -143,6 +164,13 @@ func parseOTelMetricQuery(r *http.Request) (otelMetricQuery, *apiError) {
 		query.Until = parsed
 	}
 	query.Query = strings.TrimSpace(values.Get("query"))
+	if raw := strings.TrimSpace(values.Get("self")); raw != "" {
+		self, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, &apiError{Status: http.StatusBadRequest, Message: "invalid self", Code: errorCodeInvalidParameter}
+		}
+		query.Self = &self
+	}
 	return query, nil
 }
 
@@ -153,7 +181,7 @@ func parseOTelLimit(raw string, defaultLimit int) (int, *apiError) {
 	}
 	limit, err := strconv.Atoi(raw)
 	if err != nil || limit <= 0 {
-		return 0, &apiError{Status: http.StatusBadRequest, Message: "invalid limit"}
+		return 0, &apiError{Status: http.StatusBadRequest, Message: "invalid limit", Code: errorCodeInvalidParameter}
 	}
 	if limit > maxOTelQueryLimit {
 		limit = maxOTelQueryLimit
@@ -168,43 +196,100 @@ func parseTimeParam(raw string) (*time.Time, *apiError) {
 	}
 	parsed, err := time.Parse(time.RFC3339, raw)
 	if err != nil {
-		return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid timestamp"}
+		return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid timestamp", Code: errorCodeInvalidParameter}
 	}
 	return &parsed, nil
 }
 
 func ingestOTelLogRecord(w http.ResponseWriter, r *http.Request) *apiError {
 	if r.Body == nil {
-		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 	limited := http.MaxBytesReader(w, r.Body, maxOTelLogBodyBytes*2)
 	decoder := json.NewDecoder(limited)
 	decoder.UseNumber()
 	var payload map[string]any
 	if err := decoder.Decode(&payload); err != nil && err != io.EOF {
-		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return invalidBodyError(err)
 	}
 
-	severityNumber, severityText, apiErr := parseOTelSeverity(payload)
+	record, apiErr := buildOTelLogRecord(payload, time.Now().UTC())
 	if apiErr != nil {
 		return apiErr
 	}
+	logglobal.Logger(otlpUILoggerName).Emit(r.Context(), record)
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// ingestOTelLogBatch accepts an array of log records. Each entry is validated
+// like a single POST /api/otel/logs body; invalid entries are reported by index
+// and do not prevent the rest from being emitted.
+func ingestOTelLogBatch(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Body == nil {
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
+	}
+	limited := http.MaxBytesReader(w, r.Body, maxOTelLogBatchBodyBytes)
+	decoder := json.NewDecoder(limited)
+	decoder.UseNumber()
+	var entries []json.RawMessage
+	if err := decoder.Decode(&entries); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return &apiError{Status: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf("batch exceeds %d bytes", maxOTelLogBatchBodyBytes), Code: errorCodePayloadTooLarge}
+		}
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
+	}
+	if len(entries) > maxOTelLogBatchEntries {
+		return &apiError{Status: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf("batch exceeds %d entries", maxOTelLogBatchEntries), Code: errorCodePayloadTooLarge}
+	}
+
+	logger := logglobal.Logger(otlpUILoggerName)
+	now := time.Now().UTC()
+	response := otelLogBatchResponse{Rejected: []otelLogBatchRejection{}}
+	for index, raw := range entries {
+		entryDecoder := json.NewDecoder(bytes.NewReader(raw))
+		entryDecoder.UseNumber()
+		var payload map[string]any
+		if err := entryDecoder.Decode(&payload); err != nil || payload == nil {
+			response.Rejected = append(response.Rejected, otelLogBatchRejection{Index: index, Message: "log entry must be an object", Code: errorCodeInvalidBody})
+			continue
+		}
+		record, apiErr := buildOTelLogRecord(payload, now)
+		if apiErr != nil {
+			code := apiErr.Code
+			if code == "" {
+				code = errorCodeForStatus(apiErr.Status)
+			}
+			response.Rejected = append(response.Rejected, otelLogBatchRejection{Index: index, Message: apiErr.Message, Code: code, Fields: apiErr.Fields})
+			continue
+		}
+		logger.Emit(r.Context(), record)
+		response.Accepted++
+	}
+	writeJSON(w, http.StatusOK, response)
+	return nil
+}
+
+func buildOTelLogRecord(payload map[string]any, now time.Time) (otellog.Record, *apiError) {
+	var record otellog.Record
+	severityNumber, severityText, apiErr := parseOTelSeverity(payload)
+	if apiErr != nil {
+		return record, withField(apiErr, "severity_number")
+	}
 	bodyValue, apiErr := parseOTelLogBody(payload["body"])
 	if apiErr != nil {
-		return apiErr
+		return record, withField(apiErr, "body")
 	}
 	attributes, apiErr := parseOTelLogAttributes(payload["attributes"])
 	if apiErr != nil {
-		return apiErr
+		return record, withField(apiErr, "attributes")
 	}
 	attributes = ensureOTelLogDefaults(attributes)
 	if len(attributes) > maxOTelLogAttributes {
-		return &apiError{Status: http.StatusBadRequest, Message: "too many attributes"}
+		return record, withField(&apiError{Status: http.StatusBadRequest, Message: "too many attributes", Code: errorCodeInvalidAttribute}, "attributes")
 	}
 
-	logger := logglobal.Logger(otlpUILoggerName)
-	now := time.Now().UTC()
-	var record otellog.Record
 	record.SetTimestamp(now)
 	record.SetObservedTimestamp(now)
 	if severityNumber > 0 {
@@ -217,9 +302,7 @@ func ingestOTelLogRecord(w http.ResponseWriter, r *http.Request) *apiError {
 	if len(attributes) > 0 {
 		record.AddAttributes(attributes...)
 	}
-	logger.Emit(r.Context(), record)
-	w.WriteHeader(http.StatusNoContent)
-	return nil
+	return record, nil
 }
 
 func parseOTelSeverity(payload map[string]any) (int, string, *apiError) {
@@ -231,7 +314,7 @@ func parseOTelSeverity(payload map[string]any) (int, string, *apiError) {
 	if rawNumber, ok := payload["severity_number"]; ok {
 		parsed, ok := parseOTelSeverityNumber(rawNumber)
 		if !ok {
-			return 0, "", &apiError{Status: http.StatusBadRequest, Message: "invalid severity number"}
+			return 0, "", &apiError{Status: http.StatusBadRequest, Message: "invalid severity number", Code: errorCodeValidationFailed}
 		}
 		severityNumber = parsed
 	}
@@ -283,7 +366,7 @@ func severityFromText(raw string) otellog.Severity {
 
 func parseOTelLogBody(raw any) (otellog.Value, *apiError) {
 	if raw == nil {
-		return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "missing body"}
+		return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "missing body", Code: errorCodeInvalidBody}
 	}
 	value, apiErr := parseOTLPAnyValue(raw)
 	if apiErr != nil {
@@ -291,7 +374,7 @@ func parseOTelLogBody(raw any) (otellog.Value, *apiError) {
 	}
 	if value.Kind() == otellog.KindString {
 		if len([]byte(value.AsString())) > maxOTelLogBodyBytes {
-			return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "body too large"}
+			return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "body too large", Code: errorCodePayloadTooLarge}
 		}
 	}
 	return value, nil
@@ -306,7 +389,7 @@ func parseOTelLogAttributes(raw any) ([]otellog.KeyValue, *apiError) {
 		attributes := make([]otellog.KeyValue, 0, len(typed))
 		for key, value := range typed {
 			if !validOTelKey(key) {
-				return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid attribute key"}
+				return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid attribute key", Code: errorCodeInvalidAttribute}
 			}
 			parsed, apiErr := parseOTLPAnyValue(value)
 			if apiErr != nil {
@@ -327,7 +410,7 @@ func parseOTelLogAttributes(raw any) ([]otellog.KeyValue, *apiError) {
 			}
 			key, _ := extractString(entryMap, "key")
 			if !validOTelKey(key) {
-				return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid attribute key"}
+				return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid attribute key", Code: errorCodeInvalidAttribute}
 			}
 			value, apiErr := parseOTLPAnyValue(entryMap["value"])
 			if apiErr != nil {
@@ -340,7 +423,7 @@ func parseOTelLogAttributes(raw any) ([]otellog.KeyValue, *apiError) {
 		}
 		return attributes, nil
 	default:
-		return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid attributes"}
+		return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid attributes", Code: errorCodeInvalidAttribute}
 	}
 }
 
@@ -398,7 +481,7 @@ func parseOTLPAnyValue(raw any) (otellog.Value, *apiError) {
 				}
 				key, _ := extractString(entryMap, "key")
 				if !validOTelKey(key) {
-					return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "invalid attribute key"}
+					return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "invalid attribute key", Code: errorCodeInvalidAttribute}
 				}
 				parsed, apiErr := parseOTLPAnyValue(entryMap["value"])
 				if apiErr != nil {
@@ -412,7 +495,7 @@ func parseOTLPAnyValue(raw any) (otellog.Value, *apiError) {
 			return otellog.MapValue(converted...), nil
 		}
 	}
-	return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "invalid value"}
+	return otellog.Value{}, &apiError{Status: http.StatusBadRequest, Message: "invalid value", Code: errorCodeValidationFailed}
 }
 
 func parseOTelIntValue(raw any) (int64, bool) {
@@ -433,7 +516,7 @@ func parseOTelIntValue(raw any) (int64, bool) {
 
 func validateOTelValueLength(value otellog.Value) *apiError {
 	if value.Kind() == otellog.KindString && len(value.AsString()) > maxOTelLogValueLength {
-		return &apiError{Status: http.StatusBadRequest, Message: "attribute value too large"}
+		return &apiError{Status: http.StatusBadRequest, Message: "attribute value too large", Code: errorCodeInvalidAttribute}
 	}
 	return nil
 }
@@ -491,6 +574,9 @@ func filterOTelMetricRecords(records []map[string]any, query otelMetricQuery) []
 		if query.Name != "" && !strings.EqualFold(otelMetricName(record), query.Name) {
 			continue
 		}
+		if query.Self != nil && isCollectorSelfMetric(otelMetricName(record)) != *query.Self {
+			continue
+		}
 		if query.Query != "" && !recordMatchesQuery(record, query.Query) {
 			continue
 		}
@@ -595,6 +681,12 @@ func otelMetricName(record map[string]any) string {
 	return value
 }
 
+// isCollectorSelfMetric matches the collector's own telemetry, such as
+// otelcol_receiver_refused_spans and otelcol_exporter_send_failed_log_records.
+func isCollectorSelfMetric(name string) bool {
+	return strings.HasPrefix(name, "otelcol_") || strings.HasPrefix(name, "otelcol.")
+}
+
 func buildTraceSummary(record map[string]any) map[string]any {
 	summary := make(map[string]any, len(record)+6)
 	for key, value := range record {
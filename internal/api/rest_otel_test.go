@@ -91,6 +91,60 @@ func TestHandleOTelMetrics(t *testing.T) {
 	}
 }
 
+func TestHandleOTelMetricsSelfFilter(t *testing.T) {
+	dataPath := filepath.Join(t.TempDir(), "otel.json")
+	lines := []string{
+		`{"resourceMetrics":[{"scopeMetrics":[{"metrics":[{"name":"gestalt.workflow.started","sum":{"dataPoints":[{"timeUnixNano":"1700000000000000000","asInt":"3"}]}}]}]}]}`,
+		`{"resourceMetrics":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"otelcol-contrib"}}]},"scopeMetrics":[{"metrics":[{"name":"otelcol_receiver_refused_spans","sum":{"dataPoints":[{"timeUnixNano":"1700000001000000000","asInt":"2"}]}}]}]}]}`,
+	}
+	if err := os.WriteFile(dataPath, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatalf("write otel fixture: %v", err)
+	}
+	t.Cleanup(otel.ClearActiveCollector)
+
+	rest := &RestHandler{}
+	names := func(query string) []string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/otel/metrics"+query, nil)
+		resp := httptest.NewRecorder()
+		if err := rest.handleOTelMetrics(resp, req); err != nil {
+			t.Fatalf("handleOTelMetrics error: %v", err)
+		}
+		var metrics []map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+			t.Fatalf("decode metrics: %v", err)
+		}
+		var result []string
+		for _, metric := range metrics {
+			name, _ := metric["name"].(string)
+			result = append(result, name)
+		}
+		return result
+	}
+
+	otel.SetActiveCollector(otel.CollectorInfo{DataPath: dataPath})
+	req := httptest.NewRequest(http.MethodGet, "/api/otel/metrics?self=true", nil)
+	if err := rest.handleOTelMetrics(httptest.NewRecorder(), req); err == nil || err.Status != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 with self-metrics disabled, got %v", err)
+	}
+
+	otel.SetActiveCollector(otel.CollectorInfo{DataPath: dataPath, SelfMetricsEnabled: true})
+	if got := names("?self=true"); len(got) != 1 || got[0] != "otelcol_receiver_refused_spans" {
+		t.Fatalf("expected only collector self-metrics, got %v", got)
+	}
+	if got := names("?self=false"); len(got) != 1 || got[0] != "gestalt.workflow.started" {
+		t.Fatalf("expected self-metrics excluded, got %v", got)
+	}
+	if got := names(""); len(got) != 2 {
+		t.Fatalf("expected all metrics without a filter, got %v", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/otel/metrics?self=maybe", nil)
+	if err := rest.handleOTelMetrics(httptest.NewRecorder(), req); err == nil || err.Code != errorCodeInvalidParameter {
+		t.Fatalf("expected invalid_parameter for bad self value, got %v", err)
+	}
+}
+
 func TestHandleOTelLogsPostValidation(t *testing.T) {
 	rest := &RestHandler{}
 	req := httptest.NewRequest(http.MethodPost, "/api/otel/logs", strings.NewReader(`{}`))
@@ -100,6 +154,24 @@ func TestHandleOTelLogsPostValidation(t *testing.T) {
 	}
 }
 
+func TestHandleOTelLogsPostValidationReportsField(t *testing.T) {
+	rest := &RestHandler{}
+	handler := restHandler("", nil, rest.handleOTelLogs)
+	req := httptest.NewRequest(http.MethodPost, "/api/otel/logs", strings.NewReader(`{"severity_text":"info"}`))
+	resp := httptest.NewRecorder()
+	handler(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", resp.Code)
+	}
+	var payload errorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Message != "missing body" || len(payload.Fields) != 1 || payload.Fields[0].Field != "body" {
+		t.Fatalf("unexpected error payload: %+v", payload)
+	}
+}
+
 func TestHandleOTelLogsPostAccepted(t *testing.T) {
 	rest := &RestHandler{}
 	req := httptest.NewRequest(http.MethodPost, "/api/otel/logs", strings.NewReader(`{"severity_text":"info","body":"hello"}`))
@@ -112,6 +184,70 @@ func TestHandleOTelLogsPostAccepted(t *testing.T) {
 	}
 }
 
+func TestHandleOTelLogsBatchReportsRejectedEntries(t *testing.T) {
+	rest := &RestHandler{}
+	body := `[{"severity_text":"info","body":"hello"},{},"nope",{"severity_number":"x","body":"bad"},{"severity_text":"error","body":"boom"}]`
+	req := httptest.NewRequest(http.MethodPost, "/api/otel/logs/batch", strings.NewReader(body))
+	resp := httptest.NewRecorder()
+	if err := rest.handleOTelLogsBatch(resp, req); err != nil {
+		t.Fatalf("handleOTelLogsBatch error: %v", err)
+	}
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.Code)
+	}
+	var payload otelLogBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Accepted != 2 {
+		t.Fatalf("expected 2 accepted, got %d", payload.Accepted)
+	}
+	if len(payload.Rejected) != 3 {
+		t.Fatalf("expected 3 rejected, got %+v", payload.Rejected)
+	}
+	for i, want := range []int{1, 2, 3} {
+		rejected := payload.Rejected[i]
+		if rejected.Index != want || rejected.Message == "" || rejected.Code == "" {
+			t.Fatalf("unexpected rejection %d: %+v", i, rejected)
+		}
+	}
+}
+
+func TestHandleOTelLogsBatchRejectsInvalidBody(t *testing.T) {
+	rest := &RestHandler{}
+	req := httptest.NewRequest(http.MethodPost, "/api/otel/logs/batch", strings.NewReader(`{"body":"not an array"}`))
+	resp := httptest.NewRecorder()
+	err := rest.handleOTelLogsBatch(resp, req)
+	if err == nil || err.Status != http.StatusBadRequest || err.Code != errorCodeInvalidBody {
+		t.Fatalf("expected invalid body error, got %+v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/otel/logs/batch", nil)
+	resp = httptest.NewRecorder()
+	if err := rest.handleOTelLogsBatch(resp, req); err == nil || err.Status != http.StatusMethodNotAllowed {
+		t.Fatalf("expected method not allowed, got %+v", err)
+	}
+}
+
+func TestHandleOTelLogsBatchRejectsOversizedBatch(t *testing.T) {
+	rest := &RestHandler{}
+	body := "[" + strings.TrimSuffix(strings.Repeat(`{"body":"x"},`, maxOTelLogBatchEntries+1), ",") + "]"
+	req := httptest.NewRequest(http.MethodPost, "/api/logs/batch", strings.NewReader(body))
+	resp := httptest.NewRecorder()
+	err := rest.handleOTelLogsBatch(resp, req)
+	if err == nil || err.Status != http.StatusRequestEntityTooLarge || err.Code != errorCodePayloadTooLarge {
+		t.Fatalf("expected payload_too_large for too many entries, got %+v", err)
+	}
+
+	body = `[{"body":"` + strings.Repeat("x", maxOTelLogBatchBodyBytes) + `"}]`
+	req = httptest.NewRequest(http.MethodPost, "/api/logs/batch", strings.NewReader(body))
+	resp = httptest.NewRecorder()
+	err = rest.handleOTelLogsBatch(resp, req)
+	if err == nil || err.Status != http.StatusRequestEntityTooLarge || err.Code != errorCodePayloadTooLarge {
+		t.Fatalf("expected payload_too_large for an oversized body, got %+v", err)
+	}
+}
+
 func writeOTelFixture(t *testing.T) string {
 	t.Helper()
 	dir := t.TempDir()
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gestalt/internal/terminal"
+)
+
+// handleTerminalOutputText serves the session's output buffer as plain text,
+// one line per line, for curl and scripts that do not want JSON. Escape
+// sequences are stripped unless ansi=true; raw=true reads the output from
+// before any output transform, as it does for the JSON endpoint.
+func (h *RestHandler) handleTerminalOutputText(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	raw, apiErr := parseBoolQuery(r, "raw")
+	if apiErr != nil {
+		return apiErr
+	}
+	ansi, apiErr := parseBoolQuery(r, "ansi")
+	if apiErr != nil {
+		return apiErr
+	}
+
+	session, ok := h.Manager.Get(id)
+	if !ok {
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	}
+	lines := session.OutputLines()
+	if raw {
+		lines = session.RawOutputLines()
+	}
+	body := strings.Join(lines, "\n")
+	if !ansi {
+		body = terminal.StripANSI(strings.ReplaceAll(body, "\r\n", "\n"))
+	}
+	if body != "" && !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(body))
+	return nil
+}
+
+func parseBoolQuery(r *http.Request, name string) (bool, *apiError) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &apiError{Status: http.StatusBadRequest, Message: "invalid " + name, Code: errorCodeInvalidParameter}
+	}
+	return parsed, nil
+}
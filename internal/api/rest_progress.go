@@ -0,0 +1,29 @@
+package api
+
+import "net/http"
+
+// handleProgress returns plan progress for every session that has one, so a
+// dashboard does not have to poll each session's progress endpoint.
+func (h *RestHandler) handleProgress(w http.ResponseWriter, r *http.Request) *apiError {
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+
+	response := progressResponse{Sessions: map[string]terminalProgressResponse{}}
+	for _, info := range h.Manager.List() {
+		session, ok := h.Manager.Get(info.ID)
+		if !ok {
+			continue
+		}
+		progress, ok := session.PlanProgress()
+		if !ok {
+			continue
+		}
+		response.Sessions[info.ID] = newTerminalProgressResponse(progress)
+	}
+	writeJSON(w, http.StatusOK, response)
+	return nil
+}
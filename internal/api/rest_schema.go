@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"gestalt/internal/agent"
+	internalschema "gestalt/internal/schema"
+	"gestalt/internal/skill"
+)
+
+// publishedSchemas are the config schemas served under /api/schema/:name.
+var publishedSchemas = map[string]string{
+	"agent": agent.SchemaAgentConfig,
+	"skill": skill.SchemaSkillFrontmatter,
+}
+
+// handleSchema serves the JSON Schema for agent config files and SKILL.md
+// frontmatter so editors and CI can validate against the server's own view.
+func (h *RestHandler) handleSchema(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/schema/"), "/")
+	key, ok := publishedSchemas[name]
+	if !ok {
+		return &apiError{Status: http.StatusNotFound, Message: "schema not found"}
+	}
+	s, err := internalschema.Resolve(key)
+	if err != nil {
+		return &apiError{Status: http.StatusInternalServerError, Message: err.Error()}
+	}
+	writeJSON(w, http.StatusOK, s)
+	return nil
+}
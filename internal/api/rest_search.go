@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gestalt/internal/terminal"
+)
+
+func (h *RestHandler) handleSearch(w http.ResponseWriter, r *http.Request) *apiError {
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+
+	query := r.URL.Query()
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		return &apiError{Status: http.StatusBadRequest, Message: "missing q", Code: errorCodeInvalidParameter}
+	}
+	options := terminal.SearchOptions{}
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid limit", Code: errorCodeInvalidParameter}
+		}
+		options.Limit = parsed
+	}
+	if raw := strings.TrimSpace(query.Get("logs")); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid logs", Code: errorCodeInvalidParameter}
+		}
+		options.IncludeLogs = parsed
+	}
+
+	matches, truncated := h.Manager.SearchOutput(q, options)
+	response := searchResponse{
+		Query:     q,
+		Matches:   make([]searchMatch, 0, len(matches)),
+		Truncated: truncated,
+	}
+	for _, match := range matches {
+		response.Matches = append(response.Matches, searchMatch{
+			SessionID: match.SessionID,
+			Title:     match.Title,
+			Line:      match.Line,
+			Snippet:   match.Snippet,
+		})
+	}
+	writeJSON(w, http.StatusOK, response)
+	return nil
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gestalt/internal/terminal"
+)
+
+// sessionLogTailDefault is how many lines a tail returns without ?tail=.
+const sessionLogTailDefault = 100
+
+type sessionLogFileSummary struct {
+	SessionID string    `json:"session_id"`
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"mtime"`
+}
+
+type sessionLogFilesResponse struct {
+	Enabled bool                    `json:"enabled"`
+	Files   []sessionLogFileSummary `json:"files"`
+}
+
+type sessionLogTailResponse struct {
+	sessionLogFileSummary
+	Lines []string `json:"lines"`
+}
+
+// handleSessionLogFiles lists the session log files in the session log
+// directory, newest first.
+func (h *RestHandler) handleSessionLogFiles(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+
+	files, err := h.Manager.SessionLogFiles()
+	if err != nil {
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to list session logs"}
+	}
+	response := sessionLogFilesResponse{
+		Enabled: h.Manager.SessionPersistenceEnabled(),
+		Files:   make([]sessionLogFileSummary, 0, len(files)),
+	}
+	for _, file := range files {
+		response.Files = append(response.Files, newSessionLogFileSummary(file))
+	}
+	writeJSON(w, http.StatusOK, response)
+	return nil
+}
+
+// handleSessionLogTail returns the last lines of a session's newest log file.
+func (h *RestHandler) handleSessionLogTail(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/sessions/logs/"), "/")
+	if id == "" || strings.Contains(id, "/") {
+		return &apiError{Status: http.StatusNotFound, Message: "not found"}
+	}
+	tail := sessionLogTailDefault
+	if raw := strings.TrimSpace(r.URL.Query().Get("tail")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid tail", Code: errorCodeInvalidParameter}
+		}
+		tail = min(parsed, terminal.DefaultHistoryLines)
+	}
+
+	file, lines, err := h.Manager.SessionLogTail(id, tail)
+	if err != nil {
+		if errors.Is(err, terminal.ErrSessionLogNotFound) {
+			return &apiError{Status: http.StatusNotFound, Message: "session log not found", Code: errorCodeSessionLogNotFound}
+		}
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to read session log"}
+	}
+	lines, _ = sanitizeOutputLines(lines)
+	writeJSON(w, http.StatusOK, sessionLogTailResponse{
+		sessionLogFileSummary: newSessionLogFileSummary(file),
+		Lines:                 lines,
+	})
+	return nil
+}
+
+func newSessionLogFileSummary(file terminal.SessionLogFile) sessionLogFileSummary {
+	return sessionLogFileSummary{
+		SessionID: file.SessionID,
+		Name:      file.Name,
+		Size:      file.Size,
+		ModTime:   file.ModTime.UTC(),
+	}
+}
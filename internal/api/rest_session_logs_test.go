@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gestalt/internal/terminal"
+)
+
+func TestSessionLogEndpoints(t *testing.T) {
+	logDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(logDir, "s1-20250102-030405.txt"), []byte("alpha\nbeta\ngamma\n"), 0o644); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:         "/bin/sh",
+		PtyFactory:    &fakeFactory{},
+		SessionLogDir: logDir,
+	})
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, manager, "", StatusConfig{}, "", nil, nil, nil, nil)
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/api/sessions/logs")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var list sessionLogFilesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode list: %v", err)
+	}
+	if !list.Enabled || len(list.Files) != 1 || list.Files[0].SessionID != "s1" || list.Files[0].Size != 17 {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	rec = get("/api/sessions/logs/s1?tail=2")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var tail sessionLogTailResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &tail); err != nil {
+		t.Fatalf("decode tail: %v", err)
+	}
+	if tail.Name != "s1-20250102-030405.txt" || len(tail.Lines) != 2 || tail.Lines[0] != "beta" || tail.Lines[1] != "gamma" {
+		t.Fatalf("unexpected tail: %+v", tail)
+	}
+
+	rec = get("/api/sessions/logs/missing")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	var payload errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if payload.Code != errorCodeSessionLogNotFound {
+		t.Fatalf("expected %s, got %q", errorCodeSessionLogNotFound, payload.Code)
+	}
+	if rec := get("/api/sessions/logs/s1?tail=0"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid tail, got %d", rec.Code)
+	}
+	if rec := get("/api/sessions/logs/..%2F..%2Fetc%2Fpasswd"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for path id, got %d", rec.Code)
+	}
+}
@@ -18,25 +18,34 @@ func (h *RestHandler) handleSkills(w http.ResponseWriter, r *http.Request) *apiE
 	}
 
 	agentID := strings.TrimSpace(r.URL.Query().Get("agent"))
-	metas := h.Manager.ListSkills()
 	if agentID != "" {
 		agentProfile, ok := h.Manager.GetAgent(agentID)
 		if !ok {
-			return &apiError{Status: http.StatusNotFound, Message: "agent not found"}
-		}
-		byName := make(map[string]terminal.SkillMetadata, len(metas))
-		for _, meta := range metas {
-			byName[meta.Name] = meta
+			return &apiError{Status: http.StatusNotFound, Message: "agent not found", Code: errorCodeAgentUnknown}
 		}
-		filtered := make([]terminal.SkillMetadata, 0, len(agentProfile.Skills))
-		for _, name := range agentProfile.Skills {
-			if meta, ok := byName[name]; ok {
-				filtered = append(filtered, meta)
-			}
+		return writeJSONFields(w, r, http.StatusOK, h.agentSkillSummaries(agentProfile.Skills))
+	}
+	return writeJSONFields(w, r, http.StatusOK, newSkillSummaries(h.Manager.ListSkills()))
+}
+
+// agentSkillSummaries summarizes the named skills in the agent's order,
+// skipping names that are not loaded.
+func (h *RestHandler) agentSkillSummaries(names []string) []skillSummary {
+	metas := h.Manager.ListSkills()
+	byName := make(map[string]terminal.SkillMetadata, len(metas))
+	for _, meta := range metas {
+		byName[meta.Name] = meta
+	}
+	filtered := make([]terminal.SkillMetadata, 0, len(names))
+	for _, name := range names {
+		if meta, ok := byName[name]; ok {
+			filtered = append(filtered, meta)
 		}
-		metas = filtered
 	}
+	return newSkillSummaries(filtered)
+}
 
+func newSkillSummaries(metas []terminal.SkillMetadata) []skillSummary {
 	response := make([]skillSummary, 0, len(metas))
 	for _, meta := range metas {
 		response = append(response, skillSummary{
@@ -49,9 +58,7 @@ func (h *RestHandler) handleSkills(w http.ResponseWriter, r *http.Request) *apiE
 			HasAssets:     hasSkillDir(meta.Path, "assets"),
 		})
 	}
-
-	writeJSON(w, http.StatusOK, response)
-	return nil
+	return response
 }
 
 func hasSkillDir(base, name string) bool {
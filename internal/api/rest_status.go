@@ -53,6 +53,13 @@ func (h *RestHandler) handleStatus(w http.ResponseWriter, r *http.Request) *apiE
 		Built:                  versionInfo.Built,
 		GitCommit:              versionInfo.GitCommit,
 	}
+	if clientID := r.Header.Get(focusClientHeader); clientID != "" {
+		if focus, ok := h.Manager.FocusedSession(clientID); ok {
+			response.FocusedSessionID = focus.SessionID
+		}
+	} else if focus, ok := h.Manager.LatestFocus(); ok {
+		response.FocusedSessionID = focus.SessionID
+	}
 	collectorStatus := otel.CollectorStatusSnapshot()
 	response.OTelCollectorRunning = collectorStatus.Running
 	response.OTelCollectorPID = collectorStatus.PID
@@ -6,16 +6,23 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"gestalt/internal/agent"
 	"gestalt/internal/event"
 	"gestalt/internal/flow"
 	"gestalt/internal/notify"
+	"gestalt/internal/runner/launchspec"
 	"gestalt/internal/terminal"
 )
 
+// maxOutputPollWait caps how long GET /api/sessions/:id/output?after= blocks.
+const maxOutputPollWait = 30 * time.Second
+
 func (h *RestHandler) handleTerminals(w http.ResponseWriter, r *http.Request) *apiError {
 	if err := h.requireManager(); err != nil {
 		return err
@@ -23,7 +30,7 @@ func (h *RestHandler) handleTerminals(w http.ResponseWriter, r *http.Request) *a
 
 	switch r.Method {
 	case http.MethodGet:
-		return h.listTerminals(w)
+		return h.listTerminals(w, r)
 	case http.MethodPost:
 		return h.createTerminal(w, r)
 	default:
@@ -31,6 +38,43 @@ func (h *RestHandler) handleTerminals(w http.ResponseWriter, r *http.Request) *a
 	}
 }
 
+// handleTerminalCapabilities reports the values the create form can offer, so
+// the UI does not have to hardcode them.
+func (h *RestHandler) handleTerminalCapabilities(w http.ResponseWriter, r *http.Request) *apiError {
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+
+	// Roles are free-form; report the ones sessions currently use.
+	seen := make(map[string]struct{})
+	roles := make([]string, 0)
+	for _, info := range h.Manager.List() {
+		role := strings.TrimSpace(info.Role)
+		if role == "" {
+			continue
+		}
+		if _, ok := seen[role]; ok {
+			continue
+		}
+		seen[role] = struct{}{}
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+
+	writeJSON(w, http.StatusOK, terminalCapabilitiesResponse{
+		Roles:      roles,
+		Runners:    []string{string(launchspec.RunnerKindExternal), string(launchspec.RunnerKindServer)},
+		Interfaces: []string{agent.AgentInterfaceCLI},
+		GUIModules: []string{},
+		// Workflow routes were removed; flows replace them.
+		WorkflowsEnabled: false,
+	})
+	return nil
+}
+
 func (h *RestHandler) handleTerminal(w http.ResponseWriter, r *http.Request) *apiError {
 	if err := h.requireManager(); err != nil {
 		return err
@@ -58,6 +102,32 @@ func (h *RestHandler) handleTerminal(w http.ResponseWriter, r *http.Request) *ap
 		return h.handleTerminalNotify(w, r, id)
 	case terminalPathProgress:
 		return h.handleTerminalProgress(w, r, id)
+	case terminalPathLabels:
+		return h.handleTerminalLabels(w, r, id)
+	case terminalPathClone:
+		return h.handleTerminalClone(w, r, id)
+	case terminalPathChildren:
+		return h.handleTerminalChildren(w, r, id)
+	case terminalPathTranscript:
+		return h.handleTerminalTranscript(w, r, id)
+	case terminalPathFocus:
+		return h.handleTerminalFocus(w, r, id)
+	case terminalPathReplayLast:
+		return h.handleTerminalReplayLast(w, r, id)
+	case terminalPathInputHistoryExport:
+		return h.handleTerminalInputHistoryExport(w, r, id)
+	case terminalPathCast:
+		return h.handleTerminalCast(w, r, id)
+	case terminalPathOutputText:
+		return h.handleTerminalOutputText(w, r, id)
+	case terminalPathPause:
+		return h.handleTerminalPause(w, r, id, true)
+	case terminalPathResume:
+		return h.handleTerminalPause(w, r, id, false)
+	case terminalPathWebhooks:
+		return h.handleTerminalWebhooks(w, r, id)
+	case terminalPathWebhook:
+		return h.handleTerminalWebhook(w, r, id, path.Base(strings.TrimSuffix(r.URL.Path, "/")))
 	default:
 		return h.handleTerminalDelete(w, r, id)
 	}
@@ -70,37 +140,88 @@ func (h *RestHandler) handleTerminalInput(w http.ResponseWriter, r *http.Request
 
 	session, ok := h.Manager.Get(id)
 	if !ok {
-		return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	}
 
-	payload, err := io.ReadAll(r.Body)
-	if err != nil {
-		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
-	}
-	if len(payload) == 0 {
-		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
-	}
-	if writeErr := session.Write(payload); writeErr != nil {
-		agentID := strings.TrimSpace(session.AgentID)
-		if agentID == "" {
-			agentID = "<agent-id>"
+	var payload []byte
+	if isFileInput(r) {
+		fromFile, apiErr := h.readInputFile(r)
+		if apiErr != nil {
+			return apiErr
 		}
-		if errors.Is(writeErr, terminal.ErrTmuxWindowNotFound) || errors.Is(writeErr, terminal.ErrTmuxSessionNotFound) {
-			return &apiError{Status: http.StatusConflict, Message: fmt.Sprintf("session window not found; run gestalt-agent %s", agentID)}
-		}
-		if errors.Is(writeErr, terminal.ErrTmuxUnavailable) {
-			return &apiError{Status: http.StatusServiceUnavailable, Message: fmt.Sprintf("tmux unavailable; run gestalt-agent %s", agentID)}
+		payload = fromFile
+	} else {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 		}
-		if errors.Is(writeErr, terminal.ErrRunnerUnavailable) && strings.EqualFold(strings.TrimSpace(session.Runner), "external") {
-			return &apiError{Status: http.StatusConflict, Message: fmt.Sprintf("session input bridge unavailable; run gestalt-agent %s", agentID)}
+		if len(body) == 0 {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 		}
-		return &apiError{Status: http.StatusInternalServerError, Message: "failed to write terminal input"}
+		payload = body
+	}
+	if writeErr := session.Write(payload); writeErr != nil {
+		return terminalWriteError(session, writeErr)
 	}
 
 	writeJSON(w, http.StatusOK, agentInputResponse{Bytes: len(payload)})
 	return nil
 }
 
+// terminalWriteError maps a failed session write to the API error that tells
+// the caller how to recover.
+func terminalWriteError(session *terminal.Session, writeErr error) *apiError {
+	agentID := strings.TrimSpace(session.AgentID)
+	if agentID == "" {
+		agentID = "<agent-id>"
+	}
+	if errors.Is(writeErr, terminal.ErrTmuxWindowNotFound) || errors.Is(writeErr, terminal.ErrTmuxSessionNotFound) {
+		return &apiError{Status: http.StatusConflict, Message: fmt.Sprintf("session window not found; run gestalt-agent %s", agentID), Code: errorCodeTmuxWindowNotFound}
+	}
+	if errors.Is(writeErr, terminal.ErrSessionReadOnly) {
+		return &apiError{Status: http.StatusConflict, Message: "session is read-only", Code: errorCodeSessionReadOnly}
+	}
+	if errors.Is(writeErr, terminal.ErrSessionPaused) {
+		return &apiError{Status: http.StatusConflict, Message: "session is paused", Code: errorCodeSessionPaused}
+	}
+	if errors.Is(writeErr, terminal.ErrTmuxUnavailable) {
+		return &apiError{Status: http.StatusServiceUnavailable, Message: fmt.Sprintf("tmux unavailable; run gestalt-agent %s", agentID), Code: errorCodeTmuxUnavailable}
+	}
+	if errors.Is(writeErr, terminal.ErrRunnerUnavailable) && strings.EqualFold(strings.TrimSpace(session.Runner), "external") {
+		return &apiError{Status: http.StatusConflict, Message: fmt.Sprintf("session input bridge unavailable; run gestalt-agent %s", agentID), Code: errorCodeInputBridgeUnavailable}
+	}
+	return &apiError{Status: http.StatusInternalServerError, Message: "failed to write terminal input"}
+}
+
+// handleTerminalReplayLast re-sends the most recent input history entry.
+func (h *RestHandler) handleTerminalReplayLast(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(w, "POST")
+	}
+
+	session, ok := h.Manager.Get(id)
+	if !ok {
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	}
+	entry, err := h.Manager.ReplayLastInput(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, terminal.ErrSessionNotFound):
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+		case errors.Is(err, terminal.ErrNoInputHistory):
+			return &apiError{Status: http.StatusConflict, Message: "no input history to replay", Code: errorCodeNoInputHistory}
+		default:
+			return terminalWriteError(session, err)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, inputHistoryEntry{
+		Command:   entry.Command,
+		Timestamp: entry.Timestamp,
+	})
+	return nil
+}
+
 func (h *RestHandler) handleTerminalActivate(w http.ResponseWriter, r *http.Request, id string) *apiError {
 	if r.Method != http.MethodPost {
 		return methodNotAllowed(w, "POST")
@@ -108,13 +229,13 @@ func (h *RestHandler) handleTerminalActivate(w http.ResponseWriter, r *http.Requ
 
 	if err := h.Manager.ActivateSessionWindow(id); err != nil {
 		if errors.Is(err, terminal.ErrSessionNotFound) {
-			return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 		}
 		if errors.Is(err, terminal.ErrSessionNotTmuxManaged) {
-			return &apiError{Status: http.StatusConflict, Message: "session is not tmux-managed"}
+			return &apiError{Status: http.StatusConflict, Message: "session is not tmux-managed", Code: errorCodeNotTmuxManaged}
 		}
 		if errors.Is(err, terminal.ErrTmuxSessionNotFound) {
-			return &apiError{Status: http.StatusServiceUnavailable, Message: "tmux session not found; start an agent to recreate it"}
+			return &apiError{Status: http.StatusServiceUnavailable, Message: "tmux session not found; start an agent to recreate it", Code: errorCodeTmuxSessionNotFound}
 		}
 		return &apiError{Status: http.StatusInternalServerError, Message: "failed to activate tmux window"}
 	}
@@ -123,31 +244,121 @@ func (h *RestHandler) handleTerminalActivate(w http.ResponseWriter, r *http.Requ
 	return nil
 }
 
-func (h *RestHandler) listTerminals(w http.ResponseWriter) *apiError {
+// focusClientHeader identifies the browser tab or tool setting session focus.
+const focusClientHeader = "X-Gestalt-Client"
+
+// handleTerminalFocus records (POST) or clears (DELETE) the session a client
+// is looking at. Clients identify themselves with the X-Gestalt-Client
+// header; without it they share the default focus slot.
+func (h *RestHandler) handleTerminalFocus(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method == http.MethodDelete {
+		if _, err := h.Manager.UnfocusSession(r.Header.Get(focusClientHeader), id); err != nil {
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(w, "POST, DELETE")
+	}
+
+	focus, err := h.Manager.FocusSession(r.Header.Get(focusClientHeader), id)
+	if err != nil {
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	}
+	writeJSON(w, http.StatusOK, terminalFocusResponse{
+		SessionID: focus.SessionID,
+		ClientID:  focus.ClientID,
+		FocusedAt: focus.FocusedAt,
+	})
+	return nil
+}
+
+// handleTerminalPause pauses or resumes a session's process and returns the
+// updated summary.
+func (h *RestHandler) handleTerminalPause(w http.ResponseWriter, r *http.Request, id string, pause bool) *apiError {
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(w, "POST")
+	}
+
+	var session *terminal.Session
+	var err error
+	if pause {
+		session, err = h.Manager.PauseSession(id)
+	} else {
+		session, err = h.Manager.ResumeSession(id)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, terminal.ErrSessionNotFound):
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+		case errors.Is(err, terminal.ErrPauseUnsupported):
+			return &apiError{Status: http.StatusNotImplemented, Message: err.Error(), Code: errorCodePauseUnsupported}
+		case errors.Is(err, terminal.ErrSessionNotPausable):
+			return &apiError{Status: http.StatusConflict, Message: err.Error(), Code: errorCodeNotPausable}
+		case errors.Is(err, terminal.ErrSessionNotRunning), errors.Is(err, terminal.ErrSessionNotPaused):
+			return &apiError{Status: http.StatusConflict, Message: err.Error(), Code: errorCodeConflict}
+		default:
+			return &apiError{Status: http.StatusInternalServerError, Message: "failed to signal session process"}
+		}
+	}
+	writeJSON(w, http.StatusOK, newTerminalSummary(session.Info()))
+	return nil
+}
+
+// stopAllGrace is how long stop-all waits after SIGTERM before SIGKILL.
+const stopAllGrace = 2 * time.Second
+
+// handleStopAllTerminals is the emergency stop: it kills every pty-backed
+// session's processes but, unlike delete, keeps the sessions listed.
+func (h *RestHandler) handleStopAllTerminals(w http.ResponseWriter, r *http.Request) *apiError {
+	if err := h.requireManager(); err != nil {
+		return err
+	}
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(w, "POST")
+	}
+	if r.Body == nil {
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
+	}
+
+	var request stopAllRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil && err != io.EOF {
+		return invalidBodyError(err)
+	}
+	if !request.Confirm {
+		return fieldValidationError(http.StatusBadRequest, "confirm", `set "confirm": true to stop all sessions`)
+	}
+
+	stopped := h.Manager.StopAll(stopAllGrace)
+	if stopped == nil {
+		stopped = []string{}
+	}
+	writeJSON(w, http.StatusOK, stopAllResponse{Stopped: len(stopped), SessionIDs: stopped})
+	return nil
+}
+
+func (h *RestHandler) listTerminals(w http.ResponseWriter, r *http.Request) *apiError {
+	selector := parseLabelSelector(r)
 	h.Manager.PruneMissingExternalTmuxSessions()
 	infos := h.Manager.List()
 	response := make([]terminalSummary, 0, len(infos))
 	for _, info := range infos {
-		response = append(response, terminalSummary{
-			ID:          info.ID,
-			Title:       info.Title,
-			Role:        info.Role,
-			CreatedAt:   info.CreatedAt,
-			Status:      info.Status,
-			LLMType:     info.LLMType,
-			Model:       info.Model,
-			Interface:   info.Interface,
-			Runner:      info.Runner,
-			Command:     info.Command,
-			Skills:      info.Skills,
-			PromptFiles: info.PromptFiles,
-		})
+		if len(selector) > 0 && !info.MatchesLabels(selector) {
+			continue
+		}
+		response = append(response, newTerminalSummary(info))
 	}
-	writeJSON(w, http.StatusOK, response)
-	return nil
+	return writeJSONFields(w, r, http.StatusOK, response)
 }
 
 func (h *RestHandler) createTerminal(w http.ResponseWriter, r *http.Request) *apiError {
+	include, err := parseCreateInclude(r)
+	if err != nil {
+		return err
+	}
 	request, err := decodeCreateTerminalRequest(r)
 	if err != nil {
 		return err
@@ -157,7 +368,7 @@ func (h *RestHandler) createTerminal(w http.ResponseWriter, r *http.Request) *ap
 		agentProfile, reloaded, loadErr := h.Manager.LoadAgentForSession(request.Agent)
 		if loadErr != nil {
 			if errors.Is(loadErr, terminal.ErrAgentNotFound) {
-				return &apiError{Status: http.StatusBadRequest, Message: "unknown agent"}
+				return withField(&apiError{Status: http.StatusBadRequest, Message: "unknown agent", Code: errorCodeAgentUnknown}, "agent")
 			}
 			return &apiError{Status: http.StatusInternalServerError, Message: fmt.Sprintf("failed to refresh agent config: %s", loadErr.Error())}
 		}
@@ -175,49 +386,118 @@ func (h *RestHandler) createTerminal(w http.ResponseWriter, r *http.Request) *ap
 	}
 
 	session, createErr := h.Manager.CreateWithOptions(terminal.CreateOptions{
-		AgentID: request.Agent,
-		Role:    request.Role,
-		Title:   request.Title,
-		Runner:  request.Runner,
+		AgentID:       request.Agent,
+		Role:          request.Role,
+		Title:         request.Title,
+		Runner:        request.Runner,
+		Labels:        request.Labels,
+		ParentID:      request.ParentID,
+		InitialInput:  request.InitialInput,
+		Banner:        request.Banner,
+		NoPersist:     request.NoPersist,
+		ReadyPattern:  request.ReadyPattern,
+		ReadyDelay:    time.Duration(request.ReadyDelayMS) * time.Millisecond,
+		WaitReady:     request.WaitReady,
+		AttachPID:     request.AttachPID,
+		Encoding:      request.Encoding,
+		PromptPattern: request.PromptPattern,
 	})
 	if createErr != nil {
-		if errors.Is(createErr, terminal.ErrAgentRequired) {
-			return &apiError{Status: http.StatusBadRequest, Message: "agent is required"}
-		}
-		if errors.Is(createErr, terminal.ErrAgentNotFound) {
-			return &apiError{Status: http.StatusBadRequest, Message: "unknown agent"}
-		}
-		var tmuxErr *terminal.ExternalTmuxError
-		if errors.As(createErr, &tmuxErr) {
-			return &apiError{Status: http.StatusInternalServerError, Message: tmuxErr.Message}
-		}
-		var dupErr *terminal.AgentAlreadyRunningError
-		if errors.As(createErr, &dupErr) {
-			return &apiError{
-				Status:    http.StatusConflict,
-				Message:   fmt.Sprintf("agent %q is already running", dupErr.AgentName),
-				SessionID: dupErr.TerminalID,
+		return createTerminalError(createErr)
+	}
+
+	response := terminalCreateResponse{
+		terminalSummary: newTerminalSummary(session.Info()),
+	}
+	if session.LaunchSpec != nil {
+		response.Launch = session.LaunchSpec
+	}
+	h.includeAgentContext(&response, request.Agent, include)
+	writeJSON(w, http.StatusCreated, response)
+	return nil
+}
+
+const (
+	createIncludeSkills = "skills"
+	createIncludePrompt = "prompt"
+)
+
+// parseCreateInclude reads ?include=skills,prompt from a create request.
+func parseCreateInclude(r *http.Request) (map[string]bool, *apiError) {
+	include := map[string]bool{}
+	for _, value := range r.URL.Query()["include"] {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.ToLower(strings.TrimSpace(part))
+			switch part {
+			case "":
+			case createIncludeSkills, createIncludePrompt:
+				include[part] = true
+			default:
+				return nil, &apiError{Status: http.StatusBadRequest, Message: fmt.Sprintf("invalid include %q", part), Code: errorCodeInvalidParameter}
 			}
 		}
-		return &apiError{Status: http.StatusInternalServerError, Message: "failed to create terminal"}
+	}
+	return include, nil
+}
+
+// includeAgentContext inlines the new session's agent skills and rendered
+// prompt when the request asked for them. The session already exists by
+// now, so a prompt that fails to render is logged and left out rather than
+// failing the request.
+func (h *RestHandler) includeAgentContext(response *terminalCreateResponse, agentID string, include map[string]bool) {
+	agentID = strings.TrimSpace(agentID)
+	if agentID == "" || len(include) == 0 {
+		return
+	}
+	if include[createIncludeSkills] {
+		if agentProfile, ok := h.Manager.GetAgent(agentID); ok {
+			response.Skills = h.agentSkillSummaries(agentProfile.Skills)
+		}
+	}
+	if include[createIncludePrompt] {
+		preview, err := h.Manager.PreviewAgentPrompt(agentID)
+		if err != nil {
+			if h.Logger != nil {
+				h.Logger.Warn("agent prompt include failed", map[string]string{
+					"gestalt.category": "agent",
+					"gestalt.source":   "backend",
+					"agent.id":         agentID,
+					"agent_id":         agentID,
+					"error":            err.Error(),
+				})
+			}
+			return
+		}
+		files := preview.Files
+		if files == nil {
+			files = []string{}
+		}
+		response.Prompt = &agentPromptResponse{
+			AgentID:        preview.AgentID,
+			SessionID:      preview.SessionID,
+			Mode:           preview.Mode,
+			Prompt:         preview.Prompt,
+			PromptFiles:    files,
+			SkippedPrompts: skippedPromptEntries(preview.Skipped),
+		}
+	}
+}
+
+func (h *RestHandler) handleTerminalClone(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodPost {
+		return methodNotAllowed(w, "POST")
+	}
+
+	session, err := h.Manager.Clone(id)
+	if err != nil {
+		if errors.Is(err, terminal.ErrSessionNotFound) {
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+		}
+		return createTerminalError(err)
 	}
 
-	info := session.Info()
 	response := terminalCreateResponse{
-		terminalSummary: terminalSummary{
-			ID:          info.ID,
-			Title:       info.Title,
-			Role:        info.Role,
-			CreatedAt:   info.CreatedAt,
-			Status:      info.Status,
-			LLMType:     info.LLMType,
-			Model:       info.Model,
-			Interface:   info.Interface,
-			Runner:      info.Runner,
-			Command:     info.Command,
-			Skills:      info.Skills,
-			PromptFiles: info.PromptFiles,
-		},
+		terminalSummary: newTerminalSummary(session.Info()),
 	}
 	if session.LaunchSpec != nil {
 		response.Launch = session.LaunchSpec
@@ -226,21 +506,112 @@ func (h *RestHandler) createTerminal(w http.ResponseWriter, r *http.Request) *ap
 	return nil
 }
 
+func (h *RestHandler) handleTerminalChildren(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+
+	children, err := h.Manager.Children(id)
+	if err != nil {
+		if errors.Is(err, terminal.ErrSessionNotFound) {
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+		}
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to list child sessions"}
+	}
+	response := make([]terminalSummary, 0, len(children))
+	for _, info := range children {
+		response = append(response, newTerminalSummary(info))
+	}
+	writeJSON(w, http.StatusOK, response)
+	return nil
+}
+
+// createTerminalError maps a session creation failure to an API error.
+func createTerminalError(err error) *apiError {
+	if errors.Is(err, terminal.ErrAgentRequired) {
+		return withField(&apiError{Status: http.StatusBadRequest, Message: "agent is required", Code: errorCodeAgentRequired}, "agent")
+	}
+	if errors.Is(err, terminal.ErrInvalidLabels) {
+		return withField(&apiError{Status: http.StatusBadRequest, Message: err.Error(), Code: errorCodeInvalidLabels}, "labels")
+	}
+	if errors.Is(err, terminal.ErrAgentNotFound) {
+		return withField(&apiError{Status: http.StatusBadRequest, Message: "unknown agent", Code: errorCodeAgentUnknown}, "agent")
+	}
+	if errors.Is(err, terminal.ErrInvalidReadiness) {
+		return &apiError{Status: http.StatusBadRequest, Message: err.Error(), Code: errorCodeInvalidParameter}
+	}
+	if errors.Is(err, terminal.ErrInvalidPromptPattern) {
+		return withField(&apiError{Status: http.StatusBadRequest, Message: err.Error(), Code: errorCodeInvalidParameter}, "prompt_pattern")
+	}
+	if errors.Is(err, terminal.ErrUnknownEncoding) {
+		return withField(&apiError{Status: http.StatusBadRequest, Message: err.Error(), Code: errorCodeInvalidParameter}, "encoding")
+	}
+	if errors.Is(err, terminal.ErrParentNotFound) {
+		return withField(&apiError{Status: http.StatusBadRequest, Message: "parent session not found", Code: errorCodeInvalidParameter}, "parent_id")
+	}
+	if errors.Is(err, terminal.ErrAttachUnsupported) {
+		return withField(&apiError{Status: http.StatusNotImplemented, Message: err.Error(), Code: errorCodeAttachUnsupported}, "attach_pid")
+	}
+	if errors.Is(err, terminal.ErrAttachNotAttachable) {
+		return withField(&apiError{Status: http.StatusBadRequest, Message: err.Error(), Code: errorCodeNotAttachable}, "attach_pid")
+	}
+	var tmuxErr *terminal.ExternalTmuxError
+	if errors.As(err, &tmuxErr) {
+		return &apiError{Status: http.StatusInternalServerError, Message: tmuxErr.Message, Code: errorCodeTmuxUnavailable}
+	}
+	var dupErr *terminal.AgentAlreadyRunningError
+	if errors.As(err, &dupErr) {
+		return &apiError{
+			Status:    http.StatusConflict,
+			Message:   fmt.Sprintf("agent %q is already running", dupErr.AgentName),
+			Code:      errorCodeAgentAlreadyRunning,
+			SessionID: dupErr.TerminalID,
+		}
+	}
+	return &apiError{Status: http.StatusInternalServerError, Message: "failed to create terminal"}
+}
+
 func (h *RestHandler) handleTerminalOutput(w http.ResponseWriter, r *http.Request, id string) *apiError {
 	if r.Method != http.MethodGet {
 		return methodNotAllowed(w, "GET")
 	}
 
+	after, wait, apiErr := parseOutputPollQuery(r)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	raw := false
+	if value := strings.TrimSpace(r.URL.Query().Get("raw")); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid raw", Code: errorCodeInvalidParameter}
+		}
+		raw = parsed
+	}
+
 	session, ok := h.Manager.Get(id)
 	if !ok {
-		return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	}
+
+	if after == nil {
+		if raw {
+			cursor := session.RawOutputCursor()
+			writeJSON(w, http.StatusOK, newTerminalOutputResponse(id, session.RawOutputLines(), &cursor, ""))
+			return nil
+		}
+		cursor := session.OutputCursor()
+		writeJSON(w, http.StatusOK, newTerminalOutputResponse(id, session.OutputLines(), &cursor, ""))
+		return nil
 	}
 
-	response := terminalOutputResponse{
-		ID:    id,
-		Lines: session.OutputLines(),
+	outputSince := session.OutputSince
+	if raw {
+		outputSince = session.RawOutputSince
 	}
-	writeJSON(w, http.StatusOK, response)
+	lines, cursor, partial := outputSince(r.Context(), *after, wait)
+	writeJSON(w, http.StatusOK, newTerminalOutputResponse(id, lines, &cursor, partial))
 	return nil
 }
 
@@ -261,17 +632,12 @@ func (h *RestHandler) handleTerminalHistory(w http.ResponseWriter, r *http.Reque
 	history, cursor, historyErr := h.Manager.HistoryPage(id, lines, beforeCursor)
 	if historyErr != nil {
 		if errors.Is(historyErr, terminal.ErrSessionNotFound) {
-			return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 		}
 		return &apiError{Status: http.StatusInternalServerError, Message: "failed to read terminal history"}
 	}
 
-	response := terminalOutputResponse{
-		ID:     id,
-		Lines:  history,
-		Cursor: cursor,
-	}
-	writeJSON(w, http.StatusOK, response)
+	writeJSON(w, http.StatusOK, newTerminalOutputResponse(id, history, cursor, ""))
 	return nil
 }
 
@@ -294,23 +660,10 @@ func (h *RestHandler) handleTerminalInputHistoryGet(w http.ResponseWriter, r *ht
 
 	session, ok := h.Manager.Get(id)
 	if !ok {
-		return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
-	}
-
-	entries := session.GetInputHistory()
-	if since != nil {
-		filtered := make([]terminal.InputEntry, 0, len(entries))
-		for _, entry := range entries {
-			if entry.Timestamp.After(*since) || entry.Timestamp.Equal(*since) {
-				filtered = append(filtered, entry)
-			}
-		}
-		entries = filtered
-	}
-	if limit > 0 && len(entries) > limit {
-		entries = entries[len(entries)-limit:]
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	}
 
+	entries := filterInputHistory(session.GetInputHistory(), limit, since)
 	response := make([]inputHistoryEntry, 0, len(entries))
 	for _, entry := range entries {
 		response = append(response, inputHistoryEntry{
@@ -324,24 +677,24 @@ func (h *RestHandler) handleTerminalInputHistoryGet(w http.ResponseWriter, r *ht
 
 func (h *RestHandler) handleTerminalInputHistoryPost(w http.ResponseWriter, r *http.Request, id string) *apiError {
 	if r.Body == nil {
-		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 
 	var request inputHistoryRequest
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&request); err != nil && err != io.EOF {
-		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
 	}
 
 	command := strings.TrimSpace(request.Command)
 	if command == "" {
-		return &apiError{Status: http.StatusBadRequest, Message: "missing command"}
+		return &apiError{Status: http.StatusBadRequest, Message: "missing command", Code: errorCodeValidationFailed}
 	}
 
 	session, ok := h.Manager.Get(id)
 	if !ok {
-		return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	}
 
 	session.RecordInput(command)
@@ -356,28 +709,31 @@ func (h *RestHandler) handleTerminalBell(w http.ResponseWriter, r *http.Request,
 
 	session, ok := h.Manager.Get(id)
 	if !ok {
-		return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	}
 
-	const bellContextLines = 50
-	contextLines, historyError := session.HistoryLines(bellContextLines)
+	contextLines, historyError := session.HistoryLines(terminal.BellContextLines)
 	if historyError != nil {
 		return &apiError{Status: http.StatusInternalServerError, Message: "failed to read terminal history"}
 	}
 	contextText := strings.Join(contextLines, "\n")
 
-	signalError := session.SendBellSignal(contextText)
+	signal, signalError := session.SendBellSignal(contextText)
 	if signalError != nil {
 		return &apiError{Status: http.StatusInternalServerError, Message: "failed to signal terminal bell"}
 	}
 
-	if h.Logger != nil {
+	// Bells inside the debounce window are only counted; the next signal
+	// that goes out, or the manager when the window closes, reports them.
+	if h.Logger != nil && !signal.Suppressed {
 		h.Logger.Warn("terminal bell detected", map[string]string{
 			"gestalt.category": "terminal",
 			"gestalt.source":   "backend",
 			"session.id":       id,
 			"session_id":       id,
 			"context_lines":    strconv.Itoa(len(contextLines)),
+			"bell_count":       strconv.Itoa(signal.Count),
+			"bells_coalesced":  strconv.Itoa(signal.Count - 1),
 		})
 	}
 
@@ -395,16 +751,16 @@ func (h *RestHandler) handleTerminalNotify(w http.ResponseWriter, r *http.Reques
 		return err
 	}
 	if strings.TrimSpace(request.SessionID) != id {
-		return &apiError{Status: http.StatusBadRequest, Message: "session id mismatch"}
+		return &apiError{Status: http.StatusBadRequest, Message: "session id mismatch", Code: errorCodeTerminalIDMismatch}
 	}
 
 	session, ok := h.Manager.Get(id)
 	if !ok {
-		return &apiError{Status: http.StatusNotFound, Message: "session not found"}
+		return &apiError{Status: http.StatusNotFound, Message: "session not found", Code: errorCodeTerminalNotFound}
 	}
 	agentID := strings.TrimSpace(session.AgentID)
 	if agentID == "" {
-		return &apiError{Status: http.StatusBadRequest, Message: "terminal is not an agent session"}
+		return &apiError{Status: http.StatusBadRequest, Message: "terminal is not an agent session", Code: errorCodeNotAgentSession}
 	}
 
 	isProgress := request.EventType == "progress" || request.EventType == "plan-update"
@@ -444,7 +800,7 @@ func (h *RestHandler) handleTerminalNotify(w http.ResponseWriter, r *http.Reques
 	if request.EventType == "prompt-text" || request.EventType == "prompt-voice" {
 		var payload map[string]any
 		if err := json.Unmarshal(request.Payload, &payload); err != nil || payload == nil {
-			return &apiError{Status: http.StatusUnprocessableEntity, Message: "payload must be a JSON object"}
+			return &apiError{Status: http.StatusUnprocessableEntity, Message: "payload must be a JSON object", Code: errorCodeValidationFailed}
 		}
 		updated := false
 		if _, ok := payload["git_branch"]; !ok {
@@ -488,16 +844,22 @@ func (h *RestHandler) handleTerminalNotify(w http.ResponseWriter, r *http.Reques
 	}()
 
 	if h.NotificationSink == nil {
-		return &apiError{Status: http.StatusServiceUnavailable, Message: "notification sink unavailable"}
-	}
-	sinkEvent := notify.Event{
-		Fields:     fields,
-		OccurredAt: notifyTime,
-		Level:      "info",
-		Message:    request.EventType,
-	}
-	if err := h.NotificationSink.Emit(r.Context(), sinkEvent); err != nil {
-		return &apiError{Status: http.StatusInternalServerError, Message: "failed to emit notification"}
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "notification sink unavailable", Code: errorCodeNotificationsUnavailable}
+	}
+	// The user is already looking at a focused session, so skip the
+	// notification but still dispatch the event to flows.
+	if h.Manager.IsFocused(id) {
+		logFields["notify.suppressed"] = "focused"
+	} else {
+		sinkEvent := notify.Event{
+			Fields:     fields,
+			OccurredAt: notifyTime,
+			Level:      "info",
+			Message:    request.EventType,
+		}
+		if err := h.NotificationSink.Emit(r.Context(), sinkEvent); err != nil {
+			return &apiError{Status: http.StatusInternalServerError, Message: "failed to emit notification"}
+		}
 	}
 
 	if err := h.requireFlowService(); err != nil {
@@ -516,7 +878,7 @@ func (h *RestHandler) handleTerminalNotify(w http.ResponseWriter, r *http.Reques
 				w.WriteHeader(http.StatusNoContent)
 				return nil
 			}
-			return &apiError{Status: http.StatusServiceUnavailable, Message: "flow dispatcher unavailable"}
+			return &apiError{Status: http.StatusServiceUnavailable, Message: "flow dispatcher unavailable", Code: errorCodeFlowUnavailable}
 		}
 		return &apiError{Status: http.StatusInternalServerError, Message: "failed to dispatch flow activity"}
 	}
@@ -533,7 +895,7 @@ func (h *RestHandler) handleTerminalProgress(w http.ResponseWriter, r *http.Requ
 
 	session, ok := h.Manager.Get(id)
 	if !ok {
-		return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	}
 
 	progress, ok := session.PlanProgress()
@@ -541,9 +903,13 @@ func (h *RestHandler) handleTerminalProgress(w http.ResponseWriter, r *http.Requ
 		writeJSON(w, http.StatusOK, terminalProgressResponse{HasProgress: false})
 		return nil
 	}
+	writeJSON(w, http.StatusOK, newTerminalProgressResponse(progress))
+	return nil
+}
 
+func newTerminalProgressResponse(progress terminal.PlanProgress) terminalProgressResponse {
 	updatedAt := progress.UpdatedAt
-	response := terminalProgressResponse{
+	return terminalProgressResponse{
 		HasProgress: true,
 		PlanFile:    progress.PlanFile,
 		L1:          progress.L1,
@@ -552,7 +918,38 @@ func (h *RestHandler) handleTerminalProgress(w http.ResponseWriter, r *http.Requ
 		TaskState:   progress.TaskState,
 		UpdatedAt:   &updatedAt,
 	}
-	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *RestHandler) handleTerminalLabels(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodPatch {
+		return methodNotAllowed(w, "PATCH")
+	}
+	if r.Body == nil {
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
+	}
+
+	var request terminalLabelsRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
+	}
+
+	if _, err := h.Manager.UpdateSessionLabels(id, request.Labels); err != nil {
+		if errors.Is(err, terminal.ErrSessionNotFound) {
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+		}
+		if errors.Is(err, terminal.ErrInvalidLabels) {
+			return &apiError{Status: http.StatusBadRequest, Message: err.Error(), Code: errorCodeInvalidLabels}
+		}
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to update labels"}
+	}
+
+	session, ok := h.Manager.Get(id)
+	if !ok {
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	}
+	writeJSON(w, http.StatusOK, newTerminalSummary(session.Info()))
 	return nil
 }
 
@@ -561,9 +958,22 @@ func (h *RestHandler) handleTerminalDelete(w http.ResponseWriter, r *http.Reques
 		return methodNotAllowed(w, "DELETE")
 	}
 
-	if err := h.Manager.Delete(id); err != nil {
+	cascade := false
+	if raw := strings.TrimSpace(r.URL.Query().Get("cascade")); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid cascade", Code: errorCodeInvalidParameter}
+		}
+		cascade = parsed
+	}
+
+	deleteSession := h.Manager.Delete
+	if cascade {
+		deleteSession = h.Manager.DeleteTree
+	}
+	if err := deleteSession(id); err != nil {
 		if err == terminal.ErrSessionNotFound {
-			return &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 		}
 		return &apiError{Status: http.StatusInternalServerError, Message: "failed to delete terminal"}
 	}
@@ -575,12 +985,12 @@ func (h *RestHandler) handleTerminalDelete(w http.ResponseWriter, r *http.Reques
 func parseTerminalPath(path string) (string, terminalPathAction, *apiError) {
 	trimmed := strings.TrimPrefix(path, "/api/sessions/")
 	if trimmed == path {
-		return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	}
 
 	trimmed = strings.TrimSuffix(trimmed, "/")
 	if trimmed == "" {
-		return "", terminalPathTerminal, &apiError{Status: http.StatusBadRequest, Message: "missing terminal id"}
+		return "", terminalPathTerminal, &apiError{Status: http.StatusBadRequest, Message: "missing terminal id", Code: errorCodeTerminalIDRequired}
 	}
 
 	parts := strings.Split(trimmed, "/")
@@ -610,28 +1020,143 @@ func parseTerminalPath(path string) (string, terminalPathAction, *apiError) {
 			return id, terminalPathNotify, nil
 		case "progress":
 			return id, terminalPathProgress, nil
+		case "labels":
+			return id, terminalPathLabels, nil
+		case "clone":
+			return id, terminalPathClone, nil
+		case "children":
+			return id, terminalPathChildren, nil
+		case "transcript":
+			return id, terminalPathTranscript, nil
+		case "focus":
+			return id, terminalPathFocus, nil
+		case "replay-last":
+			return id, terminalPathReplayLast, nil
+		case "cast":
+			return id, terminalPathCast, nil
+		case "output.txt":
+			return id, terminalPathOutputText, nil
+		case "pause":
+			return id, terminalPathPause, nil
+		case "resume":
+			return id, terminalPathResume, nil
+		case "webhooks":
+			return id, terminalPathWebhooks, nil
 		default:
-			return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+			return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 		}
 	case 3:
-		return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		if parts[1] == "input-history" && parts[2] == "export" {
+			return id, terminalPathInputHistoryExport, nil
+		}
+		if parts[1] == "webhooks" && parts[2] != "" {
+			return id, terminalPathWebhook, nil
+		}
+		return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	default:
-		return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found"}
+		return "", terminalPathTerminal, &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
 	}
 }
 
+func newTerminalSummary(info terminal.SessionInfo) terminalSummary {
+	summary := terminalSummary{
+		ID:           info.ID,
+		Title:        info.Title,
+		Role:         info.Role,
+		CreatedAt:    info.CreatedAt,
+		Status:       info.Status,
+		LLMType:      info.LLMType,
+		Model:        info.Model,
+		Interface:    info.Interface,
+		MCP:          info.MCP,
+		Runner:       info.Runner,
+		Command:      info.Command,
+		Skills:       info.Skills,
+		PromptFiles:  info.PromptFiles,
+		Labels:       info.Labels,
+		StatusReason: info.StatusReason,
+		ParentID:     info.ParentID,
+		NoPersist:    info.NoPersist,
+		Ready:        info.Ready,
+		AttachPID:    info.AttachPID,
+		Encoding:     info.Encoding,
+	}
+	if info.WaitingForInput {
+		since := info.WaitingSince
+		summary.WaitingForInput = true
+		summary.WaitingSince = &since
+	}
+	if info.TmuxSessionName != "" {
+		summary.TmuxSessionName = info.TmuxSessionName
+		summary.TmuxHealthy = info.TmuxHealthy
+	}
+	return summary
+}
+
+// parseLabelSelector collects label.<key>=<value> query params into a selector.
+func parseLabelSelector(r *http.Request) map[string]string {
+	const labelPrefix = "label."
+	var selector map[string]string
+	for key, values := range r.URL.Query() {
+		if !strings.HasPrefix(key, labelPrefix) || len(values) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(key, labelPrefix)
+		if name == "" {
+			continue
+		}
+		if selector == nil {
+			selector = make(map[string]string)
+		}
+		selector[name] = values[0]
+	}
+	return selector
+}
+
 func parseHistoryLines(r *http.Request) (int, *apiError) {
 	lines := terminal.DefaultHistoryLines
 	if rawLines := strings.TrimSpace(r.URL.Query().Get("lines")); rawLines != "" {
 		parsed, err := strconv.Atoi(rawLines)
 		if err != nil || parsed <= 0 {
-			return lines, &apiError{Status: http.StatusBadRequest, Message: "invalid lines"}
+			return lines, &apiError{Status: http.StatusBadRequest, Message: "invalid lines", Code: errorCodeInvalidParameter}
 		}
 		lines = parsed
 	}
 	return lines, nil
 }
 
+// parseOutputPollQuery reads ?after=<cursor>&wait=<duration>. A bare number
+// for wait is taken as seconds; waits are capped at maxOutputPollWait.
+func parseOutputPollQuery(r *http.Request) (*int64, time.Duration, *apiError) {
+	values := r.URL.Query()
+	var after *int64
+	if raw := strings.TrimSpace(values.Get("after")); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			return nil, 0, &apiError{Status: http.StatusBadRequest, Message: "invalid after cursor", Code: errorCodeInvalidParameter}
+		}
+		after = &parsed
+	}
+
+	var wait time.Duration
+	if raw := strings.TrimSpace(values.Get("wait")); raw != "" {
+		if seconds, err := strconv.Atoi(raw); err == nil {
+			wait = time.Duration(seconds) * time.Second
+		} else if parsed, err := time.ParseDuration(raw); err == nil {
+			wait = parsed
+		} else {
+			return nil, 0, &apiError{Status: http.StatusBadRequest, Message: "invalid wait", Code: errorCodeInvalidParameter}
+		}
+		if wait < 0 {
+			return nil, 0, &apiError{Status: http.StatusBadRequest, Message: "invalid wait", Code: errorCodeInvalidParameter}
+		}
+		if after == nil {
+			return nil, 0, &apiError{Status: http.StatusBadRequest, Message: "wait requires after", Code: errorCodeInvalidParameter}
+		}
+	}
+	return after, min(wait, maxOutputPollWait), nil
+}
+
 func parseHistoryBeforeCursor(r *http.Request) (*int64, *apiError) {
 	rawCursor := strings.TrimSpace(r.URL.Query().Get("before_cursor"))
 	if rawCursor == "" {
@@ -639,7 +1164,7 @@ func parseHistoryBeforeCursor(r *http.Request) (*int64, *apiError) {
 	}
 	parsed, err := strconv.ParseInt(rawCursor, 10, 64)
 	if err != nil || parsed < 0 {
-		return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid before_cursor"}
+		return nil, &apiError{Status: http.StatusBadRequest, Message: "invalid before_cursor", Code: errorCodeInvalidParameter}
 	}
 	return &parsed, nil
 }
@@ -649,7 +1174,7 @@ func parseInputHistoryQuery(r *http.Request) (int, *time.Time, *apiError) {
 	if rawLimit := strings.TrimSpace(r.URL.Query().Get("limit")); rawLimit != "" {
 		parsed, err := strconv.Atoi(rawLimit)
 		if err != nil || parsed <= 0 {
-			return limit, nil, &apiError{Status: http.StatusBadRequest, Message: "invalid limit"}
+			return limit, nil, &apiError{Status: http.StatusBadRequest, Message: "invalid limit", Code: errorCodeInvalidParameter}
 		}
 		limit = parsed
 	}
@@ -657,7 +1182,7 @@ func parseInputHistoryQuery(r *http.Request) (int, *time.Time, *apiError) {
 	if rawSince := strings.TrimSpace(r.URL.Query().Get("since")); rawSince != "" {
 		parsed, err := time.Parse(time.RFC3339, rawSince)
 		if err != nil {
-			return limit, nil, &apiError{Status: http.StatusBadRequest, Message: "invalid since timestamp"}
+			return limit, nil, &apiError{Status: http.StatusBadRequest, Message: "invalid since timestamp", Code: errorCodeInvalidParameter}
 		}
 		return limit, &parsed, nil
 	}
@@ -665,9 +1190,27 @@ func parseInputHistoryQuery(r *http.Request) (int, *time.Time, *apiError) {
 	return limit, nil, nil
 }
 
+// filterInputHistory keeps entries at or after since, then the last limit of
+// those; a zero limit keeps them all.
+func filterInputHistory(entries []terminal.InputEntry, limit int, since *time.Time) []terminal.InputEntry {
+	if since != nil {
+		filtered := make([]terminal.InputEntry, 0, len(entries))
+		for _, entry := range entries {
+			if entry.Timestamp.After(*since) || entry.Timestamp.Equal(*since) {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries
+}
+
 func validateTerminalID(id string) *apiError {
 	if strings.TrimSpace(id) == "" {
-		return &apiError{Status: http.StatusBadRequest, Message: "missing terminal id"}
+		return &apiError{Status: http.StatusBadRequest, Message: "missing terminal id", Code: errorCodeTerminalIDRequired}
 	}
 	return nil
 }
@@ -681,7 +1224,7 @@ func decodeCreateTerminalRequest(r *http.Request) (createTerminalRequest, *apiEr
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&request); err != nil && err != io.EOF {
-		return request, &apiError{Status: http.StatusBadRequest, Message: "invalid request body"}
+		return request, invalidBodyError(err)
 	}
 
 	return request, nil
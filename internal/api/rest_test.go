@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,9 +13,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"gestalt/internal/agent"
@@ -467,6 +471,88 @@ func TestTerminalNotifyEndpoint(t *testing.T) {
 	}
 }
 
+func TestTerminalFocusSuppressesNotifications(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		Agents: map[string]agent.Agent{
+			"codex": {Name: "Codex", Shell: "/bin/bash", CLIType: "codex"},
+		},
+	})
+	created, err := manager.CreateWithOptions(terminal.CreateOptions{AgentID: "codex"})
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	tempDir := t.TempDir()
+	repo := flow.NewFileRepository(filepath.Join(tempDir, "automations.json"), nil)
+	writeFlowConfig(t, repo, flow.CanonicalNotifyEventType("plan-L1-wip"))
+	dispatcher := &fakeDispatcher{}
+	sink := notify.NewMemorySink()
+	handler := &RestHandler{Manager: manager, FlowService: flow.NewService(repo, dispatcher, nil), NotificationSink: sink}
+
+	req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/focus", nil)
+	req.Header.Set(focusClientHeader, "tab-1")
+	res := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var focus terminalFocusResponse
+	if err := json.NewDecoder(res.Body).Decode(&focus); err != nil {
+		t.Fatalf("decode focus: %v", err)
+	}
+	if focus.SessionID != created.ID || focus.ClientID != "tab-1" || focus.FocusedAt.IsZero() {
+		t.Fatalf("unexpected focus response: %+v", focus)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleStatus)(res, req)
+	var status statusResponse
+	if err := json.NewDecoder(res.Body).Decode(&status); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	if status.FocusedSessionID != created.ID {
+		t.Fatalf("expected focused_session_id %q, got %q", created.ID, status.FocusedSessionID)
+	}
+
+	body := `{"session_id":"` + created.ID + `","payload":{"type":"plan-L1-wip"},"event_id":"manual:focus"}`
+	req = httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/notify", strings.NewReader(body))
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", res.Code, res.Body.String())
+	}
+	if len(sink.Events()) != 0 {
+		t.Fatalf("expected notification for focused session to be suppressed, got %v", sink.Events())
+	}
+	if len(dispatcher.Requests()) != 1 {
+		t.Fatalf("expected flow dispatch to continue, got %d", len(dispatcher.Requests()))
+	}
+
+	req = httptest.NewRequest(http.MethodPost, terminalPath("missing")+"/focus", nil)
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing session, got %d", res.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, terminalPath(created.ID)+"/focus", nil)
+	req.Header.Set(focusClientHeader, "tab-1")
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", res.Code, res.Body.String())
+	}
+	if manager.IsFocused(created.ID) {
+		t.Fatalf("expected focus to be cleared")
+	}
+}
+
 func TestTerminalNotifyProgressMissingPlanFile(t *testing.T) {
 	factory := &fakeFactory{}
 	manager := newTestManager(terminal.ManagerOptions{
@@ -804,6 +890,71 @@ func TestTerminalProgressEndpointAfterNotify(t *testing.T) {
 	}
 }
 
+func TestProgressEndpointListsSessionsWithProgress(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+		Agents: map[string]agent.Agent{
+			"codex": {Name: "Codex", Shell: "/bin/bash", CLIType: "codex"},
+			"other": {Name: "Other", Shell: "/bin/bash", CLIType: "codex"},
+		},
+	})
+	withProgress, err := manager.CreateWithOptions(terminal.CreateOptions{AgentID: "codex"})
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(withProgress.ID)
+	}()
+	idle, err := manager.CreateWithOptions(terminal.CreateOptions{AgentID: "other"})
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(idle.ID)
+	}()
+
+	tempDir := t.TempDir()
+	repo := flow.NewFileRepository(filepath.Join(tempDir, "automations.json"), nil)
+	service := flow.NewService(repo, nil, nil)
+	handler := &RestHandler{Manager: manager, FlowService: service, NotificationSink: notify.NewMemorySink()}
+
+	body := `{"session_id":"` + withProgress.ID + `","payload":{"type":"progress","plan_file":"plans/plan.org","l1":"TODO First L1","l2":"WIP Second L2","task_level":2,"task_state":"WIP"}}`
+	notifyReq := httptest.NewRequest(http.MethodPost, terminalPath(withProgress.ID)+"/notify", strings.NewReader(body))
+	notifyRes := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(notifyRes, notifyReq)
+	if notifyRes.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", notifyRes.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/progress", nil)
+	res := httptest.NewRecorder()
+	restHandler("", nil, handler.handleProgress)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+
+	var payload progressResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload.Sessions) != 1 {
+		t.Fatalf("expected 1 session with progress, got %v", payload.Sessions)
+	}
+	progress, ok := payload.Sessions[withProgress.ID]
+	if !ok || !progress.HasProgress || progress.L2 != "Second L2" {
+		t.Fatalf("unexpected progress for %s: %#v", withProgress.ID, progress)
+	}
+
+	postReq := httptest.NewRequest(http.MethodPost, "/api/progress", nil)
+	postRes := httptest.NewRecorder()
+	restHandler("", nil, handler.handleProgress)(postRes, postReq)
+	if postRes.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", postRes.Code)
+	}
+}
+
 func TestTerminalNotifyProgressPublishesEvent(t *testing.T) {
 	factory := &fakeFactory{}
 	manager := newTestManager(terminal.ManagerOptions{
@@ -967,14 +1118,11 @@ func TestTerminalOutputEndpoint(t *testing.T) {
 	}
 }
 
-func TestTerminalHistoryEndpoint(t *testing.T) {
-	t.Skip("obsolete: expects PTY-backed agent output")
+func TestTerminalOutputLongPoll(t *testing.T) {
 	factory := &fakeFactory{}
-	logDir := t.TempDir()
 	manager := newTestManager(terminal.ManagerOptions{
-		Shell:         "/bin/sh",
-		PtyFactory:    factory,
-		SessionLogDir: logDir,
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
 	})
 	created, err := manager.Create(testAgentID, "", "")
 	if err != nil {
@@ -983,48 +1131,45 @@ func TestTerminalHistoryEndpoint(t *testing.T) {
 	defer func() {
 		_ = manager.Delete(created.ID)
 	}()
+	handler := &RestHandler{Manager: manager}
 
-	factory.mu.Lock()
-	pty := factory.ptys[0]
-	factory.mu.Unlock()
-	if _, err := pty.Write([]byte("hello\n")); err != nil {
-		t.Fatalf("write pty: %v", err)
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/output"+query, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
 	}
 
-	if !waitForOutputLines(created, 1, 500*time.Millisecond) {
-		t.Fatalf("expected output buffer to receive data")
+	for _, query := range []string{"?after=-1", "?after=abc", "?after=0&wait=soon", "?wait=1s"} {
+		if res := get(query); res.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %s, got %d", query, res.Code)
+		}
 	}
 
-	handler := &RestHandler{Manager: manager}
-	req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/history?lines=5", nil)
-	req.Header.Set("Authorization", "Bearer secret")
-	res := httptest.NewRecorder()
-
-	restHandler("secret", nil, handler.handleTerminal)(res, req)
+	start := time.Now()
+	res := get("?after=0&wait=50ms")
 	if res.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", res.Code)
 	}
-
+	if time.Since(start) < 50*time.Millisecond {
+		t.Fatalf("expected request to wait for new output")
+	}
 	var payload terminalOutputResponse
 	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
 		t.Fatalf("decode response: %v", err)
 	}
-	if !containsLine(payload.Lines, "hello") {
-		t.Fatalf("expected history lines to contain hello, got %v", payload.Lines)
-	}
-	if payload.Cursor != nil {
-		t.Fatalf("expected cursor to be nil when session logs are disabled for agents")
+	if payload.Cursor == nil || *payload.Cursor != 0 || len(payload.Lines) != 0 {
+		t.Fatalf("expected empty poll at cursor 0, got %+v", payload)
 	}
 }
 
-func TestTerminalHistoryPagination(t *testing.T) {
-	t.Skip("obsolete: expects PTY-backed agent output")
-	factory := &fakeFactory{}
-	logDir := t.TempDir()
+func TestTerminalOutputRaw(t *testing.T) {
 	manager := newTestManager(terminal.ManagerOptions{
-		Shell:         "/bin/sh",
-		PtyFactory:    factory,
-		SessionLogDir: logDir,
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		OutputTransform: func(data []byte) []byte {
+			return bytes.ReplaceAll(data, []byte("\x1b[31m"), nil)
+		},
 	})
 	created, err := manager.Create(testAgentID, "", "")
 	if err != nil {
@@ -1033,100 +1178,89 @@ func TestTerminalHistoryPagination(t *testing.T) {
 	defer func() {
 		_ = manager.Delete(created.ID)
 	}()
-
-	factory.mu.Lock()
-	pty := factory.ptys[0]
-	factory.mu.Unlock()
-	payloads := []string{"one", "two", "three", "four", "five"}
-	for _, entry := range payloads {
-		if _, err := pty.Write([]byte(entry + "\n")); err != nil {
-			t.Fatalf("write pty: %v", err)
-		}
-	}
-
-	if !waitForOutputLines(created, len(payloads), 500*time.Millisecond) {
-		t.Fatalf("expected output buffer to receive data")
+	created.PublishOutputChunk([]byte("\x1b[31mred\n"))
+	if !waitForOutputLines(created, 2, time.Second) {
+		t.Fatalf("expected output lines")
 	}
-
 	handler := &RestHandler{Manager: manager}
-	req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/history?lines=4", nil)
-	req.Header.Set("Authorization", "Bearer secret")
-	res := httptest.NewRecorder()
 
-	restHandler("secret", nil, handler.handleTerminal)(res, req)
-	if res.Code != http.StatusOK {
-		t.Fatalf("expected 200, got %d", res.Code)
+	get := func(query string) terminalOutputResponse {
+		req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/output"+query, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		if res.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %q, got %d", query, res.Code)
+		}
+		var payload terminalOutputResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return payload
 	}
 
-	var page terminalOutputResponse
-	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
-		t.Fatalf("decode response: %v", err)
-	}
-	nonEmpty := make([]string, 0, len(page.Lines))
-	for _, line := range page.Lines {
-		if line != "" {
-			nonEmpty = append(nonEmpty, line)
-		}
+	if payload := get(""); !containsLine(payload.Lines, "red") {
+		t.Fatalf("expected transformed line, got %q", payload.Lines)
 	}
-	if len(nonEmpty) < 2 || nonEmpty[len(nonEmpty)-2] != "four" || nonEmpty[len(nonEmpty)-1] != "five" {
-		t.Fatalf("unexpected history page: %v", page.Lines)
+	if payload := get("?raw=true"); !containsLine(payload.Lines, "\x1b[31mred") {
+		t.Fatalf("expected raw line, got %q", payload.Lines)
 	}
-	if page.Cursor != nil {
-		t.Fatalf("expected cursor to be nil when session logs are disabled for agents")
+	if payload := get("?raw=true&after=0"); !containsLine(payload.Lines, "\x1b[31mred") {
+		t.Fatalf("expected raw line after cursor, got %q", payload.Lines)
 	}
 }
 
-func TestTerminalInputHistoryEndpoint(t *testing.T) {
+func TestTerminalCapabilitiesEndpoint(t *testing.T) {
 	factory := &fakeFactory{}
 	manager := newTestManager(terminal.ManagerOptions{
 		Shell:      "/bin/sh",
 		PtyFactory: factory,
 	})
-	created, err := manager.Create(testAgentID, "", "")
+	created, err := manager.Create(testAgentID, "worker", "")
 	if err != nil {
 		t.Fatalf("create terminal: %v", err)
 	}
 	defer func() {
 		_ = manager.Delete(created.ID)
 	}()
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, manager, "", StatusConfig{}, "", nil, nil, nil, nil)
 
-	created.RecordInput("one")
-	created.RecordInput("two")
-
-	handler := &RestHandler{Manager: manager}
-	req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/input-history?limit=1", nil)
-	req.Header.Set("Authorization", "Bearer secret")
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/capabilities", nil)
 	res := httptest.NewRecorder()
-
-	restHandler("secret", nil, handler.handleTerminal)(res, req)
+	mux.ServeHTTP(res, req)
 	if res.Code != http.StatusOK {
-		t.Fatalf("expected 200, got %d", res.Code)
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
 	}
-
-	var payload []inputHistoryEntry
+	var payload terminalCapabilitiesResponse
 	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
 		t.Fatalf("decode response: %v", err)
 	}
-	if len(payload) != 1 || payload[0].Command != "two" {
-		t.Fatalf("expected last command, got %v", payload)
+	if !containsLine(payload.Roles, "worker") {
+		t.Fatalf("expected roles in use, got %v", payload.Roles)
 	}
-	if payload[0].Timestamp.IsZero() {
-		t.Fatalf("expected timestamp to be set")
+	if len(payload.Runners) != 2 || payload.Runners[0] != "external" || payload.Runners[1] != "server" {
+		t.Fatalf("unexpected runners: %v", payload.Runners)
+	}
+	if len(payload.Interfaces) != 1 || payload.Interfaces[0] != agent.AgentInterfaceCLI {
+		t.Fatalf("unexpected interfaces: %v", payload.Interfaces)
+	}
+	if payload.GUIModules == nil || payload.WorkflowsEnabled {
+		t.Fatalf("unexpected gui modules/workflows: %+v", payload)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/sessions/capabilities", nil)
+	res = httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+	if res.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", res.Code)
 	}
 }
 
-func TestTerminalInputEndpoint(t *testing.T) {
-	t.Skip("obsolete: expects PTY-backed input writes")
-	factory := &recordFactory{}
-	manager := terminal.NewManager(terminal.ManagerOptions{
+func TestSearchEndpoint(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
 		Shell:      "/bin/sh",
 		PtyFactory: factory,
-		Agents: map[string]agent.Agent{
-			testAgentID: {
-				Name:  "Codex",
-				Shell: "/bin/sh",
-			},
-		},
 	})
 	created, err := manager.Create(testAgentID, "", "")
 	if err != nil {
@@ -1135,62 +1269,877 @@ func TestTerminalInputEndpoint(t *testing.T) {
 	defer func() {
 		_ = manager.Delete(created.ID)
 	}()
+	created.PublishOutputChunk([]byte("compiling\npanic: nil map\n"))
+	if !waitForOutputLines(created, 2, time.Second) {
+		t.Fatalf("expected output buffer to receive data")
+	}
 
-	handler := &RestHandler{Manager: manager}
-	req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/input", strings.NewReader("hello"))
-	req.Header.Set("Authorization", "Bearer secret")
-	res := httptest.NewRecorder()
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, manager, "secret", StatusConfig{}, "", nil, nil, nil, nil)
+	get := func(query string, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/search"+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		return res
+	}
 
-	restHandler("secret", nil, handler.handleTerminal)(res, req)
+	if res := get("?q=panic", ""); res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", res.Code)
+	}
+	for _, query := range []string{"", "?q=", "?q=panic&limit=0", "?q=panic&logs=maybe"} {
+		if res := get(query, "secret"); res.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %q, got %d", query, res.Code)
+		}
+	}
+
+	res := get("?q=PANIC&limit=5", "secret")
 	if res.Code != http.StatusOK {
-		t.Fatalf("expected 200, got %d", res.Code)
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
 	}
-	var payload agentInputResponse
+	var payload searchResponse
 	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
 		t.Fatalf("decode response: %v", err)
 	}
-	if payload.Bytes != len("hello") {
-		t.Fatalf("expected %d bytes, got %d", len("hello"), payload.Bytes)
+	if payload.Truncated || len(payload.Matches) != 1 {
+		t.Fatalf("expected one match, got %+v", payload)
 	}
-	select {
-	case got := <-factory.pty.writes:
-		if string(got) != "hello" {
-			t.Fatalf("expected raw bytes %q, got %q", "hello", string(got))
-		}
-	case <-time.After(200 * time.Millisecond):
-		t.Fatal("timed out waiting for input write")
+	match := payload.Matches[0]
+	if match.SessionID != created.ID || match.Line != 2 || match.Snippet != "panic: nil map" {
+		t.Fatalf("unexpected match: %+v", match)
 	}
 }
 
-func TestTerminalInputEndpointTmuxManagedAgentSession(t *testing.T) {
-	tmuxClient := &fakeTmuxClient{hasSession: true}
+func TestTerminalTranscriptEndpoint(t *testing.T) {
 	manager := newTestManager(terminal.ManagerOptions{
 		Shell:      "/bin/sh",
 		PtyFactory: &fakeFactory{},
-		Agents: map[string]agent.Agent{
-			"codex": {
-				Name:      "Codex",
-				Shell:     "codex -c model=o3",
-				CLIType:   "codex",
-				Interface: agent.AgentInterfaceCLI,
-			},
-		},
-		StartExternalTmuxWindow: func(_ *launchspec.LaunchSpec) error { return nil },
-		TmuxClientFactory:       func() terminal.TmuxClient { return tmuxClient },
 	})
-	created, err := manager.CreateWithOptions(terminal.CreateOptions{AgentID: "codex"})
+	created, err := manager.Create(testAgentID, "", "")
 	if err != nil {
-		t.Fatalf("create tmux-managed terminal: %v", err)
+		t.Fatalf("create terminal: %v", err)
 	}
 	defer func() {
 		_ = manager.Delete(created.ID)
-		if hubID, _ := manager.AgentsHubStatus(); hubID != "" {
-			_ = manager.Delete(hubID)
-		}
 	}()
+	created.PublishOutputChunk([]byte("\x1b[31mfail\x1b[0m <ok>\n"))
+	if !waitForOutputLines(created, 1, time.Second) {
+		t.Fatalf("expected output buffer to receive data")
+	}
 
 	handler := &RestHandler{Manager: manager}
-	req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/input", strings.NewReader("hello\nworld\n"))
+	get := func(query, encoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/transcript"+query, nil)
+		if encoding != "" {
+			req.Header.Set("Accept-Encoding", encoding)
+		}
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+
+	res := get("", "")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	if body := res.Body.String(); body != "fail <ok>\n" {
+		t.Fatalf("unexpected txt transcript: %q", body)
+	}
+	if disposition := res.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, "attachment;") || !strings.Contains(disposition, "-transcript.txt") {
+		t.Fatalf("unexpected content disposition: %q", disposition)
+	}
+
+	res = get("?format=ansi", "")
+	if body := res.Body.String(); body != "\x1b[31mfail\x1b[0m <ok>\n" {
+		t.Fatalf("unexpected ansi transcript: %q", body)
+	}
+
+	res = get("?format=html", "")
+	if contentType := res.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Fatalf("unexpected html content type: %q", contentType)
+	}
+	if body := res.Body.String(); !strings.Contains(body, `<span style="color:#cd0000">fail</span> &lt;ok&gt;`) {
+		t.Fatalf("unexpected html transcript: %s", body)
+	}
+
+	res = get("?format=ansi", "br, gzip")
+	if res.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip encoding, got headers %v", res.Header())
+	}
+	reader, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("read gzip body: %v", err)
+	}
+	if string(decoded) != "\x1b[31mfail\x1b[0m <ok>\n" {
+		t.Fatalf("unexpected gzip transcript: %q", decoded)
+	}
+
+	if res := get("?format=pdf", ""); res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown format, got %d", res.Code)
+	}
+	req := httptest.NewRequest(http.MethodGet, terminalPath("missing")+"/transcript", nil)
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing session, got %d", res.Code)
+	}
+}
+
+func TestTerminalCastEndpoint(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+	if err := created.Resize(120, 40); err != nil {
+		t.Fatalf("resize: %v", err)
+	}
+	created.PublishOutputChunk([]byte("\x1b[32mhello\x1b[0m\r\n"))
+	if !waitForOutputLines(created, 1, time.Second) {
+		t.Fatalf("expected output buffer to receive data")
+	}
+
+	handler := &RestHandler{Manager: manager}
+	req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/cast", nil)
+	res := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	if contentType := res.Header().Get("Content-Type"); contentType != "application/x-asciicast" {
+		t.Fatalf("unexpected content type: %q", contentType)
+	}
+	if disposition := res.Header().Get("Content-Disposition"); !strings.Contains(disposition, "-session.cast") {
+		t.Fatalf("unexpected content disposition: %q", disposition)
+	}
+
+	lines := strings.Split(strings.TrimSpace(res.Body.String()), "\n")
+	var header struct {
+		Version int `json:"version"`
+		Width   int `json:"width"`
+		Height  int `json:"height"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("decode header: %v", err)
+	}
+	if header.Version != 2 || header.Width != 120 || header.Height != 40 {
+		t.Fatalf("unexpected header: %+v", header)
+	}
+	var output strings.Builder
+	for _, line := range lines[1:] {
+		var event []any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("decode event %q: %v", line, err)
+		}
+		if len(event) != 3 || event[1] != "o" {
+			t.Fatalf("unexpected event: %v", event)
+		}
+		output.WriteString(event[2].(string))
+	}
+	if !strings.Contains(output.String(), "\x1b[32mhello\x1b[0m\r\n") {
+		t.Fatalf("expected recorded output in cast, got %q", output.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, terminalPath("missing")+"/cast", nil)
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", res.Code)
+	}
+}
+
+func TestTerminalOutputTextEndpoint(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+	created.PublishOutputChunk([]byte("\x1b[32mhello\x1b[0m\r\nworld\r\n"))
+	if !waitForOutputLines(created, 2, time.Second) {
+		t.Fatalf("expected output buffer to receive data")
+	}
+
+	handler := &RestHandler{Manager: manager}
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/output.txt"+query, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+
+	res := get("")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	if contentType := res.Header().Get("Content-Type"); contentType != "text/plain; charset=utf-8" {
+		t.Fatalf("unexpected content type: %q", contentType)
+	}
+	if body := res.Body.String(); !strings.HasPrefix(body, "hello\nworld\n") {
+		t.Fatalf("expected plain text output, got %q", body)
+	}
+
+	res = get("?ansi=true")
+	if body := res.Body.String(); !strings.Contains(body, "\x1b[32mhello\x1b[0m") {
+		t.Fatalf("expected escape sequences with ansi=true, got %q", body)
+	}
+
+	res = get("?ansi=maybe")
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeInvalidParameter {
+		t.Fatalf("expected 400 for invalid ansi, got %d: %s", res.Code, res.Body.String())
+	}
+}
+
+func TestTerminalPauseEndpointRejectsSessionsWithoutProcess(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	handler := &RestHandler{Manager: manager}
+	call := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+
+	res := call(http.MethodPost, terminalPath(created.ID)+"/pause")
+	if res.Code != http.StatusConflict || decodeErrorCode(t, res) != errorCodeNotPausable {
+		t.Fatalf("expected not_pausable, got %d %s", res.Code, res.Body.String())
+	}
+	res = call(http.MethodPost, terminalPath(created.ID)+"/resume")
+	if res.Code != http.StatusConflict || decodeErrorCode(t, res) != errorCodeConflict {
+		t.Fatalf("expected conflict, got %d %s", res.Code, res.Body.String())
+	}
+	res = call(http.MethodPost, terminalPath("missing")+"/pause")
+	if res.Code != http.StatusNotFound || decodeErrorCode(t, res) != errorCodeTerminalNotFound {
+		t.Fatalf("expected terminal_not_found, got %d %s", res.Code, res.Body.String())
+	}
+	res = call(http.MethodGet, terminalPath(created.ID)+"/pause")
+	if res.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", res.Code)
+	}
+}
+
+func TestTerminalChildrenEndpoint(t *testing.T) {
+	agentsDir := t.TempDir()
+	for _, id := range []string{"lead", "worker"} {
+		agentTOML := "name = \"" + id + "\"\nshell = \"/bin/sh\"\n"
+		if err := os.WriteFile(filepath.Join(agentsDir, id+".toml"), []byte(agentTOML), 0644); err != nil {
+			t.Fatalf("write agent: %v", err)
+		}
+	}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		AgentsDir:  agentsDir,
+		Agents: map[string]agent.Agent{
+			"lead":   {Name: "lead"},
+			"worker": {Name: "worker"},
+		},
+	})
+	lead, err := manager.Create("lead", "", "")
+	if err != nil {
+		t.Fatalf("create lead: %v", err)
+	}
+	handler := &RestHandler{Manager: manager}
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		res := httptest.NewRecorder()
+		if path == "/api/sessions" {
+			restHandler("", nil, handler.handleTerminals)(res, req)
+		} else {
+			restHandler("", nil, handler.handleTerminal)(res, req)
+		}
+		return res
+	}
+
+	res := do(http.MethodPost, "/api/sessions", `{"agent":"worker","parent_id":"missing"}`)
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeInvalidParameter {
+		t.Fatalf("expected 400 for unknown parent, got %d: %s", res.Code, res.Body.String())
+	}
+	res = do(http.MethodPost, "/api/sessions", `{"agent":"worker","parent_id":"`+lead.ID+`"}`)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", res.Code, res.Body.String())
+	}
+	var created terminalCreateResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if created.ParentID != lead.ID {
+		t.Fatalf("expected parent_id %q, got %q", lead.ID, created.ParentID)
+	}
+
+	res = do(http.MethodGet, terminalPath(lead.ID)+"/children", "")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var children []terminalSummary
+	if err := json.NewDecoder(res.Body).Decode(&children); err != nil {
+		t.Fatalf("decode children: %v", err)
+	}
+	if len(children) != 1 || children[0].ID != created.ID {
+		t.Fatalf("unexpected children: %+v", children)
+	}
+	if res := do(http.MethodGet, terminalPath("missing")+"/children", ""); res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing parent, got %d", res.Code)
+	}
+
+	if res := do(http.MethodDelete, terminalPath(lead.ID)+"?cascade=nope", ""); res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid cascade, got %d", res.Code)
+	}
+	if res := do(http.MethodDelete, terminalPath(lead.ID)+"?cascade=true", ""); res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", res.Code, res.Body.String())
+	}
+	if _, ok := manager.Get(created.ID); ok {
+		t.Fatalf("expected child session to be deleted with its parent")
+	}
+}
+
+func TestCreateTerminalNoPersist(t *testing.T) {
+	agentsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(agentsDir, "worker.toml"), []byte("name = \"worker\"\nshell = \"/bin/sh\"\n"), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		AgentsDir:  agentsDir,
+		Agents: map[string]agent.Agent{
+			"worker": {Name: "worker"},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(`{"agent":"worker","no_persist":true}`))
+	res := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminals)(res, req)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", res.Code, res.Body.String())
+	}
+	var created terminalCreateResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !created.NoPersist {
+		t.Fatalf("expected no_persist in create response")
+	}
+	session, ok := manager.Get(created.ID)
+	if !ok || !session.Info().NoPersist {
+		t.Fatalf("expected session to be created without persistence")
+	}
+}
+
+func TestTerminalSummaryTmuxHealth(t *testing.T) {
+	plain := newTerminalSummary(terminal.SessionInfo{ID: "shell"})
+	if plain.TmuxSessionName != "" || plain.TmuxHealthy != nil {
+		t.Fatalf("expected tmux fields omitted, got %q %v", plain.TmuxSessionName, plain.TmuxHealthy)
+	}
+	unknown := newTerminalSummary(terminal.SessionInfo{ID: "Codex 1", TmuxSessionName: "Gestalt repo"})
+	if unknown.TmuxSessionName != "Gestalt repo" || unknown.TmuxHealthy != nil {
+		t.Fatalf("expected unknown tmux health, got %q %v", unknown.TmuxSessionName, unknown.TmuxHealthy)
+	}
+	healthy := false
+	gone := newTerminalSummary(terminal.SessionInfo{ID: "Codex 1", TmuxSessionName: "Gestalt repo", TmuxHealthy: &healthy})
+	if gone.TmuxSessionName != "Gestalt repo" || gone.TmuxHealthy == nil || *gone.TmuxHealthy {
+		t.Fatalf("expected unhealthy tmux summary, got %q %v", gone.TmuxSessionName, gone.TmuxHealthy)
+	}
+}
+
+func TestCreateTerminalReadiness(t *testing.T) {
+	agentsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(agentsDir, "worker.toml"), []byte("name = \"worker\"\nshell = \"/bin/sh\"\n"), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		AgentsDir:  agentsDir,
+		Agents: map[string]agent.Agent{
+			"worker": {Name: "worker"},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+	create := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminals)(res, req)
+		return res
+	}
+
+	res := create(`{"agent":"worker","ready_pattern":"("}`)
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeInvalidParameter {
+		t.Fatalf("expected 400 for invalid ready_pattern, got %d: %s", res.Code, res.Body.String())
+	}
+
+	res = create(`{"agent":"worker","ready_pattern":"\\$ $"}`)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", res.Code, res.Body.String())
+	}
+	var created terminalCreateResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if created.Ready {
+		t.Fatalf("expected session to be reported not ready before its prompt")
+	}
+}
+
+func TestCreateTerminalEncoding(t *testing.T) {
+	agentsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(agentsDir, "worker.toml"), []byte("name = \"worker\"\nshell = \"/bin/sh\"\n"), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		AgentsDir:  agentsDir,
+		Agents: map[string]agent.Agent{
+			"worker": {Name: "worker"},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+	create := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminals)(res, req)
+		return res
+	}
+
+	res := create(`{"agent":"worker","encoding":"klingon"}`)
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeInvalidParameter {
+		t.Fatalf("expected 400 for unknown encoding, got %d: %s", res.Code, res.Body.String())
+	}
+
+	res = create(`{"agent":"worker","encoding":"latin1"}`)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", res.Code, res.Body.String())
+	}
+	var created terminalCreateResponse
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if created.Encoding != "latin1" {
+		t.Fatalf("expected encoding latin1 in summary, got %q", created.Encoding)
+	}
+}
+
+func TestCreateTerminalPromptPattern(t *testing.T) {
+	agentsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(agentsDir, "worker.toml"), []byte("name = \"worker\"\nshell = \"/bin/sh\"\n"), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		AgentsDir:  agentsDir,
+		Agents: map[string]agent.Agent{
+			"worker": {Name: "worker"},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+	create := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminals)(res, req)
+		return res
+	}
+
+	res := create(`{"agent":"worker","prompt_pattern":"("}`)
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeInvalidParameter {
+		t.Fatalf("expected 400 for invalid prompt_pattern, got %d: %s", res.Code, res.Body.String())
+	}
+
+	res = create(`{"agent":"worker","prompt_pattern":"\\$ $"}`)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", res.Code, res.Body.String())
+	}
+	var created map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if waiting, ok := created["waiting_for_input"].(bool); !ok || waiting {
+		t.Fatalf("expected waiting_for_input false before any prompt, got %v", created["waiting_for_input"])
+	}
+
+	waiting := newTerminalSummary(terminal.SessionInfo{ID: "worker 1", WaitingForInput: true, WaitingSince: time.Now()})
+	if !waiting.WaitingForInput || waiting.WaitingSince == nil {
+		t.Fatalf("expected waiting summary, got %v %v", waiting.WaitingForInput, waiting.WaitingSince)
+	}
+}
+
+func TestCreateTerminalReportsFieldErrors(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{Shell: "/bin/sh", PtyFactory: &fakeFactory{}})
+	handler := &RestHandler{Manager: manager}
+	cases := []struct {
+		body  string
+		field string
+	}{
+		{body: `{"agent":"codex","role":7}`, field: "role"},
+		{body: `{"agent":"codex","colour":"red"}`, field: "colour"},
+		{body: `{"agent":"missing"}`, field: "agent"},
+		{body: `{}`, field: "agent"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminals)(res, req)
+		if res.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %s, got %d", tc.body, res.Code)
+		}
+		var payload errorResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if payload.Message == "" || len(payload.Fields) != 1 || payload.Fields[0].Field != tc.field {
+			t.Fatalf("expected field %q for %s, got %+v", tc.field, tc.body, payload)
+		}
+	}
+}
+
+func TestTerminalCloneEndpoint(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+	handler := &RestHandler{Manager: manager}
+
+	do := func(method, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, terminalPath(id)+"/clone", nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+
+	if res := do(http.MethodGet, created.ID); res.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", res.Code)
+	}
+	if res := do(http.MethodPost, "missing"); res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", res.Code)
+	}
+
+	// Agent sessions are singletons, so cloning a running one reports it;
+	// replacing a stopped source is covered in the terminal package.
+	res := do(http.MethodPost, created.ID)
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", res.Code, res.Body.String())
+	}
+	var payload errorResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Code != errorCodeAgentAlreadyRunning || payload.SessionID != created.ID {
+		t.Fatalf("unexpected error payload: %+v", payload)
+	}
+}
+
+func TestTerminalHistoryEndpoint(t *testing.T) {
+	t.Skip("obsolete: expects PTY-backed agent output")
+	factory := &fakeFactory{}
+	logDir := t.TempDir()
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:         "/bin/sh",
+		PtyFactory:    factory,
+		SessionLogDir: logDir,
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	factory.mu.Lock()
+	pty := factory.ptys[0]
+	factory.mu.Unlock()
+	if _, err := pty.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("write pty: %v", err)
+	}
+
+	if !waitForOutputLines(created, 1, 500*time.Millisecond) {
+		t.Fatalf("expected output buffer to receive data")
+	}
+
+	handler := &RestHandler{Manager: manager}
+	req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/history?lines=5", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+
+	restHandler("secret", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+
+	var payload terminalOutputResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !containsLine(payload.Lines, "hello") {
+		t.Fatalf("expected history lines to contain hello, got %v", payload.Lines)
+	}
+	if payload.Cursor != nil {
+		t.Fatalf("expected cursor to be nil when session logs are disabled for agents")
+	}
+}
+
+func TestTerminalHistoryPagination(t *testing.T) {
+	t.Skip("obsolete: expects PTY-backed agent output")
+	factory := &fakeFactory{}
+	logDir := t.TempDir()
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:         "/bin/sh",
+		PtyFactory:    factory,
+		SessionLogDir: logDir,
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	factory.mu.Lock()
+	pty := factory.ptys[0]
+	factory.mu.Unlock()
+	payloads := []string{"one", "two", "three", "four", "five"}
+	for _, entry := range payloads {
+		if _, err := pty.Write([]byte(entry + "\n")); err != nil {
+			t.Fatalf("write pty: %v", err)
+		}
+	}
+
+	if !waitForOutputLines(created, len(payloads), 500*time.Millisecond) {
+		t.Fatalf("expected output buffer to receive data")
+	}
+
+	handler := &RestHandler{Manager: manager}
+	req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/history?lines=4", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+
+	restHandler("secret", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+
+	var page terminalOutputResponse
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	nonEmpty := make([]string, 0, len(page.Lines))
+	for _, line := range page.Lines {
+		if line != "" {
+			nonEmpty = append(nonEmpty, line)
+		}
+	}
+	if len(nonEmpty) < 2 || nonEmpty[len(nonEmpty)-2] != "four" || nonEmpty[len(nonEmpty)-1] != "five" {
+		t.Fatalf("unexpected history page: %v", page.Lines)
+	}
+	if page.Cursor != nil {
+		t.Fatalf("expected cursor to be nil when session logs are disabled for agents")
+	}
+}
+
+func TestTerminalInputHistoryEndpoint(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	created.RecordInput("one")
+	created.RecordInput("two")
+
+	handler := &RestHandler{Manager: manager}
+	req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/input-history?limit=1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+
+	restHandler("secret", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+
+	var payload []inputHistoryEntry
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload) != 1 || payload[0].Command != "two" {
+		t.Fatalf("expected last command, got %v", payload)
+	}
+	if payload[0].Timestamp.IsZero() {
+		t.Fatalf("expected timestamp to be set")
+	}
+}
+
+func TestTerminalInputHistoryExport(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	created.RecordInput("cd /tmp")
+	created.RecordInput("make test")
+
+	handler := &RestHandler{Manager: manager}
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/input-history/export"+query, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+
+	res := get("?format=sh")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	if got := res.Header().Get("Content-Disposition"); !strings.Contains(got, "-input-history.sh") {
+		t.Fatalf("unexpected content disposition %q", got)
+	}
+	body := res.Body.String()
+	if !strings.HasPrefix(body, "#!/bin/sh\n") {
+		t.Fatalf("expected shebang, got %q", body)
+	}
+	if !strings.Contains(body, "\ncd /tmp\n") || strings.Index(body, "cd /tmp") > strings.Index(body, "make test") {
+		t.Fatalf("expected commands in order, got %q", body)
+	}
+	if strings.Count(body, "\n# 20") != 2 {
+		t.Fatalf("expected a timestamp comment per command, got %q", body)
+	}
+
+	if body := get("?limit=1").Body.String(); strings.Contains(body, "cd /tmp") || !strings.Contains(body, "make test") {
+		t.Fatalf("expected only the last command with limit=1, got %q", body)
+	}
+	if res := get("?format=ps1"); res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown format, got %d", res.Code)
+	}
+}
+
+func TestTerminalInputEndpoint(t *testing.T) {
+	t.Skip("obsolete: expects PTY-backed input writes")
+	factory := &recordFactory{}
+	manager := terminal.NewManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+		Agents: map[string]agent.Agent{
+			testAgentID: {
+				Name:  "Codex",
+				Shell: "/bin/sh",
+			},
+		},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	handler := &RestHandler{Manager: manager}
+	req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/input", strings.NewReader("hello"))
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+
+	restHandler("secret", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	var payload agentInputResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Bytes != len("hello") {
+		t.Fatalf("expected %d bytes, got %d", len("hello"), payload.Bytes)
+	}
+	select {
+	case got := <-factory.pty.writes:
+		if string(got) != "hello" {
+			t.Fatalf("expected raw bytes %q, got %q", "hello", string(got))
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("timed out waiting for input write")
+	}
+}
+
+func TestTerminalInputEndpointTmuxManagedAgentSession(t *testing.T) {
+	tmuxClient := &fakeTmuxClient{hasSession: true}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		Agents: map[string]agent.Agent{
+			"codex": {
+				Name:      "Codex",
+				Shell:     "codex -c model=o3",
+				CLIType:   "codex",
+				Interface: agent.AgentInterfaceCLI,
+			},
+		},
+		StartExternalTmuxWindow: func(_ *launchspec.LaunchSpec) error { return nil },
+		TmuxClientFactory:       func() terminal.TmuxClient { return tmuxClient },
+	})
+	created, err := manager.CreateWithOptions(terminal.CreateOptions{AgentID: "codex"})
+	if err != nil {
+		t.Fatalf("create tmux-managed terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+		if hubID, _ := manager.AgentsHubStatus(); hubID != "" {
+			_ = manager.Delete(hubID)
+		}
+	}()
+
+	handler := &RestHandler{Manager: manager}
+	req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/input", strings.NewReader("hello\nworld\n"))
 	req.Header.Set("Authorization", "Bearer secret")
 	res := httptest.NewRecorder()
 
@@ -1429,25 +2378,137 @@ func TestTerminalInputHistoryPostEndpoint(t *testing.T) {
 		t.Fatalf("expected 204, got %d", res.Code)
 	}
 
-	getReq := httptest.NewRequest(
-		http.MethodGet,
-		terminalPath(created.ID)+"/input-history?limit=1",
-		nil,
-	)
-	getReq.Header.Set("Authorization", "Bearer secret")
-	getRes := httptest.NewRecorder()
+	getReq := httptest.NewRequest(
+		http.MethodGet,
+		terminalPath(created.ID)+"/input-history?limit=1",
+		nil,
+	)
+	getReq.Header.Set("Authorization", "Bearer secret")
+	getRes := httptest.NewRecorder()
+
+	restHandler("secret", nil, handler.handleTerminal)(getRes, getReq)
+	if getRes.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", getRes.Code)
+	}
+
+	var payload []inputHistoryEntry
+	if err := json.NewDecoder(getRes.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload) != 1 || payload[0].Command != "echo hi" {
+		t.Fatalf("unexpected history payload: %v", payload)
+	}
+}
+
+func TestTerminalReplayLastEndpoint(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	handler := &RestHandler{Manager: manager}
+	replay := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/replay-last", nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+
+	res := replay()
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected 409 without history, got %d", res.Code)
+	}
+	var errPayload errorResponse
+	if err := json.NewDecoder(res.Body).Decode(&errPayload); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if errPayload.Code != errorCodeNoInputHistory {
+		t.Fatalf("expected code %q, got %q", errorCodeNoInputHistory, errPayload.Code)
+	}
+
+	created.RecordInput("echo hi")
+
+	res = replay()
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var entry inputHistoryEntry
+	if err := json.NewDecoder(res.Body).Decode(&entry); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if entry.Command != "echo hi" {
+		t.Fatalf("expected replayed command, got %q", entry.Command)
+	}
+	if history := created.GetInputHistory(); len(history) != 2 {
+		t.Fatalf("expected replay to be recorded, got %v", history)
+	}
+
+	missing := httptest.NewRequest(http.MethodPost, terminalPath("missing")+"/replay-last", nil)
+	missingRes := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(missingRes, missing)
+	if missingRes.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing session, got %d", missingRes.Code)
+	}
+}
+
+func TestStopAllTerminalsRequiresConfirm(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, manager, "", StatusConfig{}, "", nil, nil, nil, nil)
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/sessions/stop-all", strings.NewReader(body))
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		return res
+	}
 
-	restHandler("secret", nil, handler.handleTerminal)(getRes, getReq)
-	if getRes.Code != http.StatusOK {
-		t.Fatalf("expected 200, got %d", getRes.Code)
+	for _, body := range []string{"", `{}`, `{"confirm":false}`} {
+		res := post(body)
+		if res.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %q, got %d", body, res.Code)
+		}
+		var payload errorResponse
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode error: %v", err)
+		}
+		if len(payload.Fields) != 1 || payload.Fields[0].Field != "confirm" {
+			t.Fatalf("expected confirm field error for %q, got %+v", body, payload)
+		}
 	}
 
-	var payload []inputHistoryEntry
-	if err := json.NewDecoder(getRes.Body).Decode(&payload); err != nil {
+	res := post(`{"confirm":true}`)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var payload stopAllResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
 		t.Fatalf("decode response: %v", err)
 	}
-	if len(payload) != 1 || payload[0].Command != "echo hi" {
-		t.Fatalf("unexpected history payload: %v", payload)
+	// Agent sessions here run without a local process, so nothing is killed.
+	if payload.Stopped != 0 || payload.SessionIDs == nil {
+		t.Fatalf("unexpected response %+v", payload)
+	}
+	if _, ok := manager.Get(created.ID); !ok {
+		t.Fatalf("expected session to remain after stop-all")
 	}
 }
 
@@ -1520,9 +2581,94 @@ func TestCreateTerminalWithAgent(t *testing.T) {
 	if payload.Runner != "external" {
 		t.Fatalf("expected runner external, got %q", payload.Runner)
 	}
+	if payload.MCP {
+		t.Fatalf("expected cli session to report mcp false")
+	}
+	defer func() {
+		_ = manager.Delete(payload.ID)
+	}()
+}
+
+func TestCreateTerminalIncludesAgentContext(t *testing.T) {
+	root := t.TempDir()
+	skillDir := filepath.Join(root, "git-workflows")
+	agentsDir := filepath.Join(root, "agents")
+	for _, dir := range []string{skillDir, agentsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	agentTOML := "name = \"Coder\"\nshell = \"/bin/bash\"\nprompt = [\"greet\"]\nskills = [\"git-workflows\"]\n"
+	if err := os.WriteFile(filepath.Join(agentsDir, "coder.toml"), []byte(agentTOML), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		AgentsDir:  agentsDir,
+		PromptFS: fstest.MapFS{
+			"prompts/greet.tmpl": &fstest.MapFile{Data: []byte("hello there\n")},
+		},
+		PromptDir: "prompts",
+		Agents: map[string]agent.Agent{
+			"coder": {
+				Name:    "Coder",
+				Shell:   "/bin/bash",
+				Prompts: agent.PromptList{"greet"},
+				Skills:  []string{"git-workflows"},
+			},
+		},
+		Skills: map[string]*skill.Skill{
+			"git-workflows": {Name: "git-workflows", Description: "Helpful git workflows", Path: skillDir},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+	create := func(path string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"agent":"coder"}`))
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminals)(res, req)
+		return res
+	}
+
+	res := create("/api/sessions")
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d %s", res.Code, res.Body.String())
+	}
+	var lean map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&lean); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if _, ok := lean["skills"]; ok {
+		t.Fatalf("expected no skills by default, got %v", lean["skills"])
+	}
+	if _, ok := lean["prompt"]; ok {
+		t.Fatalf("expected no prompt by default, got %v", lean["prompt"])
+	}
+	_ = manager.Delete(lean["id"].(string))
+
+	res = create("/api/sessions?include=skills,prompt")
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d %s", res.Code, res.Body.String())
+	}
+	var payload terminalCreateResponse
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
 	defer func() {
 		_ = manager.Delete(payload.ID)
 	}()
+	if len(payload.Skills) != 1 || payload.Skills[0].Name != "git-workflows" {
+		t.Fatalf("unexpected skills: %+v", payload.Skills)
+	}
+	if payload.Prompt == nil || !strings.Contains(payload.Prompt.Prompt, "hello there") {
+		t.Fatalf("expected rendered prompt, got %+v", payload.Prompt)
+	}
+
+	res = create("/api/sessions?include=history")
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeInvalidParameter {
+		t.Fatalf("expected invalid_parameter, got %d %s", res.Code, res.Body.String())
+	}
 }
 
 func TestCreateTerminalDuplicateAgent(t *testing.T) {
@@ -1590,8 +2736,8 @@ func TestCreateTerminalDuplicateAgent(t *testing.T) {
 	if payload.Message == "" {
 		t.Fatalf("expected error message")
 	}
-	if payload.Code != "conflict" {
-		t.Fatalf("expected error code %q, got %q", "conflict", payload.Code)
+	if payload.Code != "agent_already_running" {
+		t.Fatalf("expected error code %q, got %q", "agent_already_running", payload.Code)
 	}
 }
 
@@ -1664,6 +2810,63 @@ func TestListTerminalsIncludesModelMetadata(t *testing.T) {
 	}
 }
 
+func TestListTerminalsFieldsSelector(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "build", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	handler := &RestHandler{Manager: manager}
+	get := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions"+query, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminals)(res, req)
+		return res
+	}
+
+	res := get("?fields=id,%20status,bogus")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var payload []map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	found := false
+	for _, entry := range payload {
+		if len(entry) != 2 || entry["status"] == nil {
+			t.Fatalf("expected only id and status, got %v", entry)
+		}
+		found = found || entry["id"] == created.ID
+	}
+	if !found {
+		t.Fatalf("expected %q in %v", created.ID, payload)
+	}
+
+	res = get("?fields=id,bogus&fields_strict=true")
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown strict field, got %d", res.Code)
+	}
+	var errPayload errorResponse
+	if err := json.NewDecoder(res.Body).Decode(&errPayload); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if len(errPayload.Fields) != 1 || errPayload.Fields[0].Field != "fields" || !strings.Contains(errPayload.Fields[0].Error, "bogus") {
+		t.Fatalf("expected fields error naming bogus, got %+v", errPayload)
+	}
+
+	if res := get("?fields=id,parent_id&fields_strict=true"); res.Code != http.StatusOK {
+		t.Fatalf("expected omitempty fields to be known in strict mode, got %d", res.Code)
+	}
+}
+
 func TestListTerminalsIncludesPromptFiles(t *testing.T) {
 	factory := &fakeFactory{}
 	manager := newTestManager(terminal.ManagerOptions{
@@ -1909,6 +3112,40 @@ func TestSkillsEndpointUnknownAgent(t *testing.T) {
 	}
 }
 
+func TestSchemaEndpoint(t *testing.T) {
+	handler := &RestHandler{}
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleSchema)(res, req)
+		return res
+	}
+
+	for name, required := range map[string]string{"agent": "name", "skill": "description"} {
+		res := get("/api/schema/" + name)
+		if res.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", name, res.Code)
+		}
+		var payload struct {
+			Properties map[string]any `json:"properties"`
+			Required   []string       `json:"required"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("%s: decode: %v", name, err)
+		}
+		if _, ok := payload.Properties[required]; !ok {
+			t.Fatalf("%s: expected %q property, got %v", name, required, payload.Properties)
+		}
+		if !slices.Contains(payload.Required, required) {
+			t.Fatalf("%s: expected %q to be required, got %v", name, required, payload.Required)
+		}
+	}
+
+	if res := get("/api/schema/flow-file"); res.Code != http.StatusNotFound {
+		t.Fatalf("expected unpublished schema to 404, got %d", res.Code)
+	}
+}
+
 func TestPlansEndpointReturnsEmptyList(t *testing.T) {
 	root := t.TempDir()
 	cwd, err := os.Getwd()
@@ -2027,6 +3264,34 @@ func TestTerminalBellEndpointReturnsNoContent(t *testing.T) {
 	}
 }
 
+func TestTerminalBellEndpointCoalescesBells(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:        "/bin/sh",
+		PtyFactory:   &fakeFactory{},
+		BellDebounce: time.Hour,
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	handler := &RestHandler{Manager: manager}
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, terminalPath(created.ID)+"/bell", nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		if res.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", res.Code)
+		}
+	}
+	if signal, _ := created.SendBellSignal(""); !signal.Suppressed || signal.Count != 3 {
+		t.Fatalf("expected later bells coalesced, got %+v", signal)
+	}
+}
+
 func TestTerminalBellEndpointMissingSession(t *testing.T) {
 	manager := newTestManager(terminal.ManagerOptions{Shell: "/bin/sh"})
 	handler := &RestHandler{Manager: manager}
@@ -2039,6 +3304,21 @@ func TestTerminalBellEndpointMissingSession(t *testing.T) {
 	}
 }
 
+func TestCreateTerminalAttachPIDNotAttachable(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{Shell: "/bin/sh", PtyFactory: &fakeFactory{}})
+	handler := &RestHandler{Manager: manager}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(`{"attach_pid":-1}`))
+	res := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminals)(res, req)
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", res.Code, res.Body.String())
+	}
+	if code := decodeErrorCode(t, res); code != "not_attachable" {
+		t.Fatalf("expected not_attachable, got %q", code)
+	}
+}
+
 func TestCreateTerminalRejectsUnsupportedInterfaceProfile(t *testing.T) {
 	t.Skip("obsolete: interface setting removed")
 	dir := t.TempDir()
@@ -2220,6 +3500,10 @@ func TestParseTerminalPath(t *testing.T) {
 		{name: "activate-trailing-slash", path: "/api/sessions/123/activate/", id: "123", action: terminalPathActivate},
 		{name: "input-history", path: "/api/sessions/123/input-history", id: "123", action: terminalPathInputHistory},
 		{name: "input-history-trailing-slash", path: "/api/sessions/123/input-history/", id: "123", action: terminalPathInputHistory},
+		{name: "transcript", path: "/api/sessions/123/transcript", id: "123", action: terminalPathTranscript},
+		{name: "focus", path: "/api/sessions/123/focus", id: "123", action: terminalPathFocus},
+		{name: "replay-last", path: "/api/sessions/123/replay-last", id: "123", action: terminalPathReplayLast},
+		{name: "input-history-export", path: "/api/sessions/123/input-history/export", id: "123", action: terminalPathInputHistoryExport},
 		{name: "workflow-resume", path: "/api/sessions/123/workflow/resume", wantErr: true, status: http.StatusNotFound},
 		{name: "workflow-resume-trailing-slash", path: "/api/sessions/123/workflow/resume/", wantErr: true, status: http.StatusNotFound},
 		{name: "workflow-history", path: "/api/sessions/123/workflow/history", wantErr: true, status: http.StatusNotFound},
@@ -2257,3 +3541,157 @@ func TestParseTerminalPath(t *testing.T) {
 		})
 	}
 }
+
+func TestTerminalLabelsCreateFilterAndPatch(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+		Agents: map[string]agent.Agent{
+			"codex": {Name: "Codex", Shell: "/bin/bash", CLIType: "codex"},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(`{"agent":"codex","labels":{"team":"core","ticket":"42"}}`))
+	res := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminals)(res, req)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", res.Code, res.Body.String())
+	}
+	var created terminalSummary
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+	if created.Labels["team"] != "core" || created.Labels["ticket"] != "42" {
+		t.Fatalf("unexpected labels: %#v", created.Labels)
+	}
+
+	listLabels := func(query string) []terminalSummary {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions?"+query, nil)
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminals)(res, req)
+		if res.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", res.Code)
+		}
+		var payload []terminalSummary
+		if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return payload
+	}
+	if got := listLabels("label.team=core"); len(got) != 1 || got[0].ID != created.ID {
+		t.Fatalf("expected labelled session, got %#v", got)
+	}
+	if got := listLabels("label.team=other"); len(got) != 0 {
+		t.Fatalf("expected no sessions, got %#v", got)
+	}
+
+	req = httptest.NewRequest(http.MethodPatch, terminalPath(created.ID)+"/labels", strings.NewReader(`{"labels":{"ticket":"","stage":"review"}}`))
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var patched terminalSummary
+	if err := json.NewDecoder(res.Body).Decode(&patched); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if _, ok := patched.Labels["ticket"]; ok {
+		t.Fatalf("expected ticket label removed, got %#v", patched.Labels)
+	}
+	if patched.Labels["team"] != "core" || patched.Labels["stage"] != "review" {
+		t.Fatalf("unexpected labels: %#v", patched.Labels)
+	}
+}
+
+func TestTerminalLabelsRejectsInvalid(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+		Agents: map[string]agent.Agent{
+			"codex": {Name: "Codex", Shell: "/bin/bash", CLIType: "codex"},
+		},
+	})
+	handler := &RestHandler{Manager: manager}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/sessions", strings.NewReader(`{"agent":"codex","labels":{"bad key":"x"}}`))
+	res := httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminals)(res, req)
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", res.Code)
+	}
+
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	value := strings.Repeat("v", 300)
+	req = httptest.NewRequest(http.MethodPatch, terminalPath(created.ID)+"/labels", strings.NewReader(`{"labels":{"team":"`+value+`"}}`))
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", res.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPatch, "/api/sessions/missing/labels", strings.NewReader(`{"labels":{"team":"core"}}`))
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", res.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, terminalPath(created.ID)+"/labels", nil)
+	res = httptest.NewRecorder()
+	restHandler("", nil, handler.handleTerminal)(res, req)
+	if res.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", res.Code)
+	}
+}
+
+func TestErrorResponsesIncludeSpecificCodes(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{Shell: "/bin/sh", PtyFactory: &fakeFactory{}})
+	handler := &RestHandler{Manager: manager}
+
+	cases := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		handler apiHandler
+		status  int
+		code    string
+	}{
+		{"missing terminal", http.MethodGet, "/api/sessions/missing/output", "", handler.handleTerminal, http.StatusNotFound, "terminal_not_found"},
+		{"unknown agent", http.MethodPost, "/api/sessions", `{"agent":"nope"}`, handler.handleTerminals, http.StatusBadRequest, "agent_unknown"},
+		{"missing agent", http.MethodPost, "/api/sessions", `{"title":"x"}`, handler.handleTerminals, http.StatusBadRequest, "agent_required"},
+		{"invalid body", http.MethodPost, "/api/sessions", `{"bogus":true}`, handler.handleTerminals, http.StatusBadRequest, "invalid_body"},
+		{"method", http.MethodPut, "/api/sessions", "", handler.handleTerminals, http.StatusMethodNotAllowed, "method_not_allowed"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			res := httptest.NewRecorder()
+			restHandler("", nil, tc.handler)(res, req)
+			if res.Code != tc.status {
+				t.Fatalf("expected %d, got %d", tc.status, res.Code)
+			}
+			var payload errorResponse
+			if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if payload.Code != tc.code {
+				t.Fatalf("expected code %q, got %q", tc.code, payload.Code)
+			}
+		})
+	}
+}
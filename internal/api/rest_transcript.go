@@ -0,0 +1,120 @@
+package api
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"gestalt/internal/terminal"
+)
+
+const (
+	transcriptFormatText = "txt"
+	transcriptFormatANSI = "ansi"
+	transcriptFormatHTML = "html"
+)
+
+// handleTerminalTranscript serves a session's output history as a download.
+// txt strips escape sequences, ansi keeps them for replay in a terminal, and
+// html renders colors for viewing in a browser. Responses are gzipped when the
+// client accepts it.
+func (h *RestHandler) handleTerminalTranscript(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = transcriptFormatText
+	}
+	switch format {
+	case transcriptFormatText, transcriptFormatANSI, transcriptFormatHTML:
+	default:
+		return &apiError{Status: http.StatusBadRequest, Message: "invalid format", Code: errorCodeInvalidParameter}
+	}
+	lines, err := parseHistoryLines(r)
+	if err != nil {
+		return err
+	}
+
+	history, historyErr := h.Manager.HistoryLines(id, lines)
+	if historyErr != nil {
+		if errors.Is(historyErr, terminal.ErrSessionNotFound) {
+			return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+		}
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to read terminal history"}
+	}
+	raw := strings.Join(history, "\n")
+
+	var body, contentType, disposition string
+	filename := transcriptFilename(id, format)
+	switch format {
+	case transcriptFormatANSI:
+		body = raw
+		contentType = "text/plain; charset=utf-8"
+		disposition = fmt.Sprintf("attachment; filename=%q", filename)
+	case transcriptFormatHTML:
+		body = renderTranscriptHTML(id, raw)
+		contentType = "text/html; charset=utf-8"
+		disposition = fmt.Sprintf("inline; filename=%q", filename)
+	default:
+		body = terminal.StripANSI(strings.ReplaceAll(raw, "\r\n", "\n"))
+		contentType = "text/plain; charset=utf-8"
+		disposition = fmt.Sprintf("attachment; filename=%q", filename)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !acceptsGzip(r) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+		return nil
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(http.StatusOK)
+	gz := gzip.NewWriter(w)
+	_, _ = gz.Write([]byte(body))
+	_ = gz.Close()
+	return nil
+}
+
+func transcriptFilename(id, format string) string {
+	return downloadFilename(id, "transcript", format)
+}
+
+// downloadFilename builds an attachment name like <id>-<kind>.<ext> with
+// characters that would break the Content-Disposition header replaced.
+func downloadFilename(id, kind, ext string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '"' || r < 0x20 {
+			return '-'
+		}
+		return r
+	}, id)
+	return name + "-" + kind + "." + ext
+}
+
+func renderTranscriptHTML(id, raw string) string {
+	title := html.EscapeString(id)
+	var builder strings.Builder
+	builder.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	builder.WriteString(title)
+	builder.WriteString(" transcript</title>\n<style>body{margin:0;background:#1e1e1e;color:#e5e5e5}pre{margin:0;padding:1rem;font-family:ui-monospace,SFMono-Regular,Menlo,Consolas,monospace;font-size:13px;white-space:pre-wrap}</style>\n</head>\n<body>\n<pre>")
+	builder.WriteString(terminal.ANSIToHTML(raw))
+	builder.WriteString("</pre>\n</body>\n</html>\n")
+	return builder.String()
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+	}
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"gestalt/internal/otel"
 	"gestalt/internal/runner/launchspec"
 	"gestalt/internal/terminal"
+	"gestalt/internal/watcher"
 )
 
 type RestHandler struct {
@@ -28,33 +29,70 @@ type RestHandler struct {
 	SessionFontSize        string
 	SessionInputFontFamily string
 	SessionInputFontSize   string
-	gitMutex               sync.RWMutex
+	// AuthToken is only used to redact the token from diagnostics snapshots.
+	AuthToken string
+	// InputFileRoot bounds the files session input may be read from.
+	InputFileRoot string
+	// Watcher is the filesystem watcher whose paths are reported.
+	Watcher *watcher.Watcher
+	// DefaultAgentPath is the file storing the default agent ID.
+	DefaultAgentPath string
+	gitMutex         sync.RWMutex
 }
 
 type terminalSummary struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Role        string    `json:"role"`
-	CreatedAt   time.Time `json:"created_at"`
-	Status      string    `json:"status"`
-	LLMType     string    `json:"llm_type"`
-	Model       string    `json:"model"`
-	Interface   string    `json:"interface"`
-	Runner      string    `json:"runner,omitempty"`
-	Command     string    `json:"command,omitempty"`
-	Skills      []string  `json:"skills"`
-	PromptFiles []string  `json:"prompt_files"`
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Role        string            `json:"role"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Status      string            `json:"status"`
+	LLMType     string            `json:"llm_type"`
+	Model       string            `json:"model"`
+	Interface   string            `json:"interface"`
+	MCP         bool              `json:"mcp"`
+	Runner      string            `json:"runner,omitempty"`
+	Command     string            `json:"command,omitempty"`
+	Skills      []string          `json:"skills"`
+	PromptFiles []string          `json:"prompt_files"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	// StatusReason is set when Status is "stopped".
+	StatusReason string `json:"status_reason,omitempty"`
+	ParentID     string `json:"parent_id,omitempty"`
+	NoPersist    bool   `json:"no_persist,omitempty"`
+	Ready        bool   `json:"ready"`
+	// TmuxSessionName and TmuxHealthy are only set for tmux-managed sessions.
+	TmuxSessionName string `json:"tmux_session_name,omitempty"`
+	TmuxHealthy     *bool  `json:"tmux_healthy,omitempty"`
+	// AttachPID is the process a read-only attached session follows.
+	AttachPID int `json:"attach_pid,omitempty"`
+	// Encoding is set when the session transcodes from a non-UTF-8 encoding.
+	Encoding string `json:"encoding,omitempty"`
+	// WaitingForInput is true while the session sits at a prompt.
+	WaitingForInput bool       `json:"waiting_for_input"`
+	WaitingSince    *time.Time `json:"waiting_since,omitempty"`
+}
+
+type terminalFocusResponse struct {
+	SessionID string    `json:"session_id"`
+	ClientID  string    `json:"client_id"`
+	FocusedAt time.Time `json:"focused_at"`
 }
 
 type terminalCreateResponse struct {
 	terminalSummary
 	Launch *launchspec.LaunchSpec `json:"launch,omitempty"`
+	// Skills and Prompt are only filled in when asked for with ?include=.
+	Skills []skillSummary       `json:"skills,omitempty"`
+	Prompt *agentPromptResponse `json:"prompt,omitempty"`
 }
 
 type terminalOutputResponse struct {
-	ID     string   `json:"id"`
-	Lines  []string `json:"lines"`
-	Cursor *int64   `json:"cursor,omitempty"`
+	ID      string   `json:"id"`
+	Lines   []string `json:"lines"`
+	Cursor  *int64   `json:"cursor,omitempty"`
+	Partial string   `json:"partial,omitempty"`
+	// Sanitized is set when invalid UTF-8 was replaced with U+FFFD.
+	Sanitized bool `json:"sanitized,omitempty"`
 }
 
 type inputHistoryEntry struct {
@@ -77,6 +115,7 @@ type statusResponse struct {
 	SessionInputFontSize      string    `json:"session_input_font_size"`
 	AgentsSessionID           string    `json:"agents_session_id,omitempty"`
 	AgentsTmuxSession         string    `json:"agents_tmux_session,omitempty"`
+	FocusedSessionID          string    `json:"focused_session_id,omitempty"`
 	WorkingDir                string    `json:"working_dir"`
 	GitOrigin                 string    `json:"git_origin"`
 	GitBranch                 string    `json:"git_branch"`
@@ -150,10 +189,16 @@ type gitLogFile struct {
 }
 
 type errorResponse struct {
-	Message   string `json:"message"`
-	Error     string `json:"error,omitempty"`
-	Code      string `json:"code,omitempty"`
-	SessionID string `json:"session_id,omitempty"`
+	Message   string       `json:"message"`
+	Error     string       `json:"error,omitempty"`
+	Code      string       `json:"code,omitempty"`
+	SessionID string       `json:"session_id,omitempty"`
+	Fields    []fieldError `json:"fields,omitempty"`
+}
+
+type fieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
 }
 
 type agentSummary struct {
@@ -165,6 +210,45 @@ type agentSummary struct {
 	SessionID string `json:"session_id"`
 	Running   bool   `json:"running"`
 	Hidden    bool   `json:"hidden"`
+	Default   bool   `json:"default,omitempty"`
+}
+
+type defaultAgentRequest struct {
+	AgentID string `json:"agent_id"`
+}
+
+// defaultAgentResponse reports the default agent; Missing is set when the
+// stored ID no longer names a loaded agent.
+type defaultAgentResponse struct {
+	AgentID string `json:"agent_id"`
+	Name    string `json:"name,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+type agentPromptResponse struct {
+	AgentID        string               `json:"agent_id"`
+	SessionID      string               `json:"session_id"`
+	Mode           string               `json:"mode"`
+	Prompt         string               `json:"prompt"`
+	PromptFiles    []string             `json:"prompt_files"`
+	SkippedPrompts []skippedPromptEntry `json:"skipped_prompts,omitempty"`
+}
+
+type skippedPromptEntry struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+type otelLogBatchResponse struct {
+	Accepted int                     `json:"accepted"`
+	Rejected []otelLogBatchRejection `json:"rejected"`
+}
+
+type otelLogBatchRejection struct {
+	Index   int          `json:"index"`
+	Message string       `json:"message"`
+	Code    string       `json:"code,omitempty"`
+	Fields  []fieldError `json:"fields,omitempty"`
 }
 
 type agentInputResponse struct {
@@ -182,10 +266,57 @@ type skillSummary struct {
 }
 
 type createTerminalRequest struct {
-	Title  string `json:"title"`
-	Role   string `json:"role"`
-	Agent  string `json:"agent"`
-	Runner string `json:"runner,omitempty"`
+	Title         string            `json:"title"`
+	Role          string            `json:"role"`
+	Agent         string            `json:"agent"`
+	Runner        string            `json:"runner,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	ParentID      string            `json:"parent_id,omitempty"`
+	InitialInput  string            `json:"initial_input,omitempty"`
+	Banner        string            `json:"banner,omitempty"`
+	NoPersist     bool              `json:"no_persist,omitempty"`
+	ReadyPattern  string            `json:"ready_pattern,omitempty"`
+	ReadyDelayMS  int64             `json:"ready_delay_ms,omitempty"`
+	WaitReady     bool              `json:"wait_ready,omitempty"`
+	AttachPID     int               `json:"attach_pid,omitempty"`
+	Encoding      string            `json:"encoding,omitempty"`
+	PromptPattern string            `json:"prompt_pattern,omitempty"`
+}
+
+type terminalCapabilitiesResponse struct {
+	Roles            []string `json:"roles"`
+	Runners          []string `json:"runners"`
+	Interfaces       []string `json:"interfaces"`
+	GUIModules       []string `json:"gui_modules"`
+	WorkflowsEnabled bool     `json:"workflows_enabled"`
+}
+
+type stopAllRequest struct {
+	Confirm bool `json:"confirm"`
+}
+
+type stopAllResponse struct {
+	Stopped    int      `json:"stopped"`
+	SessionIDs []string `json:"session_ids"`
+}
+
+type terminalLabelsRequest struct {
+	Labels map[string]string `json:"labels"`
+}
+
+type sessionWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+	Secret string   `json:"secret,omitempty"`
+}
+
+type sessionWebhookResponse struct {
+	ID        string    `json:"id"`
+	SessionID string    `json:"session_id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type terminalProgressResponse struct {
@@ -198,6 +329,10 @@ type terminalProgressResponse struct {
 	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
 }
 
+type progressResponse struct {
+	Sessions map[string]terminalProgressResponse `json:"sessions"`
+}
+
 type notifyRequest struct {
 	SessionID  string          `json:"session_id"`
 	EventType  string          `json:"-"`
@@ -221,4 +356,30 @@ const (
 	terminalPathProgress
 	terminalPathWorkflowResume
 	terminalPathWorkflowHistory
+	terminalPathLabels
+	terminalPathClone
+	terminalPathChildren
+	terminalPathTranscript
+	terminalPathFocus
+	terminalPathReplayLast
+	terminalPathInputHistoryExport
+	terminalPathCast
+	terminalPathPause
+	terminalPathResume
+	terminalPathWebhooks
+	terminalPathWebhook
+	terminalPathOutputText
 )
+
+type searchMatch struct {
+	SessionID string `json:"session_id"`
+	Title     string `json:"title"`
+	Line      int    `json:"line"`
+	Snippet   string `json:"snippet"`
+}
+
+type searchResponse struct {
+	Query     string        `json:"query"`
+	Matches   []searchMatch `json:"matches"`
+	Truncated bool          `json:"truncated"`
+}
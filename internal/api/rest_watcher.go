@@ -0,0 +1,28 @@
+package api
+
+import "net/http"
+
+type watcherPathsResponse struct {
+	Paths         []string `json:"paths"`
+	ActiveWatches int      `json:"active_watches"`
+}
+
+// handleWatcherPaths lists the paths the filesystem watcher is monitoring,
+// for checking whether a change should have been seen at all.
+func (h *RestHandler) handleWatcherPaths(w http.ResponseWriter, r *http.Request) *apiError {
+	if r.Method != http.MethodGet {
+		return methodNotAllowed(w, "GET")
+	}
+	if h.Watcher == nil {
+		return &apiError{Status: http.StatusServiceUnavailable, Message: "filesystem watcher unavailable", Code: errorCodeWatcherUnavailable}
+	}
+	paths := h.Watcher.WatchedPaths()
+	if paths == nil {
+		paths = []string{}
+	}
+	writeJSON(w, http.StatusOK, watcherPathsResponse{
+		Paths:         paths,
+		ActiveWatches: h.Watcher.Metrics().ActiveWatches,
+	})
+	return nil
+}
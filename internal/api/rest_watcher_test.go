@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gestalt/internal/watcher"
+)
+
+func TestWatcherPathsEndpoint(t *testing.T) {
+	fsWatcher, err := watcher.New()
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer fsWatcher.Close()
+	path := filepath.Join(t.TempDir(), "plan.org")
+	if err := os.WriteFile(path, []byte("* plan\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	handle, err := fsWatcher.Watch(path, func(watcher.Event) {})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer handle.Close()
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, nil, "secret", StatusConfig{Watcher: fsWatcher}, "", nil, nil, nil, nil)
+	get := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/watcher/paths", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := get(""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", rec.Code)
+	}
+	rec := get("secret")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var payload watcherPathsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload.Paths) != 1 || payload.Paths[0] != path || payload.ActiveWatches != 1 {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestWatcherPathsUnavailable(t *testing.T) {
+	handler := &RestHandler{}
+	req := httptest.NewRequest(http.MethodGet, "/api/watcher/paths", nil)
+	rec := httptest.NewRecorder()
+	restHandler("", nil, handler.handleWatcherPaths)(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+}
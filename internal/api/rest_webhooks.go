@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"gestalt/internal/terminal"
+)
+
+// handleTerminalWebhooks lists a session's webhooks or registers a new one.
+// The secret used to sign deliveries is only returned by the POST.
+func (h *RestHandler) handleTerminalWebhooks(w http.ResponseWriter, r *http.Request, id string) *apiError {
+	switch r.Method {
+	case http.MethodGet:
+		hooks, err := h.Manager.SessionWebhooks(id)
+		if err != nil {
+			return webhookError(err)
+		}
+		response := make([]sessionWebhookResponse, 0, len(hooks))
+		for _, hook := range hooks {
+			response = append(response, newSessionWebhookResponse(hook))
+		}
+		writeJSON(w, http.StatusOK, response)
+		return nil
+	case http.MethodPost:
+		if r.Body == nil {
+			return &apiError{Status: http.StatusBadRequest, Message: "invalid request body", Code: errorCodeInvalidBody}
+		}
+		var request sessionWebhookRequest
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			return invalidBodyError(err)
+		}
+		hook, err := h.Manager.AddSessionWebhook(id, terminal.WebhookOptions{
+			URL:    request.URL,
+			Events: request.Events,
+			Secret: request.Secret,
+		})
+		if err != nil {
+			return webhookError(err)
+		}
+		writeJSON(w, http.StatusCreated, newSessionWebhookResponse(hook))
+		return nil
+	default:
+		return methodNotAllowed(w, "GET, POST")
+	}
+}
+
+func (h *RestHandler) handleTerminalWebhook(w http.ResponseWriter, r *http.Request, id, webhookID string) *apiError {
+	if r.Method != http.MethodDelete {
+		return methodNotAllowed(w, "DELETE")
+	}
+	if err := h.Manager.RemoveSessionWebhook(id, webhookID); err != nil {
+		return webhookError(err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func webhookError(err error) *apiError {
+	switch {
+	case errors.Is(err, terminal.ErrSessionNotFound):
+		return &apiError{Status: http.StatusNotFound, Message: "terminal not found", Code: errorCodeTerminalNotFound}
+	case errors.Is(err, terminal.ErrWebhookNotFound):
+		return &apiError{Status: http.StatusNotFound, Message: "webhook not found", Code: errorCodeWebhookNotFound}
+	case errors.Is(err, terminal.ErrInvalidWebhook):
+		return fieldValidationError(http.StatusBadRequest, "url", err.Error())
+	case errors.Is(err, terminal.ErrWebhookLimit):
+		return &apiError{Status: http.StatusConflict, Message: err.Error(), Code: errorCodeWebhookLimit}
+	default:
+		return &apiError{Status: http.StatusInternalServerError, Message: "failed to update webhooks"}
+	}
+}
+
+func newSessionWebhookResponse(hook terminal.SessionWebhook) sessionWebhookResponse {
+	events := hook.Events
+	if events == nil {
+		events = []string{}
+	}
+	return sessionWebhookResponse{
+		ID:        hook.ID,
+		SessionID: hook.SessionID,
+		URL:       hook.URL,
+		Events:    events,
+		Secret:    hook.Secret,
+		CreatedAt: hook.CreatedAt,
+	}
+}
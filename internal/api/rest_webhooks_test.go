@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gestalt/internal/terminal"
+)
+
+func TestTerminalWebhooksEndpoint(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	created, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create terminal: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(created.ID)
+	}()
+
+	handler := &RestHandler{Manager: manager}
+	call := func(method, path, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		res := httptest.NewRecorder()
+		restHandler("", nil, handler.handleTerminal)(res, req)
+		return res
+	}
+	base := terminalPath(created.ID) + "/webhooks"
+
+	res := call(http.MethodPost, base, `{"url":"not a url"}`)
+	if res.Code != http.StatusBadRequest || decodeErrorCode(t, res) != errorCodeValidationFailed {
+		t.Fatalf("expected validation_failed, got %d %s", res.Code, res.Body.String())
+	}
+
+	res = call(http.MethodPost, base, `{"url":"https://example.com/hook","events":["terminal_stopped"]}`)
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d %s", res.Code, res.Body.String())
+	}
+	var hook sessionWebhookResponse
+	if err := json.NewDecoder(res.Body).Decode(&hook); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if hook.ID == "" || hook.Secret == "" || hook.SessionID != created.ID || len(hook.Events) != 1 {
+		t.Fatalf("unexpected webhook: %+v", hook)
+	}
+
+	res = call(http.MethodGet, base, "")
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", res.Code, res.Body.String())
+	}
+	var listed []sessionWebhookResponse
+	if err := json.NewDecoder(res.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != hook.ID || listed[0].Secret != "" {
+		t.Fatalf("unexpected webhook list: %+v", listed)
+	}
+
+	res = call(http.MethodDelete, base+"/"+hook.ID, "")
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d %s", res.Code, res.Body.String())
+	}
+	res = call(http.MethodDelete, base+"/"+hook.ID, "")
+	if res.Code != http.StatusNotFound || decodeErrorCode(t, res) != errorCodeWebhookNotFound {
+		t.Fatalf("expected webhook_not_found, got %d %s", res.Code, res.Body.String())
+	}
+	res = call(http.MethodGet, terminalPath("missing")+"/webhooks", "")
+	if res.Code != http.StatusNotFound || decodeErrorCode(t, res) != errorCodeTerminalNotFound {
+		t.Fatalf("expected terminal_not_found, got %d %s", res.Code, res.Body.String())
+	}
+}
@@ -23,6 +23,17 @@ type StatusConfig struct {
 	SessionFontSize        string
 	SessionInputFontFamily string
 	SessionInputFontSize   string
+	// AllowMetricsReset registers POST /api/metrics/reset.
+	AllowMetricsReset bool
+	// InputFileRoot enables {"file": ...} session input for files under this
+	// directory. Empty disables file input.
+	InputFileRoot string
+	// Watcher backs GET /api/watcher/paths. Nil reports the watcher as
+	// unavailable.
+	Watcher *watcher.Watcher
+	// DefaultAgentPath backs /api/agents/default. Empty keeps no default and
+	// rejects updates.
+	DefaultAgentPath string
 }
 
 func RegisterRoutes(mux *http.ServeMux, manager *terminal.Manager, authToken string, statusConfig StatusConfig, staticDir string, frontendFS fs.FS, logger *logging.Logger, eventBus *event.Bus[watcher.Event], flowService *flow.Service) {
@@ -52,6 +63,10 @@ func RegisterRoutes(mux *http.ServeMux, manager *terminal.Manager, authToken str
 		SessionFontSize:        statusConfig.SessionFontSize,
 		SessionInputFontFamily: statusConfig.SessionInputFontFamily,
 		SessionInputFontSize:   statusConfig.SessionInputFontSize,
+		AuthToken:              authToken,
+		InputFileRoot:          statusConfig.InputFileRoot,
+		Watcher:                statusConfig.Watcher,
+		DefaultAgentPath:       statusConfig.DefaultAgentPath,
 	}
 	meter := otelapi.GetMeterProvider().Meter("gestalt/api")
 	tracer := otelapi.Tracer("gestalt/api")
@@ -69,7 +84,7 @@ func RegisterRoutes(mux *http.ServeMux, manager *terminal.Manager, authToken str
 		instrument = func(next http.Handler) http.Handler { return next }
 	}
 	wrap := func(route, category, operation string, handler http.Handler) http.Handler {
-		return otel.WithRouteInfo(instrument(loggingMiddleware(logger, handler)), otel.RouteInfo{
+		return otel.WithRouteInfo(instrument(routeMetricsMiddleware(loggingMiddleware(logger, handler))), otel.RouteInfo{
 			Route:     route,
 			Category:  category,
 			Operation: operation,
@@ -111,6 +126,12 @@ func RegisterRoutes(mux *http.ServeMux, manager *terminal.Manager, authToken str
 		Logger:    logger,
 		AuthToken: authToken,
 	}))
+	mux.Handle("/api/stream", securityHeadersMiddleware(cacheControlNoStore, &StreamHandler{
+		Manager:   manager,
+		Bus:       eventBus,
+		Logger:    logger,
+		AuthToken: authToken,
+	}))
 	mux.Handle("/api/events/stream", securityHeadersMiddleware(cacheControlNoStore, &EventsSSEHandler{
 		Manager:   manager,
 		Bus:       eventBus,
@@ -133,15 +154,33 @@ func RegisterRoutes(mux *http.ServeMux, manager *terminal.Manager, authToken str
 	}))
 
 	mux.Handle("/api/status", wrap("/api/status", "status", "read", restHandler(authToken, logger, rest.handleStatus)))
+	mux.Handle("/api/metrics/routes", wrap("/api/metrics/routes", "status", "query", restHandler(authToken, logger, rest.handleMetricsRoutes)))
 	mux.Handle("/api/metrics/summary", wrap("/api/metrics/summary", "status", "query", restHandler(authToken, logger, rest.handleMetricsSummary)))
+	if statusConfig.AllowMetricsReset {
+		mux.Handle("/api/metrics/reset", wrap("/api/metrics/reset", "status", "update", restHandler(authToken, logger, rest.handleMetricsReset)))
+	}
+	mux.Handle("/api/diagnostics/snapshot", wrap("/api/diagnostics/snapshot", "status", "read", restHandler(authToken, logger, rest.handleDiagnosticsSnapshot)))
+	mux.Handle("/api/watcher/paths", wrap("/api/watcher/paths", "status", "read", restHandler(authToken, logger, rest.handleWatcherPaths)))
 	mux.Handle("/api/git/log", wrap("/api/git/log", "status", "query", restHandler(authToken, logger, rest.handleGitLog)))
 	mux.Handle("/api/agents", wrap("/api/agents", "agents", "read", restHandler(authToken, logger, rest.handleAgents)))
+	mux.Handle("/api/agents/default", wrap("/api/agents/default", "agents", "auto", restHandler(authToken, logger, rest.handleDefaultAgent)))
+	mux.Handle("/api/agents/", wrap("/api/agents/:name", "agents", "read", restHandler(authToken, logger, rest.handleAgent)))
 	mux.Handle("/api/skills", wrap("/api/skills", "skills", "read", restHandler(authToken, logger, rest.handleSkills)))
+	mux.Handle("/api/schema/", wrap("/api/schema/:name", "config", "read", restHandler(authToken, logger, rest.handleSchema)))
+	mux.Handle("/api/logs/batch", wrap("/api/logs/batch", "logs", "create", restHandler(authToken, logger, rest.handleOTelLogsBatch)))
+	mux.Handle("/api/logs/", wrap("/api/logs/:id", "logs", "read", restHandler(authToken, logger, rest.handleLogEntry)))
 	mux.Handle("/api/otel/logs", wrap("/api/otel/logs", "logs", "create", restHandler(authToken, logger, rest.handleOTelLogs)))
+	mux.Handle("/api/otel/logs/batch", wrap("/api/otel/logs/batch", "logs", "create", restHandler(authToken, logger, rest.handleOTelLogsBatch)))
 	mux.Handle("/api/otel/traces", wrap("/api/otel/traces", "traces", "query", restHandler(authToken, logger, rest.handleOTelTraces)))
 	mux.Handle("/api/otel/metrics", wrap("/api/otel/metrics", "metrics", "query", restHandler(authToken, logger, rest.handleOTelMetrics)))
 	mux.Handle("/api/sessions", wrap("/api/sessions", "sessions", "auto", restHandler(authToken, logger, rest.handleTerminals)))
+	mux.Handle("/api/sessions/stop-all", wrap("/api/sessions/stop-all", "sessions", "update", restHandler(authToken, logger, rest.handleStopAllTerminals)))
+	mux.Handle("/api/sessions/logs", wrap("/api/sessions/logs", "sessions", "read", restHandler(authToken, logger, rest.handleSessionLogFiles)))
+	mux.Handle("/api/sessions/logs/", wrap("/api/sessions/logs/:id", "sessions", "read", restHandler(authToken, logger, rest.handleSessionLogTail)))
+	mux.Handle("/api/sessions/capabilities", wrap("/api/sessions/capabilities", "sessions", "read", restHandler(authToken, logger, rest.handleTerminalCapabilities)))
 	mux.Handle("/api/sessions/", wrap("/api/sessions/:id", "sessions", "auto", restHandler(authToken, logger, rest.handleTerminal)))
+	mux.Handle("/api/search", wrap("/api/search", "sessions", "query", restHandler(authToken, logger, rest.handleSearch)))
+	mux.Handle("/api/progress", wrap("/api/progress", "sessions", "read", restHandler(authToken, logger, rest.handleProgress)))
 	mux.Handle("/api/plans", wrap("/api/plans", "plan", "read", restHandler(authToken, logger, rest.handlePlansList)))
 	mux.Handle("/api/flow/activities", wrap("/api/flow/activities", "flow", "read", restHandler(authToken, logger, rest.handleFlowActivities)))
 	mux.Handle("/api/flow/event-types", wrap("/api/flow/event-types", "flow", "read", restHandler(authToken, logger, rest.handleFlowEventTypes)))
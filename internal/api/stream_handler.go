@@ -0,0 +1,375 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gestalt/internal/event"
+	"gestalt/internal/logging"
+	"gestalt/internal/terminal"
+	"gestalt/internal/watcher"
+
+	"github.com/gorilla/websocket"
+)
+
+// streamQueueSize bounds the per-connection frame queue. All feeds share it, so
+// a slow client applies backpressure to every subscription at once.
+const streamQueueSize = 256
+
+const (
+	streamActionSubscribe   = "subscribe"
+	streamActionUnsubscribe = "unsubscribe"
+
+	streamFrameOutput       = "output"
+	streamFrameEvent        = "event"
+	streamFrameSubscribed   = "subscribed"
+	streamFrameUnsubscribed = "unsubscribed"
+	streamFrameClosed       = "closed"
+	streamFrameError        = "error"
+	streamFrameResume       = "resume"
+	streamFrameResumed      = "resumed"
+)
+
+// StreamHandler multiplexes session output and events over one websocket.
+type StreamHandler struct {
+	Manager        *terminal.Manager
+	Bus            *event.Bus[watcher.Event]
+	Logger         *logging.Logger
+	AuthToken      string
+	AllowedOrigins []string
+}
+
+type streamControlMessage struct {
+	Action   string   `json:"action"`
+	Sessions []string `json:"sessions,omitempty"`
+	Events   []string `json:"events,omitempty"`
+}
+
+type streamFrame struct {
+	Type      string         `json:"type"`
+	SessionID string         `json:"session_id,omitempty"`
+	EventType string         `json:"event_type,omitempty"`
+	Timestamp time.Time      `json:"timestamp,omitzero"`
+	Output    []byte         `json:"output,omitempty"`
+	Path      string         `json:"path,omitempty"`
+	Data      map[string]any `json:"data,omitempty"`
+	Code      string         `json:"code,omitempty"`
+	Message   string         `json:"message,omitempty"`
+}
+
+type streamConnection struct {
+	manager *terminal.Manager
+	frames  chan streamFrame
+	done    chan struct{}
+
+	mu         sync.Mutex
+	sessions   map[string]*streamSubscription
+	eventTypes map[string]struct{}
+	// eventTime is the timestamp of the last queued event, or the connection
+	// start, and bounds the events replayed on resume.
+	eventTime time.Time
+	// changed is set when frames were queued since the last resume token.
+	changed atomic.Bool
+}
+
+type streamSubscription struct {
+	cancel func()
+	// cursor counts the complete output lines queued to the client.
+	cursor atomic.Int64
+}
+
+func (h *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !requireWSToken(w, r, h.AuthToken, h.Logger) {
+		return
+	}
+
+	conn, err := upgradeWebSocket(w, r, h.AllowedOrigins)
+	if err != nil {
+		logWSError(h.Logger, r, wsError{
+			Status:  http.StatusBadRequest,
+			Message: "websocket upgrade failed",
+			Err:     err,
+		})
+		return
+	}
+	defer conn.Close()
+
+	spanCtx, span := startWebSocketSpan(r, "/api/stream")
+	defer span.End()
+	r = r.WithContext(spanCtx)
+
+	if h.Manager == nil {
+		writeWSError(w, r, conn, h.Logger, wsError{
+			Status:       http.StatusInternalServerError,
+			Code:         errorCodeManagerUnavailable,
+			Message:      "terminal manager unavailable",
+			SendEnvelope: true,
+		})
+		return
+	}
+
+	stream := &streamConnection{
+		manager:    h.Manager,
+		frames:     make(chan streamFrame, streamQueueSize),
+		done:       make(chan struct{}),
+		sessions:   make(map[string]*streamSubscription),
+		eventTypes: make(map[string]struct{}),
+		eventTime:  time.Now().UTC(),
+	}
+	defer stream.close()
+
+	writer, err := startWSWriteLoop(w, r, wsStreamConfig[streamFrame]{
+		Conn:   conn,
+		Output: stream.frames,
+		Logger: h.Logger,
+	})
+	if err != nil {
+		writeWSError(w, r, conn, h.Logger, wsError{
+			Status:       http.StatusInternalServerError,
+			Message:      "stream unavailable",
+			Err:          err,
+			SendEnvelope: true,
+		})
+		return
+	}
+	defer writer.Stop()
+
+	terminalBus := h.Manager.TerminalBus()
+	if terminalBus != nil {
+		events, cancel := terminalBus.SubscribeFiltered(func(terminalEvent event.TerminalEvent) bool {
+			return stream.wantsEvent(terminalEvent.Type())
+		})
+		defer cancel()
+		go stream.forwardTerminalEvents(events)
+	}
+	if h.Bus != nil {
+		events, cancel := h.Bus.SubscribeFiltered(func(watcherEvent watcher.Event) bool {
+			return stream.wantsEvent(watcherEvent.Type)
+		})
+		defer cancel()
+		go stream.forwardWatcherEvents(events)
+	}
+
+	if token := r.URL.Query().Get("resume"); token != "" {
+		state, err := decodeResumeToken(token, time.Now().UTC())
+		if err != nil {
+			stream.send(streamFrame{Type: streamFrameError, Code: errorCodeInvalidParameter, Message: "resume token invalid or expired"})
+		} else {
+			stream.resume(r, state, terminalBus)
+		}
+	}
+	stream.sendResumeToken()
+	go stream.refreshResumeTokens(streamResumeInterval)
+
+	for {
+		msgType, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if msgType != websocket.TextMessage {
+			continue
+		}
+		var control streamControlMessage
+		if err := json.Unmarshal(msg, &control); err != nil {
+			stream.send(streamFrame{Type: streamFrameError, Code: errorCodeInvalidBody, Message: "invalid control message"})
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(control.Action)) {
+		case streamActionSubscribe:
+			stream.subscribe(control)
+			stream.sendResumeToken()
+		case streamActionUnsubscribe:
+			stream.unsubscribe(control)
+			stream.sendResumeToken()
+		default:
+			stream.send(streamFrame{Type: streamFrameError, Code: errorCodeInvalidRequest, Message: "unknown action"})
+		}
+	}
+}
+
+func (s *streamConnection) subscribe(control streamControlMessage) {
+	s.mu.Lock()
+	for _, eventType := range control.Events {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			s.eventTypes[eventType] = struct{}{}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, id := range control.Sessions {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		session, ok := s.manager.Get(id)
+		if !ok {
+			s.send(streamFrame{Type: streamFrameError, SessionID: id, Code: errorCodeTerminalNotFound, Message: "terminal not found"})
+			continue
+		}
+
+		s.mu.Lock()
+		if _, exists := s.sessions[id]; exists {
+			s.mu.Unlock()
+			continue
+		}
+		// Read the cursor before subscribing: lines published in between are
+		// never delivered, so a resume should replay them.
+		cursor := session.OutputCursor()
+		output, cancel := session.Subscribe()
+		subscription := &streamSubscription{cancel: cancel}
+		subscription.cursor.Store(cursor)
+		s.sessions[id] = subscription
+		s.mu.Unlock()
+
+		go s.forwardOutput(id, subscription, output)
+	}
+	s.send(streamFrame{Type: streamFrameSubscribed, Data: s.snapshot()})
+}
+
+func (s *streamConnection) unsubscribe(control streamControlMessage) {
+	s.mu.Lock()
+	for _, eventType := range control.Events {
+		delete(s.eventTypes, strings.TrimSpace(eventType))
+	}
+	var cancels []func()
+	for _, id := range control.Sessions {
+		id = strings.TrimSpace(id)
+		if subscription, ok := s.sessions[id]; ok {
+			cancels = append(cancels, subscription.cancel)
+			delete(s.sessions, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	s.send(streamFrame{Type: streamFrameUnsubscribed, Data: s.snapshot()})
+}
+
+func (s *streamConnection) snapshot() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		sessions = append(sessions, id)
+	}
+	events := make([]string, 0, len(s.eventTypes))
+	for eventType := range s.eventTypes {
+		events = append(events, eventType)
+	}
+	return map[string]any{
+		"sessions": sessions,
+		"events":   events,
+	}
+}
+
+func (s *streamConnection) wantsEvent(eventType string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.eventTypes["*"]; ok {
+		return true
+	}
+	_, ok := s.eventTypes[eventType]
+	return ok
+}
+
+func (s *streamConnection) forwardOutput(id string, subscription *streamSubscription, output <-chan []byte) {
+	for chunk := range output {
+		frame := streamFrame{
+			Type:      streamFrameOutput,
+			SessionID: id,
+			Timestamp: time.Now().UTC(),
+			Output:    chunk,
+		}
+		if !s.send(frame) {
+			return
+		}
+		subscription.cursor.Add(terminal.CursorAdvance(chunk))
+		s.changed.Store(true)
+	}
+
+	// The output channel closes when the session ends or the client
+	// unsubscribes; only report sessions that are still subscribed.
+	s.mu.Lock()
+	current, subscribed := s.sessions[id]
+	subscribed = subscribed && current == subscription
+	if subscribed {
+		delete(s.sessions, id)
+	}
+	s.mu.Unlock()
+	if subscribed {
+		subscription.cancel()
+		s.send(streamFrame{Type: streamFrameClosed, SessionID: id})
+	}
+}
+
+func (s *streamConnection) forwardTerminalEvents(events <-chan event.TerminalEvent) {
+	for terminalEvent := range events {
+		frame := streamFrame{
+			Type:      streamFrameEvent,
+			SessionID: terminalEvent.TerminalID,
+			EventType: terminalEvent.Type(),
+			Timestamp: terminalEvent.Timestamp(),
+			Data:      terminalEvent.Data,
+		}
+		if frame.Timestamp.IsZero() {
+			frame.Timestamp = time.Now().UTC()
+		}
+		if !s.send(frame) {
+			return
+		}
+		s.noteEvent(frame.Timestamp)
+	}
+}
+
+func (s *streamConnection) forwardWatcherEvents(events <-chan watcher.Event) {
+	for watcherEvent := range events {
+		frame := streamFrame{
+			Type:      streamFrameEvent,
+			EventType: watcherEvent.Type,
+			Timestamp: watcherEvent.Timestamp,
+			Path:      watcherEvent.Path,
+		}
+		if frame.Timestamp.IsZero() {
+			frame.Timestamp = time.Now().UTC()
+		}
+		if !s.send(frame) {
+			return
+		}
+	}
+}
+
+// send queues a frame, blocking while the queue is full. It returns false once
+// the connection is closed.
+func (s *streamConnection) send(frame streamFrame) bool {
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+	select {
+	case s.frames <- frame:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+func (s *streamConnection) close() {
+	close(s.done)
+	s.mu.Lock()
+	cancels := make([]func(), 0, len(s.sessions))
+	for id, subscription := range s.sessions {
+		cancels = append(cancels, subscription.cancel)
+		delete(s.sessions, id)
+	}
+	s.mu.Unlock()
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gestalt/internal/terminal"
+
+	"github.com/gorilla/websocket"
+)
+
+func readStreamFrame(t *testing.T, conn *websocket.Conn, frameType string) streamFrame {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_ = conn.SetReadDeadline(deadline)
+		var frame streamFrame
+		if err := conn.ReadJSON(&frame); err != nil {
+			t.Fatalf("read %s frame: %v", frameType, err)
+		}
+		if frame.Type == frameType {
+			return frame
+		}
+	}
+}
+
+func TestStreamWebSocketMultiplexesSessions(t *testing.T) {
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	session, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(session.ID)
+	}()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("skipping websocket test (listener unavailable): %v", err)
+	}
+	server := &httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: &StreamHandler{Manager: manager}},
+	}
+	server.Start()
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/stream"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial websocket: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(streamControlMessage{Action: "subscribe", Sessions: []string{session.ID, "missing"}, Events: []string{"labels_updated"}}); err != nil {
+		t.Fatalf("write subscribe: %v", err)
+	}
+	missing := readStreamFrame(t, conn, streamFrameError)
+	if missing.SessionID != "missing" {
+		t.Fatalf("expected error for missing session, got %+v", missing)
+	}
+	readStreamFrame(t, conn, streamFrameSubscribed)
+
+	session.PublishOutputChunk([]byte("hello\n"))
+	output := readStreamFrame(t, conn, streamFrameOutput)
+	if output.SessionID != session.ID || string(output.Output) != "hello\n" {
+		t.Fatalf("unexpected output frame: %+v", output)
+	}
+
+	if _, err := manager.UpdateSessionLabels(session.ID, map[string]string{"team": "core"}); err != nil {
+		t.Fatalf("update labels: %v", err)
+	}
+	evt := readStreamFrame(t, conn, streamFrameEvent)
+	if evt.EventType != "labels_updated" || evt.SessionID != session.ID {
+		t.Fatalf("unexpected event frame: %+v", evt)
+	}
+
+	if err := conn.WriteJSON(streamControlMessage{Action: "unsubscribe", Sessions: []string{session.ID}}); err != nil {
+		t.Fatalf("write unsubscribe: %v", err)
+	}
+	unsubscribed := readStreamFrame(t, conn, streamFrameUnsubscribed)
+	if sessions, _ := unsubscribed.Data["sessions"].([]any); len(sessions) != 0 {
+		t.Fatalf("expected no sessions after unsubscribe, got %v", sessions)
+	}
+}
+
+func TestStreamWebSocketAuth(t *testing.T) {
+	handler := &StreamHandler{AuthToken: "secret"}
+	req := httptest.NewRequest(http.MethodGet, "/api/stream", nil)
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	if res.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", res.Code)
+	}
+}
+
+func TestStreamWebSocketResumeReplaysMissedOutput(t *testing.T) {
+	previousInterval := streamResumeInterval
+	streamResumeInterval = 20 * time.Millisecond
+	t.Cleanup(func() {
+		streamResumeInterval = previousInterval
+	})
+
+	manager := newTestManager(terminal.ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+	})
+	session, err := manager.Create(testAgentID, "", "")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(session.ID)
+	}()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("skipping websocket test (listener unavailable): %v", err)
+	}
+	server := &httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: &StreamHandler{Manager: manager}},
+	}
+	server.Start()
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/stream"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial websocket: %v", err)
+	}
+	if err := conn.WriteJSON(streamControlMessage{Action: "subscribe", Sessions: []string{session.ID}, Events: []string{"labels_updated"}}); err != nil {
+		t.Fatalf("write subscribe: %v", err)
+	}
+	readStreamFrame(t, conn, streamFrameSubscribed)
+
+	session.PublishOutputChunk([]byte("one\n"))
+	readStreamFrame(t, conn, streamFrameOutput)
+	delivered := session.OutputCursor()
+	var token string
+	for token == "" {
+		frame := readStreamFrame(t, conn, streamFrameResume)
+		candidate, _ := frame.Data["token"].(string)
+		state, err := decodeResumeToken(candidate, time.Now().UTC())
+		if err != nil {
+			t.Fatalf("decode resume token: %v", err)
+		}
+		if state.Sessions[session.ID] == delivered {
+			token = candidate
+		}
+	}
+	_ = conn.Close()
+
+	session.PublishOutputChunk([]byte("two\n"))
+	if !waitForOutputLines(session, 4, time.Second) {
+		t.Fatalf("expected output buffer to receive data")
+	}
+	if _, err := manager.UpdateSessionLabels(session.ID, map[string]string{"team": "core"}); err != nil {
+		t.Fatalf("update labels: %v", err)
+	}
+
+	resumed, _, err := websocket.DefaultDialer.Dial(wsURL+"?resume="+url.QueryEscape(token), nil)
+	if err != nil {
+		t.Fatalf("dial websocket: %v", err)
+	}
+	defer resumed.Close()
+	output := readStreamFrame(t, resumed, streamFrameOutput)
+	if output.SessionID != session.ID || string(output.Output) != "two\n" {
+		t.Fatalf("expected only missed output, got %+v", output)
+	}
+	evt := readStreamFrame(t, resumed, streamFrameEvent)
+	if evt.EventType != "labels_updated" || evt.SessionID != session.ID {
+		t.Fatalf("expected missed event, got %+v", evt)
+	}
+	done := readStreamFrame(t, resumed, streamFrameResumed)
+	if sessions, _ := done.Data["sessions"].([]any); len(sessions) != 1 {
+		t.Fatalf("expected resumed subscription, got %v", done.Data)
+	}
+
+	session.PublishOutputChunk([]byte("three\n"))
+	live := readStreamFrame(t, resumed, streamFrameOutput)
+	if string(live.Output) != "three\n" {
+		t.Fatalf("expected live output after resume, got %q", live.Output)
+	}
+
+	rejected, _, err := websocket.DefaultDialer.Dial(wsURL+"?resume=forged.token", nil)
+	if err != nil {
+		t.Fatalf("dial websocket: %v", err)
+	}
+	defer rejected.Close()
+	if frame := readStreamFrame(t, rejected, streamFrameError); frame.Code != errorCodeInvalidParameter {
+		t.Fatalf("expected invalid token error, got %+v", frame)
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"gestalt/internal/event"
+)
+
+// streamResumeTTL bounds how long after it was issued a token is accepted.
+const streamResumeTTL = 5 * time.Minute
+
+// streamResumeInterval is how often a fresh token is sent while frames are
+// flowing.
+var streamResumeInterval = 5 * time.Second
+
+var errResumeTokenInvalid = errors.New("invalid resume token")
+
+// resumeTokenKey signs resume tokens. It is generated per process, so tokens
+// do not survive a restart; neither do the output cursors they carry.
+var resumeTokenKey = sync.OnceValue(func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic("resume token key: " + err.Error())
+	}
+	return key
+})
+
+// streamResumeState is what a resume token carries: the output cursor (count
+// of complete lines delivered) per subscribed session, the subscribed event
+// types, and the time of the last delivered event.
+type streamResumeState struct {
+	Sessions  map[string]int64 `json:"sessions"`
+	Events    []string         `json:"events,omitempty"`
+	EventTime time.Time        `json:"event_time,omitzero"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+func encodeResumeToken(state streamResumeState) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + signResumePayload(encoded), nil
+}
+
+func decodeResumeToken(token string, now time.Time) (streamResumeState, error) {
+	encoded, signature, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signResumePayload(encoded))) {
+		return streamResumeState{}, errResumeTokenInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return streamResumeState{}, errResumeTokenInvalid
+	}
+	var state streamResumeState
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return streamResumeState{}, errResumeTokenInvalid
+	}
+	if now.After(state.ExpiresAt) {
+		return streamResumeState{}, errResumeTokenInvalid
+	}
+	return state, nil
+}
+
+func signResumePayload(encoded string) string {
+	mac := hmac.New(sha256.New, resumeTokenKey())
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// resume restores the subscriptions in state, replaying output after each
+// session's cursor and buffered terminal events newer than the last one the
+// client saw. The partial last line is always resent, since the cursor only
+// counts complete lines.
+func (s *streamConnection) resume(r *http.Request, state streamResumeState, terminalBus *event.Bus[event.TerminalEvent]) {
+	s.mu.Lock()
+	for _, eventType := range state.Events {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			s.eventTypes[eventType] = struct{}{}
+		}
+	}
+	if !state.EventTime.IsZero() {
+		s.eventTime = state.EventTime
+	}
+	s.mu.Unlock()
+
+	for id, cursor := range state.Sessions {
+		session, ok := s.manager.Get(id)
+		if !ok {
+			s.send(streamFrame{Type: streamFrameError, SessionID: id, Code: errorCodeTerminalNotFound, Message: "terminal not found"})
+			continue
+		}
+		s.mu.Lock()
+		if _, exists := s.sessions[id]; exists {
+			s.mu.Unlock()
+			continue
+		}
+		// As in subscribe, read before subscribing so the cursor never
+		// counts lines the client has not been sent.
+		lines, next, partial := session.OutputSince(r.Context(), cursor, 0)
+		output, cancel := session.Subscribe()
+		subscription := &streamSubscription{cancel: cancel}
+		subscription.cursor.Store(next)
+		s.sessions[id] = subscription
+		s.mu.Unlock()
+
+		if replay := joinReplayLines(lines, partial); replay != "" {
+			s.send(streamFrame{Type: streamFrameOutput, SessionID: id, Timestamp: time.Now().UTC(), Output: []byte(replay)})
+		}
+		go s.forwardOutput(id, subscription, output)
+	}
+
+	if len(state.Events) > 0 && !state.EventTime.IsZero() {
+		for _, terminalEvent := range terminalBus.DumpHistory() {
+			if !terminalEvent.Timestamp().After(state.EventTime) || !s.wantsEvent(terminalEvent.Type()) {
+				continue
+			}
+			s.send(streamFrame{
+				Type:      streamFrameEvent,
+				SessionID: terminalEvent.TerminalID,
+				EventType: terminalEvent.Type(),
+				Timestamp: terminalEvent.Timestamp(),
+				Data:      terminalEvent.Data,
+			})
+			s.noteEvent(terminalEvent.Timestamp())
+		}
+	}
+	s.send(streamFrame{Type: streamFrameResumed, Data: s.snapshot()})
+}
+
+// joinReplayLines rebuilds output from buffered lines. The buffer records an
+// empty line after each chunk that ends in a newline, so joining with "\n"
+// restores that newline rather than adding another.
+func joinReplayLines(lines []string, partial string) string {
+	replay := strings.Join(lines, "\n")
+	if partial == "" {
+		return replay
+	}
+	if len(lines) > 0 && lines[len(lines)-1] != "" {
+		replay += "\n"
+	}
+	return replay + partial
+}
+
+func (s *streamConnection) noteEvent(at time.Time) {
+	s.mu.Lock()
+	if at.After(s.eventTime) {
+		s.eventTime = at
+	}
+	s.mu.Unlock()
+	s.changed.Store(true)
+}
+
+// sendResumeToken queues a token describing what has been queued so far.
+// Frames are written in order, so by the time the client reads the token it
+// has received everything the token accounts for.
+func (s *streamConnection) sendResumeToken() {
+	s.changed.Store(false)
+	expiresAt := time.Now().UTC().Add(streamResumeTTL)
+	state := streamResumeState{
+		Sessions:  map[string]int64{},
+		ExpiresAt: expiresAt,
+	}
+	s.mu.Lock()
+	for id, subscription := range s.sessions {
+		state.Sessions[id] = subscription.cursor.Load()
+	}
+	for eventType := range s.eventTypes {
+		state.Events = append(state.Events, eventType)
+	}
+	state.EventTime = s.eventTime
+	s.mu.Unlock()
+
+	token, err := encodeResumeToken(state)
+	if err != nil {
+		return
+	}
+	s.send(streamFrame{
+		Type: streamFrameResume,
+		Data: map[string]any{
+			"token":      token,
+			"expires_at": expiresAt,
+		},
+	})
+}
+
+// refreshResumeTokens sends a new token every interval while frames flow, so
+// the client's latest token stays close to what it has received.
+func (s *streamConnection) refreshResumeTokens(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if s.changed.Load() {
+				s.sendResumeToken()
+			}
+		}
+	}
+}
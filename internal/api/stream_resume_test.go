@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResumeTokenRoundTripAndExpiry(t *testing.T) {
+	now := time.Now().UTC()
+	token, err := encodeResumeToken(streamResumeState{
+		Sessions:  map[string]int64{"Coder 1": 42},
+		Events:    []string{"labels_updated"},
+		ExpiresAt: now.Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("encode token: %v", err)
+	}
+	state, err := decodeResumeToken(token, now)
+	if err != nil {
+		t.Fatalf("decode token: %v", err)
+	}
+	if state.Sessions["Coder 1"] != 42 || len(state.Events) != 1 {
+		t.Fatalf("unexpected state %+v", state)
+	}
+	if _, err := decodeResumeToken(token, now.Add(2*time.Minute)); err == nil {
+		t.Fatalf("expected expired token to be rejected")
+	}
+	if _, err := decodeResumeToken("x"+token, now); err == nil {
+		t.Fatalf("expected tampered token to be rejected")
+	}
+}
+
+func TestJoinReplayLines(t *testing.T) {
+	cases := []struct {
+		lines   []string
+		partial string
+		want    string
+	}{
+		{lines: []string{"two", ""}, want: "two\n"},
+		{lines: []string{"two"}, partial: "thr", want: "two\nthr"},
+		{lines: []string{"two", ""}, partial: "x", want: "two\nx"},
+		{partial: "prompt$ ", want: "prompt$ "},
+		{want: ""},
+	}
+	for _, tc := range cases {
+		if got := joinReplayLines(tc.lines, tc.partial); got != tc.want {
+			t.Fatalf("joinReplayLines(%q, %q) = %q, want %q", tc.lines, tc.partial, got, tc.want)
+		}
+	}
+}
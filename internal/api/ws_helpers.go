@@ -31,6 +31,7 @@ type wsStreamConfig[T any] struct {
 type wsError struct {
 	Status       int
 	CloseCode    int
+	Code         string
 	Message      string
 	Err          error
 	SendEnvelope bool
@@ -39,6 +40,7 @@ type wsError struct {
 type wsErrorPayload struct {
 	Type      string `json:"type"`
 	Message   string `json:"message"`
+	Code      string `json:"code,omitempty"`
 	Status    int    `json:"status"`
 	CloseCode int    `json:"close_code,omitempty"`
 }
@@ -214,10 +216,15 @@ func writeWSError(w http.ResponseWriter, r *http.Request, conn *websocket.Conn,
 
 	deadline := time.Now().Add(wsWriteTimeout)
 	if wsErr.SendEnvelope {
+		code := wsErr.Code
+		if code == "" {
+			code = errorCodeForStatus(status)
+		}
 		_ = conn.SetWriteDeadline(deadline)
 		_ = conn.WriteJSON(wsErrorPayload{
 			Type:      "error",
 			Message:   reason,
+			Code:      code,
 			Status:    status,
 			CloseCode: closeCode,
 		})
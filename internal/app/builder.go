@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/fs"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -23,10 +24,13 @@ type BuildOptions struct {
 	ConfigOverlay        fs.FS
 	ConfigRoot           string
 	AgentsDir            string
+	ConfigLayers         []string
+	ConfigFile           string
 	ProcessRegistry      *process.Registry
 	SessionLogDir        string
 	InputHistoryDir      string
 	SessionRetentionDays int
+	SessionDirMaxBytes   int64
 	BufferLines          int
 	SessionLogMaxBytes   int64
 	HistoryScanMaxBytes  int64
@@ -34,6 +38,10 @@ type BuildOptions struct {
 	TUIMode              string
 	TUISnapshotInterval  time.Duration
 	PortResolver         ports.PortResolver
+	RedactPatterns       []string
+	OutputRateLimit      int64
+	LauncherCheckWindow  time.Duration
+	BellDebounce         time.Duration
 }
 
 type BuildResult struct {
@@ -61,6 +69,9 @@ func (e BuildError) Unwrap() error {
 const (
 	StageLoadSkills = "load_skills"
 	StageLoadAgents = "load_agents"
+	StageLayers     = "config_layers"
+	StageConfigFile = "config_file"
+	StageRedact     = "redact_patterns"
 )
 
 func Build(options BuildOptions) (*BuildResult, error) {
@@ -79,35 +90,101 @@ func Build(options BuildOptions) (*BuildResult, error) {
 		configOverlay = options.ConfigFS
 	}
 
-	skills, err := LoadSkills(options.Logger, options.ConfigFS, options.ConfigRoot)
+	if err := checkConfigLayers(options.ConfigLayers); err != nil {
+		return nil, BuildError{Stage: StageLayers, Err: err}
+	}
+
+	var configFile *ConfigFile
+	if strings.TrimSpace(options.ConfigFile) != "" {
+		loaded, err := LoadConfigFile(options.ConfigFile)
+		if err != nil {
+			return nil, BuildError{Stage: StageConfigFile, Err: err}
+		}
+		configFile = loaded
+	}
+
+	// Config layers sit below the primary config dir: they are merged in
+	// order first, and the primary dir's skills, prompts, and agents win.
+	skills, skillSources, err := mergeLayerSkills(options.Logger, options.ConfigLayers)
+	if err != nil {
+		return nil, BuildError{Stage: StageLoadSkills, Err: err}
+	}
+	primarySkills, err := LoadSkills(options.Logger, options.ConfigFS, options.ConfigRoot)
 	if err != nil {
 		return nil, BuildError{Stage: StageLoadSkills, Err: err}
 	}
+	for name, entry := range primarySkills {
+		skills[name] = entry
+		delete(skillSources, name)
+	}
+	if configFile != nil {
+		skills = configFile.MergeSkills(options.Logger, skills)
+	}
+	skillIndex := BuildSkillIndex(skills)
 
-	agents, err := LoadAgents(options.Logger, configOverlay, options.ConfigRoot, BuildSkillIndex(skills))
+	primaryDir := filepath.Dir(options.AgentsDir)
+	promptFS := layerPromptFS(configOverlay, options.ConfigRoot, options.ConfigLayers)
+	promptDir := path.Join(options.ConfigRoot, "prompts")
+	var agents map[string]agent.Agent
+	var agentDirs map[string]string
+	if len(options.ConfigLayers) == 0 {
+		agents, err = LoadAgents(options.Logger, configOverlay, options.ConfigRoot, skillIndex)
+	} else {
+		agents, agentDirs, err = mergeLayerAgents(options.Logger, options.ConfigLayers, promptFS, promptDir, skillIndex)
+		if err == nil {
+			loader := agent.Loader{Logger: options.Logger, PromptFS: promptFS, Parents: agents}
+			var primaryAgents map[string]agent.Agent
+			primaryAgents, err = loader.Load(configOverlay, path.Join(options.ConfigRoot, "agents"), promptDir, skillIndex)
+			if err == nil {
+				overlayAgents(options.Logger, agents, agentDirs, primaryAgents, primaryDir, "")
+			}
+		}
+	}
 	if err != nil {
 		return nil, BuildError{Stage: StageLoadAgents, Err: err}
 	}
+	if len(options.ConfigLayers) > 0 {
+		agentSources := make(map[string]string, len(agentDirs))
+		for id, dir := range agentDirs {
+			agentSources[id] = filepath.Dir(dir)
+		}
+		logConfigSources(options.Logger, "skill", mapKeys(skills), skillSources, primaryDir)
+		logConfigSources(options.Logger, "agent", mapKeys(agents), agentSources, primaryDir)
+	}
+	if configFile != nil {
+		agents = configFile.MergeAgents(options.Logger, agents, skillIndex)
+	}
+
+	if _, err := terminal.NewOutputRedactor(options.RedactPatterns); err != nil {
+		return nil, BuildError{Stage: StageRedact, Err: err}
+	}
 
 	manager := terminal.NewManager(terminal.ManagerOptions{
 		Shell:                options.Shell,
 		ProcessRegistry:      options.ProcessRegistry,
 		Agents:               agents,
 		AgentsDir:            options.AgentsDir,
+		AgentDirs:            agentDirs,
+		AgentLayerDirs:       layerAgentsDirs(options.ConfigLayers),
 		Skills:               skills,
 		Logger:               options.Logger,
 		SessionLogDir:        options.SessionLogDir,
 		InputHistoryDir:      options.InputHistoryDir,
 		SessionRetentionDays: options.SessionRetentionDays,
+		SessionDirMaxBytes:   options.SessionDirMaxBytes,
 		BufferLines:          options.BufferLines,
 		SessionLogMaxBytes:   options.SessionLogMaxBytes,
 		HistoryScanMaxBytes:  options.HistoryScanMaxBytes,
 		LogCodexEvents:       options.LogCodexEvents,
 		TUIMode:              options.TUIMode,
 		TUISnapshotInterval:  options.TUISnapshotInterval,
-		PromptFS:             configOverlay,
-		PromptDir:            path.Join(options.ConfigRoot, "prompts"),
+		PromptFS:             promptFS,
+		PromptDir:            promptDir,
 		PortResolver:         options.PortResolver,
+		RedactPatterns:       options.RedactPatterns,
+		OutputRateLimit:      options.OutputRateLimit,
+		LauncherCheckWindow:  options.LauncherCheckWindow,
+		BellDebounce:         options.BellDebounce,
 	})
 
 	return &BuildResult{
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gestalt/internal/agent"
+	"gestalt/internal/logging"
+	"gestalt/internal/skill"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is a single merged config file holding agents and inline skills.
+type ConfigFile struct {
+	Path   string
+	Agents []map[string]any
+	Skills []*skill.Skill
+}
+
+type configFileData struct {
+	Agents []map[string]any  `yaml:"agents"`
+	Skills []configFileSkill `yaml:"skills"`
+}
+
+type configFileSkill struct {
+	Name          string         `yaml:"name"`
+	Description   string         `yaml:"description"`
+	License       string         `yaml:"license"`
+	Compatibility string         `yaml:"compatibility"`
+	Metadata      map[string]any `yaml:"metadata"`
+	AllowedTools  []string       `yaml:"allowed_tools"`
+	Content       string         `yaml:"content"`
+	Extends       string         `yaml:"extends"`
+}
+
+// LoadConfigFile reads a gestalt.yaml or gestalt.json file. JSON is parsed
+// with the YAML decoder, which accepts it as a subset.
+func LoadConfigFile(filePath string) (*ConfigFile, error) {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	switch ext {
+	case ".yaml", ".yml", ".json":
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read config file %s: %w", filePath, err)
+	}
+	var parsed configFileData
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", filePath, err)
+	}
+
+	file := &ConfigFile{Path: filePath, Agents: parsed.Agents}
+	seen := make(map[string]struct{}, len(parsed.Skills))
+	for index, entry := range parsed.Skills {
+		entrySkill := &skill.Skill{
+			Name:          strings.TrimSpace(entry.Name),
+			Description:   strings.TrimSpace(entry.Description),
+			License:       strings.TrimSpace(entry.License),
+			Compatibility: strings.TrimSpace(entry.Compatibility),
+			Metadata:      entry.Metadata,
+			AllowedTools:  entry.AllowedTools,
+			Content:       entry.Content,
+			Extends:       strings.TrimSpace(entry.Extends),
+		}
+		if err := entrySkill.Validate(); err != nil {
+			return nil, fmt.Errorf("config file %s: skill %d: %w", filePath, index, err)
+		}
+		if _, ok := seen[entrySkill.Name]; ok {
+			return nil, fmt.Errorf("config file %s: duplicate skill %q", filePath, entrySkill.Name)
+		}
+		seen[entrySkill.Name] = struct{}{}
+		file.Skills = append(file.Skills, entrySkill)
+	}
+	return file, nil
+}
+
+// MergeSkills overlays the file's skills onto skills loaded from the config
+// directory. File entries win on name collisions.
+func (f *ConfigFile) MergeSkills(logger *logging.Logger, skills map[string]*skill.Skill) map[string]*skill.Skill {
+	merged := make(map[string]*skill.Skill, len(skills)+len(f.Skills))
+	for name, entry := range skills {
+		merged[name] = entry
+	}
+	// File skills extend other file skills or those from the config
+	// directory, as agents in the file do.
+	fileSkills := make(map[string]*skill.Skill, len(f.Skills))
+	for _, entry := range f.Skills {
+		fileSkills[entry.Name] = entry
+	}
+	for name, err := range skill.ResolveExtendsWithParents(fileSkills, skills) {
+		if logger != nil {
+			logger.Warn("config file skill ignored", map[string]string{
+				"skill": name,
+				"path":  f.Path,
+				"error": err.Error(),
+			})
+		}
+		delete(fileSkills, name)
+	}
+	for _, entry := range f.Skills {
+		if _, ok := fileSkills[entry.Name]; !ok {
+			continue
+		}
+		if _, ok := merged[entry.Name]; ok && logger != nil {
+			logger.Info("config file skill overrides directory skill", map[string]string{
+				"skill": entry.Name,
+				"path":  f.Path,
+			})
+		}
+		merged[entry.Name] = entry
+	}
+	return merged
+}
+
+// MergeAgents overlays the file's agents onto agents loaded from the config
+// directory. File entries win on ID collisions; a directory agent whose name
+// matches a file agent under a different ID is dropped.
+func (f *ConfigFile) MergeAgents(logger *logging.Logger, agents map[string]agent.Agent, skillIndex map[string]struct{}) map[string]agent.Agent {
+	loader := agent.Loader{Logger: logger, Parents: agents}
+	inline := loader.LoadInline(f.Path, f.Agents, skillIndex)
+
+	inlineNames := make(map[string]string, len(inline))
+	for id, profile := range inline {
+		inlineNames[strings.ToLower(strings.TrimSpace(profile.Name))] = id
+	}
+	merged := make(map[string]agent.Agent, len(agents)+len(inline))
+	for id, profile := range agents {
+		if _, ok := inline[id]; ok {
+			if logger != nil {
+				logger.Info("config file agent overrides directory agent", map[string]string{
+					"agent_id": id,
+					"path":     f.Path,
+				})
+			}
+			continue
+		}
+		if owner, ok := inlineNames[strings.ToLower(strings.TrimSpace(profile.Name))]; ok {
+			if logger != nil {
+				logger.Warn("agent duplicate name ignored", map[string]string{
+					"agent_id": id,
+					"name":     profile.Name,
+					"owner":    owner,
+					"path":     f.Path,
+				})
+			}
+			continue
+		}
+		merged[id] = profile
+	}
+	for id, profile := range inline {
+		merged[id] = profile
+	}
+	return merged
+}
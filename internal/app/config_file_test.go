@@ -0,0 +1,149 @@
+package app
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gestalt/internal/logging"
+)
+
+func TestBuildMergesConfigFile(t *testing.T) {
+	root := t.TempDir()
+	configRoot := filepath.Join(root, ".gestalt")
+	agentsDir := filepath.Join(configRoot, "config", "agents")
+	if err := os.MkdirAll(agentsDir, 0o755); err != nil {
+		t.Fatalf("mkdir agents: %v", err)
+	}
+	writeAgent := func(id, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(agentsDir, id+".toml"), []byte(contents), 0o644); err != nil {
+			t.Fatalf("write agent: %v", err)
+		}
+	}
+	writeAgent("codex", "name = \"Codex\"\nshell = \"/bin/bash\"\n")
+	writeAgent("local", "name = \"Local\"\nshell = \"/bin/sh\"\n")
+
+	configFile := filepath.Join(root, "gestalt.yaml")
+	contents := strings.Join([]string{
+		"agents:",
+		"  - id: codex",
+		"    name: Codex File",
+		"    shell: /bin/zsh",
+		"    skills: [review]",
+		"  - id: extra",
+		"    name: Extra",
+		"    shell: /bin/bash",
+		"skills:",
+		"  - name: review",
+		"    description: Review changes",
+		"    content: Look closely.",
+		"",
+	}, "\n")
+	if err := os.WriteFile(configFile, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+
+	logger := logging.NewLoggerWithOutput(logging.NewLogBuffer(10), logging.LevelInfo, io.Discard)
+	configFS := os.DirFS(configRoot)
+	result, err := Build(BuildOptions{
+		Logger:        logger,
+		Shell:         "/bin/bash",
+		ConfigFS:      configFS,
+		ConfigOverlay: configFS,
+		ConfigRoot:    "config",
+		AgentsDir:     agentsDir,
+		ConfigFile:    configFile,
+	})
+	if err != nil {
+		t.Fatalf("build app: %v", err)
+	}
+	if len(result.Agents) != 3 {
+		t.Fatalf("expected 3 agents, got %d", len(result.Agents))
+	}
+	codex := result.Agents["codex"]
+	if codex.Name != "Codex File" || codex.Shell != "/bin/zsh" {
+		t.Fatalf("expected config file to override codex, got %+v", codex)
+	}
+	if len(codex.Skills) != 1 || codex.Skills[0] != "review" {
+		t.Fatalf("expected inline skill to resolve, got %v", codex.Skills)
+	}
+	if _, ok := result.Agents["local"]; !ok {
+		t.Fatalf("expected directory-only agent to load")
+	}
+	if result.Skills["review"] == nil || result.Skills["review"].Content != "Look closely." {
+		t.Fatalf("expected inline skill, got %+v", result.Skills["review"])
+	}
+
+	profile, _, err := result.Manager.LoadAgentForSession("extra")
+	if err != nil || profile == nil || profile.Name != "Extra" {
+		t.Fatalf("expected inline agent to survive reload, got %+v (%v)", profile, err)
+	}
+}
+
+func TestLoadConfigFileRejectsInvalidSkill(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "gestalt.json")
+	if err := os.WriteFile(configFile, []byte(`{"skills":[{"name":"Bad Name","description":"x"}]}`), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	if _, err := LoadConfigFile(configFile); err == nil {
+		t.Fatalf("expected invalid skill error")
+	}
+}
+
+func TestBuildResolvesConfigFileSkillExtends(t *testing.T) {
+	root := t.TempDir()
+	configRoot := filepath.Join(root, ".gestalt")
+	skillDir := filepath.Join(configRoot, "config", "skills", "review")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatalf("mkdir skill: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: review\ndescription: Dir review\nlicense: MIT\nallowed_tools: [git]\n---\nDir.\n"), 0o644); err != nil {
+		t.Fatalf("write skill: %v", err)
+	}
+
+	configFile := filepath.Join(root, "gestalt.yaml")
+	contents := strings.Join([]string{
+		"skills:",
+		"  - name: review",
+		"    description: File review",
+		"    extends: review",
+		"  - name: strict",
+		"    description: Strict review",
+		"    extends: review",
+		"  - name: orphan",
+		"    description: Orphan",
+		"    extends: missing",
+		"",
+	}, "\n")
+	if err := os.WriteFile(configFile, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+
+	configFS := os.DirFS(configRoot)
+	result, err := Build(BuildOptions{
+		Logger:        logging.NewLoggerWithOutput(logging.NewLogBuffer(10), logging.LevelInfo, io.Discard),
+		Shell:         "/bin/bash",
+		ConfigFS:      configFS,
+		ConfigOverlay: configFS,
+		ConfigRoot:    "config",
+		AgentsDir:     filepath.Join(configRoot, "config", "agents"),
+		ConfigFile:    configFile,
+	})
+	if err != nil {
+		t.Fatalf("build app: %v", err)
+	}
+	review := result.Skills["review"]
+	if review == nil || review.Description != "File review" || review.License != "MIT" {
+		t.Fatalf("expected file review to extend the dir copy, got %+v", review)
+	}
+	strict := result.Skills["strict"]
+	if strict == nil || strict.License != "MIT" || len(strict.AllowedTools) != 1 {
+		t.Fatalf("expected strict to extend the file review, got %+v", strict)
+	}
+	if _, ok := result.Skills["orphan"]; ok {
+		t.Fatalf("expected skill with a missing base to be dropped")
+	}
+}
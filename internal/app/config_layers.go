@@ -0,0 +1,187 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gestalt/internal/agent"
+	"gestalt/internal/logging"
+	"gestalt/internal/skill"
+)
+
+// mountFS exposes fsys under root, so a layer dir reads like the primary
+// config FS does at its config root.
+type mountFS struct {
+	root string
+	fsys fs.FS
+}
+
+func (m mountFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	switch {
+	case m.root == ".":
+		return m.fsys.Open(name)
+	case name == m.root:
+		return m.fsys.Open(".")
+	}
+	rest, ok := strings.CutPrefix(name, m.root+"/")
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return m.fsys.Open(rest)
+}
+
+// layeredFS opens each name from the last layer that has it.
+type layeredFS []fs.FS
+
+func (l layeredFS) Open(name string) (fs.File, error) {
+	for i := len(l) - 1; i >= 0; i-- {
+		file, err := l[i].Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func checkConfigLayers(dirs []string) error {
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("config dir %s: %w", dir, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("config dir %s is not a directory", dir)
+		}
+	}
+	return nil
+}
+
+// layerPromptFS layers the prompts of each dir under base, mounted at
+// configRoot so prompt paths stay the same; base wins.
+func layerPromptFS(base fs.FS, configRoot string, dirs []string) fs.FS {
+	if len(dirs) == 0 {
+		return base
+	}
+	layers := make(layeredFS, 0, len(dirs)+1)
+	for _, dir := range dirs {
+		layers = append(layers, mountFS{root: path.Clean(configRoot), fsys: os.DirFS(dir)})
+	}
+	return append(layers, base)
+}
+
+// mergeLayerSkills loads skills from each dir in order; later dirs win on
+// name collisions. Layer skills report their path on disk.
+func mergeLayerSkills(logger *logging.Logger, dirs []string) (map[string]*skill.Skill, map[string]string, error) {
+	merged := make(map[string]*skill.Skill)
+	sources := make(map[string]string)
+	for _, dir := range dirs {
+		layer, err := LoadSkills(logger, os.DirFS(dir), ".")
+		if err != nil {
+			return nil, nil, fmt.Errorf("config dir %s: %w", dir, err)
+		}
+		for name, entry := range layer {
+			entry.Path = filepath.Join(dir, filepath.FromSlash(entry.Path))
+			merged[name] = entry
+			sources[name] = dir
+		}
+	}
+	return merged, sources, nil
+}
+
+// mergeLayerAgents loads agents from each dir in order; later dirs win on
+// ID collisions, and an earlier agent whose name matches a later one under a
+// different ID is dropped. It returns the agents dir each agent reloads from.
+func mergeLayerAgents(logger *logging.Logger, dirs []string, promptFS fs.FS, promptsDir string, skillIndex map[string]struct{}) (map[string]agent.Agent, map[string]string, error) {
+	merged := make(map[string]agent.Agent)
+	agentDirs := make(map[string]string)
+	for _, dir := range dirs {
+		// Layer agents may extend agents from the dirs below, including one
+		// with their own ID that they override.
+		loader := agent.Loader{Logger: logger, PromptFS: promptFS, Parents: merged}
+		layer, err := loader.Load(os.DirFS(dir), "agents", promptsDir, skillIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("config dir %s: %w", dir, err)
+		}
+		overlayAgents(logger, merged, agentDirs, layer, dir, filepath.Join(dir, "agents"))
+	}
+	return merged, agentDirs, nil
+}
+
+// overlayAgents puts layer, loaded from config dir dir, over merged. An
+// agent in merged whose name matches a layer agent under a different ID is
+// dropped. Layer agents record agentsDir in agentDirs; an empty agentsDir
+// marks the primary config dir, whose agents reload from the registry's own
+// agents dir.
+func overlayAgents(logger *logging.Logger, merged map[string]agent.Agent, agentDirs map[string]string, layer map[string]agent.Agent, dir, agentsDir string) {
+	for id, profile := range layer {
+		name := strings.ToLower(strings.TrimSpace(profile.Name))
+		for otherID, other := range merged {
+			if otherID != id && strings.ToLower(strings.TrimSpace(other.Name)) == name {
+				if logger != nil {
+					logger.Warn("agent duplicate name ignored", map[string]string{
+						"agent_id": otherID,
+						"name":     other.Name,
+						"owner":    id,
+						"path":     dir,
+					})
+				}
+				delete(merged, otherID)
+				delete(agentDirs, otherID)
+			}
+		}
+		merged[id] = profile
+		if agentsDir == "" {
+			delete(agentDirs, id)
+		} else {
+			agentDirs[id] = agentsDir
+		}
+	}
+}
+
+// layerAgentsDirs returns the agents dir of each config dir, in order.
+func layerAgentsDirs(dirs []string) []string {
+	agentsDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		agentsDirs = append(agentsDirs, filepath.Join(dir, "agents"))
+	}
+	return agentsDirs
+}
+
+// logConfigSources logs the config dir each agent and skill resolved from,
+// so layered setups can tell which copy is in use.
+func logConfigSources(logger *logging.Logger, kind string, names []string, sources map[string]string, primary string) {
+	if logger == nil {
+		return
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dir, ok := sources[name]
+		if !ok {
+			dir = primary
+		}
+		logger.Info(kind+" resolved from config dir", map[string]string{
+			kind:         name,
+			"config_dir": dir,
+		})
+	}
+}
+
+func mapKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gestalt/internal/logging"
+)
+
+func TestBuildLayersConfigDirs(t *testing.T) {
+	root := t.TempDir()
+	configRoot := filepath.Join(root, ".gestalt")
+	primary := filepath.Join(configRoot, "config")
+	base := filepath.Join(root, "base")
+	team := filepath.Join(root, "team")
+	writeFile := func(path, contents string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	writeFile(filepath.Join(base, "agents", "codex.toml"), "name = \"Codex\"\nshell = \"/bin/bash\"\n")
+	writeFile(filepath.Join(base, "agents", "shared.toml"), "name = \"Shared\"\nshell = \"/bin/sh\"\n")
+	writeFile(filepath.Join(team, "agents", "codex.toml"), "name = \"Team Codex\"\nshell = \"/bin/bash\"\nprompt = \"team\"\nskills = [\"review\"]\n")
+	writeFile(filepath.Join(team, "prompts", "team.tmpl"), "team prompt\n")
+	writeFile(filepath.Join(team, "skills", "review", "SKILL.md"), "---\nname: review\ndescription: Team review\n---\nTeam.\n")
+	writeFile(filepath.Join(team, "skills", "lint", "SKILL.md"), "---\nname: lint\ndescription: Team lint\n---\nLint.\n")
+	writeFile(filepath.Join(primary, "agents", "local.toml"), "name = \"Local\"\nshell = \"/bin/sh\"\n")
+	writeFile(filepath.Join(primary, "skills", "review", "SKILL.md"), "---\nname: review\ndescription: User review\n---\nUser.\n")
+
+	logBuffer := logging.NewLogBuffer(50)
+	logger := logging.NewLoggerWithOutput(logBuffer, logging.LevelInfo, io.Discard)
+	configFS := os.DirFS(configRoot)
+	result, err := Build(BuildOptions{
+		Logger:        logger,
+		Shell:         "/bin/bash",
+		ConfigFS:      configFS,
+		ConfigOverlay: configFS,
+		ConfigRoot:    "config",
+		AgentsDir:     filepath.Join(primary, "agents"),
+		ConfigLayers:  []string{base, team},
+	})
+	if err != nil {
+		t.Fatalf("build app: %v", err)
+	}
+
+	if len(result.Agents) != 3 {
+		t.Fatalf("expected 3 agents, got %d", len(result.Agents))
+	}
+	if result.Agents["codex"].Name != "Team Codex" {
+		t.Fatalf("expected team codex to win, got %q", result.Agents["codex"].Name)
+	}
+	if result.Agents["local"].Name != "Local" {
+		t.Fatalf("expected primary local agent, got %q", result.Agents["local"].Name)
+	}
+	if result.Agents["shared"].Name != "Shared" {
+		t.Fatalf("expected base shared agent, got %q", result.Agents["shared"].Name)
+	}
+	review := result.Skills["review"]
+	if review == nil || review.Description != "User review" {
+		t.Fatalf("expected primary review skill to win, got %#v", review)
+	}
+	lint := result.Skills["lint"]
+	if lint == nil {
+		t.Fatalf("expected team lint skill")
+	}
+	if want := filepath.Join(team, "skills", "lint"); lint.Path != want {
+		t.Fatalf("expected skill path %q, got %q", want, lint.Path)
+	}
+
+	profile, _, err := result.Manager.LoadAgentForSession("codex")
+	if err != nil {
+		t.Fatalf("load agent for session: %v", err)
+	}
+	if profile.Name != "Team Codex" {
+		t.Fatalf("expected reload from team dir, got %q", profile.Name)
+	}
+
+	sources := map[string]string{}
+	for _, entry := range logBuffer.List() {
+		if entry.Message == "agent resolved from config dir" {
+			sources[entry.Context["agent"]] = entry.Context["config_dir"]
+		}
+	}
+	if sources["codex"] != team || sources["shared"] != base || sources["local"] != primary {
+		t.Fatalf("unexpected agent sources: %v", sources)
+	}
+}
+
+func TestBuildRejectsMissingConfigLayer(t *testing.T) {
+	root := t.TempDir()
+	configRoot := filepath.Join(root, ".gestalt")
+	if err := os.MkdirAll(filepath.Join(configRoot, "config", "agents"), 0o755); err != nil {
+		t.Fatalf("mkdir agents: %v", err)
+	}
+	configFS := os.DirFS(configRoot)
+	_, err := Build(BuildOptions{
+		Logger:        logging.NewLoggerWithOutput(logging.NewLogBuffer(10), logging.LevelInfo, io.Discard),
+		Shell:         "/bin/bash",
+		ConfigFS:      configFS,
+		ConfigOverlay: configFS,
+		ConfigRoot:    "config",
+		AgentsDir:     filepath.Join(configRoot, "config", "agents"),
+		ConfigLayers:  []string{filepath.Join(root, "missing")},
+	})
+	var buildErr BuildError
+	if !errors.As(err, &buildErr) || buildErr.Stage != StageLayers {
+		t.Fatalf("expected config layers build error, got %v", err)
+	}
+}
+
+func TestBuildLayerAgentsExtendLowerDirs(t *testing.T) {
+	root := t.TempDir()
+	configRoot := filepath.Join(root, ".gestalt")
+	primary := filepath.Join(configRoot, "config")
+	base := filepath.Join(root, "base")
+	team := filepath.Join(root, "team")
+	writeFile := func(path, contents string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	writeFile(filepath.Join(base, "agents", "coder.toml"), "name = \"Coder\"\nshell = \"/bin/bash\"\nonair_string = \"$\"\n")
+	writeFile(filepath.Join(team, "agents", "coder.toml"), "name = \"Team Coder\"\nextends = \"coder\"\nmodel = \"large\"\n")
+	writeFile(filepath.Join(team, "agents", "reviewer.toml"), "name = \"Reviewer\"\nextends = \"coder\"\n")
+	writeFile(filepath.Join(primary, "agents", "coder.toml"), "name = \"My Coder\"\nextends = \"coder\"\nmodel = \"small\"\n")
+
+	configFS := os.DirFS(configRoot)
+	result, err := Build(BuildOptions{
+		Logger:        logging.NewLoggerWithOutput(logging.NewLogBuffer(50), logging.LevelInfo, io.Discard),
+		Shell:         "/bin/bash",
+		ConfigFS:      configFS,
+		ConfigOverlay: configFS,
+		ConfigRoot:    "config",
+		AgentsDir:     filepath.Join(primary, "agents"),
+		ConfigLayers:  []string{base, team},
+	})
+	if err != nil {
+		t.Fatalf("build app: %v", err)
+	}
+
+	coder := result.Agents["coder"]
+	if coder.Name != "My Coder" || coder.Model != "small" || coder.Shell != "/bin/bash" || coder.OnAirString != "$" {
+		t.Fatalf("expected primary coder over team and base, got %+v", coder)
+	}
+	reviewer := result.Agents["reviewer"]
+	if reviewer.Name != "Reviewer" || reviewer.Model != "large" || reviewer.OnAirString != "$" {
+		t.Fatalf("expected reviewer to extend the team coder, got %+v", reviewer)
+	}
+
+	for id, want := range map[string]string{"coder": "small", "reviewer": "large"} {
+		profile, _, err := result.Manager.LoadAgentForSession(id)
+		if err != nil {
+			t.Fatalf("reload %s: %v", id, err)
+		}
+		if profile.Model != want || profile.OnAirString != "$" {
+			t.Fatalf("expected reloaded %s to keep inherited fields, got %+v", id, profile)
+		}
+	}
+}
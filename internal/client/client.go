@@ -22,6 +22,14 @@ type SessionInfo struct {
 	ID string `json:"id"`
 }
 
+// DefaultAgent is the response of GET /api/agents/default. AgentID is empty
+// when no default is set.
+type DefaultAgent struct {
+	AgentID string `json:"agent_id"`
+	Name    string `json:"name"`
+	Missing bool   `json:"missing"`
+}
+
 type HTTPError struct {
 	StatusCode int
 	Message    string
@@ -141,6 +149,15 @@ func FetchAgents(client *http.Client, baseURL, token string) ([]AgentInfo, error
 }
 
 func SendSessionInput(client *http.Client, baseURL, token, sessionID string, payload []byte) error {
+	return SendSessionInputAs(client, baseURL, token, sessionID, DefaultInputContentType, payload)
+}
+
+// DefaultInputContentType labels session input as raw bytes.
+const DefaultInputContentType = "application/octet-stream"
+
+// SendSessionInputAs posts payload as session input labeled with
+// contentType, e.g. application/json for structured input.
+func SendSessionInputAs(client *http.Client, baseURL, token, sessionID, contentType string, payload []byte) error {
 	client = ensureClient(client)
 	baseURL = strings.TrimRight(baseURL, "/")
 	if baseURL == "" {
@@ -155,7 +172,10 @@ func SendSessionInput(client *http.Client, baseURL, token, sessionID string, pay
 	if err != nil {
 		return fmt.Errorf("build request failed: %w", err)
 	}
-	request.Header.Set("Content-Type", "application/octet-stream")
+	if strings.TrimSpace(contentType) == "" {
+		contentType = DefaultInputContentType
+	}
+	request.Header.Set("Content-Type", contentType)
 	addToken(request, token)
 
 	response, err := client.Do(request)
@@ -210,6 +230,40 @@ func FetchSessions(client *http.Client, baseURL, token string) ([]SessionInfo, e
 	return sessions, nil
 }
 
+// FetchDefaultAgent returns the server's default agent.
+func FetchDefaultAgent(client *http.Client, baseURL, token string) (DefaultAgent, error) {
+	client = ensureClient(client)
+	baseURL = strings.TrimRight(baseURL, "/")
+	if baseURL == "" {
+		return DefaultAgent{}, errors.New("base URL is required")
+	}
+
+	request, err := http.NewRequest(http.MethodGet, baseURL+"/api/agents/default", nil)
+	if err != nil {
+		return DefaultAgent{}, fmt.Errorf("build default agent request failed: %w", err)
+	}
+	addToken(request, token)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return DefaultAgent{}, fmt.Errorf("default agent request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message := readErrorMessage(response)
+		return DefaultAgent{}, &HTTPError{StatusCode: response.StatusCode, Message: message}
+	}
+
+	var payload DefaultAgent
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		return DefaultAgent{}, fmt.Errorf("decode default agent response: %w", err)
+	}
+	payload.AgentID = strings.TrimSpace(payload.AgentID)
+	payload.Name = strings.TrimSpace(payload.Name)
+	return payload, nil
+}
+
 // CreateExternalAgentSession starts a session for agentID using the external runner.
 func CreateExternalAgentSession(client *http.Client, baseURL, token, agentID string) (SessionInfo, error) {
 	client = ensureClient(client)
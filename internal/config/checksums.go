@@ -0,0 +1,204 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var ErrChecksumManifestMissing = errors.New("checksum manifest not found")
+
+// ChecksumManifestName is the file, next to version.json, that records what
+// the extractor left on disk.
+const ChecksumManifestName = "checksums.json"
+
+// ChecksumManifest maps config-relative paths (slash separated) to the
+// sha256 of the embedded file the extractor installed there.
+type ChecksumManifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// ChecksumReport lists how the config dir differs from its checksum
+// manifest. Extra files are ones the extractor did not write, ignoring its
+// own bookkeeping (baseline manifest, .dist sidecars, and backups).
+type ChecksumReport struct {
+	Modified []string `json:"modified"`
+	Missing  []string `json:"missing"`
+	Extra    []string `json:"extra"`
+}
+
+// Clean reports whether the config dir matches the manifest exactly.
+func (r ChecksumReport) Clean() bool {
+	return len(r.Modified) == 0 && len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+// ChecksumManifestPath returns where the checksum manifest for configDir
+// lives: alongside version.json in the config root.
+func ChecksumManifestPath(configDir string) string {
+	return filepath.Join(filepath.Dir(filepath.Clean(configDir)), ChecksumManifestName)
+}
+
+// WriteChecksumManifest writes manifest to path, creating its directory.
+func WriteChecksumManifest(path string, manifest ChecksumManifest) error {
+	if manifest.Files == nil {
+		manifest.Files = map[string]string{}
+	}
+	payload, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	payload = append(payload, '\n')
+	return writeFileAtomic(path, 0o644, bytes.NewReader(payload))
+}
+
+// extractedChecksums records, for every path in manifest, the sha256 of the
+// embedded file the extractor installs. When the file on disk is not the
+// embedded one (the user's copy was kept), the entry from previous is carried
+// forward instead, so edits made before or after extraction still show up as
+// modified rather than being blessed by re-hashing the disk.
+func extractedChecksums(sourceFS fs.FS, destDir string, manifest map[string]string, previous ChecksumManifest) (ChecksumManifest, error) {
+	checksums := ChecksumManifest{Files: make(map[string]string, len(manifest))}
+	for relPath, expectedHash := range manifest {
+		sourcePath := path.Join("config", relPath)
+		if expectedHash == "" {
+			hash, err := hashFileFromFS(sourceFS, sourcePath)
+			if err != nil {
+				return ChecksumManifest{}, err
+			}
+			expectedHash = hash
+		}
+		if prior, ok := previous.Files[relPath]; ok {
+			localHash, err := hashFile(filepath.Join(destDir, filepath.FromSlash(relPath)))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return ChecksumManifest{}, err
+			}
+			if err == nil && localHash != expectedHash {
+				checksums.Files[relPath] = prior
+				continue
+			}
+		}
+		sum, err := checksumFileFromFS(sourceFS, sourcePath)
+		if err != nil {
+			return ChecksumManifest{}, err
+		}
+		checksums.Files[relPath] = sum
+	}
+	return checksums, nil
+}
+
+func LoadChecksumManifest(path string) (ChecksumManifest, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ChecksumManifest{}, ErrChecksumManifestMissing
+		}
+		return ChecksumManifest{}, err
+	}
+	var manifest ChecksumManifest
+	if err := json.Unmarshal(payload, &manifest); err != nil {
+		return ChecksumManifest{}, err
+	}
+	if manifest.Files == nil {
+		manifest.Files = map[string]string{}
+	}
+	return manifest, nil
+}
+
+// VerifyChecksums compares the files under configDir with manifest.
+func VerifyChecksums(configDir string, manifest ChecksumManifest) (ChecksumReport, error) {
+	report := ChecksumReport{Modified: []string{}, Missing: []string{}, Extra: []string{}}
+	for relPath, expected := range manifest.Files {
+		sum, err := checksumFile(filepath.Join(configDir, filepath.FromSlash(relPath)))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				report.Missing = append(report.Missing, relPath)
+				continue
+			}
+			return ChecksumReport{}, err
+		}
+		if sum != expected {
+			report.Modified = append(report.Modified, relPath)
+		}
+	}
+
+	err := filepath.WalkDir(configDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(configDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if _, ok := manifest.Files[rel]; ok || isExtractorArtifact(rel) {
+			return nil
+		}
+		report.Extra = append(report.Extra, rel)
+		return nil
+	})
+	if err != nil {
+		return ChecksumReport{}, err
+	}
+
+	sort.Strings(report.Modified)
+	sort.Strings(report.Missing)
+	sort.Strings(report.Extra)
+	return report, nil
+}
+
+// isExtractorArtifact matches files the extractor writes besides the config
+// itself: the baseline manifest, .dist sidecars, and .bck backups, including
+// their timestamped rotations.
+func isExtractorArtifact(rel string) bool {
+	base := path.Base(rel)
+	if base == baselineManifestName {
+		return true
+	}
+	return strings.HasSuffix(base, ".dist") || strings.Contains(base, ".dist.") ||
+		strings.HasSuffix(base, ".bck") || strings.Contains(base, ".bck.")
+}
+
+func checksumFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	buffer := make([]byte, ioBufferSize)
+	if _, err := io.CopyBuffer(hasher, file, buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func checksumFileFromFS(sourceFS fs.FS, sourcePath string) (string, error) {
+	file, err := sourceFS.Open(sourcePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	buffer := make([]byte, ioBufferSize)
+	if _, err := io.CopyBuffer(hasher, file, buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"gestalt"
+)
+
+func TestExtractorWritesChecksumManifest(t *testing.T) {
+	root := t.TempDir()
+	destDir := filepath.Join(root, "config")
+	manifest := map[string]string{
+		"agents/coder.toml": embeddedHash(t, "config/agents/coder.toml"),
+	}
+	checksumPath := ChecksumManifestPath(destDir)
+	if checksumPath != filepath.Join(root, ChecksumManifestName) {
+		t.Fatalf("unexpected checksum path %q", checksumPath)
+	}
+
+	extractor := Extractor{BackupLimit: 1, ChecksumPath: checksumPath}
+	if err := extractor.Extract(gestalt.EmbeddedConfigFS, destDir, manifest); err != nil {
+		t.Fatalf("extract failed: %v", err)
+	}
+
+	checksums, err := LoadChecksumManifest(checksumPath)
+	if err != nil {
+		t.Fatalf("load checksum manifest: %v", err)
+	}
+	if len(checksums.Files) != 1 || checksums.Files["agents/coder.toml"] == "" {
+		t.Fatalf("unexpected checksum manifest: %#v", checksums.Files)
+	}
+	report, err := VerifyChecksums(destDir, checksums)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !report.Clean() {
+		t.Fatalf("expected clean report, got %#v", report)
+	}
+}
+
+func TestExtractorChecksumsKeepUserEdits(t *testing.T) {
+	root := t.TempDir()
+	destDir := filepath.Join(root, "config")
+	sourceFS := fstest.MapFS{
+		"config/agents/coder.toml": &fstest.MapFile{Data: []byte("name = \"coder\"\n"), Mode: 0o644},
+	}
+	manifest := map[string]string{"agents/coder.toml": embeddedHashFromFS(t, sourceFS, "config/agents/coder.toml")}
+	checksumPath := ChecksumManifestPath(destDir)
+	extractor := Extractor{BackupLimit: 1, ChecksumPath: checksumPath}
+	if err := extractor.Extract(sourceFS, destDir, manifest); err != nil {
+		t.Fatalf("extract failed: %v", err)
+	}
+
+	// The user edits the file; the next extraction keeps the edit and must
+	// not record its hash as pristine.
+	writeTestFile(t, filepath.Join(destDir, "agents", "coder.toml"), "name = \"edited\"\n")
+	if err := extractor.Extract(sourceFS, destDir, manifest); err != nil {
+		t.Fatalf("re-extract failed: %v", err)
+	}
+	checksums, err := LoadChecksumManifest(checksumPath)
+	if err != nil {
+		t.Fatalf("load checksum manifest: %v", err)
+	}
+	report, err := VerifyChecksums(destDir, checksums)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !reflect.DeepEqual(report.Modified, []string{"agents/coder.toml"}) {
+		t.Fatalf("expected kept edit to be reported modified, got %#v", report)
+	}
+
+	// Without a previous entry the embedded hash is recorded, not the disk.
+	if err := os.Remove(checksumPath); err != nil {
+		t.Fatalf("remove checksum manifest: %v", err)
+	}
+	if err := extractor.Extract(sourceFS, destDir, manifest); err != nil {
+		t.Fatalf("re-extract failed: %v", err)
+	}
+	checksums, err = LoadChecksumManifest(checksumPath)
+	if err != nil {
+		t.Fatalf("load checksum manifest: %v", err)
+	}
+	report, err = VerifyChecksums(destDir, checksums)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !reflect.DeepEqual(report.Modified, []string{"agents/coder.toml"}) {
+		t.Fatalf("expected edit to be reported modified, got %#v", report)
+	}
+}
+
+func TestVerifyChecksumsReportsDifferences(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "agents", "coder.toml"), "name = \"coder\"\n")
+	writeTestFile(t, filepath.Join(dir, "agents", "gone.toml"), "name = \"gone\"\n")
+	manifestPath := filepath.Join(t.TempDir(), ChecksumManifestName)
+	if err := WriteChecksumManifest(manifestPath, ChecksumManifest{Files: map[string]string{
+		"agents/coder.toml": testChecksum(t, filepath.Join(dir, "agents", "coder.toml")),
+		"agents/gone.toml":  testChecksum(t, filepath.Join(dir, "agents", "gone.toml")),
+	}}); err != nil {
+		t.Fatalf("write checksum manifest: %v", err)
+	}
+	if err := WriteBaselineManifest(dir, nil); err != nil {
+		t.Fatalf("write baseline manifest: %v", err)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "agents", "coder.toml"), "name = \"edited\"\n")
+	writeTestFile(t, filepath.Join(dir, "agents", "coder.toml.dist"), "dist\n")
+	writeTestFile(t, filepath.Join(dir, "agents", "coder.toml.bck.20260101-000000"), "backup\n")
+	writeTestFile(t, filepath.Join(dir, "agents", "new.toml"), "name = \"new\"\n")
+	if err := os.Remove(filepath.Join(dir, "agents", "gone.toml")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	manifest, err := LoadChecksumManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("load checksum manifest: %v", err)
+	}
+	report, err := VerifyChecksums(dir, manifest)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !reflect.DeepEqual(report.Modified, []string{"agents/coder.toml"}) {
+		t.Fatalf("unexpected modified: %v", report.Modified)
+	}
+	if !reflect.DeepEqual(report.Missing, []string{"agents/gone.toml"}) {
+		t.Fatalf("unexpected missing: %v", report.Missing)
+	}
+	if !reflect.DeepEqual(report.Extra, []string{"agents/new.toml"}) {
+		t.Fatalf("unexpected extra: %v", report.Extra)
+	}
+}
+
+func TestLoadChecksumManifestMissing(t *testing.T) {
+	_, err := LoadChecksumManifest(filepath.Join(t.TempDir(), ChecksumManifestName))
+	if !errors.Is(err, ErrChecksumManifestMissing) {
+		t.Fatalf("expected ErrChecksumManifestMissing, got %v", err)
+	}
+}
+
+func testChecksum(t *testing.T, path string) string {
+	t.Helper()
+	sum, err := checksumFile(path)
+	if err != nil {
+		t.Fatalf("checksum %s: %v", path, err)
+	}
+	return sum
+}
+
+func writeTestFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"gestalt/internal/logging"
@@ -25,6 +26,11 @@ type Extractor struct {
 	BackupLimit int
 	LastUpdated time.Time
 	Resolver    *ConffileResolver
+	// Workers caps concurrent file extraction; zero uses runtime.NumCPU().
+	Workers int
+	// ChecksumPath, when set, receives a checksum manifest of the embedded
+	// files after each successful extraction.
+	ChecksumPath string
 }
 
 type ExtractStats struct {
@@ -48,7 +54,7 @@ func (e *Extractor) ExtractWithStats(sourceFS fs.FS, destDir string, manifest ma
 		}
 	}
 	if len(manifest) == 0 {
-		if err := WriteBaselineManifest(destDir, manifest); err != nil {
+		if err := e.writeManifests(sourceFS, destDir, manifest); err != nil {
 			return stats, err
 		}
 		return stats, nil
@@ -64,16 +70,7 @@ func (e *Extractor) ExtractWithStats(sourceFS fs.FS, destDir string, manifest ma
 		return stats, err
 	}
 
-	workerCount := runtime.NumCPU()
-	if workerCount < 1 {
-		workerCount = 1
-	}
-	if e != nil && e.Resolver != nil && e.Resolver.Interactive {
-		workerCount = 1
-	}
-	if workerCount > len(paths) {
-		workerCount = len(paths)
-	}
+	workerCount := e.workerCount(len(paths))
 	if workerCount <= 1 {
 		for _, relPath := range paths {
 			fileStats, err := e.extractRel(sourceFS, destDir, relPath, manifest[relPath], baseline)
@@ -84,33 +81,35 @@ func (e *Extractor) ExtractWithStats(sourceFS fs.FS, destDir string, manifest ma
 			stats.Skipped += fileStats.Skipped
 			stats.BackedUp += fileStats.BackedUp
 		}
-		if err := WriteBaselineManifest(destDir, manifest); err != nil {
+		if err := e.writeManifests(sourceFS, destDir, manifest); err != nil {
 			return stats, err
 		}
 		return stats, nil
 	}
 
-	jobs := make(chan string)
+	// Each path maps to a distinct destination (and backup) file, so workers
+	// never touch the same file. Errors are recorded by path index so the
+	// reported error is the first failing path in sorted order, regardless of
+	// which worker finished first.
+	jobs := make(chan int)
+	errs := make([]error, len(paths))
+	var extracted, skipped, backedUp atomic.Int64
+	var failed atomic.Bool
 	var waitGroup sync.WaitGroup
-	var statsMu sync.Mutex
-	var errOnce sync.Once
-	var firstErr error
 
 	worker := func() {
 		defer waitGroup.Done()
-		for relPath := range jobs {
+		for index := range jobs {
+			relPath := paths[index]
 			fileStats, err := e.extractRel(sourceFS, destDir, relPath, manifest[relPath], baseline)
 			if err != nil {
-				errOnce.Do(func() {
-					firstErr = err
-				})
+				errs[index] = err
+				failed.Store(true)
 				continue
 			}
-			statsMu.Lock()
-			stats.Extracted += fileStats.Extracted
-			stats.Skipped += fileStats.Skipped
-			stats.BackedUp += fileStats.BackedUp
-			statsMu.Unlock()
+			extracted.Add(int64(fileStats.Extracted))
+			skipped.Add(int64(fileStats.Skipped))
+			backedUp.Add(int64(fileStats.BackedUp))
 		}
 	}
 
@@ -118,21 +117,72 @@ func (e *Extractor) ExtractWithStats(sourceFS fs.FS, destDir string, manifest ma
 	for i := 0; i < workerCount; i++ {
 		go worker()
 	}
-	for _, relPath := range paths {
-		jobs <- relPath
+	for index := range paths {
+		if failed.Load() {
+			break
+		}
+		jobs <- index
 	}
 	close(jobs)
 	waitGroup.Wait()
 
-	if firstErr != nil {
-		return stats, firstErr
+	stats.Extracted = int(extracted.Load())
+	stats.Skipped = int(skipped.Load())
+	stats.BackedUp = int(backedUp.Load())
+	for _, err := range errs {
+		if err != nil {
+			return stats, err
+		}
 	}
-	if err := WriteBaselineManifest(destDir, manifest); err != nil {
+	if err := e.writeManifests(sourceFS, destDir, manifest); err != nil {
 		return stats, err
 	}
 	return stats, nil
 }
 
+func (e *Extractor) writeManifests(sourceFS fs.FS, destDir string, manifest map[string]string) error {
+	if err := WriteBaselineManifest(destDir, manifest); err != nil {
+		return err
+	}
+	if e == nil || strings.TrimSpace(e.ChecksumPath) == "" {
+		return nil
+	}
+	previous, err := LoadChecksumManifest(e.ChecksumPath)
+	if err != nil && !errors.Is(err, ErrChecksumManifestMissing) {
+		e.logWarn("checksum manifest unreadable, rebuilding", map[string]string{
+			"path":  e.ChecksumPath,
+			"error": err.Error(),
+		})
+	}
+	checksums, err := extractedChecksums(sourceFS, destDir, manifest, previous)
+	if err != nil {
+		return fmt.Errorf("checksum extracted files: %w", err)
+	}
+	if err := WriteChecksumManifest(e.ChecksumPath, checksums); err != nil {
+		return fmt.Errorf("write checksum manifest: %w", err)
+	}
+	return nil
+}
+
+// workerCount returns how many files to extract concurrently. Interactive
+// conflict resolution always runs serially so prompts do not interleave.
+func (e *Extractor) workerCount(fileCount int) int {
+	workers := runtime.NumCPU()
+	if e != nil && e.Workers > 0 {
+		workers = e.Workers
+	}
+	if e != nil && e.Resolver != nil && e.Resolver.Interactive {
+		workers = 1
+	}
+	if workers > fileCount {
+		workers = fileCount
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
 func (e *Extractor) extractRel(sourceFS fs.FS, destDir, relPath, expectedHash string, baseline map[string]string) (ExtractStats, error) {
 	sourcePath := path.Join("config", relPath)
 	destPath := filepath.Join(destDir, filepath.FromSlash(relPath))
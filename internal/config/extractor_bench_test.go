@@ -12,6 +12,8 @@ import (
 
 const benchmarkFileCount = 300
 const benchmarkPayloadSize = 1024
+const benchmarkSmallFileCount = 2000
+const benchmarkSmallPayloadSize = 128
 
 func BenchmarkExtractorCold(b *testing.B) {
 	sourceFS, manifest := buildBenchmarkFS(benchmarkFileCount, benchmarkPayloadSize)
@@ -25,6 +27,28 @@ func BenchmarkExtractorCold(b *testing.B) {
 	}
 }
 
+// BenchmarkExtractorManySmallFiles compares serial and parallel cold
+// extraction on a large tree of small files.
+func BenchmarkExtractorManySmallFiles(b *testing.B) {
+	sourceFS, manifest := buildBenchmarkFS(benchmarkSmallFileCount, benchmarkSmallPayloadSize)
+	for _, workers := range []int{1, 0} {
+		name := "serial"
+		if workers == 0 {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				destDir := b.TempDir()
+				extractor := Extractor{BackupLimit: 1, Workers: workers}
+				if _, err := extractor.ExtractWithStats(sourceFS, destDir, manifest); err != nil {
+					b.Fatalf("extract failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkExtractorWarm(b *testing.B) {
 	sourceFS, manifest := buildBenchmarkFS(benchmarkFileCount, benchmarkPayloadSize)
 	destDir := b.TempDir()
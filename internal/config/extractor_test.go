@@ -821,3 +821,41 @@ func embeddedHash(t *testing.T, path string) string {
 	_, _ = hasher.Write(data)
 	return fmt.Sprintf("%016x", hasher.Sum64())
 }
+
+func TestExtractorParallelStatsAndErrors(t *testing.T) {
+	sourceFS, manifest := buildBenchmarkFS(64, 64)
+
+	destDir := t.TempDir()
+	extractor := Extractor{BackupLimit: 1, Workers: 8}
+	stats, err := extractor.ExtractWithStats(sourceFS, destDir, manifest)
+	if err != nil {
+		t.Fatalf("extract failed: %v", err)
+	}
+	if stats.Extracted != 64 || stats.Skipped != 0 || stats.BackedUp != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	stats, err = extractor.ExtractWithStats(sourceFS, destDir, manifest)
+	if err != nil {
+		t.Fatalf("re-extract failed: %v", err)
+	}
+	if stats.Extracted != 0 || stats.Skipped != 64 {
+		t.Fatalf("unexpected stats on re-extract: %+v", stats)
+	}
+
+	failDir := t.TempDir()
+	for _, name := range []string{"agent-0010.toml", "agent-0050.toml"} {
+		if err := os.MkdirAll(filepath.Join(failDir, "agents", name), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		_, err := extractor.ExtractWithStats(sourceFS, failDir, manifest)
+		if err == nil {
+			t.Fatalf("expected extraction error")
+		}
+		if !strings.Contains(err.Error(), "agent-0010.toml") {
+			t.Fatalf("expected first failing path in error, got %v", err)
+		}
+	}
+}
@@ -22,6 +22,14 @@ type SessionSettings struct {
 	TUIMode               string
 	TUISnapshotIntervalMS int64
 	LogCodexEvents        bool
+	RedactPatterns        []string
+	OutputRateLimit       int64
+	// LauncherCheckWindowMS is how long new session output is watched for a
+	// missing launcher; zero or negative leaves the check off.
+	LauncherCheckWindowMS int64
+	// BellDebounceMS coalesces repeated bells from a session; zero uses the
+	// manager default, negative signals every bell.
+	BellDebounceMS int64
 }
 
 func LoadSettings(path string, defaultsPayload []byte, overrides map[string]any) (Settings, error) {
@@ -69,6 +77,10 @@ func LoadSettings(path string, defaultsPayload []byte, overrides map[string]any)
 	settings.Session.TUIMode = stringSetting(values, "session.tui-mode", "")
 	settings.Session.TUISnapshotIntervalMS = intSetting(values, "session.tui-snapshot-interval-ms", 0)
 	settings.Session.LogCodexEvents = boolSetting(values, "session.log-codex-events", boolSetting(defaults, "session.log-codex-events", false))
+	settings.Session.RedactPatterns = stringListSetting(values, "session.redact-patterns")
+	settings.Session.OutputRateLimit = intSetting(values, "session.output-rate-limit", 0)
+	settings.Session.LauncherCheckWindowMS = intSetting(values, "session.launcher-check-window-ms", 0)
+	settings.Session.BellDebounceMS = intSetting(values, "session.bell-debounce-ms", 0)
 
 	return normalizeSettings(settings, defaults), nil
 }
@@ -126,6 +138,36 @@ func stringSetting(values map[string]any, key string, fallback string) string {
 	return fallback
 }
 
+func stringListSetting(values map[string]any, key string) []string {
+	value, ok := values[tomlkeys.NormalizeKey(key)]
+	if !ok {
+		return nil
+	}
+	var items []string
+	switch typed := value.(type) {
+	case []string:
+		items = typed
+	case []any:
+		for _, item := range typed {
+			if parsed, ok := item.(string); ok {
+				items = append(items, parsed)
+			}
+		}
+	case string:
+		items = strings.Split(typed, ",")
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
 func boolSetting(values map[string]any, key string, fallback bool) bool {
 	value, ok := values[tomlkeys.NormalizeKey(key)]
 	if !ok {
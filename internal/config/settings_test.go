@@ -114,3 +114,79 @@ input-font-size = "12px"
 		t.Fatalf("expected input font-size override, got %q", settings.Session.InputFontSize)
 	}
 }
+
+func TestLoadSettingsRedactPatterns(t *testing.T) {
+	defaultsPayload, err := fs.ReadFile(gestalt.EmbeddedConfigFS, "config/gestalt.toml")
+	if err != nil {
+		t.Fatalf("read defaults: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gestalt.toml")
+	payload := "[session]\nredact-patterns = [\"env:API_TOKEN\", \" sk-[A-Za-z0-9]+ \", \"\"]\n"
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	settings, err := LoadSettings(path, defaultsPayload, nil)
+	if err != nil {
+		t.Fatalf("load settings: %v", err)
+	}
+	got := settings.Session.RedactPatterns
+	if len(got) != 2 || got[0] != "env:API_TOKEN" || got[1] != "sk-[A-Za-z0-9]+" {
+		t.Fatalf("unexpected redact patterns: %#v", got)
+	}
+}
+
+func TestLoadSettingsOutputRateLimit(t *testing.T) {
+	defaultsPayload, err := fs.ReadFile(gestalt.EmbeddedConfigFS, "config/gestalt.toml")
+	if err != nil {
+		t.Fatalf("read defaults: %v", err)
+	}
+
+	settings, err := LoadSettings("", defaultsPayload, nil)
+	if err != nil {
+		t.Fatalf("load settings: %v", err)
+	}
+	if settings.Session.OutputRateLimit != 0 {
+		t.Fatalf("expected unlimited output by default, got %d", settings.Session.OutputRateLimit)
+	}
+
+	settings, err = LoadSettings("", defaultsPayload, map[string]any{"session.output-rate-limit": int64(65536)})
+	if err != nil {
+		t.Fatalf("load settings: %v", err)
+	}
+	if settings.Session.OutputRateLimit != 65536 {
+		t.Fatalf("expected output rate limit override, got %d", settings.Session.OutputRateLimit)
+	}
+}
+
+func TestLoadSettingsLauncherCheckWindow(t *testing.T) {
+	defaultsPayload, err := fs.ReadFile(gestalt.EmbeddedConfigFS, "config/gestalt.toml")
+	if err != nil {
+		t.Fatalf("read defaults: %v", err)
+	}
+
+	settings, err := LoadSettings("", defaultsPayload, map[string]any{"session.launcher-check-window-ms": int64(5000)})
+	if err != nil {
+		t.Fatalf("load settings: %v", err)
+	}
+	if settings.Session.LauncherCheckWindowMS != 5000 {
+		t.Fatalf("expected launcher check window override, got %d", settings.Session.LauncherCheckWindowMS)
+	}
+}
+
+func TestLoadSettingsBellDebounce(t *testing.T) {
+	defaultsPayload, err := fs.ReadFile(gestalt.EmbeddedConfigFS, "config/gestalt.toml")
+	if err != nil {
+		t.Fatalf("read defaults: %v", err)
+	}
+
+	settings, err := LoadSettings("", defaultsPayload, map[string]any{"session.bell-debounce-ms": int64(500)})
+	if err != nil {
+		t.Fatalf("load settings: %v", err)
+	}
+	if settings.Session.BellDebounceMS != 500 {
+		t.Fatalf("expected bell debounce override, got %d", settings.Session.BellDebounceMS)
+	}
+}
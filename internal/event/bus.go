@@ -116,7 +116,7 @@ func (b *Bus[T]) SubscribeFiltered(filter func(T) bool) (<-chan T, func()) {
 		close(ch)
 		return ch, func() {}
 	}
-	b.subscribers[id] = subscription[T]{id: id, ch: ch, filter: filter}
+	b.subscribers[id] = subscription[T]{id: id, ch: ch, filter: filter, done: make(chan struct{}), sendMu: &sync.RWMutex{}}
 	filtered, unfiltered := b.countSubscribersLocked()
 	b.mu.Unlock()
 
@@ -217,7 +217,7 @@ func (b *Bus[T]) Close() {
 		b.mu.Unlock()
 
 		for _, sub := range subscribers {
-			close(sub.ch)
+			sub.close()
 		}
 		b.setSubscriberCounts(0, 0)
 	})
@@ -243,6 +243,17 @@ type subscription[T any] struct {
 	id     uint64
 	ch     chan T
 	filter func(T) bool
+	// done is closed when the subscription is removed, releasing senders
+	// blocked on ch; sendMu keeps ch open until they have returned.
+	done   chan struct{}
+	sendMu *sync.RWMutex
+}
+
+func (s subscription[T]) close() {
+	close(s.done)
+	s.sendMu.Lock()
+	close(s.ch)
+	s.sendMu.Unlock()
 }
 
 func (b *Bus[T]) sendToSubscriber(sub subscription[T], event T, eventType string) {
@@ -258,6 +269,8 @@ func (b *Bus[T]) nonBlockingSend(sub subscription[T], event T, eventType string)
 		select {
 		case sub.ch <- event:
 			return true
+		case <-sub.done:
+			return false
 		default:
 			return false
 		}
@@ -271,14 +284,20 @@ func (b *Bus[T]) blockingSend(sub subscription[T], event T, eventType string) {
 	start := time.Now()
 	delivered := b.safeSend(sub, func() bool {
 		if b.options.WriteTimeout <= 0 {
-			sub.ch <- event
-			return true
+			select {
+			case sub.ch <- event:
+				return true
+			case <-sub.done:
+				return false
+			}
 		}
 		timer := time.NewTimer(b.options.WriteTimeout)
 		defer timer.Stop()
 		select {
 		case sub.ch <- event:
 			return true
+		case <-sub.done:
+			return false
 		case <-timer.C:
 			return false
 		}
@@ -306,6 +325,13 @@ func (b *Bus[T]) safeSend(sub subscription[T], send func() bool) (delivered bool
 			delivered = false
 		}
 	}()
+	sub.sendMu.RLock()
+	defer sub.sendMu.RUnlock()
+	select {
+	case <-sub.done:
+		return false
+	default:
+	}
 	return send()
 }
 
@@ -313,14 +339,14 @@ func (b *Bus[T]) removeSubscriber(id uint64) {
 	if b == nil {
 		return
 	}
-	var ch chan T
+	var sub subscription[T]
 	var filtered int
 	var unfiltered int
 	removed := false
 	b.mu.Lock()
 	if existing, ok := b.subscribers[id]; ok {
 		delete(b.subscribers, id)
-		ch = existing.ch
+		sub = existing
 		removed = true
 	}
 	if removed {
@@ -328,10 +354,8 @@ func (b *Bus[T]) removeSubscriber(id uint64) {
 	}
 	b.mu.Unlock()
 
-	if removed && ch != nil {
-		close(ch)
-	}
 	if removed {
+		sub.close()
 		b.setSubscriberCounts(filtered, unfiltered)
 	}
 }
@@ -198,6 +198,43 @@ func TestBusBlockOnFullTimeout(t *testing.T) {
 	}
 }
 
+func TestBusCloseReleasesBlockedPublish(t *testing.T) {
+	bus := NewBus[int](context.Background(), BusOptions{
+		Name:                 "block",
+		SubscriberBufferSize: 1,
+		BlockOnFull:          true,
+	})
+
+	ch, _ := bus.Subscribe()
+	bus.Publish(1)
+
+	done := make(chan struct{})
+	go func() {
+		bus.Publish(2)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("publish returned too early in block mode")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	bus.Close()
+
+	select {
+	case <-done:
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("publish did not return after close")
+	}
+	if got := <-ch; got != 1 {
+		t.Fatalf("expected buffered event, got %d", got)
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("expected subscriber channel to be closed")
+	}
+}
+
 func TestBusSubscribeFiltered(t *testing.T) {
 	bus := NewBus[int](context.Background(), BusOptions{})
 	t.Cleanup(bus.Close)
@@ -179,6 +179,7 @@ func newWatcherFilter() *watcherFilter {
 	return &watcherFilter{
 		allowed: map[string]struct{}{
 			watcher.EventTypeFileChanged:      {},
+			watcher.EventTypeRenamed:          {},
 			watcher.EventTypeGitBranchChanged: {},
 		},
 		limiter: newBridgeLimiter(defaultWatcherRateLimit, defaultWatcherWindow),
@@ -62,6 +62,7 @@ func NormalizeEvent(source any) map[string]string {
 func NormalizeWatcherEvent(event watcher.Event) map[string]string {
 	fields := baseFields(event.Type, event.Timestamp)
 	setField(fields, "path", event.Path)
+	setField(fields, "old_path", event.OldPath)
 	if op := strings.TrimSpace(event.Op.String()); op != "" {
 		setField(fields, "op", op)
 	}
@@ -2,58 +2,113 @@ package flow
 
 import "strings"
 
+type notifyTypeRoute struct {
+	name      string
+	eventType string
+}
+
+// notifyTypeAliases are the payload types routed to a specific flow event,
+// in the order KnownNotifyTypes reports them.
+var notifyTypeAliases = []notifyTypeRoute{
+	{"agent-turn-complete", "agent-turn-complete"},
+	{"plan-new", "plan-new"},
+	{"new-plan", "plan-new"},
+	{"plan-update", "plan-update"},
+	{"progress", "plan-update"},
+	{"work-start", "work-start"},
+	{"start", "work-start"},
+	{"work-progress", "work-progress"},
+	{"work-finish", "work-finish"},
+	{"finish", "work-finish"},
+	{"git-commit", "git-commit"},
+	{"commit", "git-commit"},
+	{"prompt-voice", "prompt-voice"},
+	{"prompt-text", "prompt-text"},
+}
+
+// notifyTypePrefixes route any payload type starting with name, e.g.
+// plan-L1-wip. They are checked in order after the exact aliases.
+var notifyTypePrefixes = []notifyTypeRoute{
+	{"plan-", "plan-update"},
+	{"prompt-voice", "prompt-voice"},
+	{"prompt-text", "prompt-text"},
+	{"agent-turn", "agent-turn-complete"},
+}
+
 func CanonicalNotifyEventType(raw string) string {
-	normalized := normalizeNotifyToken(raw)
-	if normalized == "" {
-		return "agent-turn-complete"
+	if eventType, ok := lookupNotifyType(NormalizeNotifyType(raw)); ok {
+		return eventType
 	}
-	switch normalized {
-	case "new-plan", "plan-new":
-		return "plan-new"
-	case "plan-update":
-		return "plan-update"
-	case "progress":
-		return "plan-update"
-	case "start", "work-start":
-		return "work-start"
-	case "work-progress":
-		return "work-progress"
-	case "finish", "work-finish":
-		return "work-finish"
-	case "git-commit", "commit":
-		return "git-commit"
-	case "prompt-voice":
-		return "prompt-voice"
-	case "prompt-text":
-		return "prompt-text"
-	default:
-		if strings.HasPrefix(normalized, "plan-") {
-			return "plan-update"
-		}
-		if strings.HasPrefix(normalized, "work-") {
-			switch strings.TrimPrefix(normalized, "work-") {
-			case "start":
-				return "work-start"
-			case "progress":
-				return "work-progress"
-			case "finish":
-				return "work-finish"
-			}
+	return "agent-turn-complete"
+}
+
+// IsKnownNotifyType reports whether raw names a payload type that is routed
+// to a specific event rather than falling back to agent-turn-complete.
+func IsKnownNotifyType(raw string) bool {
+	_, ok := lookupNotifyType(NormalizeNotifyType(raw))
+	return ok
+}
+
+// KnownNotifyTypes lists the accepted payload types, with prefixes shown as
+// patterns such as plan-*.
+func KnownNotifyTypes() []string {
+	names := make([]string, 0, len(notifyTypeAliases)+len(notifyTypePrefixes))
+	for _, alias := range notifyTypeAliases {
+		names = append(names, alias.name)
+	}
+	for _, prefix := range notifyTypePrefixes {
+		names = append(names, prefix.name+"*")
+	}
+	return names
+}
+
+// NotifyEventTypes lists the flow event types notify payloads are routed to,
+// in the order of the first alias for each.
+func NotifyEventTypes() []string {
+	seen := make(map[string]struct{}, len(notifyTypeAliases))
+	eventTypes := make([]string, 0, len(notifyTypeAliases))
+	for _, alias := range notifyTypeAliases {
+		if _, ok := seen[alias.eventType]; ok {
+			continue
 		}
-		if strings.HasPrefix(normalized, "prompt-voice") {
-			return "prompt-voice"
+		seen[alias.eventType] = struct{}{}
+		eventTypes = append(eventTypes, alias.eventType)
+	}
+	return eventTypes
+}
+
+// IsNotifyEventType reports whether eventType is a flow event type that
+// notify payloads are routed to.
+func IsNotifyEventType(eventType string) bool {
+	eventType = strings.ToLower(strings.TrimSpace(eventType))
+	for _, alias := range notifyTypeAliases {
+		if alias.eventType == eventType {
+			return true
 		}
-		if strings.HasPrefix(normalized, "prompt-text") {
-			return "prompt-text"
+	}
+	return false
+}
+
+func lookupNotifyType(normalized string) (string, bool) {
+	if normalized == "" {
+		return "", false
+	}
+	for _, alias := range notifyTypeAliases {
+		if normalized == alias.name {
+			return alias.eventType, true
 		}
-		if strings.HasPrefix(normalized, "agent-turn") {
-			return "agent-turn-complete"
+	}
+	for _, prefix := range notifyTypePrefixes {
+		if strings.HasPrefix(normalized, prefix.name) {
+			return prefix.eventType, true
 		}
-		return "agent-turn-complete"
 	}
+	return "", false
 }
 
-func normalizeNotifyToken(raw string) string {
+// NormalizeNotifyType lower-cases raw and collapses runs of other characters
+// to a single dash, so "New Plan" and "new__plan" both become "new-plan".
+func NormalizeNotifyType(raw string) string {
 	lower := strings.ToLower(raw)
 	var builder strings.Builder
 	builder.Grow(len(lower))
@@ -1,6 +1,9 @@
 package flow
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestCanonicalNotifyEventType(testingContext *testing.T) {
 	cases := map[string]string{
@@ -32,3 +35,41 @@ func TestCanonicalNotifyEventType(testingContext *testing.T) {
 		}
 	}
 }
+
+func TestKnownNotifyTypesAreRecognised(testingContext *testing.T) {
+	for _, name := range KnownNotifyTypes() {
+		value := name
+		if prefix, ok := strings.CutSuffix(name, "*"); ok {
+			value = prefix + "x"
+		}
+		if !IsKnownNotifyType(value) {
+			testingContext.Fatalf("expected listed type %q to be known", name)
+		}
+	}
+	for _, value := range []string{"", "custom-type", "work-later"} {
+		if IsKnownNotifyType(value) {
+			testingContext.Fatalf("expected %q to be unknown", value)
+		}
+	}
+}
+
+func TestNotifyEventTypesCoverEveryRoute(testingContext *testing.T) {
+	eventTypes := NotifyEventTypes()
+	for _, name := range KnownNotifyTypes() {
+		value := strings.TrimSuffix(name, "*") + "x"
+		if !strings.HasSuffix(name, "*") {
+			value = name
+		}
+		eventType := CanonicalNotifyEventType(value)
+		found := false
+		for _, listed := range eventTypes {
+			found = found || listed == eventType
+		}
+		if !found || !IsNotifyEventType(eventType) {
+			testingContext.Fatalf("expected %q (from %q) among notify event types %v", eventType, name, eventTypes)
+		}
+	}
+	if IsNotifyEventType("file-change") || IsNotifyEventType("new-plan") {
+		testingContext.Fatalf("expected only canonical notify event types")
+	}
+}
@@ -78,7 +78,7 @@ func validateTriggerWhere(trigger EventTrigger) error {
 	if len(trigger.Where) == 0 {
 		return nil
 	}
-	if !isNotifyEventType(trigger.EventType) {
+	if !IsNotifyEventType(trigger.EventType) {
 		return nil
 	}
 	allowed := map[string]struct{}{
@@ -96,15 +96,6 @@ func validateTriggerWhere(trigger EventTrigger) error {
 	return nil
 }
 
-func isNotifyEventType(eventType string) bool {
-	switch strings.ToLower(strings.TrimSpace(eventType)) {
-	case "plan-new", "plan-update", "work-start", "work-progress", "work-finish", "agent-turn-complete", "prompt-voice", "prompt-text":
-		return true
-	default:
-		return false
-	}
-}
-
 func validateActivityConfig(def ActivityDef, config map[string]any) error {
 	if config == nil {
 		config = map[string]any{}
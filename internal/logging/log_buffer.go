@@ -9,6 +9,7 @@ import (
 type LogBuffer struct {
 	mu      sync.Mutex
 	entries *buffer.Ring[LogEntry]
+	nextID  uint64
 }
 
 func NewLogBuffer(size int) *LogBuffer {
@@ -17,15 +18,35 @@ func NewLogBuffer(size int) *LogBuffer {
 	}
 }
 
-func (b *LogBuffer) Add(entry LogEntry) {
+// Add stores entry with the next sequence ID and returns it with the ID set.
+func (b *LogBuffer) Add(entry LogEntry) LogEntry {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	if b.entries == nil {
-		return
+		return entry
 	}
 
+	b.nextID++
+	entry.ID = b.nextID
 	b.entries.Add(entry)
+	return entry
+}
+
+// Get returns the entry with id, or false once it has aged out of the buffer.
+func (b *LogBuffer) Get(id uint64) (LogEntry, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.entries == nil || id == 0 {
+		return LogEntry{}, false
+	}
+	for _, entry := range b.entries.List() {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return LogEntry{}, false
 }
 
 func (b *LogBuffer) List() []LogEntry {
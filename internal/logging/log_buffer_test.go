@@ -64,3 +64,21 @@ func TestLogBufferConcurrentAdds(t *testing.T) {
 		t.Fatalf("expected 50 entries, got %d", len(entries))
 	}
 }
+
+func TestLogBufferAssignsIDsAndGet(t *testing.T) {
+	buffer := NewLogBuffer(2)
+	first := buffer.Add(LogEntry{Message: "first"})
+	second := buffer.Add(LogEntry{Message: "second"})
+	if first.ID != 1 || second.ID != 2 {
+		t.Fatalf("expected ids 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+	buffer.Add(LogEntry{Message: "third"})
+
+	if _, ok := buffer.Get(first.ID); ok {
+		t.Fatalf("expected first entry to have aged out")
+	}
+	entry, ok := buffer.Get(second.ID)
+	if !ok || entry.Message != "second" {
+		t.Fatalf("expected second entry, got %#v (found %v)", entry, ok)
+	}
+}
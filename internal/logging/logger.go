@@ -27,6 +27,7 @@ type Logger struct {
 	baseContext map[string]string
 	logBus      *event.Bus[LogEntry]
 	otelLogger  otellog.Logger
+	sampler     *logSampler
 }
 
 func NewLogger(buffer *LogBuffer, minLevel Level) *Logger {
@@ -52,6 +53,7 @@ func NewLoggerWithOutput(buffer *LogBuffer, minLevel Level, output io.Writer) *L
 		minLevel:   normalizeLevel(minLevel),
 		logBus:     logBus,
 		otelLogger: logglobal.Logger("gestalt/internal/logging"),
+		sampler:    newLogSampler(),
 	}
 }
 
@@ -87,6 +89,7 @@ func (l *Logger) With(fields map[string]string) *Logger {
 		baseContext: cloneFields(l.baseContext, fields),
 		logBus:      l.logBus,
 		otelLogger:  l.otelLogger,
+		sampler:     l.sampler,
 	}
 }
 
@@ -129,7 +132,7 @@ func (l *Logger) log(level Level, message string, fields map[string]string) {
 		entry.Context = nil
 	}
 	if l.buffer != nil {
-		l.buffer.Add(entry)
+		entry = l.buffer.Add(entry)
 	}
 	if l.logBus != nil {
 		l.logBus.Publish(entry)
@@ -247,6 +250,9 @@ func (l *Logger) emitOTel(entry LogEntry) {
 	record.SetSeverity(severity)
 	record.SetSeverityText(string(entry.Level))
 	record.SetBody(otellog.StringValue(entry.Message))
+	if entry.ID != 0 {
+		record.AddAttributes(otellog.Int64("gestalt.log_id", int64(entry.ID)))
+	}
 
 	if len(entry.Context) > 0 {
 		attrs := make([]otellog.KeyValue, 0, len(entry.Context))
@@ -3,6 +3,7 @@ package logging
 import (
 	"context"
 	"io"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -174,3 +175,60 @@ func TestLoggerEmitsOTelLogRecord(t *testing.T) {
 		t.Fatalf("expected test_id attribute, got %v", attrs["test_id"])
 	}
 }
+
+func TestLoggerDebugSampledLogsOneInN(t *testing.T) {
+	buffer := NewLogBuffer(20)
+	logger := NewLoggerWithOutput(buffer, LevelDebug, io.Discard)
+
+	for i := 0; i < 7; i++ {
+		logger.DebugSampled("chunk", 3, "output chunk", map[string]string{"index": strconv.Itoa(i)})
+	}
+
+	entries := buffer.List()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 sampled entries, got %d", len(entries))
+	}
+	for i, want := range []string{"0", "3", "6"} {
+		if entries[i].Context["index"] != want {
+			t.Fatalf("entry %d: expected index %s, got %v", i, want, entries[i].Context)
+		}
+		if entries[i].Context["sample_rate"] != "1/3" {
+			t.Fatalf("entry %d: expected sample_rate 1/3, got %v", i, entries[i].Context)
+		}
+	}
+	if _, ok := entries[0].Context["sample_skipped"]; ok {
+		t.Fatalf("expected no skipped count on first entry, got %v", entries[0].Context)
+	}
+	if entries[1].Context["sample_skipped"] != "2" {
+		t.Fatalf("expected 2 skipped before second entry, got %v", entries[1].Context)
+	}
+}
+
+func TestLoggerDebugSampledRespectsLevelAndInterval(t *testing.T) {
+	buffer := NewLogBuffer(20)
+	infoLogger := NewLoggerWithOutput(buffer, LevelInfo, io.Discard)
+	infoLogger.DebugSampled("chunk", 1, "output chunk", nil)
+	if len(buffer.List()) != 0 {
+		t.Fatalf("expected debug sampling to respect min level")
+	}
+
+	logger := NewLoggerWithOutput(buffer, LevelDebug, io.Discard)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	logger.sampler.now = func() time.Time { return now }
+	logger.With(map[string]string{"component": "pty"}).SetSampling(SamplingOptions{Interval: time.Minute})
+
+	logger.DebugSampled("chunk", 1, "output chunk", nil)
+	logger.DebugSampled("chunk", 1, "output chunk", nil)
+	now = now.Add(time.Minute)
+	logger.DebugSampled("chunk", 1, "output chunk", nil)
+	if got := len(buffer.List()); got != 2 {
+		t.Fatalf("expected interval to limit entries to 2, got %d", got)
+	}
+
+	logger.SetSampling(SamplingOptions{Disabled: true})
+	logger.DebugSampled("chunk", 100, "output chunk", nil)
+	logger.DebugSampled("chunk", 100, "output chunk", nil)
+	if got := len(buffer.List()); got != 4 {
+		t.Fatalf("expected disabled sampling to log every call, got %d", got)
+	}
+}
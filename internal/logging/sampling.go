@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SamplingOptions tune DebugSampled for every logger derived from the one
+// they are set on.
+type SamplingOptions struct {
+	// Interval additionally rate-limits each key to at most one entry per
+	// interval; zero leaves only the 1-in-N sampling.
+	Interval time.Duration
+	// Disabled logs every DebugSampled call, for chasing a hot path itself.
+	Disabled bool
+}
+
+type logSampler struct {
+	mu      sync.Mutex
+	options SamplingOptions
+	keys    map[string]*sampleState
+	now     func() time.Time
+}
+
+type sampleState struct {
+	count    uint64
+	skipped  uint64
+	lastEmit time.Time
+}
+
+func newLogSampler() *logSampler {
+	return &logSampler{
+		keys: make(map[string]*sampleState),
+		now:  time.Now,
+	}
+}
+
+// allow reports whether the call for key should be logged and how many calls
+// were skipped since the key last logged.
+func (s *logSampler) allow(key string, every int) (bool, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.options.Disabled {
+		return true, 0
+	}
+	state, ok := s.keys[key]
+	if !ok {
+		state = &sampleState{}
+		s.keys[key] = state
+	}
+	state.count++
+	emit := every <= 1 || state.count%uint64(every) == 1
+	now := s.now()
+	if emit && s.options.Interval > 0 && !state.lastEmit.IsZero() && now.Sub(state.lastEmit) < s.options.Interval {
+		emit = false
+	}
+	if !emit {
+		state.skipped++
+		return false, 0
+	}
+	skipped := state.skipped
+	state.skipped = 0
+	state.lastEmit = now
+	return true, skipped
+}
+
+// SetSampling replaces the sampling options used by DebugSampled.
+func (l *Logger) SetSampling(options SamplingOptions) {
+	if l == nil || l.sampler == nil {
+		return
+	}
+	l.sampler.mu.Lock()
+	l.sampler.options = options
+	l.sampler.mu.Unlock()
+}
+
+// DebugSampled logs a debug entry for the first call with key and then once
+// every n calls, so per-line or per-chunk diagnostics stay readable. Emitted
+// entries carry sample_rate and, after a gap, sample_skipped.
+func (l *Logger) DebugSampled(key string, n int, message string, fields map[string]string) {
+	if l == nil || !l.Enabled(LevelDebug) {
+		return
+	}
+	if l.sampler == nil {
+		l.log(LevelDebug, message, fields)
+		return
+	}
+	if key == "" {
+		key = message
+	}
+	ok, skipped := l.sampler.allow(key, n)
+	if !ok {
+		return
+	}
+	extra := map[string]string{"sample_rate": "1/" + strconv.Itoa(max(n, 1))}
+	if skipped > 0 {
+		extra["sample_skipped"] = strconv.FormatUint(skipped, 10)
+	}
+	l.log(LevelDebug, message, cloneFields(fields, extra))
+}
@@ -12,6 +12,9 @@ const (
 )
 
 type LogEntry struct {
+	// ID is assigned by LogBuffer.Add and increases with each entry, so it
+	// stays valid for as long as the entry is buffered.
+	ID        uint64            `json:"id,omitempty"`
 	Timestamp time.Time         `json:"timestamp"`
 	Level     Level             `json:"level"`
 	Message   string            `json:"message"`
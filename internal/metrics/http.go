@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// httpLatencySamples is how many recent durations each route keeps for
+// percentile estimates.
+const httpLatencySamples = 1024
+
+type httpRouteKey struct {
+	route  string
+	method string
+}
+
+type httpRouteStats struct {
+	mu      sync.Mutex
+	count   int64
+	errors  int64
+	samples []time.Duration
+	next    int
+}
+
+// HTTPRouteSnapshot summarizes requests served by one route template and
+// method. Percentiles cover the most recent requests only.
+type HTTPRouteSnapshot struct {
+	Route  string
+	Method string
+	Count  int64
+	Errors int64
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+}
+
+// RecordHTTPRequest counts a request against its route template; responses
+// with a 4xx or 5xx status count as errors. Callers pass a template such as
+// "/api/sessions/:id" rather than the raw path to keep cardinality bounded.
+func (r *Registry) RecordHTTPRequest(route, method string, status int, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	key := httpRouteKey{
+		route:  normalizeMetricLabel(route, "unknown"),
+		method: normalizeHTTPMethod(method),
+	}
+	value, _ := r.httpRoutes.LoadOrStore(key, &httpRouteStats{})
+	stats := value.(*httpRouteStats)
+
+	stats.mu.Lock()
+	stats.count++
+	if status >= http.StatusBadRequest {
+		stats.errors++
+	}
+	if len(stats.samples) < httpLatencySamples {
+		stats.samples = append(stats.samples, duration)
+	} else {
+		stats.samples[stats.next] = duration
+		stats.next = (stats.next + 1) % httpLatencySamples
+	}
+	stats.mu.Unlock()
+}
+
+// normalizeHTTPMethod keeps the standard methods and folds anything else
+// into OTHER, so clients sending made-up methods cannot add route entries
+// without bound.
+func normalizeHTTPMethod(method string) string {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	}
+	return "OTHER"
+}
+
+// HTTPRouteSnapshots returns per-route request stats sorted by route and
+// method.
+func (r *Registry) HTTPRouteSnapshots() []HTTPRouteSnapshot {
+	if r == nil {
+		return nil
+	}
+	var snapshots []HTTPRouteSnapshot
+	r.httpRoutes.Range(func(key, value interface{}) bool {
+		routeKey, ok := key.(httpRouteKey)
+		if !ok {
+			return true
+		}
+		stats := value.(*httpRouteStats)
+		stats.mu.Lock()
+		snapshot := HTTPRouteSnapshot{
+			Route:  routeKey.route,
+			Method: routeKey.method,
+			Count:  stats.count,
+			Errors: stats.errors,
+		}
+		samples := append([]time.Duration(nil), stats.samples...)
+		stats.mu.Unlock()
+
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		snapshot.P50 = percentile(samples, 0.50)
+		snapshot.P90 = percentile(samples, 0.90)
+		snapshot.P99 = percentile(samples, 0.99)
+		snapshots = append(snapshots, snapshot)
+		return true
+	})
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].Route == snapshots[j].Route {
+			return snapshots[i].Method < snapshots[j].Method
+		}
+		return snapshots[i].Route < snapshots[j].Route
+	})
+	return snapshots
+}
+
+// percentile uses the nearest-rank method on sorted samples.
+func percentile(sorted []time.Duration, quantile float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(quantile*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+func (r *Registry) writeHTTPPrometheus(writer io.Writer) {
+	snapshots := r.HTTPRouteSnapshots()
+
+	writeHelp(writer, "gestalt_http_requests_total", "HTTP requests by route")
+	fmt.Fprintln(writer, "# TYPE gestalt_http_requests_total counter")
+	writeHelp(writer, "gestalt_http_request_errors_total", "HTTP requests answered with a 4xx or 5xx status")
+	fmt.Fprintln(writer, "# TYPE gestalt_http_request_errors_total counter")
+	writeHelp(writer, "gestalt_http_request_duration_seconds", "Recent HTTP request latency by route")
+	fmt.Fprintln(writer, "# TYPE gestalt_http_request_duration_seconds summary")
+
+	for _, snapshot := range snapshots {
+		labels := fmt.Sprintf("route=%s,method=%s", formatLabel(snapshot.Route), formatLabel(snapshot.Method))
+		fmt.Fprintf(writer, "gestalt_http_requests_total{%s} %d\n", labels, snapshot.Count)
+		fmt.Fprintf(writer, "gestalt_http_request_errors_total{%s} %d\n", labels, snapshot.Errors)
+		fmt.Fprintf(writer, "gestalt_http_request_duration_seconds{%s,quantile=\"0.5\"} %.6f\n", labels, snapshot.P50.Seconds())
+		fmt.Fprintf(writer, "gestalt_http_request_duration_seconds{%s,quantile=\"0.9\"} %.6f\n", labels, snapshot.P90.Seconds())
+		fmt.Fprintf(writer, "gestalt_http_request_duration_seconds{%s,quantile=\"0.99\"} %.6f\n", labels, snapshot.P99.Seconds())
+	}
+}
@@ -23,6 +23,7 @@ type Registry struct {
 	activities        sync.Map
 	eventBuses        sync.Map
 	eventTypes        sync.Map
+	httpRoutes        sync.Map
 	otelOnce          sync.Once
 	otelMetrics       *otelRegistry
 }
@@ -279,9 +280,28 @@ func (r *Registry) WritePrometheus(writer io.Writer) error {
 		fmt.Fprintf(writer, "gestalt_activity_retries_total{activity=%s} %d\n", label, stats.retries.Load())
 	}
 
+	r.writeHTTPPrometheus(writer)
+
 	return nil
 }
 
+// Reset zeroes the workflow counters and drops activity, event-type, and
+// HTTP route stats.
+// Subscriber gauges reflect live state and are kept; OpenTelemetry instruments
+// are cumulative and are not affected.
+func (r *Registry) Reset() {
+	if r == nil {
+		return
+	}
+	r.workflowStarted.Store(0)
+	r.workflowCompleted.Store(0)
+	r.workflowFailed.Store(0)
+	r.workflowPaused.Store(0)
+	r.activities.Clear()
+	r.eventTypes.Clear()
+	r.httpRoutes.Clear()
+}
+
 func (r *Registry) EventBusSnapshots() []EventBusSnapshot {
 	if r == nil {
 		return nil
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistryResetZeroesCounts(t *testing.T) {
+	registry := &Registry{}
+	registry.IncWorkflowStarted()
+	registry.IncWorkflowFailed()
+	registry.RecordActivity("send", time.Second, errors.New("boom"), 2)
+	registry.IncEventPublished("terminal_events", "terminal_created")
+	registry.SetEventSubscriberCounts("terminal_events", 1, 2)
+
+	registry.Reset()
+
+	var out bytes.Buffer
+	if err := registry.WritePrometheus(&out); err != nil {
+		t.Fatalf("write prometheus: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		"gestalt_workflows_started_total 0",
+		"gestalt_workflows_failed_total 0",
+		"gestalt_event_subscribers{bus=\"terminal_events\",filtered=\"false\"} 2",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output:\n%s", want, text)
+		}
+	}
+	for _, unwanted := range []string{"activity=\"send\"", "type=\"terminal_created\""} {
+		if strings.Contains(text, unwanted) {
+			t.Fatalf("expected %q to be cleared:\n%s", unwanted, text)
+		}
+	}
+
+	registry.RecordActivity("send", time.Second, nil, 1)
+	out.Reset()
+	_ = registry.WritePrometheus(&out)
+	if !strings.Contains(out.String(), "gestalt_activity_duration_seconds_count{activity=\"send\"} 1") {
+		t.Fatalf("expected counting to resume after reset:\n%s", out.String())
+	}
+}
+
+func TestRecordHTTPRequestPercentiles(t *testing.T) {
+	registry := &Registry{}
+	for i := 1; i <= 100; i++ {
+		status := 200
+		if i%10 == 0 {
+			status = 500
+		}
+		registry.RecordHTTPRequest("/api/sessions/:id", "get", status, time.Duration(i)*time.Millisecond)
+	}
+
+	snapshots := registry.HTTPRouteSnapshots()
+	if len(snapshots) != 1 {
+		t.Fatalf("expected 1 route, got %#v", snapshots)
+	}
+	snapshot := snapshots[0]
+	if snapshot.Method != "GET" || snapshot.Count != 100 || snapshot.Errors != 10 {
+		t.Fatalf("unexpected snapshot: %#v", snapshot)
+	}
+	if snapshot.P50 != 50*time.Millisecond || snapshot.P90 != 90*time.Millisecond || snapshot.P99 != 99*time.Millisecond {
+		t.Fatalf("unexpected percentiles: %#v", snapshot)
+	}
+
+	var out bytes.Buffer
+	if err := registry.WritePrometheus(&out); err != nil {
+		t.Fatalf("write prometheus: %v", err)
+	}
+	if !strings.Contains(out.String(), `gestalt_http_requests_total{route="/api/sessions/:id",method="GET"} 100`) {
+		t.Fatalf("expected route counter in output:\n%s", out.String())
+	}
+
+	registry.Reset()
+	if snapshots := registry.HTTPRouteSnapshots(); len(snapshots) != 0 {
+		t.Fatalf("expected reset to drop route stats, got %#v", snapshots)
+	}
+}
+
+func TestRecordHTTPRequestFoldsUnknownMethods(t *testing.T) {
+	registry := &Registry{}
+	for i := 0; i < 50; i++ {
+		registry.RecordHTTPRequest("/api/sessions", fmt.Sprintf("FOO%d", i), 401, time.Millisecond)
+	}
+	registry.RecordHTTPRequest("/api/sessions", "post", 201, time.Millisecond)
+
+	snapshots := registry.HTTPRouteSnapshots()
+	if len(snapshots) != 2 {
+		t.Fatalf("expected OTHER and POST entries, got %#v", snapshots)
+	}
+	methods := map[string]int64{}
+	for _, snapshot := range snapshots {
+		methods[snapshot.Method] = snapshot.Count
+	}
+	if methods["OTHER"] != 50 || methods["POST"] != 1 {
+		t.Fatalf("unexpected method counts: %v", methods)
+	}
+}
@@ -56,6 +56,9 @@ type CollectorInfo struct {
 	HTTPEndpoint   string
 	RemoteEndpoint string
 	RemoteInsecure bool
+	// SelfMetricsEnabled reports whether the collector exports its own
+	// metrics into the metrics file.
+	SelfMetricsEnabled bool
 }
 
 type CollectorStatus struct {
@@ -191,13 +194,14 @@ func StartCollector(options Options) (*Collector, error) {
 		stderr:     stderr,
 		configPath: options.ConfigPath,
 		info: CollectorInfo{
-			StartTime:      time.Now().UTC(),
-			ConfigPath:     options.ConfigPath,
-			DataPath:       dataPath,
-			GRPCEndpoint:   options.GRPCEndpoint,
-			HTTPEndpoint:   options.HTTPEndpoint,
-			RemoteEndpoint: options.RemoteEndpoint,
-			RemoteInsecure: options.RemoteInsecure,
+			StartTime:          time.Now().UTC(),
+			ConfigPath:         options.ConfigPath,
+			DataPath:           dataPath,
+			GRPCEndpoint:       options.GRPCEndpoint,
+			HTTPEndpoint:       options.HTTPEndpoint,
+			RemoteEndpoint:     options.RemoteEndpoint,
+			RemoteInsecure:     options.RemoteInsecure,
+			SelfMetricsEnabled: options.SelfMetricsEnabled,
 		},
 		logger:       options.Logger,
 		options:      options,
@@ -631,13 +635,14 @@ func (collector *Collector) restartProcess() error {
 	collector.stderr = stderr
 	collector.configPath = options.ConfigPath
 	collector.info = CollectorInfo{
-		StartTime:      time.Now().UTC(),
-		ConfigPath:     options.ConfigPath,
-		DataPath:       dataPath,
-		GRPCEndpoint:   options.GRPCEndpoint,
-		HTTPEndpoint:   options.HTTPEndpoint,
-		RemoteEndpoint: options.RemoteEndpoint,
-		RemoteInsecure: options.RemoteInsecure,
+		StartTime:          time.Now().UTC(),
+		ConfigPath:         options.ConfigPath,
+		DataPath:           dataPath,
+		GRPCEndpoint:       options.GRPCEndpoint,
+		HTTPEndpoint:       options.HTTPEndpoint,
+		RemoteEndpoint:     options.RemoteEndpoint,
+		RemoteInsecure:     options.RemoteInsecure,
+		SelfMetricsEnabled: options.SelfMetricsEnabled,
 	}
 	collector.logger = options.Logger
 	collector.mu.Unlock()
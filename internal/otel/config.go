@@ -77,9 +77,21 @@ func buildCollectorConfig(grpcEndpoint, httpEndpoint, dataPath, remoteEndpoint s
 		}
 	}
 	builder.WriteString("\nservice:\n")
-	if !selfMetricsEnabled {
-		builder.WriteString("  telemetry:\n")
-		builder.WriteString("    metrics:\n")
+	builder.WriteString("  telemetry:\n")
+	builder.WriteString("    metrics:\n")
+	if selfMetricsEnabled {
+		// Push self-metrics into our own receiver so they land in the
+		// metrics file next to everything else.
+		builder.WriteString("      level: normal\n")
+		builder.WriteString("      readers:\n")
+		builder.WriteString("        - periodic:\n")
+		builder.WriteString("            exporter:\n")
+		builder.WriteString("              otlp:\n")
+		builder.WriteString("                protocol: http/protobuf\n")
+		builder.WriteString("                endpoint: ")
+		builder.WriteString(strconv.Quote("http://" + httpEndpoint))
+		builder.WriteString("\n")
+	} else {
 		builder.WriteString("      level: none\n")
 		builder.WriteString("      readers: []\n")
 	}
@@ -35,6 +35,24 @@ func TestWriteCollectorConfigWritesFile(t *testing.T) {
 	}
 }
 
+func TestWriteCollectorConfigExportsSelfMetrics(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "collector.yaml")
+	if err := WriteCollectorConfig(configPath, "otel.json", "127.0.0.1:4317", "127.0.0.1:4318", "", false, true); err != nil {
+		t.Fatalf("WriteCollectorConfig failed: %v", err)
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	text := string(content)
+	if strings.Contains(text, "level: none") {
+		t.Fatalf("expected telemetry metrics enabled in config: %s", text)
+	}
+	if !strings.Contains(text, "protocol: http/protobuf") || !strings.Contains(text, "endpoint: \"http://127.0.0.1:4318\"") {
+		t.Fatalf("expected self-metrics exported to the local receiver: %s", text)
+	}
+}
+
 func TestOptionsFromEnvDefaults(t *testing.T) {
 	opts := OptionsFromEnv("state")
 	if !opts.Enabled {
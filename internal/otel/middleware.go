@@ -357,6 +357,12 @@ func (err *apiErrorWrapper) Error() string {
 	return err.message
 }
 
+// RouteTemplate returns the normalized route for r, such as
+// "/api/sessions/:id/output", falling back to the raw path.
+func RouteTemplate(r *http.Request) string {
+	return resolveRouteInfo(r).Route
+}
+
 func resolveRouteInfo(r *http.Request) RouteInfo {
 	info := RouteInfo{}
 	if r != nil {
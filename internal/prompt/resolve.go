@@ -47,3 +47,21 @@ func ResolvePromptPath(promptFS fs.FS, promptDir, promptName string) (string, er
 	}
 	return "", fmt.Errorf("prompt %q not found", promptName)
 }
+
+// EnvVariantName returns the env-specific variant of promptName, inserting
+// env before the extension when promptName has one ("codex.txt" becomes
+// "codex.staging.txt", "codex" becomes "codex.staging"). It returns "" when
+// env is empty or not a plain name.
+func EnvVariantName(promptName, env string) string {
+	promptName = strings.TrimSpace(promptName)
+	env = strings.TrimSpace(env)
+	if promptName == "" || env == "" || env == "." || env == ".." || strings.ContainsAny(env, `/\`) {
+		return ""
+	}
+	extension := path.Ext(promptName)
+	switch strings.ToLower(extension) {
+	case ".tmpl", ".txt", ".md":
+		return strings.TrimSuffix(promptName, extension) + "." + env + extension
+	}
+	return promptName + "." + env
+}
@@ -0,0 +1,23 @@
+package prompt
+
+import "testing"
+
+func TestEnvVariantName(t *testing.T) {
+	cases := []struct {
+		name   string
+		env    string
+		expect string
+	}{
+		{name: "codex", env: "staging", expect: "codex.staging"},
+		{name: "codex.txt", env: "staging", expect: "codex.staging.txt"},
+		{name: "notes.MD", env: "prod", expect: "notes.prod.MD"},
+		{name: "codex", env: "", expect: ""},
+		{name: "codex", env: "../x", expect: ""},
+		{name: "", env: "staging", expect: ""},
+	}
+	for _, tc := range cases {
+		if got := EnvVariantName(tc.name, tc.env); got != tc.expect {
+			t.Fatalf("EnvVariantName(%q, %q) = %q, want %q", tc.name, tc.env, got, tc.expect)
+		}
+	}
+}
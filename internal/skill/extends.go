@@ -0,0 +1,119 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ResolveExtends merges base skill metadata into skills that declare an
+// extends key. Child values win: license and allowed_tools are inherited only
+// when the child leaves them empty, and metadata keys are merged with the
+// child's entries taking precedence. Skills whose base is missing, invalid,
+// or part of a cycle are reported in the returned map and left unresolved.
+func ResolveExtends(skills map[string]*Skill) map[string]error {
+	return ResolveExtendsWithParents(skills, nil)
+}
+
+// ResolveExtendsWithParents is ResolveExtends where a base not among skills
+// is looked up in parents, typically skills that are already resolved from
+// the config directory. A skill that extends its own name builds on the
+// parent of that name. Parents are not modified.
+func ResolveExtendsWithParents(skills, parents map[string]*Skill) map[string]error {
+	resolver := extendsResolver{
+		skills:  skills,
+		parents: parents,
+		state:   make(map[string]int, len(skills)),
+		errs:    make(map[string]error),
+	}
+	for id := range skills {
+		resolver.resolve(id, nil)
+	}
+	return resolver.errs
+}
+
+const (
+	extendsVisiting = iota + 1
+	extendsResolved
+)
+
+type extendsCycleError struct {
+	chain []string
+}
+
+func (e extendsCycleError) Error() string {
+	return fmt.Sprintf("skill extends cycle: %s", strings.Join(e.chain, " -> "))
+}
+
+type extendsResolver struct {
+	skills  map[string]*Skill
+	parents map[string]*Skill
+	state   map[string]int
+	errs    map[string]error
+}
+
+func (r *extendsResolver) resolve(id string, chain []string) error {
+	switch r.state[id] {
+	case extendsResolved:
+		return r.errs[id]
+	case extendsVisiting:
+		return extendsCycleError{chain: append(chain, id)}
+	}
+
+	child := r.skills[id]
+	if child == nil || child.Extends == "" {
+		r.state[id] = extendsResolved
+		return nil
+	}
+
+	r.state[id] = extendsVisiting
+	var err error
+	base, ok := r.skills[child.Extends]
+	if child.Extends == id || !ok {
+		base, ok = r.parents[child.Extends]
+		if ok && base != nil {
+			r.state[id] = extendsResolved
+			mergeBase(child, base)
+			return nil
+		}
+	}
+	switch {
+	case !ok || base == nil:
+		err = fmt.Errorf("base skill %q not found", child.Extends)
+	default:
+		if baseErr := r.resolve(child.Extends, append(chain, id)); baseErr != nil {
+			var cycle extendsCycleError
+			if errors.As(baseErr, &cycle) {
+				err = baseErr
+			} else {
+				err = fmt.Errorf("base skill %q: %w", child.Extends, baseErr)
+			}
+		}
+	}
+	r.state[id] = extendsResolved
+	if err != nil {
+		r.errs[id] = err
+		return err
+	}
+	mergeBase(child, base)
+	return nil
+}
+
+func mergeBase(child, base *Skill) {
+	if child.License == "" {
+		child.License = base.License
+	}
+	if len(child.AllowedTools) == 0 && len(base.AllowedTools) > 0 {
+		child.AllowedTools = append([]string(nil), base.AllowedTools...)
+	}
+	if len(base.Metadata) > 0 {
+		merged := make(map[string]any, len(base.Metadata)+len(child.Metadata))
+		for key, value := range base.Metadata {
+			merged[key] = value
+		}
+		for key, value := range child.Metadata {
+			merged[key] = value
+		}
+		child.Metadata = merged
+	}
+}
@@ -65,6 +65,11 @@ func (l Loader) Load(skillFS fs.FS, dir string) (map[string]*Skill, error) {
 		skills[skillID] = skill
 	}
 
+	for skillID, err := range ResolveExtends(skills) {
+		l.warnLoadError(skillID, path.Join(dir, skillID, "SKILL.md"), err)
+		delete(skills, skillID)
+	}
+
 	return skills, nil
 }
 
@@ -3,6 +3,7 @@ package skill
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"testing/fstest"
 
@@ -166,3 +167,83 @@ description: Helpful git workflows
 		t.Fatalf("missing git-workflows skill")
 	}
 }
+
+func TestLoaderResolvesExtends(t *testing.T) {
+	fsys := fstest.MapFS{
+		"skills/base/SKILL.md": &fstest.MapFile{Data: []byte(`---
+name: base
+description: Shared metadata
+license: MIT
+allowed_tools: [bash]
+metadata:
+  owner: platform
+  tier: core
+---
+`)},
+		"skills/child/SKILL.md": &fstest.MapFile{Data: []byte(`---
+name: child
+description: Child skill
+extends: base
+metadata:
+  tier: extra
+---
+`)},
+	}
+
+	skills, err := Loader{}.Load(fsys, "skills")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	child, ok := skills["child"]
+	if !ok {
+		t.Fatalf("missing child skill")
+	}
+	if child.License != "MIT" {
+		t.Fatalf("expected inherited license, got %q", child.License)
+	}
+	if len(child.AllowedTools) != 1 || child.AllowedTools[0] != "bash" {
+		t.Fatalf("expected inherited allowed tools, got %v", child.AllowedTools)
+	}
+	if child.Metadata["owner"] != "platform" || child.Metadata["tier"] != "extra" {
+		t.Fatalf("unexpected merged metadata: %v", child.Metadata)
+	}
+	if skills["base"].Metadata["tier"] != "core" {
+		t.Fatalf("base metadata should be unchanged, got %v", skills["base"].Metadata)
+	}
+}
+
+func TestLoaderExtendsErrorsDropSkills(t *testing.T) {
+	skillFile := func(name, extends string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte("---\nname: " + name + "\ndescription: test\nextends: " + extends + "\n---\n")}
+	}
+	fsys := fstest.MapFS{
+		"skills/alpha/SKILL.md":  skillFile("alpha", "beta"),
+		"skills/beta/SKILL.md":   skillFile("beta", "alpha"),
+		"skills/orphan/SKILL.md": skillFile("orphan", "missing"),
+	}
+
+	buffer := logging.NewLogBuffer(10)
+	logger := logging.NewLoggerWithOutput(buffer, logging.LevelInfo, nil)
+	skills, err := Loader{Logger: logger}.Load(fsys, "skills")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(skills) != 0 {
+		t.Fatalf("expected no skills, got %d", len(skills))
+	}
+
+	failed := map[string]string{}
+	for _, entry := range buffer.List() {
+		if entry.Message == "skill load failed" {
+			failed[entry.Context["skill_id"]] = entry.Context["error"]
+		}
+	}
+	for _, id := range []string{"alpha", "beta"} {
+		if !strings.Contains(failed[id], "cycle") {
+			t.Fatalf("expected cycle error for %s, got %q", id, failed[id])
+		}
+	}
+	if !strings.Contains(failed["orphan"], `base skill "missing" not found`) {
+		t.Fatalf("expected missing base error, got %q", failed["orphan"])
+	}
+}
@@ -0,0 +1,29 @@
+package skill
+
+import (
+	"github.com/invopop/jsonschema"
+
+	internalschema "gestalt/internal/schema"
+)
+
+// SchemaSkillFrontmatter is the schema registry key for SKILL.md frontmatter.
+const SchemaSkillFrontmatter = "skill"
+
+func init() {
+	_ = internalschema.Register(SchemaSkillFrontmatter, frontmatterSchema)
+}
+
+func frontmatterSchema() *jsonschema.Schema {
+	reflector := &jsonschema.Reflector{
+		AllowAdditionalProperties: false,
+		DoNotReference:            true,
+		ExpandedStruct:            true,
+		FieldNameTag:              "yaml",
+	}
+	s := reflector.Reflect(frontmatter{})
+	if s.Version == "" {
+		s.Version = jsonschema.Version
+	}
+	s.Title = "Gestalt SKILL.md frontmatter"
+	return s
+}
@@ -0,0 +1,32 @@
+package skill
+
+import (
+	"testing"
+
+	internalschema "gestalt/internal/schema"
+)
+
+func TestSkillFrontmatterSchema(t *testing.T) {
+	s, err := internalschema.Resolve(SchemaSkillFrontmatter)
+	if err != nil {
+		t.Fatalf("resolve schema: %v", err)
+	}
+	if err := internalschema.ValidateObject(s, map[string]any{
+		"name":          "git-workflows",
+		"description":   "Helpful git workflows",
+		"extends":       "base",
+		"allowed_tools": []any{"bash"},
+	}); err != nil {
+		t.Fatalf("expected valid frontmatter: %v", err)
+	}
+	if err := internalschema.ValidateObject(s, map[string]any{"name": "git-workflows"}); err == nil {
+		t.Fatal("expected missing description to fail")
+	}
+	if err := internalschema.ValidateObject(s, map[string]any{
+		"name":        "git-workflows",
+		"description": "Helpful git workflows",
+		"licence":     "MIT",
+	}); err == nil {
+		t.Fatal("expected unknown key to fail")
+	}
+}
@@ -23,17 +23,19 @@ type Skill struct {
 	Compatibility string
 	Metadata      map[string]any
 	AllowedTools  []string
+	Extends       string
 	Path          string
 	Content       string
 }
 
 type frontmatter struct {
-	Name          string         `yaml:"name"`
-	Description   string         `yaml:"description"`
-	License       string         `yaml:"license"`
-	Compatibility string         `yaml:"compatibility"`
-	Metadata      map[string]any `yaml:"metadata"`
-	AllowedTools  []string       `yaml:"allowed_tools"`
+	Name          string         `yaml:"name" jsonschema:"required,maxLength=64,pattern=^[a-z0-9]+(?:-[a-z0-9]+)*$"`
+	Description   string         `yaml:"description" jsonschema:"required,minLength=1,maxLength=1024"`
+	License       string         `yaml:"license,omitempty"`
+	Compatibility string         `yaml:"compatibility,omitempty"`
+	Metadata      map[string]any `yaml:"metadata,omitempty"`
+	AllowedTools  []string       `yaml:"allowed_tools,omitempty"`
+	Extends       string         `yaml:"extends,omitempty"`
 }
 
 // ParseFile reads and validates a SKILL.md file on disk.
@@ -71,6 +73,7 @@ func Parse(data []byte) (*Skill, error) {
 		License:       strings.TrimSpace(fm.License),
 		Compatibility: strings.TrimSpace(fm.Compatibility),
 		Metadata:      fm.Metadata,
+		Extends:       strings.TrimSpace(fm.Extends),
 		Content:       body,
 	}
 	if len(fm.AllowedTools) > 0 {
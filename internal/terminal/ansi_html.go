@@ -0,0 +1,242 @@
+package terminal
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// ansiPalette is the xterm default palette for the 16 basic colors.
+var ansiPalette = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+type ansiStyle struct {
+	fg        string
+	bg        string
+	bold      bool
+	dim       bool
+	italic    bool
+	underline bool
+}
+
+func (s ansiStyle) css() string {
+	var parts []string
+	if s.fg != "" {
+		parts = append(parts, "color:"+s.fg)
+	}
+	if s.bg != "" {
+		parts = append(parts, "background-color:"+s.bg)
+	}
+	if s.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if s.dim {
+		parts = append(parts, "opacity:0.7")
+	}
+	if s.italic {
+		parts = append(parts, "font-style:italic")
+	}
+	if s.underline {
+		parts = append(parts, "text-decoration:underline")
+	}
+	return strings.Join(parts, ";")
+}
+
+// ANSIToHTML converts terminal output to HTML-escaped text with SGR colors
+// and attributes rendered as styled spans. Other escape sequences (cursor
+// movement, OSC titles) and control codes are dropped; tabs and newlines are
+// kept, so the result is meant to sit inside a <pre> element.
+func ANSIToHTML(input string) string {
+	var out strings.Builder
+	out.Grow(len(input))
+	var style ansiStyle
+	spanOpen := false
+	closeSpan := func() {
+		if spanOpen {
+			out.WriteString("</span>")
+			spanOpen = false
+		}
+	}
+
+	text := 0
+	flush := func(end int) {
+		if end <= text {
+			return
+		}
+		if !spanOpen {
+			if css := style.css(); css != "" {
+				out.WriteString(`<span style="` + css + `">`)
+				spanOpen = true
+			}
+		}
+		out.WriteString(html.EscapeString(input[text:end]))
+	}
+
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == 0x1b:
+			flush(i)
+			next, params, final := scanEscape(input, i)
+			if final == 'm' {
+				updated := applySGR(style, params)
+				if updated != style {
+					closeSpan()
+					style = updated
+				}
+			}
+			i = next
+			text = i
+		case c < 0x20 && c != '\t' && c != '\n', c == 0x7f:
+			flush(i)
+			i++
+			text = i
+		default:
+			i++
+		}
+	}
+	flush(len(input))
+	closeSpan()
+	return out.String()
+}
+
+// scanEscape returns the index after the escape sequence starting at start,
+// plus the parameters and final byte for CSI sequences.
+func scanEscape(input string, start int) (int, string, byte) {
+	i := start + 1
+	if i >= len(input) {
+		return i, "", 0
+	}
+	switch input[i] {
+	case '[':
+		i++
+		paramStart := i
+		for i < len(input) {
+			b := input[i]
+			if b >= 0x40 && b <= 0x7e {
+				return i + 1, input[paramStart:i], b
+			}
+			i++
+		}
+		return i, "", 0
+	case ']':
+		// OSC runs to BEL or ST (ESC \).
+		for i++; i < len(input); i++ {
+			if input[i] == 0x07 {
+				return i + 1, "", 0
+			}
+			if input[i] == 0x1b && i+1 < len(input) && input[i+1] == '\\' {
+				return i + 2, "", 0
+			}
+		}
+		return i, "", 0
+	default:
+		return i + 1, "", 0
+	}
+}
+
+func applySGR(style ansiStyle, params string) ansiStyle {
+	if params == "" {
+		return ansiStyle{}
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			style = ansiStyle{}
+		case code == 1:
+			style.bold = true
+		case code == 2:
+			style.dim = true
+		case code == 3:
+			style.italic = true
+		case code == 4:
+			style.underline = true
+		case code == 22:
+			style.bold, style.dim = false, false
+		case code == 23:
+			style.italic = false
+		case code == 24:
+			style.underline = false
+		case code >= 30 && code <= 37:
+			style.fg = ansiPalette[code-30]
+		case code >= 90 && code <= 97:
+			style.fg = ansiPalette[code-90+8]
+		case code == 39:
+			style.fg = ""
+		case code >= 40 && code <= 47:
+			style.bg = ansiPalette[code-40]
+		case code >= 100 && code <= 107:
+			style.bg = ansiPalette[code-100+8]
+		case code == 49:
+			style.bg = ""
+		case code == 38 || code == 48:
+			color, consumed := extendedColor(codes[i+1:])
+			i += consumed
+			if color == "" {
+				continue
+			}
+			if code == 38 {
+				style.fg = color
+			} else {
+				style.bg = color
+			}
+		}
+	}
+	return style
+}
+
+// extendedColor parses the arguments after 38/48: "5;n" for the 256-color
+// palette or "2;r;g;b" for truecolor. It reports how many codes it consumed.
+func extendedColor(codes []string) (string, int) {
+	if len(codes) == 0 {
+		return "", 0
+	}
+	switch codes[0] {
+	case "5":
+		if len(codes) < 2 {
+			return "", len(codes)
+		}
+		index, err := strconv.Atoi(codes[1])
+		if err != nil || index < 0 || index > 255 {
+			return "", 2
+		}
+		return xterm256Color(index), 2
+	case "2":
+		if len(codes) < 4 {
+			return "", len(codes)
+		}
+		var rgb [3]int
+		for j := range rgb {
+			value, err := strconv.Atoi(codes[j+1])
+			if err != nil || value < 0 || value > 255 {
+				return "", 4
+			}
+			rgb[j] = value
+		}
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), 4
+	default:
+		return "", 1
+	}
+}
+
+func xterm256Color(index int) string {
+	switch {
+	case index < 16:
+		return ansiPalette[index]
+	case index < 232:
+		index -= 16
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		return fmt.Sprintf("#%02x%02x%02x", levels[index/36], levels[(index/6)%6], levels[index%6])
+	default:
+		gray := 8 + (index-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
@@ -0,0 +1,22 @@
+package terminal
+
+import "testing"
+
+func TestANSIToHTML(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "a < b & c", "a &lt; b &amp; c"},
+		{"basic color", "\x1b[31mred\x1b[0m plain", `<span style="color:#cd0000">red</span> plain`},
+		{"bold bright", "\x1b[1;92mok\x1b[m", `<span style="color:#00ff00;font-weight:bold">ok</span>`},
+		{"256 and truecolor", "\x1b[38;5;196mx\x1b[48;2;1;2;3my", `<span style="color:#ff0000">x</span><span style="color:#ff0000;background-color:#010203">y</span>`},
+		{"drops non-SGR and OSC", "\x1b]0;title\x07\x1b[2Kline\r\n", "line\n"},
+	}
+	for _, tc := range cases {
+		if got := ANSIToHTML(tc.input); got != tc.want {
+			t.Fatalf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
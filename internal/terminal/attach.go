@@ -0,0 +1,169 @@
+package terminal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAttachUnsupported   = errors.New("attaching to a process is not supported on this platform")
+	ErrAttachNotAttachable = errors.New("process is not attachable")
+	ErrSessionReadOnly     = errors.New("session is read-only")
+)
+
+// attachPollInterval is how often attached output files are checked for new
+// data.
+var attachPollInterval = 250 * time.Millisecond
+
+// attachBacklogBytes is how much existing output is replayed on attach, so
+// the session does not start blank.
+const attachBacklogBytes = 64 << 10
+
+// readOnlyRunner backs attached sessions: they show a process's output but
+// have no way to write to it.
+type readOnlyRunner struct{}
+
+func (readOnlyRunner) Write([]byte) error {
+	return ErrSessionReadOnly
+}
+
+func (readOnlyRunner) Resize(uint16, uint16) error {
+	return nil
+}
+
+func (readOnlyRunner) Close() error {
+	return nil
+}
+
+// StartAttached builds a read-only session for an existing process. Output
+// is fed in by the caller.
+func (f *SessionFactory) StartAttached(request sessionCreateRequest, reservedID string) (*Session, string, error) {
+	id := reservedID
+	if id == "" {
+		if f.nextID == nil {
+			return nil, "", errors.New("session id generator unavailable")
+		}
+		id = f.nextID()
+	}
+	createdAt := f.clock.Now().UTC()
+	session := newSession(id, nil, readOnlyRunner{}, nil, request.Title, request.Role, createdAt, f.bufferLines, f.historyScanMax, f.outputPolicy, f.outputSample, nil, nil, nil, f.outputOptions(request))
+	return session, id, nil
+}
+
+// createAttachedSession adopts the output of the process request.AttachPID
+// as a read-only session. Only output the process writes to regular files
+// can be followed: a pty or pipe belongs to whoever reads its other end.
+func (m *Manager) createAttachedSession(request sessionCreateRequest) (*Session, error) {
+	pid := request.AttachPID
+	if pid <= 0 {
+		return nil, fmt.Errorf("%w: pid must be positive", ErrAttachNotAttachable)
+	}
+	if err := ValidateLabels(request.Labels); err != nil {
+		return nil, err
+	}
+	if err := ValidateEncoding(request.Encoding); err != nil {
+		return nil, err
+	}
+	request.ParentID = strings.TrimSpace(request.ParentID)
+	if request.ParentID != "" {
+		if _, ok := m.Get(request.ParentID); !ok {
+			return nil, ErrParentNotFound
+		}
+	}
+	reservedID := strings.TrimSpace(request.SessionID)
+	if reservedID != "" {
+		if err := validateSessionID(reservedID); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := attachOutputFiles(pid)
+	if err != nil {
+		return nil, err
+	}
+	if request.Title == "" {
+		request.Title = "pid " + strconv.Itoa(pid)
+	}
+	if reservedID == "" {
+		reservedID = m.nextIDValue()
+	}
+	session, id, err := m.sessionFactory.StartAttached(request, reservedID)
+	if err != nil {
+		closeAll(files)
+		return nil, err
+	}
+	session.AttachPID = pid
+	session.pid = pid
+	session.ParentID = request.ParentID
+	if len(request.Labels) > 0 {
+		_ = session.SetLabels(request.Labels)
+	}
+	session.bell = m.newSessionBell(session)
+
+	m.mu.Lock()
+	if _, exists := m.sessions[id]; exists {
+		m.mu.Unlock()
+		closeAll(files)
+		_ = session.Close()
+		return nil, fmt.Errorf("session %q already exists", id)
+	}
+	m.sessions[id] = session
+	m.mu.Unlock()
+
+	m.emitSessionStarted(id, request, "", "")
+	for _, file := range files {
+		go tailAttachedOutput(session.ctx, session, file, newSessionCodec(request.Encoding))
+	}
+	return session, nil
+}
+
+// tailAttachedOutput publishes what is appended to file until ctx is done,
+// starting with up to attachBacklogBytes of what is already there. A file
+// that shrinks is assumed truncated and read again from the start. Each file
+// gets its own codec, since decoding keeps state between reads.
+func tailAttachedOutput(ctx context.Context, session *Session, file *os.File, codec *sessionCodec) {
+	defer file.Close()
+
+	offset := int64(0)
+	if info, err := file.Stat(); err == nil && info.Size() > attachBacklogBytes {
+		offset = info.Size() - attachBacklogBytes
+	}
+	buf := make([]byte, 32<<10)
+	ticker := time.NewTicker(attachPollInterval)
+	defer ticker.Stop()
+	for {
+		if info, err := file.Stat(); err == nil && info.Size() < offset {
+			offset = 0
+		}
+		for {
+			n, err := file.ReadAt(buf, offset)
+			if n > 0 {
+				offset += int64(n)
+				session.PublishOutputChunk(codec.decode(append([]byte(nil), buf[:n]...)))
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					return
+				}
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func closeAll(files []*os.File) {
+	for _, file := range files {
+		_ = file.Close()
+	}
+}
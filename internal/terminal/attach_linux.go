@@ -0,0 +1,52 @@
+//go:build linux
+
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// attachOutputFiles opens the regular files pid's stdout and stderr point
+// at, through /proc so the files stay readable after the process exits or
+// unlinks them.
+func attachOutputFiles(pid int) ([]*os.File, error) {
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+	if _, err := os.Stat(procDir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: process %d not found", ErrAttachNotAttachable, pid)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrAttachNotAttachable, err)
+	}
+
+	var files []*os.File
+	seen := map[string]struct{}{}
+	for _, fd := range []string{"1", "2"} {
+		link := filepath.Join(procDir, "fd", fd)
+		target, err := os.Readlink(link)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[target]; ok {
+			continue
+		}
+		info, err := os.Stat(link)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		file, err := os.Open(link)
+		if err != nil {
+			continue
+		}
+		seen[target] = struct{}{}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: process %d does not write stdout or stderr to a readable regular file", ErrAttachNotAttachable, pid)
+	}
+	return files, nil
+}
@@ -0,0 +1,98 @@
+//go:build linux
+
+package terminal
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateAttachedSessionFollowsFileOutput(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "out.log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		t.Fatalf("create log: %v", err)
+	}
+	defer logFile.Close()
+	cmd := exec.Command("/bin/sh", "-c", "echo before; sleep 30")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start process: %v", err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+	waitForFileContent(t, logPath, "before")
+
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	session, err := manager.CreateWithOptions(CreateOptions{AttachPID: cmd.Process.Pid})
+	if err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	if info := session.Info(); info.AttachPID != cmd.Process.Pid {
+		t.Fatalf("expected attach pid %d, got %d", cmd.Process.Pid, info.AttachPID)
+	}
+	if _, err := logFile.WriteString("after\n"); err != nil {
+		t.Fatalf("append log: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		output := strings.Join(session.OutputLines(), "\n")
+		if strings.Contains(output, "before") && strings.Contains(output, "after") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected attached output, got %q", output)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err := session.Write([]byte("ls\n")); !errors.Is(err, ErrSessionReadOnly) {
+		t.Fatalf("expected read-only error, got %v", err)
+	}
+}
+
+func TestCreateAttachedSessionRejectsPipeOutput(t *testing.T) {
+	cmd := exec.Command("/bin/sh", "-c", "sleep 30")
+	if _, err := cmd.StdoutPipe(); err != nil {
+		t.Fatalf("stdout pipe: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start process: %v", err)
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	if _, err := manager.CreateWithOptions(CreateOptions{AttachPID: cmd.Process.Pid}); !errors.Is(err, ErrAttachNotAttachable) {
+		t.Fatalf("expected not attachable, got %v", err)
+	}
+	if _, err := manager.CreateWithOptions(CreateOptions{AttachPID: -1}); !errors.Is(err, ErrAttachNotAttachable) {
+		t.Fatalf("expected not attachable for invalid pid, got %v", err)
+	}
+}
+
+func waitForFileContent(t *testing.T, path, want string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %q in %s, got %q", want, path, data)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package terminal
+
+import "os"
+
+func attachOutputFiles(int) ([]*os.File, error) {
+	return nil, ErrAttachUnsupported
+}
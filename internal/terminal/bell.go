@@ -0,0 +1,126 @@
+package terminal
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultBellDebounce is how long after a bell signal further bells from the
+// same session are coalesced into the next one.
+const DefaultBellDebounce = 2 * time.Second
+
+// BellContextLines is how many lines of history a bell report looks at.
+const BellContextLines = 50
+
+// BellSignal reports what a bell amounted to after debouncing. Suppressed
+// bells are counted and reported by the next signal that goes out, whose
+// Count is the number of bells it stands for, itself included. For a
+// suppressed bell, Count is how many have been held back so far.
+type BellSignal struct {
+	Suppressed bool
+	Count      int
+}
+
+// bellDebouncer lets the first bell through and swallows the rest until the
+// window since the last signal has passed. Bells still held back when the
+// window closes are sent to flush as one signal, so the last burst is not
+// lost when no further bell arrives.
+type bellDebouncer struct {
+	mu         sync.Mutex
+	window     time.Duration
+	clock      Clock
+	lastSignal time.Time
+	suppressed int
+	flush      func(BellSignal)
+	timer      *time.Timer
+}
+
+func newBellDebouncer(window time.Duration, clock Clock, flush func(BellSignal)) *bellDebouncer {
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &bellDebouncer{window: window, clock: clock, flush: flush}
+}
+
+func (d *bellDebouncer) ring() BellSignal {
+	if d == nil || d.window <= 0 {
+		return BellSignal{Count: 1}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.clock.Now()
+	if !d.lastSignal.IsZero() && now.Sub(d.lastSignal) < d.window {
+		d.suppressed++
+		if d.timer == nil && d.flush != nil {
+			d.timer = time.AfterFunc(d.window-now.Sub(d.lastSignal), d.flushSuppressed)
+		}
+		return BellSignal{Suppressed: true, Count: d.suppressed}
+	}
+	d.stopTimerLocked()
+	signal := BellSignal{Count: d.suppressed + 1}
+	d.lastSignal = now
+	d.suppressed = 0
+	return signal
+}
+
+// flushSuppressed sends the bells held back during the window that just
+// closed. Its Count is only the held-back bells.
+func (d *bellDebouncer) flushSuppressed() {
+	d.mu.Lock()
+	d.timer = nil
+	if d.suppressed == 0 {
+		d.mu.Unlock()
+		return
+	}
+	signal := BellSignal{Count: d.suppressed}
+	d.lastSignal = d.clock.Now()
+	d.suppressed = 0
+	d.mu.Unlock()
+	d.flush(signal)
+}
+
+// stop drops any pending flush.
+func (d *bellDebouncer) stop() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopTimerLocked()
+	d.suppressed = 0
+}
+
+func (d *bellDebouncer) stopTimerLocked() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+// newSessionBell returns the bell debouncer for a session, reporting bells
+// flushed at the end of a window through logBellFlush.
+func (m *Manager) newSessionBell(session *Session) *bellDebouncer {
+	return newBellDebouncer(m.bellDebounce, m.clock, func(signal BellSignal) {
+		m.logBellFlush(session, signal)
+	})
+}
+
+// logBellFlush reports bells that were held back until their window closed,
+// the same way the bell endpoint reports a signal.
+func (m *Manager) logBellFlush(session *Session, signal BellSignal) {
+	if m.logger == nil || session == nil {
+		return
+	}
+	contextLines, _ := session.HistoryLines(BellContextLines)
+	m.logger.Warn("terminal bell detected", map[string]string{
+		"gestalt.category": "terminal",
+		"gestalt.source":   "backend",
+		"session.id":       session.ID,
+		"session_id":       session.ID,
+		"context_lines":    strconv.Itoa(len(contextLines)),
+		"bell_count":       strconv.Itoa(signal.Count),
+		"bells_coalesced":  strconv.Itoa(signal.Count),
+		"bells_flushed":    "true",
+	})
+}
@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+)
+
+type steppedClock struct {
+	now time.Time
+}
+
+func (c *steppedClock) Now() time.Time {
+	return c.now
+}
+
+func TestBellDebouncerCoalescesWithinWindow(t *testing.T) {
+	clock := &steppedClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	debouncer := newBellDebouncer(time.Second, clock, nil)
+
+	if signal := debouncer.ring(); signal.Suppressed || signal.Count != 1 {
+		t.Fatalf("expected first bell to signal, got %+v", signal)
+	}
+	for i := 1; i <= 3; i++ {
+		clock.now = clock.now.Add(100 * time.Millisecond)
+		if signal := debouncer.ring(); !signal.Suppressed || signal.Count != i {
+			t.Fatalf("expected bell %d to be suppressed, got %+v", i, signal)
+		}
+	}
+
+	clock.now = clock.now.Add(time.Second)
+	if signal := debouncer.ring(); signal.Suppressed || signal.Count != 4 {
+		t.Fatalf("expected signal standing for 4 bells, got %+v", signal)
+	}
+	clock.now = clock.now.Add(2 * time.Second)
+	if signal := debouncer.ring(); signal.Suppressed || signal.Count != 1 {
+		t.Fatalf("expected counter reset after signal, got %+v", signal)
+	}
+}
+
+func TestBellDebouncerDisabled(t *testing.T) {
+	debouncer := newBellDebouncer(-1, nil, nil)
+	for i := 0; i < 3; i++ {
+		if signal := debouncer.ring(); signal.Suppressed || signal.Count != 1 {
+			t.Fatalf("expected every bell to signal, got %+v", signal)
+		}
+	}
+}
+
+func TestBellDebouncerFlushesWhenWindowCloses(t *testing.T) {
+	flushed := make(chan BellSignal, 2)
+	debouncer := newBellDebouncer(20*time.Millisecond, nil, func(signal BellSignal) {
+		flushed <- signal
+	})
+	defer debouncer.stop()
+
+	if signal := debouncer.ring(); signal.Suppressed {
+		t.Fatalf("expected first bell to signal, got %+v", signal)
+	}
+	debouncer.ring()
+	debouncer.ring()
+	select {
+	case signal := <-flushed:
+		if signal.Suppressed || signal.Count != 2 {
+			t.Fatalf("expected flush standing for 2 bells, got %+v", signal)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for the trailing flush")
+	}
+	select {
+	case signal := <-flushed:
+		t.Fatalf("unexpected second flush %+v", signal)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
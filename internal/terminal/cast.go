@@ -0,0 +1,99 @@
+package terminal
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultCastWidth  = 80
+	defaultCastHeight = 24
+)
+
+// CastHeader is the first line of an asciinema v2 cast file.
+type CastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// WriteCast writes chunks as an asciinema v2 cast: the header line followed
+// by one ["<seconds>", "o", "<data>"] event per chunk, timed relative to
+// start. Output split mid-rune is carried into the next event so every event
+// holds valid UTF-8, as players expect.
+func WriteCast(w io.Writer, header CastHeader, start time.Time, chunks []TimedChunk) error {
+	header.Version = 2
+	if header.Width <= 0 {
+		header.Width = defaultCastWidth
+	}
+	if header.Height <= 0 {
+		header.Height = defaultCastHeight
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(header); err != nil {
+		return err
+	}
+
+	var carry []byte
+	var last float64
+	for _, chunk := range chunks {
+		data := append(carry, chunk.Data...)
+		carry = nil
+		if cut := incompleteRuneSuffix(data); cut > 0 {
+			carry = append([]byte(nil), data[len(data)-cut:]...)
+			data = data[:len(data)-cut]
+		}
+		if len(data) == 0 {
+			continue
+		}
+		offset := chunk.At.Sub(start).Seconds()
+		// Events must not go back in time, even if the clock did.
+		if offset < last {
+			offset = last
+		}
+		last = offset
+		if err := writeCastEvent(encoder, offset, data); err != nil {
+			return err
+		}
+	}
+	if len(carry) > 0 {
+		return writeCastEvent(encoder, last, carry)
+	}
+	return nil
+}
+
+func writeCastEvent(encoder *json.Encoder, offset float64, data []byte) error {
+	text := strings.ToValidUTF8(string(data), "�")
+	return encoder.Encode([]any{json.Number(formatCastOffset(offset)), "o", text})
+}
+
+func formatCastOffset(offset float64) string {
+	return strconv.FormatFloat(math.Round(offset*1e6)/1e6, 'f', -1, 64)
+}
+
+// incompleteRuneSuffix returns how many trailing bytes of data start a UTF-8
+// sequence that the next chunk has yet to finish.
+func incompleteRuneSuffix(data []byte) int {
+	for back := 1; back <= utf8.UTFMax-1 && back <= len(data); back++ {
+		b := data[len(data)-back]
+		if b < utf8.RuneSelf {
+			return 0
+		}
+		if utf8.RuneStart(b) {
+			if utf8.FullRune(data[len(data)-back:]) {
+				return 0
+			}
+			return back
+		}
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+package terminal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCastTimesEventsFromStart(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	chunks := []TimedChunk{
+		{At: start.Add(250 * time.Millisecond), Data: []byte("one\r\n")},
+		{At: start.Add(1500 * time.Millisecond), Data: []byte("two")},
+	}
+	var out bytes.Buffer
+	if err := WriteCast(&out, CastHeader{Timestamp: start.Unix()}, start, chunks); err != nil {
+		t.Fatalf("write cast: %v", err)
+	}
+	want := `{"version":2,"width":80,"height":24,"timestamp":` + "1767323045" + `}
+[0.25,"o","one\r\n"]
+[1.5,"o","two"]
+`
+	if out.String() != want {
+		t.Fatalf("unexpected cast:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestWriteCastKeepsRunesWhole(t *testing.T) {
+	start := time.Now()
+	euro := []byte("€")
+	chunks := []TimedChunk{
+		{At: start, Data: append([]byte("a"), euro[:2]...)},
+		{At: start.Add(time.Second), Data: append(euro[2:], 'b')},
+	}
+	var out bytes.Buffer
+	if err := WriteCast(&out, CastHeader{}, start, chunks); err != nil {
+		t.Fatalf("write cast: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and two events, got %q", out.String())
+	}
+	var texts []string
+	for _, line := range lines[1:] {
+		var event []any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("decode event: %v", err)
+		}
+		texts = append(texts, event[2].(string))
+	}
+	if texts[0] != "a" || texts[1] != "€b" {
+		t.Fatalf("unexpected event text: %q", texts)
+	}
+}
+
+func TestOutputTimelineDropsOldest(t *testing.T) {
+	timeline := NewOutputTimeline(8)
+	now := time.Now()
+	timeline.Append(now, []byte("abcd"))
+	timeline.Append(now, []byte("efgh"))
+	if _, dropped := timeline.Chunks(); dropped {
+		t.Fatalf("expected nothing dropped within budget")
+	}
+	timeline.Append(now, []byte("ij"))
+	chunks, dropped := timeline.Chunks()
+	if !dropped || len(chunks) != 2 || string(chunks[0].Data) != "efgh" {
+		t.Fatalf("unexpected chunks after overflow: %+v dropped=%v", chunks, dropped)
+	}
+}
+
+func TestOutputTimelineCompactsDroppedChunks(t *testing.T) {
+	timeline := NewOutputTimeline(16)
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		timeline.Append(now, []byte(fmt.Sprintf("%04d", i)))
+	}
+	chunks, dropped := timeline.Chunks()
+	if !dropped || len(chunks) != 4 || string(chunks[0].Data) != "0096" || string(chunks[3].Data) != "0099" {
+		t.Fatalf("unexpected chunks: %+v dropped=%v", chunks, dropped)
+	}
+	if len(timeline.chunks) > 8 {
+		t.Fatalf("expected dropped chunks to be compacted, backing slice has %d", len(timeline.chunks))
+	}
+}
+
+func TestSessionLogPersistsChunkTiming(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewSessionLogger(dir, "Coder 1", time.Now(), 0)
+	if err != nil {
+		t.Fatalf("new session logger: %v", err)
+	}
+	before := time.Now()
+	logger.Write([]byte("one\r\n"))
+	logger.Write([]byte("two"))
+	if err := logger.Close(); err != nil {
+		t.Fatalf("close session logger: %v", err)
+	}
+	if _, err := os.Stat(sessionTimingPath(logger.Path())); err != nil {
+		t.Fatalf("expected timing file next to the log: %v", err)
+	}
+
+	chunks, err := ReadSessionLogTimeline(logger.Path())
+	if err != nil {
+		t.Fatalf("read timeline: %v", err)
+	}
+	if len(chunks) != 2 || string(chunks[0].Data) != "one\r\n" || string(chunks[1].Data) != "two" {
+		t.Fatalf("unexpected chunks: %+v", chunks)
+	}
+	if chunks[0].At.Before(before.Add(-time.Second)) || chunks[1].At.Before(chunks[0].At) {
+		t.Fatalf("unexpected chunk times: %v %v", chunks[0].At, chunks[1].At)
+	}
+}
+
+func TestSessionOutputTimelineReadsSessionLog(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewSessionLogger(dir, "1", time.Now(), 0)
+	if err != nil {
+		t.Fatalf("new session logger: %v", err)
+	}
+	session := newSession("1", nil, newExternalRunner(), nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, logger, nil, sessionOutputOptions{})
+	if session.outputTimeline != nil {
+		t.Fatal("expected no in-memory timeline for a logged session")
+	}
+	output, cancel := session.Subscribe()
+	session.PublishOutputChunk([]byte("hello"))
+	select {
+	case <-output:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for output")
+	}
+	cancel()
+	_ = session.Close()
+	_ = logger.Close()
+
+	chunks, truncated, err := session.OutputTimeline()
+	if err != nil {
+		t.Fatalf("output timeline: %v", err)
+	}
+	if truncated || len(chunks) != 1 || string(chunks[0].Data) != "hello" {
+		t.Fatalf("unexpected timeline: %+v truncated=%v", chunks, truncated)
+	}
+}
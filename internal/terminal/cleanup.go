@@ -101,6 +101,7 @@ func (m *Manager) cleanupSessionLogs(now time.Time) {
 				})
 				continue
 			}
+			_ = os.Remove(sessionTimingPath(file.path))
 			m.logger.Info("session log removed", map[string]string{
 				"terminal_id": file.terminalID,
 				"path":        file.path,
@@ -0,0 +1,116 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/transform"
+)
+
+var ErrUnknownEncoding = errors.New("unknown encoding")
+
+// lookupEncoding resolves an IANA charset name such as "latin1" or
+// "windows-1252". Empty and UTF-8 names resolve to nil: output passes through.
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	name = strings.TrimSpace(name)
+	switch strings.ToLower(name) {
+	case "", "utf-8", "utf8":
+		return nil, nil
+	}
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err != nil || enc == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEncoding, name)
+	}
+	return enc, nil
+}
+
+// ValidateEncoding reports whether name is an encoding sessions can use.
+func ValidateEncoding(name string) error {
+	_, err := lookupEncoding(name)
+	return err
+}
+
+// sessionCodec transcodes a session whose process speaks a legacy encoding:
+// output is decoded to UTF-8 before it is buffered, and input is encoded on
+// its way to the process. decode keeps state across chunks and must only be
+// called from the session's read loop.
+type sessionCodec struct {
+	enc     encoding.Encoding
+	decoder transform.Transformer
+	pending []byte
+}
+
+func newSessionCodec(name string) *sessionCodec {
+	enc, err := lookupEncoding(name)
+	if err != nil || enc == nil {
+		return nil
+	}
+	return &sessionCodec{enc: enc, decoder: enc.NewDecoder()}
+}
+
+// decode converts chunk to UTF-8. A multi-byte sequence split across reads
+// is held back until the rest of it arrives.
+func (c *sessionCodec) decode(chunk []byte) []byte {
+	if c == nil {
+		return chunk
+	}
+	src := append(c.pending, chunk...)
+	c.pending = nil
+	out := make([]byte, 0, len(src)*2)
+	buf := make([]byte, len(src)*3+utf8.UTFMax)
+	for len(src) > 0 {
+		nDst, nSrc, err := c.decoder.Transform(buf, src, false)
+		out = append(out, buf[:nDst]...)
+		src = src[nSrc:]
+		switch {
+		case err == nil:
+		case errors.Is(err, transform.ErrShortDst):
+			if nDst == 0 && nSrc == 0 {
+				buf = make([]byte, len(buf)*2)
+			}
+			continue
+		case errors.Is(err, transform.ErrShortSrc):
+			c.pending = append([]byte(nil), src...)
+			return out
+		default:
+			// Decoders replace invalid input, so this should not happen;
+			// pass what is left through rather than lose it.
+			c.decoder.Reset()
+			return append(out, src...)
+		}
+	}
+	return out
+}
+
+// encode converts UTF-8 input to the session's encoding. Characters the
+// encoding cannot represent become '?', and bytes that are not valid UTF-8
+// are passed through as they are, so raw control input still works.
+func (c *sessionCodec) encode(data []byte) []byte {
+	if c == nil {
+		return data
+	}
+	if encoded, err := c.enc.NewEncoder().Bytes(data); err == nil {
+		return encoded
+	}
+	encoder := c.enc.NewEncoder()
+	out := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			out = append(out, data[0])
+			data = data[1:]
+			continue
+		}
+		encoded, err := encoder.Bytes(data[:size])
+		if err != nil {
+			encoded = []byte{'?'}
+		}
+		out = append(out, encoded...)
+		data = data[size:]
+	}
+	return out
+}
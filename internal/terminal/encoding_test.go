@@ -0,0 +1,108 @@
+package terminal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gestalt/internal/agent"
+)
+
+func TestLookupEncoding(t *testing.T) {
+	for _, name := range []string{"", "utf-8", "UTF8"} {
+		if enc, err := lookupEncoding(name); err != nil || enc != nil {
+			t.Fatalf("expected %q to pass through, got %v, %v", name, enc, err)
+		}
+	}
+	if enc, err := lookupEncoding("latin1"); err != nil || enc == nil {
+		t.Fatalf("expected latin1 to resolve, got %v", err)
+	}
+	if err := ValidateEncoding("klingon"); !errors.Is(err, ErrUnknownEncoding) {
+		t.Fatalf("expected unknown encoding error, got %v", err)
+	}
+}
+
+func TestSessionCodecDecodesSplitSequences(t *testing.T) {
+	codec := newSessionCodec("shift_jis")
+	// "日本" in Shift_JIS, split in the middle of the second character.
+	first := codec.decode([]byte{0x93, 0xfa, 0x96})
+	second := codec.decode([]byte{0x7b})
+	if got := string(first) + string(second); got != "日本" {
+		t.Fatalf("expected split sequence to decode, got %q then %q", first, second)
+	}
+}
+
+func TestSessionCodecEncodesInput(t *testing.T) {
+	codec := newSessionCodec("latin1")
+	if got := codec.encode([]byte("café\r")); string(got) != "caf\xe9\r" {
+		t.Fatalf("unexpected encoding %q", got)
+	}
+	if got := codec.encode([]byte("a→b\x1b\xff")); string(got) != "a?b\x1b\xff" {
+		t.Fatalf("expected unsupported runes replaced and raw bytes kept, got %q", got)
+	}
+	var passthrough *sessionCodec
+	if got := passthrough.encode([]byte("é")); string(got) != "é" {
+		t.Fatalf("expected nil codec to pass through, got %q", got)
+	}
+}
+
+func TestSessionTranscodesOutputAndInput(t *testing.T) {
+	pty := newScriptedPty()
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{Encoding: "latin1"})
+	defer func() {
+		_ = session.Close()
+	}()
+
+	out, cancel := session.Subscribe()
+	defer cancel()
+
+	pty.Emit("gr\xfc\xdfe\n")
+	if !receiveChunk(t, out, []byte("grüße\n")) {
+		t.Fatalf("expected decoded output chunk")
+	}
+
+	if err := session.Write([]byte("ü")); err != nil {
+		t.Fatalf("write session: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		pty.mu.Lock()
+		writes := append([][]byte(nil), pty.writes...)
+		pty.mu.Unlock()
+		if len(writes) > 0 {
+			if string(writes[0]) != "\xfc" {
+				t.Fatalf("expected latin1 input, got %q", writes[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected input to reach the pty")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCreateWithOptionsUsesAgentEncoding(t *testing.T) {
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		PtyFactory:       &captureFactory{},
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"legacy": {Name: "Legacy", Shell: "/bin/sh", Encoding: "latin1"},
+		},
+	})
+	if _, err := manager.CreateWithOptions(CreateOptions{AgentID: "legacy", Encoding: "klingon"}); !errors.Is(err, ErrUnknownEncoding) {
+		t.Fatalf("expected unknown encoding error, got %v", err)
+	}
+	session, err := manager.CreateWithOptions(CreateOptions{AgentID: "legacy"})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+	if got := session.Info().Encoding; got != "latin1" {
+		t.Fatalf("expected agent encoding on session, got %q", got)
+	}
+	if got := session.CreateOptions().Encoding; got != "latin1" {
+		t.Fatalf("expected encoding in create options, got %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"errors"
+	"gestalt/internal/agent"
 	"gestalt/internal/runner/launchspec"
 	"strings"
 )
@@ -52,6 +53,23 @@ func (m *Manager) buildExternalPromptPayloads(promptNames []string, sessionID st
 	return payloads, files
 }
 
+// buildAgentCommand expands the agent's command template into the command
+// line the session launches. When the template takes {prompt}, the agent's
+// prompts are rendered into it and reported as used, so they are not also
+// typed into the session once it starts.
+func (m *Manager) buildAgentCommand(profile *agent.Agent, promptNames []string, sessionID string) (string, []string, bool) {
+	values := agent.CommandValues{Model: profile.Model, Skills: profile.Skills}
+	var promptFiles []string
+	promptUsed := profile.CommandUses(agent.PlaceholderPrompt)
+	if promptUsed {
+		payloads, files := m.buildExternalPromptPayloads(promptNames, sessionID)
+		values.Prompt = strings.TrimSpace(strings.Join(payloads, "\n"))
+		promptFiles = files
+	}
+	command, args := profile.ExpandCommand(values)
+	return joinCommandLine(command, args), promptFiles, promptUsed
+}
+
 func buildPromptInjectionSpec(payloads []string) launchspec.PromptInjectionSpec {
 	if len(payloads) == 0 {
 		return launchspec.NormalizePromptInjection(launchspec.PromptInjectionSpec{
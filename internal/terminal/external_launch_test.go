@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gestalt/internal/agent"
+)
+
+func TestCreateExpandsAgentCommandTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "review.txt"), []byte("Review the diff.\nBe brief.\n"), 0o644); err != nil {
+		t.Fatalf("write prompt: %v", err)
+	}
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		PtyFactory:       &fakeFactory{},
+		PromptDir:        dir,
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"wrapped": {
+				Name:    "Wrapped",
+				Command: "llm-wrapper",
+				Args:    []string{"--model={model}", "--skills", "{skills}", "--system", "{prompt}"},
+				Model:   "large",
+				Skills:  []string{"review", "git"},
+				Prompts: agent.PromptList{"review"},
+			},
+		},
+	})
+	session, err := manager.CreateWithOptions(CreateOptions{AgentID: "wrapped"})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	want := []string{"llm-wrapper", "--model=large", "--skills", "review,git", "--system", "Review the diff.\nBe brief."}
+	if session.LaunchSpec == nil || !reflect.DeepEqual(session.LaunchSpec.Argv, want) {
+		t.Fatalf("expected argv %q, got %#v", want, session.LaunchSpec)
+	}
+	if payloads := session.LaunchSpec.PromptInjection.Payload; len(payloads) != 0 {
+		t.Fatalf("expected prompt passed as an argument only, got injection %q", payloads)
+	}
+}
+
+func TestCreateAgentCommandWithoutPromptInjectsPrompt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "review.txt"), []byte("Review the diff.\n"), 0o644); err != nil {
+		t.Fatalf("write prompt: %v", err)
+	}
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		PtyFactory:       &fakeFactory{},
+		PromptDir:        dir,
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"wrapped": {
+				Name:    "Wrapped",
+				Command: "llm-wrapper",
+				Args:    []string{"--model", "{model}"},
+				Prompts: agent.PromptList{"review"},
+			},
+		},
+	})
+	session, err := manager.CreateWithOptions(CreateOptions{AgentID: "wrapped"})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	want := []string{"llm-wrapper", "--model", ""}
+	if !reflect.DeepEqual(session.LaunchSpec.Argv, want) {
+		t.Fatalf("expected argv %q, got %q", want, session.LaunchSpec.Argv)
+	}
+	if payloads := session.LaunchSpec.PromptInjection.Payload; len(payloads) != 1 {
+		t.Fatalf("expected prompt to be injected, got %q", payloads)
+	}
+}
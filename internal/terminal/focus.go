@@ -0,0 +1,146 @@
+package terminal
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultFocusClient is the client key used when a focus request does not
+// identify its client.
+const DefaultFocusClient = "default"
+
+// FocusTTL is how long a focus lasts without being refreshed, so a client
+// that goes away without unfocusing does not hold it forever.
+const FocusTTL = 10 * time.Minute
+
+// SessionFocus records which session a client last focused.
+type SessionFocus struct {
+	ClientID  string
+	SessionID string
+	FocusedAt time.Time
+}
+
+// FocusSession marks id as the active session for clientID. Each client has
+// at most one focused session; focusing another replaces it. Focusing again
+// refreshes the FocusTTL.
+func (m *Manager) FocusSession(clientID, id string) (SessionFocus, error) {
+	if m == nil {
+		return SessionFocus{}, ErrSessionNotFound
+	}
+	clientID = normalizeFocusClient(clientID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return SessionFocus{}, ErrSessionNotFound
+	}
+	if m.focus == nil {
+		m.focus = make(map[string]SessionFocus)
+	}
+	now := m.clock.Now().UTC()
+	for otherID, other := range m.focus {
+		if focusExpired(other, now) {
+			delete(m.focus, otherID)
+		}
+	}
+	focus := SessionFocus{
+		ClientID:  clientID,
+		SessionID: id,
+		FocusedAt: now,
+	}
+	m.focus[clientID] = focus
+	return focus, nil
+}
+
+// UnfocusSession clears clientID's focus if it is on id. It reports whether
+// the client had id focused.
+func (m *Manager) UnfocusSession(clientID, id string) (bool, error) {
+	if m == nil {
+		return false, ErrSessionNotFound
+	}
+	clientID = normalizeFocusClient(clientID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return false, ErrSessionNotFound
+	}
+	focus, ok := m.focus[clientID]
+	if !ok || focus.SessionID != id {
+		return false, nil
+	}
+	delete(m.focus, clientID)
+	return !focusExpired(focus, m.clock.Now()), nil
+}
+
+// FocusedSession returns the session clientID last focused, if it still
+// exists and the focus has not expired.
+func (m *Manager) FocusedSession(clientID string) (SessionFocus, bool) {
+	if m == nil {
+		return SessionFocus{}, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	focus, ok := m.focus[normalizeFocusClient(clientID)]
+	if !ok || focusExpired(focus, m.clock.Now()) {
+		return SessionFocus{}, false
+	}
+	return focus, true
+}
+
+// LatestFocus returns the most recent unexpired focus across all clients.
+func (m *Manager) LatestFocus() (SessionFocus, bool) {
+	if m == nil {
+		return SessionFocus{}, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := m.clock.Now()
+	var latest SessionFocus
+	found := false
+	for _, focus := range m.focus {
+		if focusExpired(focus, now) {
+			continue
+		}
+		if !found || focus.FocusedAt.After(latest.FocusedAt) {
+			latest = focus
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// IsFocused reports whether any client has id focused.
+func (m *Manager) IsFocused(id string) bool {
+	if m == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := m.clock.Now()
+	for _, focus := range m.focus {
+		if focus.SessionID == id && !focusExpired(focus, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// clearFocusLocked drops focus entries for a removed session. Callers hold m.mu.
+func (m *Manager) clearFocusLocked(id string) {
+	for clientID, focus := range m.focus {
+		if focus.SessionID == id {
+			delete(m.focus, clientID)
+		}
+	}
+}
+
+func focusExpired(focus SessionFocus, now time.Time) bool {
+	return now.Sub(focus.FocusedAt) >= FocusTTL
+}
+
+func normalizeFocusClient(clientID string) string {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return DefaultFocusClient
+	}
+	return clientID
+}
@@ -0,0 +1,114 @@
+package terminal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gestalt/internal/agent"
+)
+
+func TestManagerFocusPerClient(t *testing.T) {
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"coder":    {Name: "Coder"},
+			"reviewer": {Name: "Reviewer"},
+		},
+	})
+	coder, err := manager.Create("coder", "", "")
+	if err != nil {
+		t.Fatalf("create coder: %v", err)
+	}
+	reviewer, err := manager.Create("reviewer", "", "")
+	if err != nil {
+		t.Fatalf("create reviewer: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(reviewer.ID)
+	}()
+
+	if _, err := manager.FocusSession("", "missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+	if _, err := manager.FocusSession("", coder.ID); err != nil {
+		t.Fatalf("focus coder: %v", err)
+	}
+	if _, err := manager.FocusSession("tab-2", reviewer.ID); err != nil {
+		t.Fatalf("focus reviewer: %v", err)
+	}
+
+	if focus, ok := manager.FocusedSession(DefaultFocusClient); !ok || focus.SessionID != coder.ID {
+		t.Fatalf("expected default client to focus %q, got %+v", coder.ID, focus)
+	}
+	if !manager.IsFocused(coder.ID) || !manager.IsFocused(reviewer.ID) {
+		t.Fatalf("expected both sessions to be focused")
+	}
+
+	if err := manager.Delete(coder.ID); err != nil {
+		t.Fatalf("delete coder: %v", err)
+	}
+	if manager.IsFocused(coder.ID) {
+		t.Fatalf("expected focus to clear when the session is deleted")
+	}
+	if _, ok := manager.FocusedSession(""); ok {
+		t.Fatalf("expected default client focus to be cleared")
+	}
+	if focus, ok := manager.LatestFocus(); !ok || focus.SessionID != reviewer.ID {
+		t.Fatalf("expected latest focus %q, got %+v", reviewer.ID, focus)
+	}
+}
+
+func TestManagerFocusExpiresAndUnfocuses(t *testing.T) {
+	clock := &steppedClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		LivenessInterval: -1,
+		Clock:            clock,
+		Agents: map[string]agent.Agent{
+			"coder": {Name: "Coder"},
+		},
+	})
+	coder, err := manager.Create("coder", "", "")
+	if err != nil {
+		t.Fatalf("create coder: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(coder.ID)
+	}()
+
+	if _, err := manager.FocusSession("tab-1", coder.ID); err != nil {
+		t.Fatalf("focus coder: %v", err)
+	}
+	clock.now = clock.now.Add(FocusTTL - time.Second)
+	if !manager.IsFocused(coder.ID) {
+		t.Fatalf("expected focus before the TTL")
+	}
+	clock.now = clock.now.Add(time.Second)
+	if manager.IsFocused(coder.ID) {
+		t.Fatalf("expected focus to expire after the TTL")
+	}
+	if _, ok := manager.FocusedSession("tab-1"); ok {
+		t.Fatalf("expected expired focus to be hidden")
+	}
+	if _, ok := manager.LatestFocus(); ok {
+		t.Fatalf("expected no latest focus after expiry")
+	}
+
+	if _, err := manager.FocusSession("tab-1", coder.ID); err != nil {
+		t.Fatalf("refocus coder: %v", err)
+	}
+	if cleared, err := manager.UnfocusSession("tab-2", coder.ID); err != nil || cleared {
+		t.Fatalf("expected other client unfocus to be a no-op, got %v %v", cleared, err)
+	}
+	if cleared, err := manager.UnfocusSession("tab-1", coder.ID); err != nil || !cleared {
+		t.Fatalf("expected unfocus to clear, got %v %v", cleared, err)
+	}
+	if manager.IsFocused(coder.ID) {
+		t.Fatalf("expected focus to be cleared")
+	}
+	if _, err := manager.UnfocusSession("tab-1", "missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
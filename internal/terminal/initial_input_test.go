@@ -0,0 +1,51 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+
+	"gestalt/internal/agent"
+)
+
+func TestCreateWithOptionsSendsInitialInput(t *testing.T) {
+	previousTimeout, previousSettle := initialInputReadyTimeout, initialInputSettleDelay
+	initialInputReadyTimeout, initialInputSettleDelay = 2*time.Second, 0
+	t.Cleanup(func() {
+		initialInputReadyTimeout, initialInputSettleDelay = previousTimeout, previousSettle
+	})
+
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"coder": {Name: "Coder", Shell: "/bin/bash"},
+		},
+	})
+	session, err := manager.CreateWithOptions(CreateOptions{AgentID: "coder", InitialInput: "make test"})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	time.Sleep(20 * time.Millisecond)
+	if history := session.GetInputHistory(); len(history) != 0 {
+		t.Fatalf("expected input to wait for the session to be ready, got %v", history)
+	}
+
+	session.PublishOutputChunk([]byte("$ "))
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		history := session.GetInputHistory()
+		if len(history) == 1 {
+			if history[0].Command != "make test" {
+				t.Fatalf("unexpected recorded input %q", history[0].Command)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected initial input in history, got %v", history)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
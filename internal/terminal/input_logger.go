@@ -1,18 +1,23 @@
 package terminal
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+
+	"gestalt/internal/logging"
 )
 
 const (
-	inputLogFlushInterval  = time.Second
-	inputLogFlushThreshold = 4 * 1024
-	inputLogChannelSize    = 256
+	inputLogFlushInterval   = time.Second
+	inputLogFlushThreshold  = 4 * 1024
+	inputLogChannelSize     = 256
+	inputLogTimestampLayout = "20060102-150405"
 )
 
 type InputLogger struct {
@@ -27,7 +32,7 @@ func NewInputLogger(dir, name string, createdAt time.Time) (*InputLogger, error)
 		return nil, fmt.Errorf("create input log dir: %w", err)
 	}
 
-	timestamp := createdAt.UTC().Format("20060102-150405")
+	timestamp := createdAt.UTC().Format(inputLogTimestampLayout)
 	path := filepath.Join(dir, fmt.Sprintf("%s-%s.jsonl", name, timestamp))
 	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 	if err != nil {
@@ -102,3 +107,99 @@ func encodeInputEntry(entry InputEntry) ([]byte, error) {
 	payload = append(payload, '\n')
 	return payload, nil
 }
+
+// loadInputHistory reads the NDJSON input logs previously written for name in
+// dir and returns the last limit entries, oldest first. Files are ordered by
+// the timestamp in their name and read newest first, stopping once limit
+// entries are collected; malformed lines are skipped, and a file that cannot
+// be read is logged and skipped.
+func loadInputHistory(dir, name string, limit int, logger *logging.Logger) ([]InputEntry, error) {
+	if dir == "" || name == "" {
+		return nil, nil
+	}
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read input log dir: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range dirEntries {
+		if entry.IsDir() || !isInputLogFor(entry.Name(), name) {
+			continue
+		}
+		paths = append(paths, entry.Name())
+	}
+	sort.Strings(paths)
+
+	var files [][]InputEntry
+	total := 0
+	for i := len(paths) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, paths[i])
+		entries, err := readInputLog(path)
+		if err != nil {
+			if logger != nil {
+				logger.Warn("input history file skipped", map[string]string{
+					"path":  path,
+					"error": err.Error(),
+				})
+			}
+			continue
+		}
+		files = append(files, entries)
+		total += len(entries)
+		if limit > 0 && total >= limit {
+			break
+		}
+	}
+	history := make([]InputEntry, 0, total)
+	for i := len(files) - 1; i >= 0; i-- {
+		history = append(history, files[i]...)
+	}
+	if limit > 0 && len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	return history, nil
+}
+
+func isInputLogFor(fileName, name string) bool {
+	base, ok := strings.CutSuffix(fileName, ".jsonl")
+	if !ok {
+		return false
+	}
+	prefix, ok := strings.CutPrefix(base, name+"-")
+	if !ok || len(prefix) != len(inputLogTimestampLayout) {
+		return false
+	}
+	_, err := time.Parse(inputLogTimestampLayout, prefix)
+	return err == nil
+}
+
+func readInputLog(path string) ([]InputEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open input log file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []InputEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry InputEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil || strings.TrimSpace(entry.Command) == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read input log file: %w", err)
+	}
+	return entries, nil
+}
@@ -2,11 +2,15 @@ package terminal
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"gestalt/internal/agent"
+	"gestalt/internal/logging"
 )
 
 func TestInputLoggerWritesJSONLines(t *testing.T) {
@@ -56,3 +60,163 @@ func TestInputLoggerWritesJSONLines(t *testing.T) {
 		t.Fatalf("unexpected second entry: %+v", got[1])
 	}
 }
+
+func TestLoadInputHistoryOrdersFilesAndSkipsOthers(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, payload string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(payload), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	write("agent-20250102-030405.jsonl", `{"Command":"second","Timestamp":"2025-01-02T03:04:05Z"}`+"\nnot json\n")
+	write("agent-20250101-000000.jsonl", `{"Command":"first","Timestamp":"2025-01-01T00:00:00Z"}`+"\n")
+	write("agent-mini-20250103-000000.jsonl", `{"Command":"other","Timestamp":"2025-01-03T00:00:00Z"}`+"\n")
+	write("agent-20250104-000000.txt", `{"Command":"ignored","Timestamp":"2025-01-04T00:00:00Z"}`+"\n")
+
+	history, err := loadInputHistory(dir, "agent", 0, nil)
+	if err != nil {
+		t.Fatalf("load input history: %v", err)
+	}
+	if len(history) != 2 || history[0].Command != "first" || history[1].Command != "second" {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+
+	limited, err := loadInputHistory(dir, "agent", 1, nil)
+	if err != nil {
+		t.Fatalf("load limited history: %v", err)
+	}
+	if len(limited) != 1 || limited[0].Command != "second" {
+		t.Fatalf("expected most recent entry, got %+v", limited)
+	}
+
+	missing, err := loadInputHistory(filepath.Join(dir, "missing"), "agent", 0, nil)
+	if err != nil || len(missing) != 0 {
+		t.Fatalf("expected empty history for missing dir, got %+v (%v)", missing, err)
+	}
+}
+
+func TestLoadInputHistoryStopsAtLimit(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "agent-20250102-000000.jsonl"), []byte(
+		`{"Command":"second","Timestamp":"2025-01-02T00:00:00Z"}`+"\n"+
+			`{"Command":"third","Timestamp":"2025-01-02T00:00:01Z"}`+"\n"), 0o644); err != nil {
+		t.Fatalf("write input log: %v", err)
+	}
+	// An older log that cannot be opened must not be read once the newer
+	// one covers the limit.
+	if err := os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "agent-20250101-000000.jsonl")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	history, err := loadInputHistory(dir, "agent", 2, nil)
+	if err != nil {
+		t.Fatalf("load input history: %v", err)
+	}
+	if len(history) != 2 || history[0].Command != "second" || history[1].Command != "third" {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+
+	// When the limit is not met the unreadable log is logged and skipped.
+	logBuffer := logging.NewLogBuffer(10)
+	logger := logging.NewLoggerWithOutput(logBuffer, logging.LevelInfo, io.Discard)
+	history, err = loadInputHistory(dir, "agent", 3, logger)
+	if err != nil {
+		t.Fatalf("expected unreadable log to be skipped, got %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected the readable entries, got %+v", history)
+	}
+	skipped := false
+	for _, entry := range logBuffer.List() {
+		if entry.Message == "input history file skipped" && strings.HasSuffix(entry.Context["path"], "agent-20250101-000000.jsonl") {
+			skipped = true
+		}
+	}
+	if !skipped {
+		t.Fatalf("expected the skipped file to be logged")
+	}
+}
+
+func TestManagerRestoresInputHistoryAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	agents := map[string]agent.Agent{
+		"codex": {Name: "Codex"},
+	}
+	newManager := func(now time.Time) *Manager {
+		return NewManager(ManagerOptions{
+			Shell:           "/bin/sh",
+			PtyFactory:      &fakeFactory{},
+			Clock:           fixedClock{now: now},
+			Agents:          agents,
+			InputHistoryDir: dir,
+		})
+	}
+
+	first := newManager(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	session, err := first.Create("codex", "build", "first")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	session.RecordInput("echo one")
+	session.RecordInput("echo two")
+	if err := first.CloseAll(); err != nil {
+		t.Fatalf("close manager: %v", err)
+	}
+
+	second := newManager(time.Date(2025, 1, 2, 4, 0, 0, 0, time.UTC))
+	defer second.CloseAll()
+	restored, err := second.Create("codex", "build", "second")
+	if err != nil {
+		t.Fatalf("create restored session: %v", err)
+	}
+	restored.RecordInput("echo three")
+
+	history := restored.GetInputHistory()
+	var commands []string
+	for _, entry := range history {
+		commands = append(commands, entry.Command)
+	}
+	if strings.Join(commands, ",") != "echo one,echo two,echo three" {
+		t.Fatalf("unexpected restored history: %v", commands)
+	}
+	if history[0].Timestamp.IsZero() {
+		t.Fatalf("expected restored timestamp")
+	}
+}
+
+func TestNoPersistSessionSkipsInputHistoryFiles(t *testing.T) {
+	dir := t.TempDir()
+	manager := NewManager(ManagerOptions{
+		Shell:           "/bin/sh",
+		PtyFactory:      &fakeFactory{},
+		Agents:          map[string]agent.Agent{"codex": {Name: "Codex"}},
+		InputHistoryDir: dir,
+	})
+	defer manager.CloseAll()
+
+	session, err := manager.CreateWithOptions(CreateOptions{AgentID: "codex", NoPersist: true})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	session.RecordInput("export TOKEN=secret")
+
+	if !session.Info().NoPersist {
+		t.Fatalf("expected session info to report no persist")
+	}
+	if got := session.GetInputHistory(); len(got) != 1 || got[0].Command != "export TOKEN=secret" {
+		t.Fatalf("expected input kept in memory, got %v", got)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read input history dir: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "Codex") {
+			t.Fatalf("expected no input history file for the session, got %s", entry.Name())
+		}
+	}
+	if !session.CreateOptions().NoPersist {
+		t.Fatalf("expected clone options to keep no persist")
+	}
+}
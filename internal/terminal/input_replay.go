@@ -0,0 +1,26 @@
+package terminal
+
+import "strings"
+
+// ReplayLastInput sends the session's most recent input history entry again,
+// submitted with Enter, and records it as a new entry.
+func (m *Manager) ReplayLastInput(id string) (InputEntry, error) {
+	session, ok := m.Get(id)
+	if !ok || session == nil {
+		return InputEntry{}, ErrSessionNotFound
+	}
+	history := session.GetInputHistory()
+	if len(history) == 0 {
+		return InputEntry{}, ErrNoInputHistory
+	}
+	command := strings.TrimRight(history[len(history)-1].Command, "\r\n")
+	if strings.TrimSpace(command) == "" {
+		return InputEntry{}, ErrNoInputHistory
+	}
+	if err := writePromptPayload(session, []byte(command+"\r")); err != nil {
+		return InputEntry{}, err
+	}
+	session.RecordInput(command)
+	replayed := session.GetInputHistory()
+	return replayed[len(replayed)-1], nil
+}
@@ -0,0 +1,119 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxSessionLabels          = 32
+	maxSessionLabelKeyBytes   = 64
+	maxSessionLabelValueBytes = 256
+)
+
+var ErrInvalidLabels = errors.New("invalid session labels")
+
+// ValidateLabels checks label count, key format, and key/value sizes.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > maxSessionLabels {
+		return fmt.Errorf("%w: at most %d labels allowed", ErrInvalidLabels, maxSessionLabels)
+	}
+	for key, value := range labels {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%w: label key is empty", ErrInvalidLabels)
+		}
+		if len(key) > maxSessionLabelKeyBytes {
+			return fmt.Errorf("%w: label key %q exceeds %d bytes", ErrInvalidLabels, key, maxSessionLabelKeyBytes)
+		}
+		for _, r := range key {
+			if unicode.IsSpace(r) || unicode.IsControl(r) {
+				return fmt.Errorf("%w: label key %q contains invalid characters", ErrInvalidLabels, key)
+			}
+		}
+		if len(value) > maxSessionLabelValueBytes {
+			return fmt.Errorf("%w: label %q value exceeds %d bytes", ErrInvalidLabels, key, maxSessionLabelValueBytes)
+		}
+		for _, r := range value {
+			if unicode.IsControl(r) {
+				return fmt.Errorf("%w: label %q value contains control characters", ErrInvalidLabels, key)
+			}
+		}
+	}
+	return nil
+}
+
+// Labels returns a copy of the session labels.
+func (s *Session) Labels() map[string]string {
+	if s == nil {
+		return nil
+	}
+	s.labelsMu.RLock()
+	defer s.labelsMu.RUnlock()
+	return copyLabels(s.labels)
+}
+
+// SetLabels replaces the session labels.
+func (s *Session) SetLabels(labels map[string]string) error {
+	if s == nil {
+		return ErrSessionClosed
+	}
+	if err := ValidateLabels(labels); err != nil {
+		return err
+	}
+	s.labelsMu.Lock()
+	s.labels = copyLabels(labels)
+	s.labelsMu.Unlock()
+	return nil
+}
+
+// UpdateLabels merges updates into the session labels; empty values remove keys.
+func (s *Session) UpdateLabels(updates map[string]string) (map[string]string, error) {
+	if s == nil {
+		return nil, ErrSessionClosed
+	}
+	s.labelsMu.Lock()
+	defer s.labelsMu.Unlock()
+	merged := copyLabels(s.labels)
+	if merged == nil {
+		merged = make(map[string]string, len(updates))
+	}
+	for key, value := range updates {
+		if value == "" {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+	if err := ValidateLabels(merged); err != nil {
+		return nil, err
+	}
+	if len(merged) == 0 {
+		merged = nil
+	}
+	s.labels = merged
+	return copyLabels(merged), nil
+}
+
+// MatchesLabels reports whether every selector key is present with the same value.
+func (info SessionInfo) MatchesLabels(selector map[string]string) bool {
+	for key, value := range selector {
+		current, ok := info.Labels[key]
+		if !ok || current != value {
+			return false
+		}
+	}
+	return true
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,116 @@
+package terminal
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gestalt/internal/event"
+)
+
+// launcherPaneDeadPattern matches the line tmux prints in a window left open
+// after its command exited with status 127.
+var launcherPaneDeadPattern = regexp.MustCompile(`(?i)^\s*pane is dead \(status 127\b`)
+
+// watchLauncherNotFound watches a new session's output for the shell's
+// "command not found" report (or tmux's dead-pane status 127) during the
+// launcher check window. Agent sessions run in tmux windows, so gestalt has
+// no exit status of its own to check and the output is all it can go on;
+// because of that the check is opt-in. A match marks the session stopped and
+// publishes a launcher_not_found terminal event so the failure is not a
+// silent dead session.
+func (m *Manager) watchLauncherNotFound(session *Session) {
+	if m == nil || session == nil || m.launcherCheckWindow <= 0 {
+		return
+	}
+	launcher := launcherName(session.Command)
+	if launcher == "" {
+		return
+	}
+	output, cancel := session.Subscribe()
+	window := m.launcherCheckWindow
+
+	go func() {
+		defer cancel()
+		timer := time.NewTimer(window)
+		defer timer.Stop()
+
+		pending := ""
+		for {
+			select {
+			case chunk, ok := <-output:
+				if !ok {
+					return
+				}
+				pending += strings.ReplaceAll(string(chunk), "\r", "\n")
+				lines := strings.Split(pending, "\n")
+				pending = lines[len(lines)-1]
+				for _, line := range lines[:len(lines)-1] {
+					if isLauncherNotFoundLine(line, launcher) {
+						m.reportLauncherNotFound(session, launcher, line)
+						return
+					}
+				}
+			case <-timer.C:
+				if isLauncherNotFoundLine(pending, launcher) {
+					m.reportLauncherNotFound(session, launcher, pending)
+				}
+				return
+			}
+		}
+	}()
+}
+
+func (m *Manager) reportLauncherNotFound(session *Session, launcher, line string) {
+	message := "launcher " + launcher + " not found"
+	if !session.markStopped(message) {
+		return
+	}
+	m.logger.Error("session launcher not found", map[string]string{
+		"gestalt.category": "terminal",
+		"gestalt.source":   "backend",
+		"session.id":       session.ID,
+		"agent_id":         session.AgentID,
+		"launcher":         launcher,
+		"output":           FilterTerminalOutput(strings.TrimSpace(line)),
+	})
+	if m.terminalBus != nil {
+		terminalEvent := event.NewTerminalEvent(session.ID, "launcher_not_found")
+		terminalEvent.Data = map[string]any{
+			"launcher": launcher,
+			"message":  message,
+		}
+		m.terminalBus.Publish(terminalEvent)
+	}
+}
+
+// launcherName returns the binary a session command starts, without its
+// directory.
+func launcherName(command string) string {
+	name, _, err := splitCommandLine(command)
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(strings.TrimSpace(name))
+}
+
+// isLauncherNotFoundLine reports whether a line of output is a shell telling
+// us launcher does not exist, e.g. "bash: codex: command not found",
+// "sh: 1: codex: not found", or tmux reporting the pane died with status 127.
+func isLauncherNotFoundLine(line, launcher string) bool {
+	line = strings.ToLower(FilterTerminalOutput(line))
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	if launcherPaneDeadPattern.MatchString(line) {
+		return true
+	}
+	launcher = strings.ToLower(launcher)
+	if !strings.Contains(line, launcher) {
+		return false
+	}
+	return strings.Contains(line, "command not found") ||
+		strings.Contains(line, launcher+": not found") ||
+		strings.Contains(line, launcher+": no such file or directory")
+}
@@ -0,0 +1,114 @@
+package terminal
+
+import (
+	"testing"
+	"time"
+
+	"gestalt/internal/agent"
+	"gestalt/internal/event"
+)
+
+func TestIsLauncherNotFoundLine(t *testing.T) {
+	cases := []struct {
+		line string
+		want bool
+	}{
+		{"bash: ghost-cli: command not found", true},
+		{"zsh: command not found: ghost-cli", true},
+		{"sh: 1: ghost-cli: not found", true},
+		{"/usr/local/bin/ghost-cli: No such file or directory", true},
+		{"Pane is dead (status 127, Tue Oct 13 10:00:00 2026)", true},
+		{"process exited with status 127", false},
+		{"make: *** [all] Error 127", false},
+		{"bash: other: command not found", false},
+		{"ghost-cli: config.toml: no such file", false},
+		{"exit status 1", false},
+		{"", false},
+	}
+	for _, tc := range cases {
+		if got := isLauncherNotFoundLine(tc.line, "ghost-cli"); got != tc.want {
+			t.Fatalf("isLauncherNotFoundLine(%q) = %v, want %v", tc.line, got, tc.want)
+		}
+	}
+}
+
+func TestManagerReportsLauncherNotFound(t *testing.T) {
+	manager := NewManager(ManagerOptions{
+		Shell:               "/bin/sh",
+		LivenessInterval:    -1,
+		LauncherCheckWindow: 2 * time.Second,
+		Agents: map[string]agent.Agent{
+			"ghost": {Name: "Ghost", Shell: "/opt/bin/ghost-cli --flag"},
+		},
+	})
+	events, cancel := manager.TerminalBus().SubscribeFiltered(func(terminalEvent event.TerminalEvent) bool {
+		return terminalEvent.Type() == "launcher_not_found"
+	})
+	defer cancel()
+
+	session, err := manager.Create("ghost", "", "")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	session.PublishOutputChunk([]byte("starting\r\nbash: /opt/bin/ghost-cli: command not found\r\n"))
+
+	select {
+	case terminalEvent := <-events:
+		if terminalEvent.TerminalID != session.ID || terminalEvent.Data["launcher"] != "ghost-cli" {
+			t.Fatalf("unexpected event: %+v", terminalEvent)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected launcher_not_found event")
+	}
+	info := session.Info()
+	if info.Status != "stopped" || info.StatusReason != "launcher ghost-cli not found" {
+		t.Fatalf("expected stopped session with reason, got %q %q", info.Status, info.StatusReason)
+	}
+}
+
+func TestManagerLauncherCheckOffByDefault(t *testing.T) {
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"ghost": {Name: "Ghost", Shell: "ghost-cli"},
+		},
+	})
+	session, err := manager.Create("ghost", "", "")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	session.PublishOutputChunk([]byte("bash: ghost-cli: command not found\r\n"))
+	time.Sleep(50 * time.Millisecond)
+	if status := session.Info().Status; status == "stopped" {
+		t.Fatalf("expected launcher check to be disabled, got %q", status)
+	}
+}
+
+func TestReportLauncherNotFoundSkipsStoppedSession(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	session := newSession("1", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer session.Close()
+	events, cancel := manager.TerminalBus().SubscribeFiltered(func(terminalEvent event.TerminalEvent) bool {
+		return terminalEvent.Type() == "launcher_not_found"
+	})
+	defer cancel()
+
+	if !session.markStopped("stopped by stop-all") {
+		t.Fatal("expected session to stop")
+	}
+	manager.reportLauncherNotFound(session, "ghost-cli", "bash: ghost-cli: command not found")
+
+	select {
+	case terminalEvent := <-events:
+		t.Fatalf("unexpected event for already stopped session: %+v", terminalEvent)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if reason := session.StopReason(); reason != "stopped by stop-all" {
+		t.Fatalf("expected original stop reason, got %q", reason)
+	}
+}
@@ -0,0 +1,80 @@
+package terminal
+
+import (
+	"strconv"
+	"time"
+
+	"gestalt/internal/event"
+)
+
+// DefaultLivenessInterval is how often pty-backed sessions are checked for a
+// dead or defunct process.
+const DefaultLivenessInterval = 10 * time.Second
+
+func (m *Manager) startLivenessCheck(interval time.Duration) {
+	if m == nil || interval < 0 {
+		return
+	}
+	if interval == 0 {
+		interval = DefaultLivenessInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.checkSessionLiveness()
+			case <-m.done:
+				return
+			}
+		}
+	}()
+}
+
+// checkSessionLiveness marks running or paused sessions whose process has exited or
+// become defunct as stopped, so they do not linger as running in List.
+func (m *Manager) checkSessionLiveness() {
+	if m == nil {
+		return
+	}
+	alive := m.processAlive
+	if alive == nil {
+		alive = processLiveness
+	}
+
+	m.mu.RLock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	m.mu.RUnlock()
+
+	for _, session := range sessions {
+		if session == nil || session.pid <= 0 {
+			continue
+		}
+		if state := session.State(); state != sessionStateRunning && state != sessionStatePaused {
+			continue
+		}
+		ok, reason := alive(session.pid)
+		if ok || !session.markStopped(reason) {
+			continue
+		}
+		m.logger.Warn("session process not alive", map[string]string{
+			"gestalt.category": "terminal",
+			"gestalt.source":   "backend",
+			"session.id":       session.ID,
+			"pid":              strconv.Itoa(session.pid),
+			"reason":           reason,
+		})
+		if m.terminalBus != nil {
+			terminalEvent := event.NewTerminalEvent(session.ID, "terminal_stopped")
+			terminalEvent.Data = map[string]any{
+				"pid":    session.pid,
+				"reason": reason,
+			}
+			m.terminalBus.Publish(terminalEvent)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package terminal
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gestalt/internal/event"
+)
+
+func TestCheckSessionLivenessMarksDeadSessionStopped(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	session := newSession("1", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer session.Close()
+	session.pid = 4242
+	manager.RegisterSession(session)
+
+	events, cancel := manager.TerminalBus().SubscribeFiltered(func(terminalEvent event.TerminalEvent) bool {
+		return terminalEvent.Type() == "terminal_stopped"
+	})
+	defer cancel()
+
+	manager.processAlive = func(pid int) (bool, string) {
+		return true, ""
+	}
+	manager.checkSessionLiveness()
+	if status := session.Info().Status; status != "running" {
+		t.Fatalf("expected running session, got %q", status)
+	}
+
+	manager.processAlive = func(pid int) (bool, string) {
+		if pid != 4242 {
+			t.Fatalf("unexpected pid %d", pid)
+		}
+		return false, "process defunct"
+	}
+	manager.checkSessionLiveness()
+
+	info := session.Info()
+	if info.Status != "stopped" || info.StatusReason != "process defunct" {
+		t.Fatalf("expected stopped session with reason, got %q %q", info.Status, info.StatusReason)
+	}
+	if err := session.Write([]byte("ls\n")); !errors.Is(err, ErrSessionClosed) {
+		t.Fatalf("expected write to stopped session to fail, got %v", err)
+	}
+
+	select {
+	case terminalEvent := <-events:
+		if terminalEvent.TerminalID != "1" || terminalEvent.Data["reason"] != "process defunct" {
+			t.Fatalf("unexpected event: %+v", terminalEvent)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected terminal_stopped event")
+	}
+
+	// A second pass must not emit again.
+	manager.checkSessionLiveness()
+	select {
+	case terminalEvent := <-events:
+		t.Fatalf("unexpected second event: %+v", terminalEvent)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestLivenessCheckStopsOnCloseAll(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	session := newSession("1", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer session.Close()
+	session.pid = 4242
+	manager.RegisterSession(session)
+
+	var checks atomic.Int64
+	manager.processAlive = func(pid int) (bool, string) {
+		checks.Add(1)
+		return true, ""
+	}
+	manager.startLivenessCheck(time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for checks.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected liveness check to run")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := manager.CloseAll(); err != nil {
+		t.Fatalf("close all: %v", err)
+	}
+	// Give an in-flight tick time to finish, then register a running session
+	// that a loop still ticking would check.
+	time.Sleep(10 * time.Millisecond)
+	running := newSession("2", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer running.Close()
+	running.pid = 4243
+	manager.RegisterSession(running)
+	before := checks.Load()
+	time.Sleep(20 * time.Millisecond)
+	if after := checks.Load(); after != before {
+		t.Fatalf("expected liveness loop to stop, got %d more checks", after-before)
+	}
+}
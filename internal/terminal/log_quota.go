@@ -0,0 +1,201 @@
+package terminal
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	sessionLogQuotaInterval = time.Minute
+	// sessionLogQuotaMaxWriteThreshold caps how much session log output may
+	// accumulate between ticks before a quota check is triggered early.
+	sessionLogQuotaMaxWriteThreshold = 1 << 20
+	sessionLogQuotaMinWriteThreshold = 4 * 1024
+)
+
+// sessionLogQuota tracks bytes written to session logs so a runaway session
+// triggers a quota check without waiting for the next tick.
+type sessionLogQuota struct {
+	maxBytes  int64
+	threshold int64
+	written   atomic.Int64
+	trigger   chan struct{}
+}
+
+func newSessionLogQuota(maxBytes int64) *sessionLogQuota {
+	if maxBytes <= 0 {
+		return nil
+	}
+	threshold := maxBytes / 10
+	if threshold > sessionLogQuotaMaxWriteThreshold {
+		threshold = sessionLogQuotaMaxWriteThreshold
+	}
+	if threshold < sessionLogQuotaMinWriteThreshold {
+		threshold = sessionLogQuotaMinWriteThreshold
+	}
+	return &sessionLogQuota{
+		maxBytes:  maxBytes,
+		threshold: threshold,
+		trigger:   make(chan struct{}, 1),
+	}
+}
+
+func (q *sessionLogQuota) noteWrite(n int) {
+	if q == nil || n <= 0 {
+		return
+	}
+	if q.written.Add(int64(n)) < q.threshold {
+		return
+	}
+	q.written.Store(0)
+	select {
+	case q.trigger <- struct{}{}:
+	default:
+	}
+}
+
+type sessionLogUsage struct {
+	path       string
+	terminalID string
+	size       int64
+	modTime    time.Time
+}
+
+func (m *Manager) startSessionLogQuota() {
+	if m == nil || m.sessionLogs == "" || m.logQuota == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(sessionLogQuotaInterval)
+		defer ticker.Stop()
+		m.enforceSessionLogQuota()
+		for {
+			select {
+			case <-ticker.C:
+			case <-m.logQuota.trigger:
+			case <-m.done:
+				return
+			}
+			m.enforceSessionLogQuota()
+		}
+	}()
+}
+
+// enforceSessionLogQuota keeps the session log directory under the configured
+// size. Logs of ended sessions are evicted oldest first; if live sessions alone
+// exceed the quota, the largest active logs are truncated.
+func (m *Manager) enforceSessionLogQuota() {
+	if m == nil || m.sessionLogs == "" || m.logQuota == nil {
+		return
+	}
+
+	entries, err := os.ReadDir(m.sessionLogs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return
+		}
+		m.logger.Warn("session log quota check failed", map[string]string{
+			"path":  m.sessionLogs,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	active := make(map[string]bool)
+	m.mu.RLock()
+	for _, session := range m.sessions {
+		if path := session.LogPath(); path != "" {
+			active[filepath.Clean(path)] = true
+		}
+	}
+	m.mu.RUnlock()
+
+	var total int64
+	var inactive, live []sessionLogUsage
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		terminalID, ok := sessionLogTerminalID(entry.Name())
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		usage := sessionLogUsage{
+			path:       filepath.Join(m.sessionLogs, entry.Name()),
+			terminalID: terminalID,
+			size:       info.Size(),
+			modTime:    info.ModTime(),
+		}
+		total += usage.size
+		if active[filepath.Clean(usage.path)] {
+			live = append(live, usage)
+		} else {
+			inactive = append(inactive, usage)
+		}
+	}
+
+	maxBytes := m.logQuota.maxBytes
+	if total <= maxBytes {
+		return
+	}
+
+	sort.Slice(inactive, func(i, j int) bool {
+		return inactive[i].modTime.Before(inactive[j].modTime)
+	})
+	for _, file := range inactive {
+		if total <= maxBytes {
+			return
+		}
+		if err := os.Remove(file.path); err != nil {
+			if os.IsNotExist(err) {
+				total -= file.size
+				continue
+			}
+			m.logger.Warn("session log quota remove failed", map[string]string{
+				"path":  file.path,
+				"error": err.Error(),
+			})
+			continue
+		}
+		_ = os.Remove(sessionTimingPath(file.path))
+		total -= file.size
+		m.logger.Info("session log evicted for disk quota", map[string]string{
+			"terminal_id": file.terminalID,
+			"path":        file.path,
+			"bytes":       strconv.FormatInt(file.size, 10),
+		})
+	}
+
+	sort.Slice(live, func(i, j int) bool {
+		return live[i].size > live[j].size
+	})
+	for _, file := range live {
+		if total <= maxBytes {
+			return
+		}
+		if err := os.Truncate(file.path, 0); err != nil {
+			m.logger.Warn("session log quota truncate failed", map[string]string{
+				"path":  file.path,
+				"error": err.Error(),
+			})
+			continue
+		}
+		// Timing entries index into the log, so they go with it.
+		_ = os.Truncate(sessionTimingPath(file.path), 0)
+		total -= file.size
+		m.logger.Warn("session log truncated for disk quota", map[string]string{
+			"terminal_id": file.terminalID,
+			"path":        file.path,
+			"bytes":       strconv.FormatInt(file.size, 10),
+			"quota_bytes": strconv.FormatInt(maxBytes, 10),
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package terminal
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnforceSessionLogQuotaEvictsOldestEndedLogs(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	oldest := createSessionLogSize(t, dir, "Coder 1", now.Add(-3*time.Hour), 400)
+	older := createSessionLogSize(t, dir, "Coder 2", now.Add(-2*time.Hour), 400)
+	newest := createSessionLogSize(t, dir, "Coder 3", now.Add(-time.Hour), 400)
+
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh"})
+	manager.sessionLogs = dir
+	manager.logQuota = newSessionLogQuota(900)
+
+	manager.enforceSessionLogQuota()
+
+	if _, err := os.Stat(oldest); !os.IsNotExist(err) {
+		t.Fatalf("expected oldest log to be evicted: %v", err)
+	}
+	for _, path := range []string{older, newest} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("expected newer log to remain: %v", err)
+		}
+	}
+}
+
+func TestEnforceSessionLogQuotaTruncatesLargestActiveLog(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewSessionLogger(dir, "Coder 1", time.Now(), 0)
+	if err != nil {
+		t.Fatalf("new session logger: %v", err)
+	}
+	defer logger.Close()
+	path := logger.Path()
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 2048)), 0o644); err != nil {
+		t.Fatalf("write session log: %v", err)
+	}
+
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh"})
+	manager.sessionLogs = dir
+	manager.logQuota = newSessionLogQuota(1024)
+	session := &Session{}
+	session.ID = "Coder 1"
+	session.logger = logger
+	manager.sessions[session.ID] = session
+
+	manager.enforceSessionLogQuota()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected active log to remain: %v", err)
+	}
+	if info.Size() != 0 {
+		t.Fatalf("expected active log to be truncated, got %d bytes", info.Size())
+	}
+}
+
+func TestSessionLogQuotaTriggersAfterWriteThreshold(t *testing.T) {
+	quota := newSessionLogQuota(40 * 1024)
+	quota.noteWrite(int(quota.threshold) - 1)
+	select {
+	case <-quota.trigger:
+		t.Fatalf("expected no trigger below threshold")
+	default:
+	}
+	quota.noteWrite(1)
+	select {
+	case <-quota.trigger:
+	default:
+		t.Fatalf("expected trigger once threshold is reached")
+	}
+}
+
+func TestSessionLogQuotaLoopStopsOnCloseAll(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	first := createSessionLogSize(t, dir, "Coder 1", now.Add(-2*time.Hour), 800)
+	createSessionLogSize(t, dir, "Coder 2", now.Add(-time.Hour), 800)
+
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh"})
+	manager.sessionLogs = dir
+	manager.logQuota = newSessionLogQuota(1000)
+	manager.startSessionLogQuota()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := os.Stat(first); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected initial quota check to evict the oldest log")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := manager.CloseAll(); err != nil {
+		t.Fatalf("close all: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	later := createSessionLogSize(t, dir, "Coder 3", now.Add(-3*time.Hour), 800)
+	manager.logQuota.trigger <- struct{}{}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := os.Stat(later); err != nil {
+		t.Fatalf("expected stopped quota loop to leave logs alone: %v", err)
+	}
+}
+
+func createSessionLogSize(t *testing.T, dir, terminalID string, timestamp time.Time, size int) string {
+	t.Helper()
+	path := createSessionLog(t, dir, terminalID, timestamp)
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o644); err != nil {
+		t.Fatalf("write session log: %v", err)
+	}
+	if err := os.Chtimes(path, timestamp, timestamp); err != nil {
+		t.Fatalf("set session log time: %v", err)
+	}
+	return path
+}
@@ -33,10 +33,13 @@ import (
 var ErrSessionNotFound = errors.New("terminal session not found")
 var ErrAgentNotFound = errors.New("agent profile not found")
 var ErrAgentRequired = errors.New("agent id is required")
+var ErrParentNotFound = errors.New("parent session not found")
 var ErrSessionNotTmuxManaged = errors.New("session is not tmux-managed")
 var ErrTmuxSessionNotFound = errors.New("tmux session not found")
 var ErrTmuxWindowNotFound = errors.New("tmux window not found")
 var ErrTmuxUnavailable = errors.New("tmux unavailable")
+var ErrNoInputHistory = errors.New("session has no input history")
+var ErrSessionLogNotFound = errors.New("session log not found")
 
 type AgentAlreadyRunningError struct {
 	AgentName  string
@@ -97,6 +100,49 @@ type ManagerOptions struct {
 	PortResolver            ports.PortResolver
 	StartExternalTmuxWindow func(*launchspec.LaunchSpec) error
 	TmuxClientFactory       func() TmuxClient
+	// LivenessInterval sets how often pty-backed session processes are checked
+	// for exit; zero uses DefaultLivenessInterval and a negative value disables
+	// the check.
+	LivenessInterval time.Duration
+	// RedactPatterns opts into scrubbing session output before it is buffered,
+	// logged, or streamed. See NewOutputRedactor for the pattern syntax.
+	RedactPatterns []string
+	// OutputRateLimit caps each pty-backed session's output in bytes per
+	// second; zero leaves it unlimited. Excess output is held in the pty and
+	// delivered in larger chunks, trading latency for stability under chatty
+	// agents.
+	OutputRateLimit int64
+	// PtyFactories are launchers an agent can select by key with its
+	// pty_factory setting; agents without one, or naming an unregistered
+	// key, use PtyFactory.
+	PtyFactories map[string]PtyFactory
+	// LauncherCheckWindow is how long after launch session output is watched
+	// for a missing launcher binary. The check is opt-in: zero or a negative
+	// value leaves it off.
+	LauncherCheckWindow time.Duration
+	// BellDebounce is how long after a bell signal further bells from the
+	// same session are coalesced; zero uses DefaultBellDebounce and a
+	// negative value signals every bell.
+	BellDebounce time.Duration
+	// SessionDirMaxBytes caps the total size of the session log directory;
+	// zero disables the quota. When it is exceeded, logs of ended sessions are
+	// evicted oldest first and then the largest active logs are truncated.
+	SessionDirMaxBytes int64
+	// PromptEnv selects env-specific prompt variants: with "staging", the
+	// prompt "codex" resolves to "codex.staging" when that file exists.
+	// Empty uses GESTALT_ENV.
+	PromptEnv string
+	// OutputTransform rewrites pty output before it is buffered, logged, or
+	// streamed, e.g. to remap ANSI colors. Untransformed output stays
+	// available through Session.RawOutputLines.
+	OutputTransform OutputTransform
+	// AgentDirs maps IDs of agents loaded from layered config dirs to the
+	// agents dir their TOML is reloaded from instead of AgentsDir.
+	AgentDirs map[string]string
+	// AgentLayerDirs lists the agents dirs of layered config dirs in
+	// precedence order, all below AgentsDir, so reloaded agents can extend
+	// lower ones.
+	AgentLayerDirs []string
 }
 
 // TmuxClient defines tmux operations used by manager activation flows.
@@ -130,27 +176,49 @@ type Manager struct {
 	sessionLogs             string
 	inputHistoryDir         string
 	retentionDays           int
+	logQuota                *sessionLogQuota
 	historyScanMax          int64
 	outputPolicy            OutputBackpressurePolicy
 	outputSample            uint64
 	portResolver            ports.PortResolver
 	promptFS                fs.FS
 	promptDir               string
+	promptEnv               string
 	promptParser            *prompt.Parser
 	processRegistry         *process.Registry
 	startExternalTmuxWindow func(*launchspec.LaunchSpec) error
 	tmuxClientFactory       func() TmuxClient
 	agentsHubMu             sync.Mutex
 	agentsHubID             string
+	processAlive            func(pid int) (bool, string)
+	launcherCheckWindow     time.Duration
+	bellDebounce            time.Duration
+	focus                   map[string]SessionFocus
+	webhooks                *webhookRegistry
+	tmuxHealth              tmuxHealthCache
+	// done is closed by CloseAll to stop the manager's background loops.
+	done     chan struct{}
+	doneOnce sync.Once
 }
 
 type sessionCreateRequest struct {
-	SessionID string
-	AgentID   string
-	Role      string
-	Title     string
-	Shell     string
-	Runner    string
+	SessionID     string
+	AgentID       string
+	Role          string
+	Title         string
+	Shell         string
+	Runner        string
+	Labels        map[string]string
+	ParentID      string
+	InitialInput  string
+	Banner        string
+	NoPersist     bool
+	ReadyPattern  string
+	ReadyDelay    time.Duration
+	WaitReady     bool
+	AttachPID     int
+	Encoding      string
+	PromptPattern string
 }
 
 type CreateOptions struct {
@@ -158,6 +226,44 @@ type CreateOptions struct {
 	Role    string
 	Title   string
 	Runner  string
+	Labels  map[string]string
+	// ParentID links the new session under an existing one, e.g. a lead
+	// agent that spawned workers.
+	ParentID string
+	// InitialInput is typed into the session once it is ready, as if sent
+	// through the input endpoint, and recorded in input history.
+	InitialInput string
+	// Banner is shown at the top of the session output, e.g. a note for
+	// whoever attaches. It goes to the output buffer and session log only;
+	// the process never sees it.
+	Banner string
+	// NoPersist keeps this session's output and input history in memory
+	// only, even when session persistence is enabled.
+	NoPersist bool
+	// ReadyPattern is a regular expression matched against the session's
+	// early output, with escape sequences removed; the session is ready once
+	// it matches. ReadyDelay instead marks it ready after a fixed delay.
+	// Without either the session is ready at once. Initial input waits for
+	// readiness when either is set.
+	ReadyPattern string
+	ReadyDelay   time.Duration
+	// WaitReady makes create return only once the session is ready, or the
+	// readiness timeout has passed.
+	WaitReady bool
+	// AttachPID creates a read-only session that follows the output of an
+	// already running process instead of starting one. No agent is needed.
+	// It is supported on Linux, for processes whose stdout or stderr is a
+	// regular file.
+	AttachPID int
+	// Encoding names the character encoding the process reads and writes,
+	// e.g. "latin1", overriding the agent's. Output is transcoded to UTF-8
+	// and input from it. Empty uses the agent's, and then UTF-8.
+	Encoding string
+	// PromptPattern is a regular expression for the session's prompt. While
+	// the tail of the output, with escape sequences removed, matches it, the
+	// session reports it is waiting for input. Shells that emit OSC 133
+	// prompt markers are detected without one.
+	PromptPattern string
 }
 
 const (
@@ -172,9 +278,19 @@ const (
 	maxSessionIDAttempts = 64
 	notifyDefaultHost    = "127.0.0.1"
 	notifyDefaultPort    = 57417
+
+	// terminalEventHistorySize is how many terminal events are kept for
+	// stream clients resuming after a reconnect.
+	terminalEventHistorySize = 256
 )
 
 var onAirTimeout = 5 * time.Second
+
+// initialInputReadyTimeout bounds how long initial input waits for a session
+// without an onair string to produce its first output; initialInputSettleDelay
+// then gives the shell time to draw its prompt.
+var initialInputReadyTimeout = promptDelay
+var initialInputSettleDelay = finalEnterDelay
 var defaultSnapshotSampleEvery uint64 = 10
 
 type AgentInfo struct {
@@ -263,6 +379,10 @@ func NewManager(opts ManagerOptions) *Manager {
 	if promptFS != nil {
 		promptDir = filepath.ToSlash(promptDir)
 	}
+	promptEnv := strings.TrimSpace(opts.PromptEnv)
+	if promptEnv == "" {
+		promptEnv = strings.TrimSpace(os.Getenv("GESTALT_ENV"))
+	}
 	portResolver := opts.PortResolver
 	promptParser := prompt.NewParser(promptFS, promptDir, ".", portResolver)
 
@@ -270,7 +390,8 @@ func NewManager(opts ManagerOptions) *Manager {
 		Name: "agent_events",
 	})
 	terminalBus := event.NewBus[event.TerminalEvent](context.Background(), event.BusOptions{
-		Name: "terminal_events",
+		Name:        "terminal_events",
+		HistorySize: terminalEventHistorySize,
 	})
 	workflowBus := event.NewBus[event.WorkflowEvent](context.Background(), event.BusOptions{
 		Name: "workflow_events",
@@ -283,6 +404,8 @@ func NewManager(opts ManagerOptions) *Manager {
 	agentRegistry := agent.NewRegistry(agent.RegistryOptions{
 		Agents:    agents,
 		AgentsDir: opts.AgentsDir,
+		AgentDirs: opts.AgentDirs,
+		LayerDirs: opts.AgentLayerDirs,
 	})
 	skills := make(map[string]*skill.Skill)
 	for id, entry := range opts.Skills {
@@ -306,16 +429,26 @@ func NewManager(opts ManagerOptions) *Manager {
 		sessionLogs:             sessionLogs,
 		inputHistoryDir:         inputHistoryDir,
 		retentionDays:           retentionDays,
+		logQuota:                newSessionLogQuota(opts.SessionDirMaxBytes),
 		historyScanMax:          historyScanMax,
 		outputPolicy:            outputPolicy,
 		outputSample:            outputSample,
 		portResolver:            portResolver,
 		promptFS:                promptFS,
 		promptDir:               promptDir,
+		promptEnv:               promptEnv,
 		promptParser:            promptParser,
 		processRegistry:         registry,
 		startExternalTmuxWindow: opts.StartExternalTmuxWindow,
 		tmuxClientFactory:       opts.TmuxClientFactory,
+		launcherCheckWindow:     opts.LauncherCheckWindow,
+		bellDebounce:            opts.BellDebounce,
+		webhooks:                newWebhookRegistry(logger),
+		tmuxHealth:              tmuxHealthCache{interval: tmuxHealthInterval},
+		done:                    make(chan struct{}),
+	}
+	if manager.bellDebounce == 0 {
+		manager.bellDebounce = DefaultBellDebounce
 	}
 	if manager.startExternalTmuxWindow == nil {
 		if runningUnderGoTest() {
@@ -336,6 +469,7 @@ func NewManager(opts ManagerOptions) *Manager {
 	manager.sessionFactory = NewSessionFactory(SessionFactoryOptions{
 		Clock:            clock,
 		PtyFactory:       factory,
+		PtyFactories:     opts.PtyFactories,
 		ProcessRegistry:  registry,
 		SessionLogDir:    sessionLogs,
 		InputHistoryDir:  inputHistoryDir,
@@ -348,8 +482,14 @@ func NewManager(opts ManagerOptions) *Manager {
 		NotificationSink: notificationSink,
 		Logger:           logger,
 		NextID:           manager.nextIDValue,
+		Redactor:         compileRedactPatterns(opts.RedactPatterns, logger),
+		OutputRateLimit:  opts.OutputRateLimit,
+		LogQuota:         manager.logQuota,
+		OutputTransform:  opts.OutputTransform,
 	})
 	manager.startSessionCleanup()
+	manager.startSessionLogQuota()
+	manager.startLivenessCheck(opts.LivenessInterval)
 	return manager
 }
 
@@ -370,13 +510,96 @@ func (m *Manager) ProcessRegistry() *process.Registry {
 
 func (m *Manager) CreateWithOptions(options CreateOptions) (*Session, error) {
 	return m.createSession(sessionCreateRequest{
-		AgentID: options.AgentID,
-		Role:    options.Role,
-		Title:   options.Title,
-		Runner:  options.Runner,
+		AgentID:       options.AgentID,
+		Role:          options.Role,
+		Title:         options.Title,
+		Runner:        options.Runner,
+		Labels:        options.Labels,
+		ParentID:      options.ParentID,
+		InitialInput:  options.InitialInput,
+		Banner:        options.Banner,
+		NoPersist:     options.NoPersist,
+		ReadyPattern:  options.ReadyPattern,
+		ReadyDelay:    options.ReadyDelay,
+		WaitReady:     options.WaitReady,
+		AttachPID:     options.AttachPID,
+		Encoding:      options.Encoding,
+		PromptPattern: options.PromptPattern,
 	})
 }
 
+// Clone starts a session with the create options of session id. Agent
+// sessions are singletons, so a stopped source is replaced: it is taken out
+// of the session table while its clone starts, put back if the clone fails,
+// and closed once the clone is running. The replacement keeps the source's
+// ID, so no terminal_closed event is published for the source. A source that
+// is still running or paused returns AgentAlreadyRunningError.
+func (m *Manager) Clone(id string) (*Session, error) {
+	source, ok := m.Get(id)
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	options := source.CreateOptions()
+	if source.State() != sessionStateStopped {
+		return m.CreateWithOptions(options)
+	}
+	if !m.detachSession(id, source) {
+		return nil, ErrSessionNotFound
+	}
+	clone, err := m.CreateWithOptions(options)
+	if err != nil {
+		if !m.restoreSession(id, source) {
+			_ = source.Close()
+		}
+		return nil, err
+	}
+	if closeErr := source.Close(); closeErr != nil {
+		m.logger.Warn("replaced session close error", map[string]string{
+			"gestalt.category": "terminal",
+			"gestalt.source":   "backend",
+			"session.id":       id,
+			"error":            closeErr.Error(),
+		})
+	}
+	return clone, nil
+}
+
+// detachSession removes session from the session table and its agent
+// mapping without closing it. It reports false when id no longer maps to
+// session.
+func (m *Manager) detachSession(id string, session *Session) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions[id] != session {
+		return false
+	}
+	delete(m.sessions, id)
+	if session.agent != nil && session.agent.Name != "" {
+		if existingID, ok := m.agentSessions[session.agent.Name]; ok && existingID == id {
+			delete(m.agentSessions, session.agent.Name)
+		}
+	}
+	return true
+}
+
+// restoreSession puts back a session removed by detachSession. It reports
+// false when its ID or agent has been taken in the meantime.
+func (m *Manager) restoreSession(id string, session *Session) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, taken := m.sessions[id]; taken {
+		return false
+	}
+	if session.agent != nil && session.agent.Name != "" {
+		if _, taken := m.agentSessions[session.agent.Name]; taken {
+			return false
+		}
+		m.agentSessions[session.agent.Name] = id
+	}
+	m.sessions[id] = session
+	return true
+}
+
 func (m *Manager) CreateWithID(sessionID, agentID, role, title, shell string) (*Session, error) {
 	trimmedID := strings.TrimSpace(sessionID)
 	if trimmedID == "" {
@@ -400,6 +623,9 @@ func (m *Manager) createSession(request sessionCreateRequest) (*Session, error)
 			return existingSession, nil
 		}
 	}
+	if request.AttachPID != 0 {
+		return m.createAttachedSession(request)
+	}
 
 	shell := m.shell
 	shellOverride := strings.TrimSpace(request.Shell)
@@ -412,13 +638,32 @@ func (m *Manager) createSession(request sessionCreateRequest) (*Session, error)
 	var profile *agent.Agent
 	var promptNames []string
 	var promptPayloads []string
-	var codexPromptFiles []string
+	var launchPromptFiles []string
 	var agentName string
 	var sanitizedAgentName string
 	reservedID := strings.TrimSpace(request.SessionID)
 	if request.AgentID == "" {
 		return nil, ErrAgentRequired
 	}
+	if err := ValidateLabels(request.Labels); err != nil {
+		return nil, err
+	}
+	readyPattern, readyErr := compileReadiness(request.ReadyPattern, request.ReadyDelay)
+	if readyErr != nil {
+		return nil, readyErr
+	}
+	if err := ValidateEncoding(request.Encoding); err != nil {
+		return nil, err
+	}
+	if _, err := compilePromptPattern(request.PromptPattern); err != nil {
+		return nil, err
+	}
+	request.ParentID = strings.TrimSpace(request.ParentID)
+	if request.ParentID != "" {
+		if _, ok := m.Get(request.ParentID); !ok {
+			return nil, ErrParentNotFound
+		}
+	}
 	if reservedID != "" {
 		if err := validateSessionID(reservedID); err != nil {
 			return nil, err
@@ -436,6 +681,9 @@ func (m *Manager) createSession(request sessionCreateRequest) (*Session, error)
 	}
 	profileCopy := agentProfile
 	profile = &profileCopy
+	if strings.TrimSpace(request.Encoding) == "" {
+		request.Encoding = agentProfile.Encoding
+	}
 	if strings.TrimSpace(agentProfile.Name) != "" {
 		request.Title = agentProfile.Name
 		agentName = agentProfile.Name
@@ -462,7 +710,15 @@ func (m *Manager) createSession(request sessionCreateRequest) (*Session, error)
 		reservedID = m.nextIDValue()
 	}
 	if !shellOverrideSet && profile != nil {
-		if strings.EqualFold(strings.TrimSpace(profile.RuntimeType()), "codex") {
+		if profile.HasCommand() {
+			command, promptFiles, promptUsed := m.buildAgentCommand(profile, promptNames, reservedID)
+			shell = command
+			profile.Shell = shell
+			launchPromptFiles = append(launchPromptFiles, promptFiles...)
+			if promptUsed {
+				promptNames = nil
+			}
+		} else if strings.EqualFold(strings.TrimSpace(profile.RuntimeType()), "codex") {
 			cfg := make(map[string]interface{}, len(profile.CLIConfig)+2)
 			for key, value := range profile.CLIConfig {
 				cfg[key] = value
@@ -494,7 +750,7 @@ func (m *Manager) createSession(request sessionCreateRequest) (*Session, error)
 			shell = joinCommandLine("codex", shellArgs)
 			profile.Shell = shell
 			if len(developerInstructions.PromptFiles) > 0 {
-				codexPromptFiles = append(codexPromptFiles, developerInstructions.PromptFiles...)
+				launchPromptFiles = append(launchPromptFiles, developerInstructions.PromptFiles...)
 				promptNames = nil
 			}
 		} else if strings.TrimSpace(profile.Shell) != "" {
@@ -561,9 +817,14 @@ func (m *Manager) createSession(request sessionCreateRequest) (*Session, error)
 		releaseReservation()
 		return nil, err
 	}
-	if len(codexPromptFiles) > 0 {
-		session.PromptFiles = append(session.PromptFiles, codexPromptFiles...)
+	if len(launchPromptFiles) > 0 {
+		session.PromptFiles = append(session.PromptFiles, launchPromptFiles...)
 	}
+	if len(request.Labels) > 0 {
+		_ = session.SetLabels(request.Labels)
+	}
+	session.ParentID = request.ParentID
+	session.NoPersist = request.NoPersist
 	if runnerKind == launchspec.RunnerKindExternal {
 		if len(promptNames) > 0 {
 			payloads, files := m.buildExternalPromptPayloads(promptNames, session.ID)
@@ -593,11 +854,18 @@ func (m *Manager) createSession(request sessionCreateRequest) (*Session, error)
 			}
 		}
 	}
+	m.startReadinessDetection(session, readyPattern, request.ReadyDelay)
+	session.bell = m.newSessionBell(session)
 	m.mu.Lock()
 	m.sessions[id] = session
 	m.mu.Unlock()
 
 	m.emitSessionStarted(id, request, agentName, shell)
+	m.watchLauncherNotFound(session)
+	m.startInitialInput(session, profile.OnAirString, request.InitialInput)
+	if request.WaitReady {
+		session.WaitReady(readyTimeout)
+	}
 
 	return session, nil
 }
@@ -698,7 +966,7 @@ func renderOutputTail(logger *logging.Logger, lines []string, maxLines, maxBytes
 	if logger != nil && len(filtered) < len(joined) {
 		reduced := len(joined) - len(filtered)
 		if reduced >= 128 {
-			logger.Debug("terminal output tail filtered", map[string]string{
+			logger.DebugSampled("terminal output tail filtered", 20, "terminal output tail filtered", map[string]string{
 				"before_bytes":  strconv.Itoa(len(joined)),
 				"after_bytes":   strconv.Itoa(len(filtered)),
 				"reduced_bytes": strconv.Itoa(reduced),
@@ -719,7 +987,7 @@ func (m *Manager) readPromptFile(promptName, sessionID string) ([]byte, []string
 	if m.promptParser == nil {
 		return nil, nil, errors.New("prompt parser unavailable")
 	}
-	result, err := m.promptParser.RenderWithContext(promptName, prompt.RenderContext{SessionID: sessionID})
+	result, err := m.promptParser.RenderWithContext(m.resolvePromptVariant(promptName), prompt.RenderContext{SessionID: sessionID})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1023,6 +1291,35 @@ func (m *Manager) GetSessionByAgent(agentName string) (*Session, bool) {
 	return session, ok
 }
 
+// GetByAgentName returns the live session for an agent, accepting either the
+// agent's display name or its config id. Names are matched case-insensitively.
+func (m *Manager) GetByAgentName(name string) (*Session, bool) {
+	name = strings.TrimSpace(name)
+	if m == nil || name == "" {
+		return nil, false
+	}
+	if id, ok := m.GetAgentTerminal(name); ok {
+		return m.Get(id)
+	}
+	if profile, ok := m.GetAgent(name); ok && strings.TrimSpace(profile.Name) != "" {
+		if id, ok := m.GetAgentTerminal(profile.Name); ok {
+			return m.Get(id)
+		}
+	}
+
+	m.mu.RLock()
+	var id string
+	for agentName, sessionID := range m.agentSessions {
+		if strings.EqualFold(agentName, name) {
+			id = sessionID
+			break
+		}
+	}
+	session, ok := m.sessions[id]
+	m.mu.RUnlock()
+	return session, ok
+}
+
 func (m *Manager) GetAgentTerminal(agentName string) (string, bool) {
 	if strings.TrimSpace(agentName) == "" {
 		return "", false
@@ -1161,16 +1458,44 @@ func (m *Manager) HistoryPage(id string, maxLines int, beforeCursor *int64) ([]s
 
 func (m *Manager) List() []SessionInfo {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	infos := make([]SessionInfo, 0, len(m.sessions))
+	var tmuxManaged []int
 	for _, session := range m.sessions {
+		if isTmuxManagedSession(session) {
+			tmuxManaged = append(tmuxManaged, len(infos))
+		}
 		infos = append(infos, session.Info())
 	}
+	m.mu.RUnlock()
 
+	if len(tmuxManaged) > 0 {
+		m.fillTmuxHealth(infos, tmuxManaged)
+	}
 	return infos
 }
 
+// UpdateSessionLabels merges label updates into a session and publishes a labels_updated event.
+func (m *Manager) UpdateSessionLabels(id string, updates map[string]string) (map[string]string, error) {
+	session, ok := m.Get(id)
+	if !ok || session == nil {
+		return nil, ErrSessionNotFound
+	}
+	labels, err := session.UpdateLabels(updates)
+	if err != nil {
+		return nil, err
+	}
+	if m.terminalBus != nil {
+		terminalEvent := event.NewTerminalEvent(id, "labels_updated")
+		data := make(map[string]any, len(labels))
+		for key, value := range labels {
+			data[key] = value
+		}
+		terminalEvent.Data = map[string]any{"labels": data}
+		m.terminalBus.Publish(terminalEvent)
+	}
+	return labels, nil
+}
+
 func (m *Manager) SessionPersistenceEnabled() bool {
 	if m == nil {
 		return false
@@ -1295,6 +1620,7 @@ func (m *Manager) Delete(id string) error {
 	session, ok := m.sessions[id]
 	if ok {
 		delete(m.sessions, id)
+		m.clearFocusLocked(id)
 		if m.agentsHubID == id {
 			m.agentsHubID = ""
 		}
@@ -1325,10 +1651,17 @@ func (m *Manager) Delete(id string) error {
 	return nil
 }
 
+// CloseAll closes every session and stops the manager's background loops.
+// It is meant for shutdown; the manager should not be used afterwards.
 func (m *Manager) CloseAll() error {
 	if m == nil {
 		return nil
 	}
+	m.doneOnce.Do(func() {
+		if m.done != nil {
+			close(m.done)
+		}
+	})
 	m.mu.Lock()
 	sessions := make(map[string]*Session, len(m.sessions))
 	for id, session := range m.sessions {
@@ -1337,6 +1670,7 @@ func (m *Manager) CloseAll() error {
 	m.sessions = make(map[string]*Session)
 	m.agentSessions = make(map[string]string)
 	m.agentsHubID = ""
+	m.focus = nil
 	m.mu.Unlock()
 
 	var errs []error
@@ -1378,7 +1712,11 @@ func waitForOnAir(session *Session, target string, timeout time.Duration) bool {
 	}
 	output, cancel := session.Subscribe()
 	defer cancel()
+	return waitForOnAirOutput(output, target, timeout)
+}
 
+// waitForOnAirOutput is waitForOnAir for a caller that already subscribed.
+func waitForOnAirOutput(output <-chan []byte, target string, timeout time.Duration) bool {
 	timer := time.NewTimer(timeout)
 	defer timer.Stop()
 
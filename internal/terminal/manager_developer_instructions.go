@@ -15,7 +15,7 @@ func (m *Manager) buildCodexDeveloperInstructions(profile *agent.Agent, sessionI
 	var renderer agent.PromptRenderer
 	if m.promptParser != nil {
 		renderer = func(promptName string, ctx prompt.RenderContext) (*prompt.RenderResult, error) {
-			return m.promptParser.RenderWithContext(promptName, ctx)
+			return m.promptParser.RenderWithContext(m.resolvePromptVariant(promptName), ctx)
 		}
 	}
 	skills := m.resolveAgentSkills(profile)
@@ -71,9 +71,11 @@ func (m *Manager) emitSessionStopped(id string, session *Session, agentID, agent
 			m.agentBus.Publish(agentEvent)
 		}
 	}
+	closed := event.NewTerminalEvent(id, "terminal_closed")
 	if m.terminalBus != nil {
-		m.terminalBus.Publish(event.NewTerminalEvent(id, "terminal_closed"))
+		m.terminalBus.Publish(closed)
 	}
+	m.webhooks.closeSession(closed)
 	if agentID != "" && m.agentBus != nil {
 		m.agentBus.Publish(event.NewAgentEvent(agentID, agentName, "agent_stopped"))
 	}
@@ -118,3 +118,141 @@ func (m *Manager) startPromptInjection(session *Session, agentID string, profile
 		}
 	}()
 }
+
+// Prompt delivery modes reported by PreviewAgentPrompt.
+const (
+	PromptModeDeveloperInstructions = "developer_instructions"
+	PromptModeStdin                 = "stdin"
+)
+
+// AgentPromptPreview is the prompt a new session for an agent would receive.
+type AgentPromptPreview struct {
+	AgentID   string
+	SessionID string
+	Mode      string
+	Prompt    string
+	Files     []string
+	// Skipped lists prompts that failed to render; session creation skips
+	// them too.
+	Skipped []SkippedPrompt
+}
+
+// SkippedPrompt is a prompt left out of a preview and why.
+type SkippedPrompt struct {
+	Name  string
+	Error string
+}
+
+// PreviewAgentPrompt resolves an agent's prompt the same way session creation
+// does, without starting a session. Codex agents get skills and prompts
+// composed into developer instructions; other runtimes get their prompt files
+// rendered in order as they would be typed into the session, and a prompt
+// that fails to render is reported in Skipped, as creation skips it.
+func (m *Manager) PreviewAgentPrompt(agentID string) (AgentPromptPreview, error) {
+	agentID = strings.TrimSpace(agentID)
+	agentProfile, ok := m.GetAgent(agentID)
+	if !ok || agentProfile.Name == "" {
+		return AgentPromptPreview{}, ErrAgentNotFound
+	}
+	profile := &agentProfile
+	preview := AgentPromptPreview{AgentID: agentID}
+	if name := sanitizeSessionName(profile.Name); name != "" {
+		preview.SessionID = canonicalAgentSessionID(name)
+	}
+
+	if strings.EqualFold(strings.TrimSpace(profile.RuntimeType()), "codex") {
+		instructions, err := m.buildCodexDeveloperInstructions(profile, preview.SessionID)
+		if err != nil {
+			return AgentPromptPreview{}, err
+		}
+		preview.Mode = PromptModeDeveloperInstructions
+		preview.Prompt = instructions.Instructions
+		preview.Files = instructions.PromptFiles
+		return preview, nil
+	}
+
+	preview.Mode = PromptModeStdin
+	payloads := make([]string, 0, len(profile.Prompts))
+	for _, promptName := range profile.Prompts {
+		promptName = strings.TrimSpace(promptName)
+		if promptName == "" {
+			continue
+		}
+		data, files, err := m.readPromptFile(promptName, preview.SessionID)
+		if err != nil {
+			preview.Skipped = append(preview.Skipped, SkippedPrompt{Name: promptName, Error: err.Error()})
+			continue
+		}
+		if len(data) == 0 {
+			continue
+		}
+		payloads = append(payloads, string(data))
+		preview.Files = append(preview.Files, files...)
+	}
+	preview.Prompt = strings.Join(payloads, "\n\n")
+	return preview, nil
+}
+
+// startInitialInput types input into a new session once it looks ready: when
+// its ready pattern or delay is met, else after the agent's onair string
+// appears or, without one, shortly after the first output. Input without a
+// trailing newline is submitted with Enter, and the command is recorded in
+// input history like input sent through the API.
+func (m *Manager) startInitialInput(session *Session, onAirString, input string) {
+	if session == nil || strings.TrimSpace(input) == "" {
+		return
+	}
+	if session.readyCh != nil {
+		go func() {
+			if !session.WaitReady(readyTimeout) {
+				m.logger.Warn("initial input sent before session was ready", map[string]string{
+					"terminal_id": session.ID,
+					"timeout_ms":  strconv.FormatInt(readyTimeout.Milliseconds(), 10),
+				})
+			}
+			m.sendInitialInput(session, input)
+		}()
+		return
+	}
+	// Subscribe before returning so early output is not missed.
+	output, cancel := session.Subscribe()
+
+	go func() {
+		if strings.TrimSpace(onAirString) != "" {
+			ready := waitForOnAirOutput(output, onAirString, onAirTimeout)
+			cancel()
+			if !ready {
+				m.logger.Warn("initial input sent before onair string", map[string]string{
+					"terminal_id":  session.ID,
+					"onair_string": onAirString,
+					"timeout_ms":   strconv.FormatInt(onAirTimeout.Milliseconds(), 10),
+				})
+			}
+		} else {
+			timer := time.NewTimer(initialInputReadyTimeout)
+			select {
+			case <-output:
+			case <-timer.C:
+			}
+			timer.Stop()
+			cancel()
+			time.Sleep(initialInputSettleDelay)
+		}
+		m.sendInitialInput(session, input)
+	}()
+}
+
+func (m *Manager) sendInitialInput(session *Session, input string) {
+	payload := []byte(input)
+	if !strings.HasSuffix(input, "\n") && !strings.HasSuffix(input, "\r") {
+		payload = append(payload, '\r')
+	}
+	if err := writePromptPayload(session, payload); err != nil {
+		m.logger.Warn("initial input write failed", map[string]string{
+			"terminal_id": session.ID,
+			"error":       err.Error(),
+		})
+		return
+	}
+	session.RecordInput(strings.TrimRight(input, "\r\n"))
+}
@@ -812,6 +812,48 @@ func TestManagerGetAgentTerminal(t *testing.T) {
 	}
 }
 
+func TestManagerGetByAgentName(t *testing.T) {
+	factory := &fakeFactory{}
+	manager := NewManager(ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: factory,
+		Agents: map[string]agent.Agent{
+			"codex": {
+				Name:  "Codex",
+				Shell: "/bin/bash",
+			},
+		},
+	})
+
+	if _, ok := manager.GetByAgentName("Codex"); ok {
+		t.Fatalf("expected no session before create")
+	}
+	session, err := manager.Create("codex", "build", "first")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	for _, name := range []string{"Codex", "codex", " CODEX "} {
+		got, ok := manager.GetByAgentName(name)
+		if !ok || got.ID != session.ID {
+			t.Fatalf("expected %q to resolve to %q", name, session.ID)
+		}
+	}
+	if _, ok := manager.GetByAgentName("Missing"); ok {
+		t.Fatalf("expected missing agent to return false")
+	}
+	if _, ok := manager.GetByAgentName(""); ok {
+		t.Fatalf("expected empty name to return false")
+	}
+
+	if err := manager.Delete(session.ID); err != nil {
+		t.Fatalf("delete session: %v", err)
+	}
+	if _, ok := manager.GetByAgentName("Codex"); ok {
+		t.Fatalf("expected no session after delete")
+	}
+}
+
 func TestManagerSkillsLoaded(t *testing.T) {
 	entries := map[string]*skill.Skill{
 		"git-workflows": {
@@ -1260,14 +1302,173 @@ func TestManagerSingletonReplacesStaleExternalTmuxSession(t *testing.T) {
 	}
 }
 
+type healthTmuxClient struct {
+	singletonTmuxClient
+	mu         sync.Mutex
+	sessionErr error
+}
+
+func (c *healthTmuxClient) set(hasSession bool, windows map[string]bool, sessionErr error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hasSession = hasSession
+	c.windows = windows
+	c.sessionErr = sessionErr
+}
+
+func (c *healthTmuxClient) HasSession(name string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hasSession, c.sessionErr
+}
+
+func (c *healthTmuxClient) HasWindow(sessionName, windowName string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.singletonTmuxClient.HasWindow(sessionName, windowName)
+}
+
+func TestManagerListReportsTmuxHealth(t *testing.T) {
+	tmuxClient := &healthTmuxClient{}
+	tmuxClient.set(true, map[string]bool{"Codex 1": true}, nil)
+	manager := NewManager(ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		Agents: map[string]agent.Agent{
+			"codex": {
+				Name:      "Codex",
+				Shell:     "/bin/bash",
+				CLIType:   "codex",
+				Interface: agent.AgentInterfaceCLI,
+			},
+		},
+		StartExternalTmuxWindow: func(_ *launchspec.LaunchSpec) error { return nil },
+		TmuxClientFactory:       func() TmuxClient { return tmuxClient },
+	})
+	manager.tmuxHealth.interval = 0
+	session, err := manager.Create("codex", "role", "title")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(session.ID)
+	}()
+
+	findInfo := func() SessionInfo {
+		t.Helper()
+		for _, info := range manager.List() {
+			if info.ID == session.ID {
+				return info
+			}
+		}
+		t.Fatalf("session %q not listed", session.ID)
+		return SessionInfo{}
+	}
+	waitHealth := func(want *bool) SessionInfo {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			info := findInfo()
+			got := info.TmuxHealthy
+			if (got == nil && want == nil) || (got != nil && want != nil && *got == *want) {
+				return info
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected tmux health %v, got %v", want, got)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	healthy, unhealthy := true, false
+
+	if info := waitHealth(&healthy); info.TmuxSessionName == "" {
+		t.Fatalf("expected tmux session name")
+	}
+
+	tmuxClient.set(true, map[string]bool{"Codex 1": false}, nil)
+	waitHealth(&unhealthy)
+
+	tmuxClient.set(false, map[string]bool{"Codex 1": true}, nil)
+	waitHealth(&unhealthy)
+
+	tmuxClient.set(true, map[string]bool{"Codex 1": true}, errors.New("tmux unavailable"))
+	if info := waitHealth(nil); info.TmuxSessionName == "" {
+		t.Fatalf("expected tmux session name while health is unknown")
+	}
+}
+
+func TestManagerListCachesTmuxHealth(t *testing.T) {
+	probes := 0
+	var probesMu sync.Mutex
+	tmuxClient := &healthTmuxClient{}
+	tmuxClient.set(true, nil, nil)
+	manager := NewManager(ManagerOptions{
+		Shell:      "/bin/sh",
+		PtyFactory: &fakeFactory{},
+		Agents: map[string]agent.Agent{
+			"codex": {
+				Name:      "Codex",
+				Shell:     "/bin/bash",
+				CLIType:   "codex",
+				Interface: agent.AgentInterfaceCLI,
+			},
+		},
+		StartExternalTmuxWindow: func(_ *launchspec.LaunchSpec) error { return nil },
+		TmuxClientFactory: func() TmuxClient {
+			probesMu.Lock()
+			probes++
+			probesMu.Unlock()
+			return tmuxClient
+		},
+	})
+	session, err := manager.Create("codex", "role", "title")
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer func() {
+		_ = manager.Delete(session.ID)
+	}()
+
+	manager.tmuxHealth.mu.Lock()
+	manager.tmuxHealth.checkedAt = time.Time{}
+	manager.tmuxHealth.mu.Unlock()
+	probesMu.Lock()
+	before := probes
+	probesMu.Unlock()
+
+	probed := func() bool {
+		for _, info := range manager.List() {
+			if info.ID == session.ID {
+				return info.TmuxHealthy != nil
+			}
+		}
+		return false
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !probed() {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected tmux health to be probed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 0; i < 10; i++ {
+		manager.List()
+	}
+	probesMu.Lock()
+	defer probesMu.Unlock()
+	if probes-before != 1 {
+		t.Fatalf("expected one tmux probe within the cache interval, got %d", probes-before)
+	}
+}
+
 func TestManagerCloseAllClearsSessions(t *testing.T) {
 	manager := NewManager(ManagerOptions{
 		PtyFactory:  &fakeFactory{},
 		BufferLines: 5,
 	})
 	startedAt := time.Now()
-	sessionOne := newSession("one", newFakePtyWithErr(errors.New("close failed")), nil, nil, "one", "role", startedAt, 5, 0, OutputBackpressureBlock, 0, nil, nil, nil)
-	sessionTwo := newSession("two", newFakePty(), nil, nil, "two", "role", startedAt, 5, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	sessionOne := newSession("one", newFakePtyWithErr(errors.New("close failed")), nil, nil, "one", "role", startedAt, 5, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	sessionTwo := newSession("two", newFakePty(), nil, nil, "two", "role", startedAt, 5, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 
 	manager.mu.Lock()
 	manager.sessions["one"] = sessionOne
@@ -1291,3 +1492,57 @@ func TestManagerCloseAllClearsSessions(t *testing.T) {
 		t.Fatalf("expected agent sessions cleared")
 	}
 }
+
+func TestManagerCloneReplacesStoppedSource(t *testing.T) {
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		PtyFactory:       &fakeFactory{},
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"coder": {Name: "Coder", Shell: "/bin/sh"},
+		},
+	})
+	source, err := manager.CreateWithOptions(CreateOptions{AgentID: "coder", Role: "review", Labels: map[string]string{"team": "core"}})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	var running *AgentAlreadyRunningError
+	if _, err := manager.Clone(source.ID); !errors.As(err, &running) {
+		t.Fatalf("expected running source to conflict, got %v", err)
+	}
+	if _, err := manager.Clone("missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected missing source error, got %v", err)
+	}
+
+	source.markStopped("exited")
+	source.Encoding = "no-such-encoding"
+	if _, err := manager.Clone(source.ID); err == nil {
+		t.Fatalf("expected clone with an invalid encoding to fail")
+	}
+	if got, ok := manager.Get(source.ID); !ok || got != source || source.State() != sessionStateStopped {
+		t.Fatalf("expected failed clone to restore the stopped source")
+	}
+	if agentSessionID, ok := manager.agentSessions["Coder"]; !ok || agentSessionID != source.ID {
+		t.Fatalf("expected failed clone to restore the agent mapping, got %q", agentSessionID)
+	}
+
+	source.Encoding = ""
+	clone, err := manager.Clone(source.ID)
+	if err != nil {
+		t.Fatalf("clone stopped session: %v", err)
+	}
+	defer manager.Delete(clone.ID)
+	if clone == source || clone.State() != sessionStateRunning {
+		t.Fatalf("expected a fresh running session, got state %v", clone.State())
+	}
+	if clone.Role != "review" || clone.Labels()["team"] != "core" {
+		t.Fatalf("expected source options on clone, got role %q labels %v", clone.Role, clone.Labels())
+	}
+	if got, ok := manager.Get(source.ID); !ok || got != clone {
+		t.Fatalf("expected clone to replace the stopped source")
+	}
+	if source.State() != sessionStateClosed {
+		t.Fatalf("expected replaced source to be closed, got state %v", source.State())
+	}
+}
@@ -1,6 +1,7 @@
 package terminal
 
 import (
+	"bytes"
 	"strings"
 	"sync"
 
@@ -14,6 +15,9 @@ type OutputBuffer struct {
 	maxLines int
 	lines    *buffer.Ring[string]
 	carry    string
+	// total counts complete lines ever appended; it is the output cursor.
+	total   int64
+	updated chan struct{}
 }
 
 func NewOutputBuffer(maxLines int) *OutputBuffer {
@@ -51,6 +55,23 @@ func (b *OutputBuffer) Append(data []byte) {
 	for _, line := range parts {
 		b.appendLine(line)
 	}
+	if len(parts) > 0 && b.updated != nil {
+		close(b.updated)
+		b.updated = nil
+	}
+}
+
+// CursorAdvance reports how far Append moves the cursor for chunk. A chunk
+// ending in a newline also records the empty line after it.
+func CursorAdvance(chunk []byte) int64 {
+	if len(chunk) == 0 {
+		return 0
+	}
+	count := int64(bytes.Count(chunk, []byte{'\n'}))
+	if chunk[len(chunk)-1] == '\n' {
+		count++
+	}
+	return count
 }
 
 func (b *OutputBuffer) Lines() []string {
@@ -68,9 +89,44 @@ func (b *OutputBuffer) Lines() []string {
 	return lines
 }
 
+// Cursor returns the number of complete lines appended so far.
+func (b *OutputBuffer) Cursor() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
+
+// LinesSince returns the complete lines appended after cursor, the cursor for
+// the next call, and the current partial line. When cursor is older than the
+// retained window, lines start at the oldest retained line; a cursor past the
+// end (for example from an earlier session) starts over. The returned
+// channel is closed when more complete lines arrive.
+func (b *OutputBuffer) LinesSince(cursor int64) ([]string, int64, string, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.updated == nil {
+		b.updated = make(chan struct{})
+	}
+	if cursor < 0 || cursor > b.total {
+		cursor = 0
+	}
+	lines := []string{}
+	if cursor < b.total && b.lines != nil {
+		retained := b.lines.List()
+		skip := int64(len(retained)) - (b.total - cursor)
+		if skip < 0 {
+			skip = 0
+		}
+		lines = append(lines, retained[skip:]...)
+	}
+	return lines, b.total, b.carry, b.updated
+}
+
 func (b *OutputBuffer) appendLine(line string) {
 	if b.lines == nil {
 		b.lines = buffer.NewRing[string](b.maxLines)
 	}
 	b.lines.Add(line)
+	b.total++
 }
@@ -74,3 +74,68 @@ func TestOutputBufferConcurrentAccessDoesNotBlock(t *testing.T) {
 		t.Fatalf("timed out waiting for concurrent append")
 	}
 }
+
+func TestOutputBufferLinesSinceCursor(t *testing.T) {
+	buffer := NewOutputBuffer(3)
+
+	lines, cursor, partial, _ := buffer.LinesSince(0)
+	if len(lines) != 0 || cursor != 0 || partial != "" {
+		t.Fatalf("expected empty buffer, got %v %d %q", lines, cursor, partial)
+	}
+
+	buffer.Append([]byte("one\ntwo\nthr"))
+	lines, cursor, partial, _ = buffer.LinesSince(0)
+	if strings.Join(lines, ",") != "one,two" || cursor != 2 || partial != "thr" {
+		t.Fatalf("unexpected first read: %v %d %q", lines, cursor, partial)
+	}
+
+	buffer.Append([]byte("ee\nfour\nfive"))
+	lines, cursor, partial, _ = buffer.LinesSince(2)
+	if strings.Join(lines, ",") != "three,four" || cursor != 4 || partial != "five" {
+		t.Fatalf("unexpected read after cursor: %v %d %q", lines, cursor, partial)
+	}
+
+	lines, _, _, _ = buffer.LinesSince(0)
+	if strings.Join(lines, ",") != "two,three,four" {
+		t.Fatalf("expected stale cursor to start at retained window, got %v", lines)
+	}
+	lines, cursor, _, _ = buffer.LinesSince(99)
+	if len(lines) != 3 || cursor != 4 {
+		t.Fatalf("expected cursor past end to start over, got %v %d", lines, cursor)
+	}
+	lines, _, _, _ = buffer.LinesSince(4)
+	if len(lines) != 0 {
+		t.Fatalf("expected no lines at current cursor, got %v", lines)
+	}
+}
+
+func TestOutputBufferLinesSinceSignalsCompleteLines(t *testing.T) {
+	buffer := NewOutputBuffer(10)
+	_, _, _, updated := buffer.LinesSince(0)
+
+	buffer.Append([]byte("partial"))
+	select {
+	case <-updated:
+		t.Fatalf("expected partial output not to signal")
+	default:
+	}
+
+	buffer.Append([]byte(" line\n"))
+	select {
+	case <-updated:
+	case <-time.After(time.Second):
+		t.Fatalf("expected complete line to signal")
+	}
+}
+
+func TestCursorAdvanceMatchesAppend(t *testing.T) {
+	buffer := NewOutputBuffer(100)
+	chunks := []string{"one\n", "par", "tial\ntwo", "\n", "\n\n", "x"}
+	for _, chunk := range chunks {
+		before := buffer.Cursor()
+		buffer.Append([]byte(chunk))
+		if got, want := CursorAdvance([]byte(chunk)), buffer.Cursor()-before; got != want {
+			t.Fatalf("chunk %q: expected advance %d, got %d", chunk, want, got)
+		}
+	}
+}
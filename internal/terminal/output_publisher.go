@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"gestalt/internal/event"
 )
@@ -34,6 +35,10 @@ type OutputPublisher struct {
 	logger        *SessionLogger
 	buffer        *OutputBuffer
 	bus           *event.Bus[[]byte]
+	redact        *redactStream
+	transform     *transformStream
+	rawBuffer     *OutputBuffer
+	timeline      *OutputTimeline
 }
 
 type OutputPublisherOptions struct {
@@ -43,6 +48,13 @@ type OutputPublisherOptions struct {
 	Policy      OutputBackpressurePolicy
 	MaxQueue    int
 	SampleEvery uint64
+	Redactor    *OutputRedactor
+	// Transform rewrites output after redaction. When set, RawBuffer receives
+	// the redacted output as it was before the transform.
+	Transform OutputTransform
+	RawBuffer *OutputBuffer
+	// Timeline records each dispatched chunk with the time it went out.
+	Timeline *OutputTimeline
 }
 
 func NewOutputPublisher(options OutputPublisherOptions) *OutputPublisher {
@@ -61,6 +73,12 @@ func NewOutputPublisher(options OutputPublisherOptions) *OutputPublisher {
 		logger:      options.Logger,
 		buffer:      options.Buffer,
 		bus:         options.Bus,
+		redact:      newRedactStream(options.Redactor),
+		transform:   newTransformStream(options.Transform),
+		timeline:    options.Timeline,
+	}
+	if publisher.transform != nil {
+		publisher.rawBuffer = options.RawBuffer
 	}
 	go publisher.run()
 	return publisher
@@ -150,15 +168,11 @@ func (p *OutputPublisher) publishSample(chunk []byte) {
 func (p *OutputPublisher) run() {
 	defer close(p.done)
 
-	for chunk := range p.input {
-		if p.logger != nil {
-			p.logger.Write(chunk)
-		}
-		if p.buffer != nil {
-			p.buffer.Append(chunk)
-		}
-		if p.bus != nil {
-			p.bus.Publish(chunk)
+	if p.redact != nil || p.transform != nil {
+		p.runStaged()
+	} else {
+		for chunk := range p.input {
+			p.dispatch(chunk)
 		}
 	}
 
@@ -169,3 +183,78 @@ func (p *OutputPublisher) run() {
 		_ = p.logger.Close()
 	}
 }
+
+// runStaged redacts and transforms output before fan-out. Output held back
+// at a chunk boundary is flushed once no new chunk arrives within
+// redactFlushDelay.
+func (p *OutputPublisher) runStaged() {
+	timer := time.NewTimer(redactFlushDelay)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		var flush <-chan time.Time
+		if p.redact.Pending() || p.transform.Pending() {
+			timer.Reset(redactFlushDelay)
+			flush = timer.C
+		}
+		select {
+		case chunk, ok := <-p.input:
+			if flush != nil {
+				timer.Stop()
+			}
+			if !ok {
+				p.flushStages()
+				return
+			}
+			if p.redact != nil {
+				chunk = p.redact.Push(chunk)
+			}
+			p.dispatchTransformed(chunk)
+		case <-flush:
+			p.flushStages()
+		}
+	}
+}
+
+func (p *OutputPublisher) flushStages() {
+	if p.redact != nil {
+		p.dispatchTransformed(p.redact.Flush())
+	}
+	if p.transform != nil {
+		p.dispatch(p.transform.Flush())
+	}
+}
+
+// dispatchTransformed records redacted output in the raw buffer and fans out
+// its transformed form.
+func (p *OutputPublisher) dispatchTransformed(chunk []byte) {
+	if p.transform == nil {
+		p.dispatch(chunk)
+		return
+	}
+	if len(chunk) == 0 {
+		return
+	}
+	if p.rawBuffer != nil {
+		p.rawBuffer.Append(chunk)
+	}
+	p.dispatch(p.transform.Push(chunk))
+}
+
+func (p *OutputPublisher) dispatch(chunk []byte) {
+	if len(chunk) == 0 {
+		return
+	}
+	if p.logger != nil {
+		p.logger.Write(chunk)
+	}
+	if p.buffer != nil {
+		p.buffer.Append(chunk)
+	}
+	if p.timeline != nil {
+		p.timeline.Append(time.Now(), chunk)
+	}
+	if p.bus != nil {
+		p.bus.Publish(chunk)
+	}
+}
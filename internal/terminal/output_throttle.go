@@ -0,0 +1,58 @@
+package terminal
+
+import (
+	"context"
+	"time"
+)
+
+// outputThrottle paces a session's output to a byte rate. The read loop waits
+// after each chunk, so excess output stays in the pty and is coalesced into
+// larger reads instead of flooding the buffer and subscribers.
+type outputThrottle struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// newOutputThrottle returns nil when bytesPerSecond is not positive, which
+// leaves output unlimited.
+func newOutputThrottle(bytesPerSecond int64) *outputThrottle {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	rate := float64(bytesPerSecond)
+	return &outputThrottle{rate: rate, tokens: rate, now: time.Now}
+}
+
+// reserve spends n bytes of budget and returns how long to wait before
+// reading more. Up to one second of output may burst through unpaced.
+func (t *outputThrottle) reserve(n int) time.Duration {
+	now := t.now()
+	if !t.last.IsZero() {
+		t.tokens = min(t.rate, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+	}
+	t.last = now
+	t.tokens -= float64(n)
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.rate * float64(time.Second))
+}
+
+// wait blocks for the delay reserve reports, returning early if ctx is done.
+func (t *outputThrottle) wait(ctx context.Context, n int) {
+	if t == nil || n <= 0 {
+		return
+	}
+	delay := t.reserve(n)
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
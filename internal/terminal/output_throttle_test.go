@@ -0,0 +1,51 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOutputThrottleDisabledByDefault(t *testing.T) {
+	if throttle := newOutputThrottle(0); throttle != nil {
+		t.Fatalf("expected nil throttle for zero rate")
+	}
+	// A nil throttle never waits.
+	var throttle *outputThrottle
+	throttle.wait(context.Background(), 1<<20)
+}
+
+func TestOutputThrottlePacesAfterBurst(t *testing.T) {
+	now := time.Unix(0, 0)
+	throttle := newOutputThrottle(1000)
+	throttle.now = func() time.Time { return now }
+
+	if delay := throttle.reserve(1000); delay != 0 {
+		t.Fatalf("expected first second to burst, got %v", delay)
+	}
+	if delay := throttle.reserve(500); delay != 500*time.Millisecond {
+		t.Fatalf("expected 500ms delay, got %v", delay)
+	}
+
+	now = now.Add(time.Second)
+	if delay := throttle.reserve(250); delay != 0 {
+		t.Fatalf("expected refilled budget, got %v", delay)
+	}
+
+	now = now.Add(time.Hour)
+	if delay := throttle.reserve(1500); delay != 500*time.Millisecond {
+		t.Fatalf("expected budget capped at one second, got %v", delay)
+	}
+}
+
+func TestOutputThrottleWaitStopsOnCancel(t *testing.T) {
+	throttle := newOutputThrottle(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	throttle.wait(ctx, 100)
+	if time.Since(start) > time.Second {
+		t.Fatalf("expected wait to return when context is done")
+	}
+}
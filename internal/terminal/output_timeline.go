@@ -0,0 +1,71 @@
+package terminal
+
+import (
+	"sync"
+	"time"
+)
+
+// timelineBytesPerLine sizes the in-memory timeline from a session's
+// scrollback, so it keeps roughly as much output as the output buffer.
+const timelineBytesPerLine = 256
+
+// TimedChunk is a piece of session output and when it was published.
+type TimedChunk struct {
+	At   time.Time
+	Data []byte
+}
+
+// OutputTimeline keeps published output chunks with their timestamps. Once
+// over its byte budget it drops the oldest chunks.
+type OutputTimeline struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	chunks   []TimedChunk
+	head     int
+	dropped  bool
+}
+
+func NewOutputTimeline(maxBytes int) *OutputTimeline {
+	if maxBytes <= 0 {
+		maxBytes = DefaultBufferLines * timelineBytesPerLine
+	}
+	return &OutputTimeline{maxBytes: maxBytes}
+}
+
+func (t *OutputTimeline) Append(at time.Time, data []byte) {
+	if t == nil || len(data) == 0 {
+		return
+	}
+	chunk := TimedChunk{At: at, Data: append([]byte(nil), data...)}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.chunks = append(t.chunks, chunk)
+	t.size += len(chunk.Data)
+	for t.size > t.maxBytes && t.head < len(t.chunks)-1 {
+		t.size -= len(t.chunks[t.head].Data)
+		t.chunks[t.head] = TimedChunk{}
+		t.head++
+		t.dropped = true
+	}
+	// Compact once the dropped prefix outgrows what is kept, so each chunk
+	// is copied a bounded number of times.
+	if t.head > 0 && t.head >= len(t.chunks)-t.head {
+		kept := copy(t.chunks, t.chunks[t.head:])
+		clear(t.chunks[kept:])
+		t.chunks = t.chunks[:kept]
+		t.head = 0
+	}
+}
+
+// Chunks returns a copy of the recorded chunks, oldest first, and whether
+// earlier output was dropped to stay within the byte budget.
+func (t *OutputTimeline) Chunks() ([]TimedChunk, bool) {
+	if t == nil {
+		return nil, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TimedChunk(nil), t.chunks[t.head:]...), t.dropped
+}
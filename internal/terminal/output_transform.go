@@ -0,0 +1,100 @@
+package terminal
+
+const (
+	escByte = 0x1b
+	belByte = 0x07
+	// maxEscapeHoldback caps how long an unterminated escape sequence at the
+	// end of a chunk is held back waiting for the rest of it.
+	maxEscapeHoldback = 256
+)
+
+// OutputTransform rewrites session output before it is buffered, logged, or
+// streamed, e.g. to remap ANSI colors or strip escape sequences that clash
+// in the dashboard. It sees escape sequences whole: a sequence split across
+// reads is held back until the rest arrives or the flush delay passes.
+type OutputTransform func([]byte) []byte
+
+// transformStream applies an OutputTransform across chunk boundaries.
+type transformStream struct {
+	transform OutputTransform
+	pending   []byte
+}
+
+func newTransformStream(transform OutputTransform) *transformStream {
+	if transform == nil {
+		return nil
+	}
+	return &transformStream{transform: transform}
+}
+
+func (s *transformStream) Push(chunk []byte) []byte {
+	data := make([]byte, 0, len(s.pending)+len(chunk))
+	data = append(data, s.pending...)
+	data = append(data, chunk...)
+
+	cut := incompleteEscapeStart(data)
+	s.pending = append(s.pending[:0], data[cut:]...)
+	if cut == 0 {
+		return nil
+	}
+	return s.transform(data[:cut])
+}
+
+func (s *transformStream) Flush() []byte {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	data := s.transform(append([]byte(nil), s.pending...))
+	s.pending = s.pending[:0]
+	return data
+}
+
+func (s *transformStream) Pending() bool {
+	return s != nil && len(s.pending) > 0
+}
+
+// incompleteEscapeStart returns where an unterminated escape sequence at the
+// end of data begins, or len(data) when data ends outside of one.
+func incompleteEscapeStart(data []byte) int {
+	limit := max(len(data)-maxEscapeHoldback, 0)
+	for i := len(data) - 1; i >= limit; i-- {
+		if data[i] != escByte {
+			continue
+		}
+		if escapeComplete(data[i:]) {
+			return len(data)
+		}
+		return i
+	}
+	return len(data)
+}
+
+// escapeComplete reports whether seq, which starts with ESC and contains no
+// other ESC, holds a whole sequence: CSI ends with a final byte in 0x40-0x7e,
+// string sequences (OSC, DCS, APC, PM, SOS) end with BEL (a trailing ESC \ is
+// a separate, complete sequence), and charset designations take one more
+// byte.
+func escapeComplete(seq []byte) bool {
+	if len(seq) < 2 {
+		return false
+	}
+	switch seq[1] {
+	case '[':
+		for _, b := range seq[2:] {
+			if b >= 0x40 && b <= 0x7e {
+				return true
+			}
+		}
+		return false
+	case ']', 'P', '_', '^', 'X':
+		for _, b := range seq[2:] {
+			if b == belByte {
+				return true
+			}
+		}
+		return false
+	case '(', ')', '*', '+', '-', '.', '/', '#', '%', ' ':
+		return len(seq) >= 3
+	}
+	return true
+}
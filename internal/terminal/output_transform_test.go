@@ -0,0 +1,87 @@
+package terminal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIncompleteEscapeStart(t *testing.T) {
+	cases := []struct {
+		data string
+		want int
+	}{
+		{data: "plain text", want: 10},
+		{data: "red \x1b[31mtext", want: 13},
+		{data: "red \x1b[3", want: 4},
+		{data: "red \x1b", want: 4},
+		{data: "title \x1b]0;name", want: 6},
+		{data: "title \x1b]0;name\x07", want: 15},
+		{data: "title \x1b]0;name\x1b\\", want: 16},
+		{data: "charset \x1b(", want: 8},
+		{data: "charset \x1b(B", want: 11},
+	}
+	for _, tc := range cases {
+		if got := incompleteEscapeStart([]byte(tc.data)); got != tc.want {
+			t.Fatalf("incompleteEscapeStart(%q) = %d, want %d", tc.data, got, tc.want)
+		}
+	}
+}
+
+func TestTransformStreamJoinsSplitEscapes(t *testing.T) {
+	remap := func(data []byte) []byte {
+		return bytes.ReplaceAll(data, []byte("\x1b[31m"), []byte("\x1b[35m"))
+	}
+	stream := newTransformStream(remap)
+
+	var out []byte
+	out = append(out, stream.Push([]byte("err: \x1b[3"))...)
+	out = append(out, stream.Push([]byte("1mboom\x1b[0m"))...)
+	out = append(out, stream.Flush()...)
+	if got := string(out); got != "err: \x1b[35mboom\x1b[0m" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
+
+func TestOutputPublisherKeepsRawOutputBeforeTransform(t *testing.T) {
+	buffer := NewOutputBuffer(10)
+	raw := NewOutputBuffer(10)
+	publisher := NewOutputPublisher(OutputPublisherOptions{
+		Buffer:    buffer,
+		RawBuffer: raw,
+		Transform: func(data []byte) []byte {
+			return bytes.ReplaceAll(data, []byte("\x1b[31m"), nil)
+		},
+	})
+
+	publisher.PublishWithContext(nil, []byte("a \x1b["))
+	publisher.PublishWithContext(nil, []byte("31mb\n"))
+	publisher.Close()
+
+	if got := strings.Join(buffer.Lines(), "\n"); got != "a b\n" {
+		t.Fatalf("unexpected transformed output %q", got)
+	}
+	if got := strings.Join(raw.Lines(), "\n"); got != "a \x1b[31mb\n" {
+		t.Fatalf("unexpected raw output %q", got)
+	}
+}
+
+func TestOutputPublisherFlushesHeldEscape(t *testing.T) {
+	buffer := NewOutputBuffer(10)
+	publisher := NewOutputPublisher(OutputPublisherOptions{
+		Buffer:    buffer,
+		Transform: func(data []byte) []byte { return data },
+	})
+	defer publisher.Close()
+
+	publisher.PublishWithContext(nil, []byte("prompt \x1b"))
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if lines := buffer.Lines(); len(lines) > 0 && lines[len(lines)-1] == "prompt \x1b" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected held escape to be flushed, got %q", buffer.Lines())
+}
@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"gestalt/internal/event"
+)
+
+var (
+	ErrPauseUnsupported   = errors.New("pausing a session is not supported on this platform")
+	ErrSessionNotPausable = errors.New("session has no local process to pause")
+	ErrSessionPaused      = errors.New("session is paused")
+	ErrSessionNotRunning  = errors.New("session is not running")
+	ErrSessionNotPaused   = errors.New("session is not paused")
+)
+
+// PauseSession stops the process group of a running pty-backed session with
+// SIGSTOP and marks it paused. The process keeps its memory and open files,
+// but input is rejected until ResumeSession. Sessions without a local
+// process, such as external agents and attached sessions, cannot be paused.
+func (m *Manager) PauseSession(id string) (*Session, error) {
+	session, ok := m.Get(id)
+	if !ok || session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if session.cmd == nil || session.cmd.Process == nil {
+		return nil, ErrSessionNotPausable
+	}
+	if !atomic.CompareAndSwapUint32(&session.state, uint32(sessionStateRunning), uint32(sessionStatePaused)) {
+		return nil, ErrSessionNotRunning
+	}
+	if err := signalPause(session.pid, session.pgid, true); err != nil {
+		atomic.CompareAndSwapUint32(&session.state, uint32(sessionStatePaused), uint32(sessionStateRunning))
+		return nil, err
+	}
+	m.publishPauseEvent(session, "terminal_paused")
+	return session, nil
+}
+
+// ResumeSession continues a session paused by PauseSession with SIGCONT.
+func (m *Manager) ResumeSession(id string) (*Session, error) {
+	session, ok := m.Get(id)
+	if !ok || session == nil {
+		return nil, ErrSessionNotFound
+	}
+	if !atomic.CompareAndSwapUint32(&session.state, uint32(sessionStatePaused), uint32(sessionStateRunning)) {
+		return nil, ErrSessionNotPaused
+	}
+	if err := signalPause(session.pid, session.pgid, false); err != nil {
+		atomic.CompareAndSwapUint32(&session.state, uint32(sessionStateRunning), uint32(sessionStatePaused))
+		return nil, err
+	}
+	m.publishPauseEvent(session, "terminal_resumed")
+	return session, nil
+}
+
+func (m *Manager) publishPauseEvent(session *Session, eventType string) {
+	m.logger.Info("session "+strings.TrimPrefix(eventType, "terminal_"), map[string]string{
+		"gestalt.category": "terminal",
+		"gestalt.source":   "backend",
+		"session.id":       session.ID,
+		"pid":              strconv.Itoa(session.pid),
+	})
+	if m.terminalBus != nil {
+		terminalEvent := event.NewTerminalEvent(session.ID, eventType)
+		terminalEvent.Data = map[string]any{
+			"pid": session.pid,
+		}
+		m.terminalBus.Publish(terminalEvent)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package terminal
+
+import "syscall"
+
+// signalPause sends SIGSTOP, or SIGCONT to resume, to the session's process
+// group.
+func signalPause(pid, pgid int, pause bool) error {
+	if pid <= 0 && pgid <= 0 {
+		return ErrSessionNotPausable
+	}
+	if pause {
+		return signalProcessGroup(pid, pgid, syscall.SIGSTOP)
+	}
+	return signalProcessGroup(pid, pgid, syscall.SIGCONT)
+}
@@ -0,0 +1,101 @@
+//go:build !windows
+
+package terminal
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestPauseAndResumeSession(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+
+	marker := filepath.Join(t.TempDir(), "ticks")
+	cmd := exec.Command("sh", "-c", "while true; do echo x >> \"$0\"; sleep 0.01; done", marker)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	session := newSession("1", newScriptedPty(), nil, cmd, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	session.pid = cmd.Process.Pid
+	session.pgid = cmd.Process.Pid
+	defer session.Close()
+	manager.RegisterSession(session)
+
+	if _, err := manager.PauseSession("1"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if status := session.Info().Status; status != "paused" {
+		t.Fatalf("expected paused status, got %q", status)
+	}
+	waitForProgress(t, marker, false)
+	if err := session.Write([]byte("ls\n")); !errors.Is(err, ErrSessionPaused) {
+		t.Fatalf("expected write to paused session to fail, got %v", err)
+	}
+	if _, err := manager.PauseSession("1"); !errors.Is(err, ErrSessionNotRunning) {
+		t.Fatalf("expected second pause to fail, got %v", err)
+	}
+
+	if _, err := manager.ResumeSession("1"); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if status := session.Info().Status; status != "running" {
+		t.Fatalf("expected running status, got %q", status)
+	}
+	waitForProgress(t, marker, true)
+	if _, err := manager.ResumeSession("1"); !errors.Is(err, ErrSessionNotPaused) {
+		t.Fatalf("expected resume of running session to fail, got %v", err)
+	}
+
+	// Stop-all still reaches a paused session.
+	if _, err := manager.PauseSession("1"); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if stopped := manager.StopAll(100 * time.Millisecond); len(stopped) != 1 {
+		t.Fatalf("expected paused session to be stopped, got %v", stopped)
+	}
+	if cmd.ProcessState == nil {
+		t.Fatalf("expected process to be reaped")
+	}
+}
+
+func TestPauseSessionWithoutProcess(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	external := newSession("1", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer external.Close()
+	manager.RegisterSession(external)
+
+	if _, err := manager.PauseSession("1"); !errors.Is(err, ErrSessionNotPausable) {
+		t.Fatalf("expected not pausable, got %v", err)
+	}
+	if _, err := manager.PauseSession("missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+// waitForProgress waits until the file the test process appends to is
+// growing, or has stopped growing.
+func waitForProgress(t *testing.T, path string, growing bool) {
+	t.Helper()
+	size := func() int64 {
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0
+		}
+		return info.Size()
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		before := size()
+		time.Sleep(150 * time.Millisecond)
+		if (size() > before) == growing {
+			return
+		}
+	}
+	t.Fatalf("expected process progress=%v", growing)
+}
@@ -0,0 +1,7 @@
+//go:build windows
+
+package terminal
+
+func signalPause(int, int, bool) error {
+	return ErrPauseUnsupported
+}
@@ -1,9 +1,13 @@
 package terminal
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,8 +19,10 @@ const (
 
 type SessionLogger struct {
 	logger       *asyncFileLogger[[]byte]
+	timing       *asyncFileLogger[sessionTimingEntry]
 	maxBytes     int64
 	bytesWritten int64
+	quota        *sessionLogQuota
 }
 
 func NewSessionLogger(dir, terminalID string, createdAt time.Time, maxBytes int64) (*SessionLogger, error) {
@@ -34,9 +40,18 @@ func NewSessionLogger(dir, terminalID string, createdAt time.Time, maxBytes int6
 		return nil, fmt.Errorf("open session log file: %w", err)
 	}
 
+	timingPath := sessionTimingPath(path)
+	timingFile, err := os.OpenFile(timingPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("open session timing file: %w", err)
+	}
+
 	logger := newAsyncFileLogger(path, file, sessionLogFlushInterval, sessionLogFlushThreshold, sessionLogChannelSize, asyncFileLoggerBlock, encodeSessionChunk)
+	timing := newAsyncFileLogger(timingPath, timingFile, sessionLogFlushInterval, sessionLogFlushThreshold, sessionLogChannelSize, asyncFileLoggerBlock, encodeSessionTiming)
 	return &SessionLogger{
 		logger:   logger,
+		timing:   timing,
 		maxBytes: maxBytes,
 	}, nil
 }
@@ -59,6 +74,8 @@ func (l *SessionLogger) Write(chunk []byte) {
 	}
 	l.bytesWritten += int64(len(chunk))
 	l.logger.Write(chunk)
+	l.timing.Write(sessionTimingEntry{at: time.Now(), size: len(chunk)})
+	l.quota.noteWrite(len(chunk))
 }
 
 func (l *SessionLogger) Path() string {
@@ -100,7 +117,11 @@ func (l *SessionLogger) Close() error {
 	if l == nil || l.logger == nil {
 		return nil
 	}
-	return l.logger.Close()
+	timingErr := l.timing.Close()
+	if err := l.logger.Close(); err != nil {
+		return err
+	}
+	return timingErr
 }
 
 func encodeSessionChunk(chunk []byte) ([]byte, error) {
@@ -109,3 +130,69 @@ func encodeSessionChunk(chunk []byte) ([]byte, error) {
 	}
 	return chunk, nil
 }
+
+// sessionTimingEntry records when a chunk of a session log was written and
+// how many bytes it took, one "<unix-nanos> <bytes>" line per chunk in the
+// .timing file next to the log.
+type sessionTimingEntry struct {
+	at   time.Time
+	size int
+}
+
+func encodeSessionTiming(entry sessionTimingEntry) ([]byte, error) {
+	if entry.size <= 0 {
+		return nil, nil
+	}
+	return []byte(strconv.FormatInt(entry.at.UnixNano(), 10) + " " + strconv.Itoa(entry.size) + "\n"), nil
+}
+
+// sessionTimingPath returns the timing file kept next to a session log.
+func sessionTimingPath(logPath string) string {
+	return strings.TrimSuffix(logPath, ".txt") + ".timing"
+}
+
+// ReadSessionLogTimeline rebuilds the timed output chunks of a session log
+// from its timing file. Output not yet flushed to either file is left out,
+// as is log output with no timing entry.
+func ReadSessionLogTimeline(logPath string) ([]TimedChunk, error) {
+	timingFile, err := os.Open(sessionTimingPath(logPath))
+	if err != nil {
+		return nil, fmt.Errorf("open session timing file: %w", err)
+	}
+	defer timingFile.Close()
+	logFile, err := os.Open(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("open session log file: %w", err)
+	}
+	defer logFile.Close()
+
+	log := bufio.NewReader(logFile)
+	var chunks []TimedChunk
+	scanner := bufio.NewScanner(timingFile)
+	for scanner.Scan() {
+		nanosText, sizeText, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok {
+			continue
+		}
+		nanos, err := strconv.ParseInt(nanosText, 10, 64)
+		if err != nil {
+			continue
+		}
+		size, err := strconv.Atoi(sizeText)
+		if err != nil || size <= 0 {
+			continue
+		}
+		data := make([]byte, size)
+		n, err := io.ReadFull(log, data)
+		if n > 0 {
+			chunks = append(chunks, TimedChunk{At: time.Unix(0, nanos), Data: data[:n]})
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read session timing file: %w", err)
+	}
+	return chunks, nil
+}
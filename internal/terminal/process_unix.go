@@ -3,6 +3,7 @@
 package terminal
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -22,6 +23,26 @@ func processGroupID(pid int) int {
 	return pgid
 }
 
+// processLiveness reports whether pid is still running. A defunct (zombie)
+// process counts as not alive.
+func processLiveness(pid int) (bool, string) {
+	if pid <= 0 {
+		return false, "invalid pid"
+	}
+	if err := syscall.Kill(pid, 0); err != nil && errors.Is(err, syscall.ESRCH) {
+		return false, "process exited"
+	}
+	if stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		// The state field follows the parenthesised command name.
+		if end := bytes.LastIndexByte(stat, ')'); end >= 0 && end+2 < len(stat) {
+			if state := stat[end+2]; state == 'Z' || state == 'X' {
+				return false, "process defunct"
+			}
+		}
+	}
+	return true, ""
+}
+
 func terminateProcessTree(cmd *exec.Cmd, pid, pgid int, timeout time.Duration) error {
 	if cmd == nil || cmd.Process == nil {
 		return nil
@@ -37,6 +58,8 @@ func terminateProcessTree(cmd *exec.Cmd, pid, pgid int, timeout time.Duration) e
 	if err := signalProcessGroup(pid, pgid, syscall.SIGTERM); err != nil && !errors.Is(err, os.ErrProcessDone) {
 		errs = append(errs, fmt.Errorf("signal group: %w", err))
 	}
+	// A paused (stopped) group only acts on SIGTERM once continued.
+	_ = signalProcessGroup(pid, pgid, syscall.SIGCONT)
 
 	exited, waitErr := waitForProcessExit(cmd, timeout)
 	if waitErr != nil && !errors.Is(waitErr, os.ErrProcessDone) && !isExpectedProcessExit(waitErr) {
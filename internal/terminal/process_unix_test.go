@@ -60,3 +60,37 @@ func TestTerminateProcessTreeKillsGroup(t *testing.T) {
 		t.Fatalf("expected parent process to be reaped")
 	}
 }
+
+func TestProcessLivenessDetectsDefunctAndExited(t *testing.T) {
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	pid := cmd.Process.Pid
+	if alive, reason := processLiveness(pid); !alive {
+		t.Fatalf("expected running process to be alive, got %q", reason)
+	}
+
+	if err := cmd.Process.Kill(); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		alive, reason := processLiveness(pid)
+		if !alive {
+			if reason != "process defunct" && reason != "process exited" {
+				t.Fatalf("unexpected reason %q", reason)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected killed process to be reported dead")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_ = cmd.Wait()
+	if alive, reason := processLiveness(pid); alive || reason != "process exited" {
+		t.Fatalf("expected reaped process to be exited, got %t %q", alive, reason)
+	}
+}
@@ -14,6 +14,12 @@ func processGroupID(pid int) int {
 	return 0
 }
 
+// processLiveness reports whether pid is still running. Windows sessions rely
+// on the pty read loop instead, so the check always reports alive.
+func processLiveness(pid int) (bool, string) {
+	return true, ""
+}
+
 func terminateProcessTree(cmd *exec.Cmd, pid, pgid int, timeout time.Duration) error {
 	if cmd == nil || cmd.Process == nil {
 		return nil
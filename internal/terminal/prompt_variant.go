@@ -0,0 +1,25 @@
+package terminal
+
+import "gestalt/internal/prompt"
+
+// resolvePromptVariant returns the env-specific variant of promptName when
+// a prompt env is set and the variant file exists, and promptName otherwise.
+// The choice is logged whenever a prompt env is set.
+func (m *Manager) resolvePromptVariant(promptName string) string {
+	variant := prompt.EnvVariantName(promptName, m.promptEnv)
+	if variant == "" {
+		return promptName
+	}
+	chosen := promptName
+	if _, err := prompt.ResolvePromptPath(m.promptFS, m.promptDir, variant); err == nil {
+		chosen = variant
+	}
+	m.logger.Info("agent prompt variant selected", map[string]string{
+		"gestalt.category": "terminal",
+		"gestalt.source":   "backend",
+		"prompt":           promptName,
+		"prompt_env":       m.promptEnv,
+		"variant":          chosen,
+	})
+	return chosen
+}
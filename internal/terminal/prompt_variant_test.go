@@ -0,0 +1,42 @@
+package terminal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePromptVariantPrefersEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"codex.txt", "codex.staging.txt", "review.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("write prompt: %v", err)
+		}
+	}
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", PromptDir: dir, PromptEnv: "staging"})
+
+	if got := manager.resolvePromptVariant("codex"); got != "codex.staging" {
+		t.Fatalf("expected codex.staging, got %q", got)
+	}
+	if got := manager.resolvePromptVariant("codex.txt"); got != "codex.staging.txt" {
+		t.Fatalf("expected codex.staging.txt, got %q", got)
+	}
+	if got := manager.resolvePromptVariant("review"); got != "review" {
+		t.Fatalf("expected fallback to review, got %q", got)
+	}
+	data, _, err := manager.readPromptFile("codex", "")
+	if err != nil {
+		t.Fatalf("read prompt: %v", err)
+	}
+	if string(data) != "codex.staging.txt" {
+		t.Fatalf("expected staging prompt content, got %q", data)
+	}
+}
+
+func TestResolvePromptVariantWithoutEnv(t *testing.T) {
+	t.Setenv("GESTALT_ENV", "")
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", PromptDir: t.TempDir()})
+	if got := manager.resolvePromptVariant("codex"); got != "codex" {
+		t.Fatalf("expected codex, got %q", got)
+	}
+}
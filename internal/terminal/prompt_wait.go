@@ -0,0 +1,132 @@
+package terminal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidPromptPattern reports a prompt pattern that does not compile.
+var ErrInvalidPromptPattern = errors.New("invalid prompt pattern")
+
+// osc133Prefix starts a semantic prompt marker: ESC ] 133 ; followed by A
+// (prompt start), B (input start), C (command output start), or D (command
+// done). Shells with prompt integration emit them around every prompt.
+var osc133Prefix = []byte("\x1b]133;")
+
+func compilePromptPattern(pattern string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPromptPattern, err)
+	}
+	return compiled, nil
+}
+
+// promptTracker follows whether a session sits at a prompt waiting for
+// input. OSC 133 markers decide once the session has emitted one; until then
+// the prompt pattern, if any, is matched against the tail of the output with
+// escape sequences removed. observe is called from the read loop only.
+type promptTracker struct {
+	pattern *regexp.Regexp
+	// raw is the recent output before escape sequences are removed; it is
+	// stripped as a whole so a sequence split across reads is still removed.
+	raw string
+	// markerTail holds the end of the last chunk in case a marker was split
+	// across reads.
+	markerTail []byte
+	sawMarker  bool
+
+	mu      sync.RWMutex
+	waiting bool
+	since   time.Time
+}
+
+func newPromptTracker(pattern string) *promptTracker {
+	compiled, _ := compilePromptPattern(pattern)
+	return &promptTracker{pattern: compiled}
+}
+
+func (t *promptTracker) observe(chunk []byte) {
+	if t == nil || len(chunk) == 0 {
+		return
+	}
+	data := append(t.markerTail, chunk...)
+	if marker, ok := lastOSC133Marker(data); ok {
+		t.sawMarker = true
+		switch marker {
+		case 'A', 'B':
+			t.setWaiting(true)
+		case 'C', 'D':
+			t.setWaiting(false)
+		}
+	}
+	keep := len(osc133Prefix)
+	if len(data) < keep {
+		keep = len(data)
+	}
+	t.markerTail = append([]byte(nil), data[len(data)-keep:]...)
+
+	if t.sawMarker || t.pattern == nil {
+		return
+	}
+	// Keep extra raw output so the window still fills after stripping.
+	t.raw += string(chunk)
+	if len(t.raw) > 2*readyMatchWindow {
+		t.raw = t.raw[len(t.raw)-2*readyMatchWindow:]
+	}
+	window := StripANSI(t.raw)
+	if len(window) > readyMatchWindow {
+		window = window[len(window)-readyMatchWindow:]
+	}
+	t.setWaiting(t.pattern.MatchString(window))
+}
+
+func (t *promptTracker) setWaiting(waiting bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.waiting == waiting {
+		return
+	}
+	t.waiting = waiting
+	if waiting {
+		t.since = time.Now().UTC()
+	} else {
+		t.since = time.Time{}
+	}
+}
+
+// state reports whether the session is waiting for input, and since when.
+func (t *promptTracker) state() (bool, time.Time) {
+	if t == nil {
+		return false, time.Time{}
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.waiting, t.since
+}
+
+// lastOSC133Marker returns the letter of the last complete OSC 133 marker in
+// data.
+func lastOSC133Marker(data []byte) (byte, bool) {
+	for end := len(data); end > 0; {
+		index := bytes.LastIndex(data[:end], osc133Prefix)
+		if index < 0 {
+			return 0, false
+		}
+		if next := index + len(osc133Prefix); next < len(data) {
+			switch data[next] {
+			case 'A', 'B', 'C', 'D':
+				return data[next], true
+			}
+		}
+		end = index
+	}
+	return 0, false
+}
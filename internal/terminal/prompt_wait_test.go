@@ -0,0 +1,84 @@
+package terminal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPromptTrackerFollowsOSC133Markers(t *testing.T) {
+	tracker := newPromptTracker("")
+	tracker.observe([]byte("\x1b]133;A\x07user@host $ \x1b]1"))
+	if waiting, since := tracker.state(); !waiting || since.IsZero() {
+		t.Fatalf("expected waiting after prompt start, got %v %v", waiting, since)
+	}
+	// The command-start marker is split across reads.
+	tracker.observe([]byte("33;C\x07"))
+	if waiting, since := tracker.state(); waiting || !since.IsZero() {
+		t.Fatalf("expected not waiting once the command runs, got %v %v", waiting, since)
+	}
+	tracker.observe([]byte("output\r\n\x1b]133;D;0\x07\x1b]133;A\x07$ "))
+	if waiting, _ := tracker.state(); !waiting {
+		t.Fatalf("expected waiting at the next prompt")
+	}
+}
+
+func TestPromptTrackerMatchesPattern(t *testing.T) {
+	tracker := newPromptTracker(`\$ $`)
+	tracker.observe([]byte("\x1b[32muser\x1b[0m $ "))
+	if waiting, _ := tracker.state(); !waiting {
+		t.Fatalf("expected waiting at the prompt")
+	}
+	tracker.observe([]byte("make\r\n"))
+	if waiting, _ := tracker.state(); waiting {
+		t.Fatalf("expected not waiting while the command runs")
+	}
+
+	// Markers take over from the pattern once seen.
+	tracker.observe([]byte("\x1b]133;C\x07$ "))
+	if waiting, _ := tracker.state(); waiting {
+		t.Fatalf("expected markers to win over the pattern")
+	}
+}
+
+func TestPromptTrackerStripsEscapesSplitAcrossReads(t *testing.T) {
+	tracker := newPromptTracker(`user \$ $`)
+	tracker.observe([]byte("\x1b[32muser\x1b[0"))
+	tracker.observe([]byte("m $ "))
+	if waiting, _ := tracker.state(); !waiting {
+		t.Fatalf("expected waiting once the split escape is stripped")
+	}
+}
+
+func TestPromptTrackerWithoutPatternOrMarkers(t *testing.T) {
+	tracker := newPromptTracker("")
+	tracker.observe([]byte("$ "))
+	if waiting, _ := tracker.state(); waiting {
+		t.Fatalf("expected no waiting state without a pattern or markers")
+	}
+	if _, err := compilePromptPattern("("); !errors.Is(err, ErrInvalidPromptPattern) {
+		t.Fatalf("expected invalid prompt pattern error, got %v", err)
+	}
+}
+
+func TestSessionInfoReportsWaitingForInput(t *testing.T) {
+	pty := newScriptedPty()
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{PromptPattern: `> $`})
+	defer func() {
+		_ = session.Close()
+	}()
+	if got := session.CreateOptions().PromptPattern; got != `> $` {
+		t.Fatalf("expected prompt pattern in create options, got %q", got)
+	}
+
+	out, cancel := session.Subscribe()
+	defer cancel()
+	pty.Emit("ready\r\n> ")
+	if !receiveChunk(t, out, []byte("ready\r\n> ")) {
+		t.Fatalf("expected output chunk")
+	}
+	info := session.Info()
+	if !info.WaitingForInput || info.WaitingSince.IsZero() {
+		t.Fatalf("expected session waiting for input, got %v %v", info.WaitingForInput, info.WaitingSince)
+	}
+}
@@ -0,0 +1,135 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidReadiness reports an unusable ready pattern or delay.
+var ErrInvalidReadiness = errors.New("invalid readiness option")
+
+// readyTimeout bounds how long readiness detection watches output, and how
+// long initial input and WaitReady creates wait for it.
+var readyTimeout = 30 * time.Second
+
+// readyMatchWindow is how much trailing output the ready pattern is matched
+// against, so a prompt split across chunks still matches.
+const readyMatchWindow = 4096
+
+// compileReadiness validates the readiness options of a create request.
+func compileReadiness(pattern string, delay time.Duration) (*regexp.Regexp, error) {
+	if delay < 0 {
+		return nil, fmt.Errorf("%w: ready delay must not be negative", ErrInvalidReadiness)
+	}
+	if strings.TrimSpace(pattern) == "" {
+		return nil, nil
+	}
+	if delay > 0 {
+		return nil, fmt.Errorf("%w: ready pattern and ready delay are mutually exclusive", ErrInvalidReadiness)
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReadiness, err)
+	}
+	return compiled, nil
+}
+
+// Ready reports whether the session has met its readiness condition.
+// Sessions created without one are ready from the start.
+func (s *Session) Ready() bool {
+	if s == nil || s.readyCh == nil {
+		return true
+	}
+	select {
+	case <-s.readyCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitReady blocks until the session is ready or timeout passes, and reports
+// whether it became ready.
+func (s *Session) WaitReady(timeout time.Duration) bool {
+	if s == nil || s.readyCh == nil {
+		return true
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-s.readyCh:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (s *Session) markReady() {
+	s.readyOnce.Do(func() {
+		close(s.readyCh)
+	})
+}
+
+// startReadinessDetection marks the session not ready until pattern matches
+// its output or delay has passed. It must run before the session is
+// published, since readyCh is not guarded.
+func (m *Manager) startReadinessDetection(session *Session, pattern *regexp.Regexp, delay time.Duration) {
+	if session == nil || (pattern == nil && delay <= 0) {
+		return
+	}
+	session.readyCh = make(chan struct{})
+	started := time.Now()
+
+	if pattern == nil {
+		go func() {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				session.markReady()
+			case <-session.ctx.Done():
+			}
+		}()
+		return
+	}
+
+	// Subscribe before reading the buffer so nothing falls between them.
+	output, cancel := session.Subscribe()
+	window := StripANSI(strings.Join(session.OutputLines(), "\n"))
+	go func() {
+		defer cancel()
+		timer := time.NewTimer(readyTimeout)
+		defer timer.Stop()
+		for {
+			if len(window) > readyMatchWindow {
+				window = window[len(window)-readyMatchWindow:]
+			}
+			if pattern.MatchString(window) {
+				session.markReady()
+				m.logger.Debug("session ready", map[string]string{
+					"terminal_id": session.ID,
+					"after_ms":    strconv.FormatInt(time.Since(started).Milliseconds(), 10),
+				})
+				return
+			}
+			select {
+			case chunk, ok := <-output:
+				if !ok {
+					return
+				}
+				window += StripANSI(string(chunk))
+			case <-timer.C:
+				m.logger.Warn("session ready pattern not seen", map[string]string{
+					"terminal_id":   session.ID,
+					"ready_pattern": pattern.String(),
+					"timeout_ms":    strconv.FormatInt(readyTimeout.Milliseconds(), 10),
+				})
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,104 @@
+package terminal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gestalt/internal/agent"
+)
+
+func newReadinessTestManager() *Manager {
+	return NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"coder": {Name: "Coder", Shell: "/bin/bash"},
+		},
+	})
+}
+
+func TestReadyPatternGatesInitialInput(t *testing.T) {
+	manager := newReadinessTestManager()
+	session, err := manager.CreateWithOptions(CreateOptions{
+		AgentID:      "coder",
+		InitialInput: "make test",
+		ReadyPattern: `\$ $`,
+	})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	session.PublishOutputChunk([]byte("loading profile...\r\n"))
+	time.Sleep(20 * time.Millisecond)
+	if session.Info().Ready {
+		t.Fatalf("expected session not ready before the prompt")
+	}
+	if history := session.GetInputHistory(); len(history) != 0 {
+		t.Fatalf("expected input to wait for the prompt, got %v", history)
+	}
+
+	session.PublishOutputChunk([]byte("\x1b[32muser\x1b[0m $ "))
+	if !session.WaitReady(time.Second) {
+		t.Fatalf("expected session to become ready")
+	}
+	if !session.Info().Ready {
+		t.Fatalf("expected summary to report ready")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(session.GetInputHistory()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected initial input after the prompt")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReadyDelayWithWaitReady(t *testing.T) {
+	manager := newReadinessTestManager()
+	start := time.Now()
+	session, err := manager.CreateWithOptions(CreateOptions{
+		AgentID:    "coder",
+		ReadyDelay: 50 * time.Millisecond,
+		WaitReady:  true,
+	})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("expected create to wait for the ready delay, returned after %v", elapsed)
+	}
+	if !session.Info().Ready {
+		t.Fatalf("expected session to be ready")
+	}
+}
+
+func TestSessionWithoutReadinessIsReady(t *testing.T) {
+	manager := newReadinessTestManager()
+	session, err := manager.CreateWithOptions(CreateOptions{AgentID: "coder"})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+	if !session.Info().Ready {
+		t.Fatalf("expected session without readiness options to be ready")
+	}
+}
+
+func TestInvalidReadinessOptions(t *testing.T) {
+	manager := newReadinessTestManager()
+	cases := []CreateOptions{
+		{AgentID: "coder", ReadyPattern: "("},
+		{AgentID: "coder", ReadyDelay: -time.Second},
+		{AgentID: "coder", ReadyPattern: "ready", ReadyDelay: time.Second},
+	}
+	for _, options := range cases {
+		if _, err := manager.CreateWithOptions(options); !errors.Is(err, ErrInvalidReadiness) {
+			t.Fatalf("expected ErrInvalidReadiness for %+v, got %v", options, err)
+		}
+	}
+}
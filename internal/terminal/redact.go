@@ -0,0 +1,184 @@
+package terminal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"gestalt/internal/logging"
+)
+
+const (
+	redactionMask = "****"
+	// redactEnvPrefix marks a pattern that redacts the value of an environment variable.
+	redactEnvPrefix = "env:"
+	// maxRedactHoldback caps how many trailing bytes are held back waiting for
+	// the next chunk, which bounds the longest secret caught across reads.
+	maxRedactHoldback = 512
+	// redactFlushDelay is how long held-back output waits for more data before
+	// it is emitted anyway, so interactive echo is not stalled.
+	redactFlushDelay = 50 * time.Millisecond
+)
+
+// OutputRedactor scrubs secrets from session output before it is buffered,
+// logged, or streamed.
+type OutputRedactor struct {
+	patterns []*regexp.Regexp
+	literals [][]byte
+}
+
+// NewOutputRedactor compiles redact patterns. Entries of the form env:NAME
+// redact the literal value of environment variable NAME; other entries are
+// regular expressions. It returns nil when no pattern applies.
+func NewOutputRedactor(patterns []string) (*OutputRedactor, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	var literals [][]byte
+	for _, raw := range patterns {
+		pattern := strings.TrimSpace(raw)
+		if pattern == "" {
+			continue
+		}
+		if name, ok := strings.CutPrefix(pattern, redactEnvPrefix); ok {
+			value := os.Getenv(strings.TrimSpace(name))
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			compiled = append(compiled, regexp.MustCompile(regexp.QuoteMeta(value)))
+			literals = append(literals, []byte(value))
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	if len(compiled) == 0 {
+		return nil, nil
+	}
+	return &OutputRedactor{patterns: compiled, literals: literals}, nil
+}
+
+// compileRedactPatterns builds a redactor, skipping invalid patterns with a
+// warning so one bad entry does not disable redaction entirely.
+func compileRedactPatterns(patterns []string, logger *logging.Logger) *OutputRedactor {
+	valid := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if _, err := NewOutputRedactor([]string{pattern}); err != nil {
+			if logger != nil {
+				logger.Warn("ignoring invalid redact pattern", map[string]string{
+					"gestalt.category": "terminal",
+					"gestalt.source":   "backend",
+					"error":            err.Error(),
+				})
+			}
+			continue
+		}
+		valid = append(valid, pattern)
+	}
+	redactor, _ := NewOutputRedactor(valid)
+	return redactor
+}
+
+// Redact replaces every pattern match in data with the redaction mask.
+func (r *OutputRedactor) Redact(data []byte) []byte {
+	if r == nil || len(data) == 0 {
+		return data
+	}
+	for _, pattern := range r.patterns {
+		data = pattern.ReplaceAllLiteral(data, []byte(redactionMask))
+	}
+	return data
+}
+
+// redactStream applies a redactor across chunk boundaries. The trailing run of
+// non-whitespace bytes, and any tail that could begin an env: literal, is held
+// back until more output arrives (or Flush is called), so a secret split across
+// reads is matched as a whole.
+type redactStream struct {
+	redactor *OutputRedactor
+	pending  []byte
+}
+
+func newRedactStream(redactor *OutputRedactor) *redactStream {
+	if redactor == nil {
+		return nil
+	}
+	return &redactStream{redactor: redactor}
+}
+
+func (s *redactStream) Push(chunk []byte) []byte {
+	data := make([]byte, 0, len(s.pending)+len(chunk))
+	data = append(data, s.pending...)
+	data = append(data, chunk...)
+
+	cut := s.holdbackStart(data)
+	s.pending = append(s.pending[:0], data[cut:]...)
+	if cut == 0 {
+		return nil
+	}
+	return s.redactor.Redact(data[:cut])
+}
+
+func (s *redactStream) Flush() []byte {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	data := s.redactor.Redact(append([]byte(nil), s.pending...))
+	s.pending = s.pending[:0]
+	return data
+}
+
+func (s *redactStream) Pending() bool {
+	return s != nil && len(s.pending) > 0
+}
+
+func (s *redactStream) holdbackStart(data []byte) int {
+	cut := len(data)
+	limit := len(data) - maxRedactHoldback
+	if limit < 0 {
+		limit = 0
+	}
+	for cut > limit {
+		if unicode.IsSpace(rune(data[cut-1])) {
+			break
+		}
+		cut--
+	}
+	for _, literal := range s.redactor.literals {
+		if start := partialSuffixStart(data, literal, limit); start < cut {
+			cut = start
+		}
+	}
+	if cut == 0 || cut == len(data) {
+		return cut
+	}
+	// Never split a match that straddles the cut; hold it back whole.
+	for moved := true; moved && cut > 0; {
+		moved = false
+		for _, pattern := range s.redactor.patterns {
+			for _, loc := range pattern.FindAllIndex(data, -1) {
+				if loc[0] < cut && loc[1] > cut {
+					cut = loc[0]
+					moved = true
+				}
+			}
+		}
+	}
+	return cut
+}
+
+// partialSuffixStart returns where the longest proper prefix of literal that
+// ends data begins, or len(data) when no such prefix exists at or after limit.
+func partialSuffixStart(data, literal []byte, limit int) int {
+	for size := min(len(literal)-1, len(data)-limit); size > 0; size-- {
+		if bytes.HasSuffix(data, literal[:size]) {
+			return len(data) - size
+		}
+	}
+	return len(data)
+}
@@ -0,0 +1,105 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewOutputRedactorPatterns(t *testing.T) {
+	t.Setenv("GESTALT_TEST_SECRET", "hunter2")
+
+	redactor, err := NewOutputRedactor([]string{"env:GESTALT_TEST_SECRET", `sk-[a-z0-9]{8}`, "env:GESTALT_TEST_UNSET", " "})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	got := string(redactor.Redact([]byte("pw=hunter2 key=sk-abcd1234 ok")))
+	if got != "pw=**** key=**** ok" {
+		t.Fatalf("unexpected redacted output: %q", got)
+	}
+}
+
+func TestNewOutputRedactorEmpty(t *testing.T) {
+	redactor, err := NewOutputRedactor([]string{"env:GESTALT_TEST_UNSET", ""})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	if redactor != nil {
+		t.Fatalf("expected nil redactor when nothing applies")
+	}
+	if got := string(redactor.Redact([]byte("plain"))); got != "plain" {
+		t.Fatalf("expected nil redactor to pass through, got %q", got)
+	}
+}
+
+func TestNewOutputRedactorInvalidPattern(t *testing.T) {
+	if _, err := NewOutputRedactor([]string{"("}); err == nil {
+		t.Fatalf("expected invalid pattern error")
+	}
+	redactor := compileRedactPatterns([]string{"(", "secret"}, nil)
+	if redactor == nil || len(redactor.patterns) != 1 {
+		t.Fatalf("expected invalid pattern to be skipped")
+	}
+}
+
+func TestRedactStreamAcrossChunks(t *testing.T) {
+	redactor, err := NewOutputRedactor([]string{"topsecret"})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	stream := newRedactStream(redactor)
+
+	var out strings.Builder
+	out.Write(stream.Push([]byte("token: top")))
+	out.Write(stream.Push([]byte("sec")))
+	out.Write(stream.Push([]byte("ret done\n")))
+	out.Write(stream.Flush())
+
+	if got := out.String(); got != "token: **** done\n" {
+		t.Fatalf("unexpected redacted stream: %q", got)
+	}
+	if stream.Pending() {
+		t.Fatalf("expected nothing pending after flush")
+	}
+}
+
+func TestRedactStreamHoldsBackStraddlingMatch(t *testing.T) {
+	t.Setenv("GESTALT_TEST_SECRET", "secret value")
+
+	redactor, err := NewOutputRedactor([]string{"env:GESTALT_TEST_SECRET"})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	stream := newRedactStream(redactor)
+
+	first := string(stream.Push([]byte("a secret valu")))
+	second := string(stream.Push([]byte("e\n")))
+	if got := first + second + string(stream.Flush()); got != "a ****\n" {
+		t.Fatalf("unexpected redacted stream: %q", got)
+	}
+}
+
+func TestOutputPublisherRedactsAndFlushesHeldOutput(t *testing.T) {
+	redactor, err := NewOutputRedactor([]string{"topsecret"})
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	buffer := NewOutputBuffer(10)
+	publisher := NewOutputPublisher(OutputPublisherOptions{
+		Buffer:   buffer,
+		Redactor: redactor,
+	})
+	defer publisher.Close()
+
+	publisher.PublishWithContext(nil, []byte("key top"))
+	publisher.PublishWithContext(nil, []byte("secret"))
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if lines := buffer.Lines(); len(lines) > 0 && lines[len(lines)-1] == "key ****" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected held output to flush redacted, got %q", buffer.Lines())
+}
@@ -0,0 +1,143 @@
+package terminal
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultSearchLimit caps matches returned by SearchOutput when no limit
+	// is requested; MaxSearchLimit is the hard ceiling.
+	DefaultSearchLimit = 100
+	MaxSearchLimit     = 1000
+
+	searchLogLines     = 10000
+	searchSnippetWidth = 160
+)
+
+// SearchOptions controls SearchOutput.
+type SearchOptions struct {
+	Limit int
+	// IncludeLogs searches the tail of each session's persisted log instead
+	// of only its in-memory output buffer.
+	IncludeLogs bool
+}
+
+// SearchMatch is one output line matching a search query.
+type SearchMatch struct {
+	SessionID string
+	Title     string
+	// Line is the 1-based position of the match within the searched lines.
+	Line    int
+	Snippet string
+}
+
+// SearchOutput finds lines containing query, case-insensitively and with
+// ANSI sequences stripped, across every session's output. Sessions are
+// searched in ID order; truncated is true when matches hit the limit.
+func (m *Manager) SearchOutput(query string, options SearchOptions) ([]SearchMatch, bool) {
+	needle := strings.TrimSpace(query)
+	if m == nil || needle == "" {
+		return []SearchMatch{}, false
+	}
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	m.mu.RLock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	m.mu.RUnlock()
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].ID < sessions[j].ID
+	})
+
+	matches := []SearchMatch{}
+	for _, session := range sessions {
+		if session == nil {
+			continue
+		}
+		lines := session.OutputLines()
+		if options.IncludeLogs {
+			if history, err := session.HistoryLines(searchLogLines); err == nil {
+				lines = history
+			}
+		}
+		for index, line := range lines {
+			cleaned := StripANSI(line)
+			position, length := indexFold(cleaned, needle)
+			if position < 0 {
+				continue
+			}
+			if len(matches) == limit {
+				return matches, true
+			}
+			matches = append(matches, SearchMatch{
+				SessionID: session.ID,
+				Title:     session.Title,
+				Line:      index + 1,
+				Snippet:   searchSnippet(cleaned, position, length),
+			})
+		}
+	}
+	return matches, false
+}
+
+// indexFold returns the byte offset and length in s of the first
+// case-insensitive match of needle, or -1. Offsets are into s itself, since
+// case mapping can change the byte length of non-ASCII text.
+func indexFold(s, needle string) (int, int) {
+	for start := 0; start < len(s); {
+		end := start
+		matched := true
+		for _, want := range needle {
+			if end >= len(s) {
+				matched = false
+				break
+			}
+			got, size := utf8.DecodeRuneInString(s[end:])
+			if got != want && !strings.EqualFold(string(got), string(want)) {
+				matched = false
+				break
+			}
+			end += size
+		}
+		if matched {
+			return start, end - start
+		}
+		_, size := utf8.DecodeRuneInString(s[start:])
+		start += size
+	}
+	return -1, 0
+}
+
+// searchSnippet trims long lines to a window centred on the match.
+func searchSnippet(line string, position, length int) string {
+	line = strings.TrimRight(line, "\r\n")
+	if len(line) <= searchSnippetWidth {
+		return line
+	}
+	position = min(position, len(line))
+	start := max(position-(searchSnippetWidth-length)/2, 0)
+	end := min(start+searchSnippetWidth, len(line))
+	start = max(end-searchSnippetWidth, 0)
+	for start > 0 && !utf8.RuneStart(line[start]) {
+		start--
+	}
+	for end < len(line) && !utf8.RuneStart(line[end]) {
+		end++
+	}
+	snippet := line[start:end]
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(line) {
+		snippet += "…"
+	}
+	return snippet
+}
@@ -0,0 +1,59 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchOutputAcrossSessions(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	first := newSession("1", nil, nil, nil, "lead", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer first.Close()
+	second := newSession("2", nil, nil, nil, "worker", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer second.Close()
+	manager.RegisterSession(first)
+	manager.RegisterSession(second)
+
+	first.outputBuffer.Append([]byte("build ok\n\x1b[31mERROR: disk full\x1b[0m\n"))
+	second.outputBuffer.Append([]byte("error: " + strings.Repeat("x", 300) + "\n"))
+
+	matches, truncated := manager.SearchOutput("error", SearchOptions{})
+	if truncated || len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d (truncated=%t)", len(matches), truncated)
+	}
+	if matches[0].SessionID != "1" || matches[0].Title != "lead" || matches[0].Line != 2 || matches[0].Snippet != "ERROR: disk full" {
+		t.Fatalf("unexpected first match: %+v", matches[0])
+	}
+	if matches[1].SessionID != "2" || !strings.HasPrefix(matches[1].Snippet, "error: ") || !strings.HasSuffix(matches[1].Snippet, "…") {
+		t.Fatalf("unexpected second match: %+v", matches[1])
+	}
+
+	matches, truncated = manager.SearchOutput("ERROR", SearchOptions{Limit: 1})
+	if !truncated || len(matches) != 1 {
+		t.Fatalf("expected truncated single match, got %d (truncated=%t)", len(matches), truncated)
+	}
+
+	if matches, _ := manager.SearchOutput("  ", SearchOptions{}); len(matches) != 0 {
+		t.Fatalf("expected no matches for blank query, got %v", matches)
+	}
+}
+
+func TestSearchSnippetOffsetsOnOriginalText(t *testing.T) {
+	// "İ" lowercases to three bytes, so offsets taken after ToLower would
+	// point past the match in the original line.
+	line := strings.Repeat("İ", 200) + "Needle" + strings.Repeat("x", 200)
+	position, length := indexFold(line, "needle")
+	if line[position:position+length] != "Needle" {
+		t.Fatalf("expected match on the original text, got %q", line[position:position+length])
+	}
+	if snippet := searchSnippet(line, position, length); !strings.Contains(snippet, "Needle") {
+		t.Fatalf("expected snippet around the match, got %q", snippet)
+	}
+	if position, _ := indexFold("ÉCOLE", "école"); position != 0 {
+		t.Fatalf("expected case-insensitive non-ASCII match, got %d", position)
+	}
+	if position, _ := indexFold("abc", "abcd"); position != -1 {
+		t.Fatalf("expected no match, got %d", position)
+	}
+}
@@ -28,6 +28,8 @@ const (
 	sessionStateRunning
 	sessionStateClosing
 	sessionStateClosed
+	sessionStateStopped
+	sessionStatePaused
 )
 
 const dsrFallbackDelay = 250 * time.Millisecond
@@ -44,6 +46,10 @@ func (s SessionState) String() string {
 		return "closing"
 	case sessionStateClosed:
 		return "closed"
+	case sessionStateStopped:
+		return "stopped"
+	case sessionStatePaused:
+		return "paused"
 	default:
 		return "running"
 	}
@@ -61,9 +67,18 @@ type SessionMeta struct {
 	Command     string
 	Runner      string
 	ConfigHash  string
+	ParentID    string
+	NoPersist   bool
 	PromptFiles []string
 	LaunchSpec  *launchspec.LaunchSpec
-	agent       *agent.Agent
+	// AttachPID is the process a read-only attached session follows.
+	AttachPID int
+	// Encoding is the process's character encoding; empty means UTF-8.
+	Encoding string
+	// PromptPattern is the pattern the session's waiting state is matched
+	// against.
+	PromptPattern string
+	agent         *agent.Agent
 }
 
 type SessionIO struct {
@@ -75,13 +90,22 @@ type SessionIO struct {
 	cmd             *exec.Cmd
 	pid             int
 	pgid            int
+	// registryMu guards processRegistry, which the factory sets after the
+	// read loop may already have closed the session.
+	registryMu      sync.Mutex
 	processRegistry *process.Registry
+	registryClosed  bool
 	outputBus       *event.Bus[[]byte]
 	outputBuffer    *OutputBuffer
+	rawOutputBuffer *OutputBuffer
+	outputTimeline  *OutputTimeline
 	logger          *SessionLogger
 	inputBuf        *InputBuffer
 	inputLog        *InputLogger
 	historyScanMax  int64
+	throttle        *outputThrottle
+	codec           *sessionCodec
+	prompt          *promptTracker
 	subs            int32
 	dsrMu           sync.Mutex
 	dsrTimer        *time.Timer
@@ -89,6 +113,8 @@ type SessionIO struct {
 	closing         sync.Once
 	closeErr        error
 	state           uint32
+	// size holds the last resize as cols<<16 | rows; zero until resized.
+	size uint32
 }
 
 // PlanProgress records the most recent plan progress update for a session.
@@ -101,12 +127,40 @@ type PlanProgress struct {
 	UpdatedAt time.Time
 }
 
+// sessionOutputOptions configures how a session processes pty output.
+type sessionOutputOptions struct {
+	Redactor *OutputRedactor
+	// RateLimit caps output in bytes per second; zero is unlimited.
+	RateLimit int64
+	// Banner is published ahead of any process output.
+	Banner string
+	// Transform rewrites output before it is buffered; the untransformed
+	// output is kept in a second buffer for raw reads.
+	Transform OutputTransform
+	// Encoding is the process's character encoding. Output is decoded to
+	// UTF-8 before anything else sees it, and input is encoded to match.
+	Encoding string
+	// PromptPattern marks the session waiting for input while the tail of
+	// its output matches, for shells without OSC 133 prompt markers.
+	PromptPattern string
+}
+
 type Session struct {
 	SessionMeta
 	SessionIO
 	progressMu  sync.RWMutex
 	progress    PlanProgress
 	hasProgress bool
+	labelsMu    sync.RWMutex
+	labels      map[string]string
+	stopMu      sync.RWMutex
+	stopReason  string
+	// readyCh is closed once the session meets its readiness condition;
+	// nil means the session had none and is always ready.
+	readyCh   chan struct{}
+	readyOnce sync.Once
+	// bell debounces bell signals; nil lets every bell through.
+	bell *bellDebouncer
 }
 
 type SessionInfo struct {
@@ -118,13 +172,35 @@ type SessionInfo struct {
 	LLMType     string
 	Model       string
 	Interface   string
+	MCP         bool
 	Runner      string
 	Command     string
 	Skills      []string
 	PromptFiles []string
-}
-
-func newSession(id string, pty Pty, runner Runner, cmd *exec.Cmd, title, role string, createdAt time.Time, bufferLines int, historyScanMax int64, outputPolicy OutputBackpressurePolicy, outputSampleEvery uint64, profile *agent.Agent, sessionLogger *SessionLogger, inputLogger *InputLogger) *Session {
+	Labels      map[string]string
+	// StatusReason explains a stopped status, e.g. "process exited".
+	StatusReason string
+	ParentID     string
+	// NoPersist is set when the session writes nothing to disk.
+	NoPersist bool
+	// Ready is false while a ready pattern or delay is still pending.
+	Ready bool
+	// TmuxSessionName and TmuxHealthy are set by Manager.List for
+	// tmux-managed sessions; TmuxHealthy is false once the backing tmux
+	// session or window is gone and nil while its health is unknown.
+	TmuxSessionName string
+	TmuxHealthy     *bool
+	// AttachPID is set for read-only sessions following an existing process.
+	AttachPID int
+	// Encoding is the process's character encoding; empty means UTF-8.
+	Encoding string
+	// WaitingForInput is set while the session sits at a prompt, and
+	// WaitingSince is when it got there.
+	WaitingForInput bool
+	WaitingSince    time.Time
+}
+
+func newSession(id string, pty Pty, runner Runner, cmd *exec.Cmd, title, role string, createdAt time.Time, bufferLines int, historyScanMax int64, outputPolicy OutputBackpressurePolicy, outputSampleEvery uint64, profile *agent.Agent, sessionLogger *SessionLogger, inputLogger *InputLogger, output sessionOutputOptions) *Session {
 	// readLoop -> output publisher; runner handles input delivery.
 	// Close cancels context and closes runner resources.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -142,6 +218,16 @@ func newSession(id string, pty Pty, runner Runner, cmd *exec.Cmd, title, role st
 		}
 	}
 	outputBuffer := NewOutputBuffer(bufferLines)
+	var rawOutputBuffer *OutputBuffer
+	if output.Transform != nil {
+		rawOutputBuffer = NewOutputBuffer(bufferLines)
+	}
+	// A session log keeps its own timing on disk; the in-memory timeline is
+	// only for sessions without one.
+	var outputTimeline *OutputTimeline
+	if sessionLogger == nil {
+		outputTimeline = NewOutputTimeline(bufferLines * timelineBytesPerLine)
+	}
 	outputBus := event.NewBus[[]byte](ctx, event.BusOptions{
 		Name:                    "terminal_output",
 		SubscriberBufferSize:    terminalOutputSubscriberBuffer,
@@ -156,6 +242,10 @@ func newSession(id string, pty Pty, runner Runner, cmd *exec.Cmd, title, role st
 		MaxQueue:    defaultOutputQueueSize,
 		Policy:      outputPolicy,
 		SampleEvery: outputSampleEvery,
+		Redactor:    output.Redactor,
+		Transform:   output.Transform,
+		RawBuffer:   rawOutputBuffer,
+		Timeline:    outputTimeline,
 	})
 	pid := 0
 	pgid := 0
@@ -165,15 +255,17 @@ func newSession(id string, pty Pty, runner Runner, cmd *exec.Cmd, title, role st
 	}
 	session := &Session{
 		SessionMeta: SessionMeta{
-			ID:        id,
-			Title:     title,
-			Role:      role,
-			CreatedAt: createdAt,
-			LLMType:   llmType,
-			Model:     model,
-			Interface: interfaceValue,
-			Runner:    string(runnerKind),
-			agent:     profile,
+			ID:            id,
+			Title:         title,
+			Role:          role,
+			CreatedAt:     createdAt,
+			LLMType:       llmType,
+			Model:         model,
+			Interface:     interfaceValue,
+			Runner:        string(runnerKind),
+			Encoding:      strings.TrimSpace(output.Encoding),
+			PromptPattern: output.PromptPattern,
+			agent:         profile,
 		},
 		SessionIO: SessionIO{
 			ctx:             ctx,
@@ -186,10 +278,15 @@ func newSession(id string, pty Pty, runner Runner, cmd *exec.Cmd, title, role st
 			pgid:            pgid,
 			outputBus:       outputBus,
 			outputBuffer:    outputBuffer,
+			rawOutputBuffer: rawOutputBuffer,
+			outputTimeline:  outputTimeline,
 			logger:          sessionLogger,
 			inputBuf:        NewInputBuffer(DefaultInputBufferSize),
 			inputLog:        inputLogger,
 			historyScanMax:  historyScanMax,
+			throttle:        newOutputThrottle(output.RateLimit),
+			codec:           newSessionCodec(output.Encoding),
+			prompt:          newPromptTracker(output.PromptPattern),
 			state:           uint32(sessionStateStarting),
 		},
 	}
@@ -199,6 +296,9 @@ func newSession(id string, pty Pty, runner Runner, cmd *exec.Cmd, title, role st
 			_ = session.Close()
 		})
 	}
+	if banner := formatSessionBanner(output.Banner); len(banner) > 0 {
+		outputPublisher.PublishWithContext(ctx, banner)
+	}
 	if pty != nil {
 		go session.readLoop()
 	}
@@ -220,24 +320,81 @@ func (s *Session) Info() SessionInfo {
 	if interfaceValue == "" {
 		interfaceValue = agent.AgentInterfaceCLI
 	}
-	return SessionInfo{
-		ID:          s.ID,
-		Title:       s.Title,
-		Role:        s.Role,
-		CreatedAt:   s.CreatedAt,
-		Status:      s.State().String(),
-		LLMType:     s.LLMType,
-		Model:       s.Model,
-		Interface:   interfaceValue,
-		Runner:      s.Runner,
-		Command:     s.Command,
-		Skills:      skills,
-		PromptFiles: promptFiles,
+	info := SessionInfo{
+		ID:           s.ID,
+		Title:        s.Title,
+		Role:         s.Role,
+		CreatedAt:    s.CreatedAt,
+		Status:       s.State().String(),
+		LLMType:      s.LLMType,
+		Model:        s.Model,
+		Interface:    interfaceValue,
+		MCP:          s.IsMCP(),
+		Runner:       s.Runner,
+		Command:      s.Command,
+		Skills:       skills,
+		PromptFiles:  promptFiles,
+		Labels:       s.Labels(),
+		StatusReason: s.StopReason(),
+		ParentID:     s.ParentID,
+		NoPersist:    s.NoPersist,
+		Ready:        s.Ready(),
+		AttachPID:    s.AttachPID,
+		Encoding:     s.Encoding,
+	}
+	info.WaitingForInput, info.WaitingSince = s.prompt.state()
+	return info
+}
+
+// CreateOptions returns the options that would create a session configured
+// like this one.
+func (s *Session) CreateOptions() CreateOptions {
+	return CreateOptions{
+		AgentID:       s.AgentID,
+		Role:          s.Role,
+		Title:         s.Title,
+		Runner:        s.Runner,
+		Labels:        s.Labels(),
+		ParentID:      s.ParentID,
+		NoPersist:     s.NoPersist,
+		Encoding:      s.Encoding,
+		PromptPattern: s.PromptPattern,
+	}
+}
+
+// markStopped flips a running or paused session to stopped with reason. It
+// reports false if the session was neither.
+func (s *Session) markStopped(reason string) bool {
+	if s == nil {
+		return false
 	}
+	if !atomic.CompareAndSwapUint32(&s.state, uint32(sessionStateRunning), uint32(sessionStateStopped)) &&
+		!atomic.CompareAndSwapUint32(&s.state, uint32(sessionStatePaused), uint32(sessionStateStopped)) {
+		return false
+	}
+	s.stopMu.Lock()
+	s.stopReason = reason
+	s.stopMu.Unlock()
+	return true
 }
 
-func (s *Session) SendBellSignal(_ string) error {
-	return nil
+// StopReason returns why the session was marked stopped, if it was.
+func (s *Session) StopReason() string {
+	if s == nil {
+		return ""
+	}
+	s.stopMu.RLock()
+	defer s.stopMu.RUnlock()
+	return s.stopReason
+}
+
+// SendBellSignal records a bell from the session. Bells within the debounce
+// window of the last signal are suppressed and folded into the next one.
+func (s *Session) SendBellSignal(_ string) (BellSignal, error) {
+	if s == nil {
+		return BellSignal{}, ErrSessionClosed
+	}
+	return s.bell.ring(), nil
 }
 
 func (s *Session) AgentName() string {
@@ -271,6 +428,8 @@ func (s *Session) PlanProgress() (PlanProgress, bool) {
 	return s.progress, true
 }
 
+// IsMCP reports whether input is delivered through an MCP bridge rather than
+// written to the PTY. Only the CLI interface exists today, so this is false.
 func (s *Session) IsMCP() bool {
 	_ = s
 	return false
@@ -303,9 +462,12 @@ func (s *Session) Write(data []byte) (err error) {
 		return ErrSessionClosed
 	}
 	state := s.State()
-	if state == sessionStateClosing || state == sessionStateClosed {
+	if state == sessionStateClosing || state == sessionStateClosed || state == sessionStateStopped {
 		return ErrSessionClosed
 	}
+	if state == sessionStatePaused {
+		return ErrSessionPaused
+	}
 	if containsDSRResponse(data) {
 		s.clearDSRFallback()
 	}
@@ -319,7 +481,7 @@ func (s *Session) Write(data []byte) (err error) {
 		}
 	}()
 
-	if err := s.runner.Write(data); err != nil {
+	if err := s.runner.Write(s.codec.encode(data)); err != nil {
 		return err
 	}
 	return nil
@@ -333,9 +495,35 @@ func (s *Session) Resize(cols, rows uint16) error {
 	if err := s.runner.Resize(cols, rows); err != nil {
 		return fmt.Errorf("resize runner: %w", err)
 	}
+	atomic.StoreUint32(&s.size, uint32(cols)<<16|uint32(rows))
 	return nil
 }
 
+// Size returns the terminal size from the last successful resize, or zeros
+// if the session was never resized.
+func (s *Session) Size() (cols, rows uint16) {
+	if s == nil {
+		return 0, 0
+	}
+	size := atomic.LoadUint32(&s.size)
+	return uint16(size >> 16), uint16(size)
+}
+
+// OutputTimeline returns the session's output chunks with the time each was
+// published, and whether older chunks were dropped to bound memory. Sessions
+// with a session log read it back from the log and its timing file.
+func (s *Session) OutputTimeline() ([]TimedChunk, bool, error) {
+	if s == nil {
+		return nil, false, nil
+	}
+	if path := s.LogPath(); path != "" {
+		chunks, err := ReadSessionLogTimeline(path)
+		return chunks, false, err
+	}
+	chunks, dropped := s.outputTimeline.Chunks()
+	return chunks, dropped, nil
+}
+
 func (s *Session) PublishOutputChunk(chunk []byte) {
 	if s == nil || s.outputPublisher == nil || len(chunk) == 0 {
 		return
@@ -370,6 +558,87 @@ func (s *Session) OutputLines() []string {
 	return s.outputBuffer.Lines()
 }
 
+// OutputCursor returns the cursor that follows the current output lines.
+func (s *Session) OutputCursor() int64 {
+	if s == nil || s.outputBuffer == nil {
+		return 0
+	}
+	return s.outputBuffer.Cursor()
+}
+
+// OutputSince returns output lines after cursor, waiting up to wait for new
+// lines when there are none yet. It returns early when ctx is done or the
+// session closes.
+func (s *Session) OutputSince(ctx context.Context, cursor int64, wait time.Duration) ([]string, int64, string) {
+	if s == nil {
+		return []string{}, cursor, ""
+	}
+	return s.outputSince(s.outputBuffer, ctx, cursor, wait)
+}
+
+// RawOutputLines returns output as it was before the manager's output
+// transform, or the regular output when no transform is configured.
+func (s *Session) RawOutputLines() []string {
+	if s == nil {
+		return nil
+	}
+	if buffer := s.rawOutput(); buffer != nil {
+		return buffer.Lines()
+	}
+	return nil
+}
+
+// RawOutputCursor returns the cursor that follows the raw output lines.
+func (s *Session) RawOutputCursor() int64 {
+	if s == nil {
+		return 0
+	}
+	if buffer := s.rawOutput(); buffer != nil {
+		return buffer.Cursor()
+	}
+	return 0
+}
+
+// RawOutputSince is OutputSince for the untransformed output.
+func (s *Session) RawOutputSince(ctx context.Context, cursor int64, wait time.Duration) ([]string, int64, string) {
+	if s == nil {
+		return []string{}, cursor, ""
+	}
+	return s.outputSince(s.rawOutput(), ctx, cursor, wait)
+}
+
+func (s *Session) rawOutput() *OutputBuffer {
+	if s.rawOutputBuffer != nil {
+		return s.rawOutputBuffer
+	}
+	return s.outputBuffer
+}
+
+func (s *Session) outputSince(buffer *OutputBuffer, ctx context.Context, cursor int64, wait time.Duration) ([]string, int64, string) {
+	if buffer == nil {
+		return []string{}, cursor, ""
+	}
+	lines, next, partial, updated := buffer.LinesSince(cursor)
+	if len(lines) > 0 || wait <= 0 {
+		return lines, next, partial
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	var sessionDone <-chan struct{}
+	if s.ctx != nil {
+		sessionDone = s.ctx.Done()
+	}
+	select {
+	case <-updated:
+	case <-timer.C:
+	case <-ctx.Done():
+	case <-sessionDone:
+	}
+	lines, next, partial, _ = buffer.LinesSince(cursor)
+	return lines, next, partial
+}
+
 func (s *Session) hasSubscribers() bool {
 	if s == nil {
 		return false
@@ -400,6 +669,17 @@ func (s *Session) RecordInput(command string) {
 	}
 }
 
+// restoreInputHistory seeds the in-memory history with entries recovered from
+// disk without writing them back to the input log.
+func (s *Session) restoreInputHistory(entries []InputEntry) {
+	if s == nil || s.inputBuf == nil {
+		return
+	}
+	for _, entry := range entries {
+		s.inputBuf.AppendEntry(entry)
+	}
+}
+
 func (s *Session) GetInputHistory() []InputEntry {
 	if s == nil || s.inputBuf == nil {
 		return []InputEntry{}
@@ -437,13 +717,23 @@ func (s *Session) setProcessRegistry(registry *process.Registry) {
 	if s == nil {
 		return
 	}
+	s.registryMu.Lock()
+	if s.registryClosed {
+		s.registryMu.Unlock()
+		if registry != nil && s.pid > 0 {
+			registry.Unregister(s.pid)
+		}
+		return
+	}
 	s.processRegistry = registry
+	s.registryMu.Unlock()
 }
 
 func (s *Session) Close() error {
 	s.closing.Do(func() {
 		s.setState(sessionStateClosing)
 		s.clearDSRFallback()
+		s.bell.stop()
 		if s.cancel != nil {
 			s.cancel()
 		}
@@ -477,8 +767,12 @@ func (s *Session) closeResources() error {
 		if err := terminateProcessTree(s.cmd, s.pid, s.pgid, sessionProcessShutdownTimeout); err != nil {
 			errs = append(errs, fmt.Errorf("terminate process: %w", err))
 		}
-		if s.processRegistry != nil && s.pid > 0 {
-			s.processRegistry.Unregister(s.pid)
+		s.registryMu.Lock()
+		registry := s.processRegistry
+		s.registryClosed = true
+		s.registryMu.Unlock()
+		if registry != nil && s.pid > 0 {
+			registry.Unregister(s.pid)
 		}
 	}
 	if s.inputLog != nil {
@@ -511,7 +805,10 @@ func (s *Session) readLoop() {
 				}
 			}
 			dsrTail = updateDSRTail(dsrTail, chunk)
-			s.PublishOutputChunk(chunk)
+			decoded := s.codec.decode(chunk)
+			s.prompt.observe(decoded)
+			s.PublishOutputChunk(decoded)
+			s.throttle.wait(s.ctx, n)
 		}
 		if err != nil {
 			_ = s.Close()
@@ -0,0 +1,13 @@
+package terminal
+
+import "strings"
+
+// formatSessionBanner normalizes banner text to terminal line endings and
+// terminates it with a newline so process output starts on a fresh line.
+func formatSessionBanner(banner string) []byte {
+	banner = strings.TrimRight(strings.ReplaceAll(banner, "\r\n", "\n"), "\n")
+	if strings.TrimSpace(banner) == "" {
+		return nil
+	}
+	return []byte(strings.ReplaceAll(banner, "\n", "\r\n") + "\r\n")
+}
@@ -0,0 +1,54 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gestalt/internal/agent"
+)
+
+func TestFormatSessionBanner(t *testing.T) {
+	if got := formatSessionBanner("  \n"); got != nil {
+		t.Fatalf("expected blank banner to be dropped, got %q", got)
+	}
+	if got := string(formatSessionBanner("reviewer\r\ndo not paste secrets\n")); got != "reviewer\r\ndo not paste secrets\r\n" {
+		t.Fatalf("unexpected banner %q", got)
+	}
+}
+
+func TestCreateWithOptionsWritesBannerToOutputOnly(t *testing.T) {
+	factory := &captureFactory{}
+	manager := NewManager(ManagerOptions{
+		Shell:            "/bin/sh",
+		PtyFactory:       factory,
+		LivenessInterval: -1,
+		Agents: map[string]agent.Agent{
+			"reviewer": {Name: "Reviewer", Shell: "/bin/sh"},
+		},
+	})
+	session, err := manager.CreateWithOptions(CreateOptions{AgentID: "reviewer", Banner: "This is the reviewer agent\ndo not paste secrets"})
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer manager.Delete(session.ID)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		output := strings.Join(session.OutputLines(), "\n")
+		if strings.Contains(output, "This is the reviewer agent") && strings.Contains(output, "do not paste secrets") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected banner in output, got %q", output)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	factory.pty.mu.Lock()
+	writes := len(factory.pty.writes)
+	factory.pty.mu.Unlock()
+	if writes != 0 {
+		t.Fatalf("expected banner to bypass the pty, got %d writes", writes)
+	}
+}
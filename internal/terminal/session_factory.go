@@ -15,6 +15,7 @@ import (
 type SessionFactoryOptions struct {
 	Clock            Clock
 	PtyFactory       PtyFactory
+	PtyFactories     map[string]PtyFactory
 	ProcessRegistry  *process.Registry
 	SessionLogDir    string
 	InputHistoryDir  string
@@ -27,11 +28,16 @@ type SessionFactoryOptions struct {
 	NotificationSink notify.Sink
 	Logger           *logging.Logger
 	NextID           func() string
+	Redactor         *OutputRedactor
+	OutputRateLimit  int64
+	LogQuota         *sessionLogQuota
+	OutputTransform  OutputTransform
 }
 
 type SessionFactory struct {
 	clock            Clock
 	ptyFactory       PtyFactory
+	ptyFactories     map[string]PtyFactory
 	processRegistry  *process.Registry
 	sessionLogDir    string
 	inputHistoryDir  string
@@ -44,6 +50,10 @@ type SessionFactory struct {
 	notificationSink notify.Sink
 	logger           *logging.Logger
 	nextID           func() string
+	redactor         *OutputRedactor
+	outputRateLimit  int64
+	logQuota         *sessionLogQuota
+	outputTransform  OutputTransform
 }
 
 func NewSessionFactory(options SessionFactoryOptions) *SessionFactory {
@@ -65,6 +75,7 @@ func NewSessionFactory(options SessionFactoryOptions) *SessionFactory {
 	return &SessionFactory{
 		clock:            clock,
 		ptyFactory:       ptyFactory,
+		ptyFactories:     options.PtyFactories,
 		processRegistry:  options.ProcessRegistry,
 		sessionLogDir:    strings.TrimSpace(options.SessionLogDir),
 		inputHistoryDir:  strings.TrimSpace(options.InputHistoryDir),
@@ -77,9 +88,46 @@ func NewSessionFactory(options SessionFactoryOptions) *SessionFactory {
 		notificationSink: options.NotificationSink,
 		logger:           options.Logger,
 		nextID:           options.NextID,
+		redactor:         options.Redactor,
+		outputRateLimit:  options.OutputRateLimit,
+		logQuota:         options.LogQuota,
+		outputTransform:  options.OutputTransform,
 	}
 }
 
+func (f *SessionFactory) outputOptions(request sessionCreateRequest) sessionOutputOptions {
+	return sessionOutputOptions{
+		Redactor:      f.redactor,
+		RateLimit:     f.outputRateLimit,
+		Banner:        request.Banner,
+		Transform:     f.outputTransform,
+		Encoding:      request.Encoding,
+		PromptPattern: request.PromptPattern,
+	}
+}
+
+// ptyFactoryFor returns the factory the agent profile selects, falling back
+// to the default when it names none or an unregistered key.
+func (f *SessionFactory) ptyFactoryFor(request sessionCreateRequest, profile *agent.Agent) PtyFactory {
+	if profile == nil {
+		return f.ptyFactory
+	}
+	key := strings.TrimSpace(profile.PtyFactory)
+	if key == "" {
+		return f.ptyFactory
+	}
+	if factory, ok := f.ptyFactories[key]; ok && factory != nil {
+		return factory
+	}
+	if f.logger != nil {
+		f.logger.Warn("pty factory not registered; using default", map[string]string{
+			"agent_id":    request.AgentID,
+			"pty_factory": key,
+		})
+	}
+	return f.ptyFactory
+}
+
 func (f *SessionFactory) Start(request sessionCreateRequest, profile *agent.Agent, shell, reservedID string) (*Session, string, error) {
 	command, args, err := splitCommandLine(shell)
 	if err != nil {
@@ -88,7 +136,7 @@ func (f *SessionFactory) Start(request sessionCreateRequest, profile *agent.Agen
 	}
 	f.logShellCommandReady(request, reservedID, shell, command, args)
 
-	pty, cmd, err := f.ptyFactory.Start(command, args...)
+	pty, cmd, err := f.ptyFactoryFor(request, profile).Start(command, args...)
 	if err != nil {
 		err = wrapPtyStartError(err)
 		f.logShellStartError(request, reservedID, shell, command, args, err)
@@ -105,12 +153,14 @@ func (f *SessionFactory) Start(request sessionCreateRequest, profile *agent.Agen
 
 	createdAt := f.clock.Now().UTC()
 	var sessionLogger *SessionLogger
-	inputLogger := f.createInputLogger(id, profile, createdAt)
+	history := f.loadInputHistory(id, profile)
+	inputLogger := f.createInputLogger(request, id, profile, createdAt)
 
 	outputPolicy := f.outputPolicy
 	outputSample := f.outputSample
 
-	session := newSession(id, pty, nil, cmd, request.Title, request.Role, createdAt, f.bufferLines, f.historyScanMax, outputPolicy, outputSample, profile, sessionLogger, inputLogger)
+	session := newSession(id, pty, nil, cmd, request.Title, request.Role, createdAt, f.bufferLines, f.historyScanMax, outputPolicy, outputSample, profile, sessionLogger, inputLogger, f.outputOptions(request))
+	session.restoreInputHistory(history)
 	session.Command = shell
 	if request.AgentID != "" {
 		session.AgentID = request.AgentID
@@ -149,12 +199,14 @@ func (f *SessionFactory) StartExternal(request sessionCreateRequest, profile *ag
 
 	createdAt := f.clock.Now().UTC()
 	var sessionLogger *SessionLogger
-	inputLogger := f.createInputLogger(id, profile, createdAt)
+	history := f.loadInputHistory(id, profile)
+	inputLogger := f.createInputLogger(request, id, profile, createdAt)
 
 	outputPolicy := f.outputPolicy
 	outputSample := f.outputSample
 
-	session := newSession(id, nil, newExternalRunner(), nil, request.Title, request.Role, createdAt, f.bufferLines, f.historyScanMax, outputPolicy, outputSample, profile, sessionLogger, inputLogger)
+	session := newSession(id, nil, newExternalRunner(), nil, request.Title, request.Role, createdAt, f.bufferLines, f.historyScanMax, outputPolicy, outputSample, profile, sessionLogger, inputLogger, f.outputOptions(request))
+	session.restoreInputHistory(history)
 	session.Command = shell
 	if request.AgentID != "" {
 		session.AgentID = request.AgentID
@@ -173,7 +225,6 @@ func isAgentSession(request sessionCreateRequest, profile *agent.Agent) bool {
 	return strings.TrimSpace(request.AgentID) != ""
 }
 
-
 func (f *SessionFactory) logShellCommandReady(request sessionCreateRequest, reservedID, shell, command string, args []string) {
 	if f.logger == nil || !f.logger.Enabled(logging.LevelDebug) {
 		return
@@ -254,11 +305,33 @@ func (f *SessionFactory) createSessionLogger(id string, createdAt time.Time) *Se
 		}
 		return nil
 	}
+	logger.quota = f.logQuota
 	return logger
 }
 
-func (f *SessionFactory) createInputLogger(id string, profile *agent.Agent, createdAt time.Time) *InputLogger {
-	if f.inputHistoryDir == "" {
+// loadInputHistory recovers input history written by earlier sessions of the
+// same agent. Sessions without an agent are keyed by id, which is not stable
+// across restarts, so they start empty.
+func (f *SessionFactory) loadInputHistory(id string, profile *agent.Agent) []InputEntry {
+	if f.inputHistoryDir == "" || profile == nil || strings.TrimSpace(profile.Name) == "" {
+		return nil
+	}
+	history, err := loadInputHistory(f.inputHistoryDir, profile.Name, DefaultInputBufferSize, f.logger)
+	if err != nil {
+		if f.logger != nil {
+			f.logger.Warn("input history load failed", map[string]string{
+				"terminal_id": id,
+				"error":       err.Error(),
+				"path":        f.inputHistoryDir,
+			})
+		}
+		return nil
+	}
+	return history
+}
+
+func (f *SessionFactory) createInputLogger(request sessionCreateRequest, id string, profile *agent.Agent, createdAt time.Time) *InputLogger {
+	if f.inputHistoryDir == "" || request.NoPersist {
 		return nil
 	}
 	historyName := id
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"gestalt/internal/agent"
 	"gestalt/internal/logging"
 )
 
@@ -37,3 +38,33 @@ func TestSessionFactoryFiltersStderr(t *testing.T) {
 		t.Fatalf("expected stderr content preserved, got %q", stderr)
 	}
 }
+
+func TestSessionFactorySelectsAgentPtyFactory(t *testing.T) {
+	defaultFactory := &recordingFactory{}
+	remoteFactory := &recordingFactory{}
+	factory := NewSessionFactory(SessionFactoryOptions{
+		PtyFactory:   defaultFactory,
+		PtyFactories: map[string]PtyFactory{"remote": remoteFactory},
+		NextID:       func() string { return "1" },
+	})
+
+	start := func(key string) {
+		t.Helper()
+		profile := &agent.Agent{Name: "Worker", PtyFactory: key}
+		session, _, err := factory.Start(sessionCreateRequest{AgentID: "worker"}, profile, "/bin/sh", "")
+		if err != nil {
+			t.Fatalf("start session: %v", err)
+		}
+		_ = session.Close()
+	}
+
+	start("remote")
+	if remoteFactory.calls != 1 || defaultFactory.calls != 0 {
+		t.Fatalf("expected registered factory, got remote=%d default=%d", remoteFactory.calls, defaultFactory.calls)
+	}
+	start("missing")
+	start("")
+	if remoteFactory.calls != 1 || defaultFactory.calls != 2 {
+		t.Fatalf("expected default fallback, got remote=%d default=%d", remoteFactory.calls, defaultFactory.calls)
+	}
+}
@@ -0,0 +1,87 @@
+package terminal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// SessionLogFile describes one persisted session log in the session log
+// directory.
+type SessionLogFile struct {
+	SessionID string
+	Name      string
+	Size      int64
+	ModTime   time.Time
+}
+
+// SessionLogFiles lists the session log files on disk, newest first. It
+// returns nothing when persistence is disabled or nothing has been written.
+func (m *Manager) SessionLogFiles() ([]SessionLogFile, error) {
+	if m == nil || m.sessionLogs == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(m.sessionLogs)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	files := make([]SessionLogFile, 0, len(entries))
+	for _, entry := range entries {
+		// Only regular files directly in the directory; a symlink could
+		// point anywhere.
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		sessionID, ok := sessionLogTerminalID(entry.Name())
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, SessionLogFile{
+			SessionID: sessionID,
+			Name:      entry.Name(),
+			Size:      info.Size(),
+			ModTime:   info.ModTime(),
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].ModTime.Equal(files[j].ModTime) {
+			return files[i].Name < files[j].Name
+		}
+		return files[i].ModTime.After(files[j].ModTime)
+	})
+	return files, nil
+}
+
+// SessionLogTail returns the last maxLines lines of the newest log file for
+// id. Only files listed by SessionLogFiles are read, so the id cannot name
+// anything outside the session log directory.
+func (m *Manager) SessionLogTail(id string, maxLines int) (SessionLogFile, []string, error) {
+	files, err := m.SessionLogFiles()
+	if err != nil {
+		return SessionLogFile{}, nil, err
+	}
+	for _, file := range files {
+		if file.SessionID != id {
+			continue
+		}
+		lines, err := readLastLines(filepath.Join(m.sessionLogs, file.Name), maxLines, m.historyScanMax)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return SessionLogFile{}, nil, ErrSessionLogNotFound
+			}
+			return SessionLogFile{}, nil, err
+		}
+		return file, lines, nil
+	}
+	return SessionLogFile{}, nil, ErrSessionLogNotFound
+}
@@ -0,0 +1,83 @@
+package terminal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSessionLogFilesListsAndTailsLogs(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "7-20250101-000000.txt")
+	newPath := filepath.Join(dir, "7-20250102-000000.txt")
+	otherPath := filepath.Join(dir, "7-b-20250102-000000.txt")
+	for path, data := range map[string]string{
+		oldPath:   "old\n",
+		newPath:   "one\ntwo\nthree\n",
+		otherPath: "other\n",
+	} {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("write log: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.md"), []byte("skip"), 0o644); err != nil {
+		t.Fatalf("write stray file: %v", err)
+	}
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	if err := os.WriteFile(outside, []byte("secret\n"), 0o644); err != nil {
+		t.Fatalf("write outside file: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "leak-20250103-000000.txt")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	now := time.Now()
+	if err := os.Chtimes(oldPath, now.Add(-time.Hour), now.Add(-time.Hour)); err != nil {
+		t.Fatalf("set old mtime: %v", err)
+	}
+
+	manager := NewManager(ManagerOptions{
+		Shell:         "/bin/sh",
+		SessionLogDir: dir,
+	})
+	files, err := manager.SessionLogFiles()
+	if err != nil {
+		t.Fatalf("session log files: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 log files, got %+v", files)
+	}
+	if files[len(files)-1].Name != filepath.Base(oldPath) {
+		t.Fatalf("expected oldest file last, got %+v", files)
+	}
+
+	file, lines, err := manager.SessionLogTail("7", 2)
+	if err != nil {
+		t.Fatalf("session log tail: %v", err)
+	}
+	if file.Name != filepath.Base(newPath) || file.SessionID != "7" {
+		t.Fatalf("expected newest log for session 7, got %+v", file)
+	}
+	if len(lines) != 2 || lines[0] != "two" || lines[1] != "three" {
+		t.Fatalf("unexpected tail lines: %q", lines)
+	}
+
+	if _, _, err := manager.SessionLogTail("leak", 10); !errors.Is(err, ErrSessionLogNotFound) {
+		t.Fatalf("expected symlinked log to be ignored, got %v", err)
+	}
+	if _, _, err := manager.SessionLogTail("../secret", 10); !errors.Is(err, ErrSessionLogNotFound) {
+		t.Fatalf("expected not found for path id, got %v", err)
+	}
+}
+
+func TestSessionLogFilesWithoutPersistence(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh"})
+	files, err := manager.SessionLogFiles()
+	if err != nil || len(files) != 0 {
+		t.Fatalf("expected no files without persistence, got %v (%v)", files, err)
+	}
+	if _, _, err := manager.SessionLogTail("1", 10); !errors.Is(err, ErrSessionLogNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
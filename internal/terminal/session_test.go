@@ -1,7 +1,9 @@
 package terminal
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -11,7 +13,7 @@ import (
 
 func TestSessionWriteAndOutput(t *testing.T) {
 	pty := newScriptedPty()
-	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 	defer func() {
 		_ = session.Close()
 	}()
@@ -46,7 +48,7 @@ func TestSessionWriteAndOutput(t *testing.T) {
 
 func TestSessionCloseTransitionsState(t *testing.T) {
 	pty := newScriptedPty()
-	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 
 	if err := session.Close(); err != nil {
 		t.Fatalf("close session: %v", err)
@@ -58,7 +60,7 @@ func TestSessionCloseTransitionsState(t *testing.T) {
 
 func TestSessionWriteAfterClose(t *testing.T) {
 	pty := newScriptedPty()
-	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 
 	if err := session.Close(); err != nil {
 		t.Fatalf("close session: %v", err)
@@ -77,7 +79,7 @@ func TestSessionWriteAfterClose(t *testing.T) {
 
 func TestSessionWriteRoutesToRunner(t *testing.T) {
 	runner := &captureRunner{}
-	session := newSession("1", nil, runner, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", nil, runner, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 
 	if err := session.Write([]byte("ls\n")); err != nil {
 		t.Fatalf("write session: %v", err)
@@ -97,7 +99,7 @@ func TestSessionWriteRoutesToRunner(t *testing.T) {
 }
 
 func TestSessionPublishOutputWithoutPty(t *testing.T) {
-	session := newSession("1", nil, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", nil, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 	defer func() {
 		_ = session.Close()
 	}()
@@ -139,7 +141,7 @@ func (r *captureRunner) Close() error {
 
 func TestSessionAutoRespondsToCursorPosition(t *testing.T) {
 	pty := newScriptedPty()
-	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 	defer func() {
 		_ = session.Close()
 	}()
@@ -163,7 +165,7 @@ func TestSessionAutoRespondsToCursorPosition(t *testing.T) {
 
 func TestSessionFallbacksCursorPositionWithSubscriber(t *testing.T) {
 	pty := newScriptedPty()
-	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 	defer func() {
 		_ = session.Close()
 	}()
@@ -190,7 +192,7 @@ func TestSessionFallbacksCursorPositionWithSubscriber(t *testing.T) {
 
 func TestSessionRecordsInputHistory(t *testing.T) {
 	pty := newScriptedPty()
-	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil)
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
 	defer func() {
 		_ = session.Close()
 	}()
@@ -219,7 +221,7 @@ func TestSessionInfoIncludesMetadata(t *testing.T) {
 		Skills:  []string{"skill-a", "skill-b"},
 	}
 	pty := newScriptedPty()
-	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, profile, nil, nil)
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, profile, nil, nil, sessionOutputOptions{})
 	session.Command = "codex -c model=o3"
 	session.PromptFiles = []string{"prompt-a", "prompt-b"}
 	defer func() {
@@ -249,3 +251,97 @@ func TestSessionInfoIncludesMetadata(t *testing.T) {
 		t.Fatalf("unexpected prompt files: %v", info.PromptFiles)
 	}
 }
+
+func TestSessionLabelsUpdate(t *testing.T) {
+	session := &Session{}
+	if err := session.SetLabels(map[string]string{"team": "core", "ticket": "42"}); err != nil {
+		t.Fatalf("set labels: %v", err)
+	}
+
+	updated, err := session.UpdateLabels(map[string]string{"ticket": "", "stage": "review"})
+	if err != nil {
+		t.Fatalf("update labels: %v", err)
+	}
+	if len(updated) != 2 || updated["team"] != "core" || updated["stage"] != "review" {
+		t.Fatalf("unexpected labels: %#v", updated)
+	}
+
+	updated["team"] = "mutated"
+	if session.Labels()["team"] != "core" {
+		t.Fatalf("expected labels to be copied")
+	}
+
+	if _, err := session.UpdateLabels(map[string]string{"bad key": "x"}); !errors.Is(err, ErrInvalidLabels) {
+		t.Fatalf("expected ErrInvalidLabels, got %v", err)
+	}
+	if session.Labels()["stage"] != "review" {
+		t.Fatalf("expected labels unchanged after invalid update")
+	}
+}
+
+func TestSessionCreateOptionsCopiesConfig(t *testing.T) {
+	session := &Session{SessionMeta: SessionMeta{AgentID: "coder", Role: "worker", Title: "Coder", Runner: "external"}}
+	if err := session.SetLabels(map[string]string{"team": "core"}); err != nil {
+		t.Fatalf("set labels: %v", err)
+	}
+
+	options := session.CreateOptions()
+	if options.AgentID != "coder" || options.Role != "worker" || options.Title != "Coder" || options.Runner != "external" {
+		t.Fatalf("unexpected options: %+v", options)
+	}
+	options.Labels["team"] = "mutated"
+	if session.Labels()["team"] != "core" {
+		t.Fatalf("expected labels to be copied")
+	}
+}
+
+func TestValidateLabelsLimits(t *testing.T) {
+	tooMany := make(map[string]string, maxSessionLabels+1)
+	for i := 0; i <= maxSessionLabels; i++ {
+		tooMany[string(rune('a'+i%26))+string(rune('a'+i/26))] = "x"
+	}
+	cases := []map[string]string{
+		tooMany,
+		{"": "x"},
+		{strings.Repeat("k", maxSessionLabelKeyBytes+1): "x"},
+		{"key": strings.Repeat("v", maxSessionLabelValueBytes+1)},
+		{"key": "line\nbreak"},
+	}
+	for i, labels := range cases {
+		if err := ValidateLabels(labels); !errors.Is(err, ErrInvalidLabels) {
+			t.Fatalf("case %d: expected ErrInvalidLabels, got %v", i, err)
+		}
+	}
+	if err := ValidateLabels(map[string]string{"team": "core"}); err != nil {
+		t.Fatalf("expected valid labels, got %v", err)
+	}
+}
+
+func TestSessionOutputSinceWaitsForNewLines(t *testing.T) {
+	pty := newScriptedPty()
+	session := newSession("1", pty, nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer session.Close()
+
+	lines, cursor, _ := session.OutputSince(context.Background(), 0, 0)
+	if len(lines) != 0 || cursor != 0 {
+		t.Fatalf("expected no output yet, got %v %d", lines, cursor)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		pty.Emit("ready\nprompt$ ")
+	}()
+	lines, cursor, partial := session.OutputSince(context.Background(), 0, 2*time.Second)
+	if len(lines) != 1 || lines[0] != "ready" || cursor != 1 || partial != "prompt$ " {
+		t.Fatalf("expected long-poll to return new line, got %v %d %q", lines, cursor, partial)
+	}
+
+	start := time.Now()
+	lines, next, _ := session.OutputSince(context.Background(), cursor, 30*time.Millisecond)
+	if len(lines) != 0 || next != cursor {
+		t.Fatalf("expected timeout with no lines, got %v %d", lines, next)
+	}
+	if time.Since(start) < 30*time.Millisecond {
+		t.Fatalf("expected long-poll to wait for the timeout")
+	}
+}
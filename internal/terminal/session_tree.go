@@ -0,0 +1,65 @@
+package terminal
+
+import "sort"
+
+// Children returns the sessions whose ParentID is id, ordered by ID.
+func (m *Manager) Children(id string) ([]SessionInfo, error) {
+	if _, ok := m.Get(id); !ok {
+		return nil, ErrSessionNotFound
+	}
+	m.mu.RLock()
+	children := make([]SessionInfo, 0)
+	for _, session := range m.sessions {
+		if session != nil && session.ParentID == id {
+			children = append(children, session.Info())
+		}
+	}
+	m.mu.RUnlock()
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].ID < children[j].ID
+	})
+	return children, nil
+}
+
+// DeleteTree deletes id and every session descended from it, children
+// first. Without it, deleting a parent leaves its children in place with a
+// dangling ParentID.
+func (m *Manager) DeleteTree(id string) error {
+	if _, ok := m.Get(id); !ok {
+		return ErrSessionNotFound
+	}
+	for _, childID := range m.descendantIDs(id) {
+		if err := m.Delete(childID); err != nil && err != ErrSessionNotFound {
+			return err
+		}
+	}
+	return m.Delete(id)
+}
+
+// descendantIDs lists descendants of id deepest first.
+func (m *Manager) descendantIDs(id string) []string {
+	m.mu.RLock()
+	byParent := make(map[string][]string)
+	for sessionID, session := range m.sessions {
+		if session != nil && session.ParentID != "" {
+			byParent[session.ParentID] = append(byParent[session.ParentID], sessionID)
+		}
+	}
+	m.mu.RUnlock()
+
+	var ordered []string
+	visited := map[string]bool{id: true}
+	var walk func(string)
+	walk = func(parentID string) {
+		for _, childID := range byParent[parentID] {
+			if visited[childID] {
+				continue
+			}
+			visited[childID] = true
+			walk(childID)
+			ordered = append(ordered, childID)
+		}
+	}
+	walk(id)
+	return ordered
+}
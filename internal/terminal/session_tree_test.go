@@ -0,0 +1,52 @@
+package terminal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChildrenAndDeleteTree(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	register := func(id, parentID string) {
+		session := newSession(id, nil, nil, nil, id, "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+		session.ParentID = parentID
+		manager.RegisterSession(session)
+	}
+	register("lead", "")
+	register("worker-b", "lead")
+	register("worker-a", "lead")
+	register("helper", "worker-a")
+	register("other", "")
+
+	children, err := manager.Children("lead")
+	if err != nil {
+		t.Fatalf("children: %v", err)
+	}
+	if len(children) != 2 || children[0].ID != "worker-a" || children[1].ID != "worker-b" || children[0].ParentID != "lead" {
+		t.Fatalf("unexpected children: %+v", children)
+	}
+	if _, err := manager.Children("missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+
+	if err := manager.DeleteTree("lead"); err != nil {
+		t.Fatalf("delete tree: %v", err)
+	}
+	for _, id := range []string{"lead", "worker-a", "worker-b", "helper"} {
+		if _, ok := manager.Get(id); ok {
+			t.Fatalf("expected %s to be deleted", id)
+		}
+	}
+	if _, ok := manager.Get("other"); !ok {
+		t.Fatalf("expected unrelated session to remain")
+	}
+}
+
+func TestCreateWithOptionsRejectsUnknownParent(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	_, err := manager.CreateWithOptions(CreateOptions{AgentID: "codex", ParentID: "missing"})
+	if !errors.Is(err, ErrParentNotFound) {
+		t.Fatalf("expected ErrParentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package terminal
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gestalt/internal/event"
+)
+
+// StopAllReason is the stop reason recorded for sessions halted by StopAll.
+const StopAllReason = "stopped by stop-all"
+
+// StopAll sends SIGTERM to the process group of every running or paused
+// pty-backed session, follows up with SIGKILL for those still alive after
+// grace, and marks the sessions stopped. Sessions keep their metadata,
+// output, and history; deleting them is still a separate step. It returns
+// the IDs of the sessions it stopped.
+func (m *Manager) StopAll(grace time.Duration) []string {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	m.mu.RUnlock()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		stopped []string
+	)
+	for _, session := range sessions {
+		if session == nil || session.cmd == nil || session.cmd.Process == nil {
+			continue
+		}
+		// Marking first rejects further input while the process winds down.
+		if !session.markStopped(StopAllReason) {
+			continue
+		}
+		wg.Add(1)
+		go func(session *Session) {
+			defer wg.Done()
+			if err := terminateProcessTree(session.cmd, session.pid, session.pgid, grace); err != nil {
+				m.logger.Warn("stop-all terminate failed", map[string]string{
+					"gestalt.category": "terminal",
+					"gestalt.source":   "backend",
+					"session.id":       session.ID,
+					"pid":              strconv.Itoa(session.pid),
+					"error":            err.Error(),
+				})
+			}
+			if m.terminalBus != nil {
+				terminalEvent := event.NewTerminalEvent(session.ID, "terminal_stopped")
+				terminalEvent.Data = map[string]any{
+					"pid":    session.pid,
+					"reason": StopAllReason,
+				}
+				m.terminalBus.Publish(terminalEvent)
+			}
+			mu.Lock()
+			stopped = append(stopped, session.ID)
+			mu.Unlock()
+		}(session)
+	}
+	wg.Wait()
+
+	sort.Strings(stopped)
+	if len(stopped) > 0 {
+		m.logger.Warn("stop-all halted sessions", map[string]string{
+			"gestalt.category": "terminal",
+			"gestalt.source":   "backend",
+			"count":            strconv.Itoa(len(stopped)),
+		})
+	}
+	return stopped
+}
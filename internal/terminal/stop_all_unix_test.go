@@ -0,0 +1,55 @@
+//go:build !windows
+
+package terminal
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestStopAllKillsProcessesAndKeepsSessions(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+
+	cmd := exec.Command("sh", "-c", "while true; do sleep 1; done")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	session := newSession("1", newScriptedPty(), nil, cmd, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer session.Close()
+	manager.RegisterSession(session)
+
+	// Sessions without a process, such as external agents, are left alone.
+	external := newSession("2", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer external.Close()
+	manager.RegisterSession(external)
+
+	stopped := manager.StopAll(100 * time.Millisecond)
+	if len(stopped) != 1 || stopped[0] != "1" {
+		t.Fatalf("expected session 1 to be stopped, got %v", stopped)
+	}
+	if cmd.ProcessState == nil {
+		t.Fatalf("expected process to be reaped")
+	}
+
+	info := session.Info()
+	if info.Status != "stopped" || info.StatusReason != StopAllReason {
+		t.Fatalf("expected stopped session, got %q %q", info.Status, info.StatusReason)
+	}
+	if _, ok := manager.Get("1"); !ok {
+		t.Fatalf("expected stopped session to stay registered")
+	}
+	if err := session.Write([]byte("ls\n")); !errors.Is(err, ErrSessionClosed) {
+		t.Fatalf("expected write to stopped session to fail, got %v", err)
+	}
+	if status := external.Info().Status; status != "running" {
+		t.Fatalf("expected external session to keep running, got %q", status)
+	}
+
+	if again := manager.StopAll(100 * time.Millisecond); len(again) != 0 {
+		t.Fatalf("expected no sessions left to stop, got %v", again)
+	}
+}
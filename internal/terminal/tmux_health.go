@@ -0,0 +1,94 @@
+package terminal
+
+import (
+	"sync"
+	"time"
+
+	"gestalt/internal/runner/tmuxsession"
+)
+
+// tmuxHealthInterval is how long List reuses a tmux health probe before it
+// starts a new one in the background.
+const tmuxHealthInterval = 5 * time.Second
+
+// tmuxHealthCache holds the result of the last tmux probe, keyed by session
+// ID. Sessions missing from windows have unknown health.
+type tmuxHealthCache struct {
+	mu         sync.Mutex
+	interval   time.Duration
+	windows    map[string]bool
+	checkedAt  time.Time
+	refreshing bool
+}
+
+// fillTmuxHealth sets TmuxSessionName for the sessions at indexes and copies
+// their health from the last probe. A stale cache is refreshed in the
+// background, so List never waits on tmux; until a probe has answered for a
+// session, and whenever the probe fails, TmuxHealthy stays nil.
+func (m *Manager) fillTmuxHealth(infos []SessionInfo, indexes []int) {
+	tmuxSessionName, err := tmuxsession.WorkdirSessionName()
+	if err != nil {
+		return
+	}
+	ids := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		infos[index].TmuxSessionName = tmuxSessionName
+		ids = append(ids, infos[index].ID)
+	}
+
+	cache := &m.tmuxHealth
+	cache.mu.Lock()
+	windows := cache.windows
+	if !cache.refreshing && time.Since(cache.checkedAt) >= cache.interval {
+		cache.refreshing = true
+		go m.refreshTmuxHealth(tmuxSessionName, ids)
+	}
+	cache.mu.Unlock()
+
+	for _, index := range indexes {
+		if healthy, ok := windows[infos[index].ID]; ok {
+			infos[index].TmuxHealthy = &healthy
+		}
+	}
+}
+
+// refreshTmuxHealth probes tmux for ids, the same way
+// PruneMissingExternalTmuxSessions does, and replaces the cached result.
+func (m *Manager) refreshTmuxHealth(tmuxSessionName string, ids []string) {
+	windows := m.probeTmuxHealth(tmuxSessionName, ids)
+
+	cache := &m.tmuxHealth
+	cache.mu.Lock()
+	cache.windows = windows
+	cache.checkedAt = time.Now()
+	cache.refreshing = false
+	cache.mu.Unlock()
+}
+
+func (m *Manager) probeTmuxHealth(tmuxSessionName string, ids []string) map[string]bool {
+	clientFactory := m.tmuxClientFactory
+	if clientFactory == nil {
+		return nil
+	}
+	client := clientFactory()
+	if client == nil {
+		return nil
+	}
+	hasSession, err := client.HasSession(tmuxSessionName)
+	if err != nil {
+		return nil
+	}
+	windows := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !hasSession {
+			windows[id] = false
+			continue
+		}
+		exists, err := client.HasWindow(tmuxSessionName, id)
+		if err != nil {
+			continue
+		}
+		windows[id] = exists
+	}
+	return windows
+}
@@ -0,0 +1,354 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"gestalt/internal/event"
+	"gestalt/internal/logging"
+)
+
+// Headers set on every webhook delivery. The signature is
+// "sha256=" followed by the hex HMAC-SHA256 of the body, keyed by the
+// webhook's secret.
+const (
+	WebhookSignatureHeader = "X-Gestalt-Signature"
+	WebhookEventHeader     = "X-Gestalt-Event"
+	WebhookDeliveryHeader  = "X-Gestalt-Delivery"
+)
+
+const (
+	maxSessionWebhooks = 16
+	webhookQueueSize   = 64
+	webhookTimeout     = 10 * time.Second
+	webhookAttempts    = 5
+)
+
+// webhookRetryBase is the wait before the first retry; it doubles for each
+// one after.
+var webhookRetryBase = time.Second
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrWebhookLimit    = fmt.Errorf("a session can have at most %d webhooks", maxSessionWebhooks)
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+)
+
+// WebhookOptions describes a webhook to register for a session.
+type WebhookOptions struct {
+	URL string
+	// Events limits deliveries to these terminal event types; empty means
+	// every event for the session.
+	Events []string
+	// Secret keys the delivery signature; one is generated when empty.
+	Secret string
+}
+
+// SessionWebhook is a registered webhook. Secret is only filled in on the
+// value AddSessionWebhook returns.
+type SessionWebhook struct {
+	ID        string
+	SessionID string
+	URL       string
+	Events    []string
+	Secret    string
+	CreatedAt time.Time
+}
+
+type sessionWebhook struct {
+	SessionWebhook
+	secret string
+	queue  chan event.TerminalEvent
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (h *sessionWebhook) wants(eventType string) bool {
+	if len(h.Events) == 0 {
+		return true
+	}
+	for _, want := range h.Events {
+		if want == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookRegistry holds per-session webhooks and delivers the session's
+// terminal events to them. Each webhook has its own queue and worker, so
+// deliveries to one URL stay in order and a slow URL does not hold up others.
+type webhookRegistry struct {
+	mu      sync.Mutex
+	hooks   map[string][]*sessionWebhook
+	started bool
+	client  *http.Client
+	logger  *logging.Logger
+}
+
+func newWebhookRegistry(logger *logging.Logger) *webhookRegistry {
+	return &webhookRegistry{
+		hooks:  make(map[string][]*sessionWebhook),
+		client: &http.Client{Timeout: webhookTimeout},
+		logger: logger,
+	}
+}
+
+// AddSessionWebhook registers a webhook that receives the session's terminal
+// events as JSON POSTs. Webhooks are removed with the session, after the
+// terminal_closed event has been delivered.
+func (m *Manager) AddSessionWebhook(sessionID string, options WebhookOptions) (SessionWebhook, error) {
+	if _, ok := m.Get(sessionID); !ok {
+		return SessionWebhook{}, ErrSessionNotFound
+	}
+	target, err := url.Parse(strings.TrimSpace(options.URL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return SessionWebhook{}, fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	events := make([]string, 0, len(options.Events))
+	for _, eventType := range options.Events {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			events = append(events, eventType)
+		}
+	}
+	secret := options.Secret
+	if secret == "" {
+		secret = randomHex(32)
+	}
+
+	registry := m.webhooks
+	ctx, cancel := context.WithCancel(context.Background())
+	hook := &sessionWebhook{
+		SessionWebhook: SessionWebhook{
+			ID:        randomHex(8),
+			SessionID: sessionID,
+			URL:       target.String(),
+			Events:    events,
+			CreatedAt: m.clock.Now().UTC(),
+		},
+		secret: secret,
+		queue:  make(chan event.TerminalEvent, webhookQueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	registry.mu.Lock()
+	if len(registry.hooks[sessionID]) >= maxSessionWebhooks {
+		registry.mu.Unlock()
+		cancel()
+		return SessionWebhook{}, ErrWebhookLimit
+	}
+	registry.hooks[sessionID] = append(registry.hooks[sessionID], hook)
+	if !registry.started && m.terminalBus != nil {
+		registry.started = true
+		events, _ := m.terminalBus.Subscribe()
+		go registry.dispatch(events)
+	}
+	registry.mu.Unlock()
+	go registry.deliverQueued(hook)
+
+	created := hook.SessionWebhook
+	created.Secret = secret
+	return created, nil
+}
+
+// SessionWebhooks lists the webhooks registered for a session.
+func (m *Manager) SessionWebhooks(sessionID string) ([]SessionWebhook, error) {
+	if _, ok := m.Get(sessionID); !ok {
+		return nil, ErrSessionNotFound
+	}
+	registry := m.webhooks
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	hooks := make([]SessionWebhook, 0, len(registry.hooks[sessionID]))
+	for _, hook := range registry.hooks[sessionID] {
+		hooks = append(hooks, hook.SessionWebhook)
+	}
+	return hooks, nil
+}
+
+// RemoveSessionWebhook unregisters a webhook, dropping any deliveries still
+// queued or being retried.
+func (m *Manager) RemoveSessionWebhook(sessionID, webhookID string) error {
+	if _, ok := m.Get(sessionID); !ok {
+		return ErrSessionNotFound
+	}
+	registry := m.webhooks
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	hooks := registry.hooks[sessionID]
+	for i, hook := range hooks {
+		if hook.ID != webhookID {
+			continue
+		}
+		hook.cancel()
+		close(hook.queue)
+		registry.hooks[sessionID] = append(hooks[:i:i], hooks[i+1:]...)
+		if len(registry.hooks[sessionID]) == 0 {
+			delete(registry.hooks, sessionID)
+		}
+		return nil
+	}
+	return ErrWebhookNotFound
+}
+
+func (r *webhookRegistry) dispatch(events <-chan event.TerminalEvent) {
+	for terminalEvent := range events {
+		if terminalEvent.EventType == "terminal_closed" {
+			// Delivered by closeSession.
+			continue
+		}
+		r.mu.Lock()
+		hooks := r.hooks[terminalEvent.TerminalID]
+		for _, hook := range hooks {
+			if !hook.wants(terminalEvent.EventType) {
+				continue
+			}
+			select {
+			case hook.queue <- terminalEvent:
+			default:
+				r.warn("webhook queue full; event dropped", hook, map[string]string{
+					"event_type": terminalEvent.EventType,
+				})
+			}
+		}
+		r.mu.Unlock()
+	}
+}
+
+// closeSession queues closed for the session's webhooks and removes them.
+// The manager calls it directly when a session stops, so cleanup does not
+// depend on the bus, which drops events for slow subscribers.
+func (r *webhookRegistry) closeSession(closed event.TerminalEvent) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hooks := r.hooks[closed.TerminalID]
+	for _, hook := range hooks {
+		if hook.wants(closed.EventType) {
+			select {
+			case hook.queue <- closed:
+			default:
+				r.warn("webhook queue full; event dropped", hook, map[string]string{
+					"event_type": closed.EventType,
+				})
+			}
+		}
+		// The worker finishes what is queued, then exits.
+		close(hook.queue)
+	}
+	delete(r.hooks, closed.TerminalID)
+}
+
+func (r *webhookRegistry) deliverQueued(hook *sessionWebhook) {
+	defer hook.cancel()
+	for terminalEvent := range hook.queue {
+		if hook.ctx.Err() != nil {
+			continue
+		}
+		if err := r.deliver(hook, terminalEvent); err != nil && hook.ctx.Err() == nil {
+			r.warn("webhook delivery failed", hook, map[string]string{
+				"event_type": terminalEvent.EventType,
+				"error":      err.Error(),
+			})
+		}
+	}
+}
+
+type webhookPayload struct {
+	Type      string         `json:"type"`
+	SessionID string         `json:"session_id"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// deliver POSTs one event, retrying with exponential backoff on network
+// errors, 5xx, 408, and 429. Other responses are final.
+func (r *webhookRegistry) deliver(hook *sessionWebhook, terminalEvent event.TerminalEvent) error {
+	body, err := json.Marshal(webhookPayload{
+		Type:      terminalEvent.EventType,
+		SessionID: terminalEvent.TerminalID,
+		Timestamp: terminalEvent.OccurredAt,
+		Data:      terminalEvent.Data,
+	})
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(hook.secret))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	deliveryID := randomHex(8)
+
+	var lastErr error
+	for attempt := 0; attempt < webhookAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-hook.ctx.Done():
+				return hook.ctx.Err()
+			case <-time.After(webhookRetryBase << (attempt - 1)):
+			}
+		}
+		request, err := http.NewRequestWithContext(hook.ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set(WebhookEventHeader, terminalEvent.EventType)
+		request.Header.Set(WebhookDeliveryHeader, deliveryID)
+		request.Header.Set(WebhookSignatureHeader, signature)
+		response, err := r.client.Do(request)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+		if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook returned %d", response.StatusCode)
+		if response.StatusCode < http.StatusInternalServerError &&
+			response.StatusCode != http.StatusRequestTimeout &&
+			response.StatusCode != http.StatusTooManyRequests {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", webhookAttempts, lastErr)
+}
+
+func (r *webhookRegistry) warn(message string, hook *sessionWebhook, extra map[string]string) {
+	if r.logger == nil {
+		return
+	}
+	fields := map[string]string{
+		"gestalt.category": "terminal",
+		"gestalt.source":   "backend",
+		"session.id":       hook.SessionID,
+		"webhook_id":       hook.ID,
+	}
+	for key, value := range extra {
+		fields[key] = value
+	}
+	r.logger.Warn(message, fields)
+}
+
+func randomHex(size int) string {
+	buf := make([]byte, size)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,187 @@
+package terminal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gestalt/internal/event"
+)
+
+type webhookDelivery struct {
+	payload   webhookPayload
+	body      []byte
+	signature string
+	eventType string
+}
+
+func TestSessionWebhookDeliversMatchingEvents(t *testing.T) {
+	previous := webhookRetryBase
+	webhookRetryBase = time.Millisecond
+	defer func() { webhookRetryBase = previous }()
+
+	deliveries := make(chan webhookDelivery, 8)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt fails, so the event has to be retried.
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload webhookPayload
+		_ = json.Unmarshal(body, &payload)
+		deliveries <- webhookDelivery{
+			payload:   payload,
+			body:      body,
+			signature: r.Header.Get(WebhookSignatureHeader),
+			eventType: r.Header.Get(WebhookEventHeader),
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	session := newSession("1", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	manager.RegisterSession(session)
+
+	hook, err := manager.AddSessionWebhook("1", WebhookOptions{
+		URL:    server.URL,
+		Events: []string{"terminal_stopped", "terminal_closed"},
+		Secret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("add webhook: %v", err)
+	}
+	if hook.ID == "" || hook.Secret != "s3cret" {
+		t.Fatalf("unexpected webhook: %+v", hook)
+	}
+	if listed, _ := manager.SessionWebhooks("1"); len(listed) != 1 || listed[0].Secret != "" {
+		t.Fatalf("expected one listed webhook without secret, got %+v", listed)
+	}
+
+	manager.TerminalBus().Publish(event.NewTerminalEvent("1", "labels_updated"))
+	stopped := event.NewTerminalEvent("1", "terminal_stopped")
+	stopped.Data = map[string]any{"reason": "test"}
+	manager.TerminalBus().Publish(stopped)
+
+	delivery := waitForWebhookDelivery(t, deliveries)
+	if delivery.eventType != "terminal_stopped" || delivery.payload.SessionID != "1" || delivery.payload.Data["reason"] != "test" {
+		t.Fatalf("unexpected delivery: %+v", delivery)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(delivery.body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); delivery.signature != want {
+		t.Fatalf("expected signature %q, got %q", want, delivery.signature)
+	}
+
+	if err := manager.Delete("1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if delivery := waitForWebhookDelivery(t, deliveries); delivery.eventType != "terminal_closed" {
+		t.Fatalf("expected terminal_closed delivery, got %q", delivery.eventType)
+	}
+	select {
+	case extra := <-deliveries:
+		t.Fatalf("unexpected delivery of %q", extra.eventType)
+	case <-time.After(50 * time.Millisecond):
+	}
+	manager.webhooks.mu.Lock()
+	remaining := len(manager.webhooks.hooks)
+	manager.webhooks.mu.Unlock()
+	if remaining != 0 {
+		t.Fatalf("expected webhooks to be removed with the session")
+	}
+}
+
+func TestSessionWebhooksRemovedWithoutBusEvent(t *testing.T) {
+	deliveries := make(chan webhookDelivery, 8)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deliveries <- webhookDelivery{eventType: r.Header.Get(WebhookEventHeader)}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	// No bus subscriber, as if every bus event were dropped.
+	manager.webhooks.started = true
+	session := newSession("1", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	manager.RegisterSession(session)
+	if _, err := manager.AddSessionWebhook("1", WebhookOptions{URL: server.URL}); err != nil {
+		t.Fatalf("add webhook: %v", err)
+	}
+
+	if err := manager.Delete("1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if delivery := waitForWebhookDelivery(t, deliveries); delivery.eventType != "terminal_closed" {
+		t.Fatalf("expected terminal_closed delivery, got %q", delivery.eventType)
+	}
+	manager.webhooks.mu.Lock()
+	remaining := len(manager.webhooks.hooks)
+	manager.webhooks.mu.Unlock()
+	if remaining != 0 {
+		t.Fatalf("expected webhooks to be removed with the session")
+	}
+}
+
+func TestSessionWebhookValidationAndRemoval(t *testing.T) {
+	manager := NewManager(ManagerOptions{Shell: "/bin/sh", LivenessInterval: -1})
+	session := newSession("1", newScriptedPty(), nil, nil, "title", "role", time.Now(), 10, 0, OutputBackpressureBlock, 0, nil, nil, nil, sessionOutputOptions{})
+	defer session.Close()
+	manager.RegisterSession(session)
+
+	if _, err := manager.AddSessionWebhook("missing", WebhookOptions{URL: "http://example.com"}); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("expected session not found, got %v", err)
+	}
+	for _, target := range []string{"", "example.com/hook", "ftp://example.com/hook"} {
+		if _, err := manager.AddSessionWebhook("1", WebhookOptions{URL: target}); !errors.Is(err, ErrInvalidWebhook) {
+			t.Fatalf("expected %q to be rejected, got %v", target, err)
+		}
+	}
+
+	hook, err := manager.AddSessionWebhook("1", WebhookOptions{URL: "http://example.com/hook"})
+	if err != nil {
+		t.Fatalf("add webhook: %v", err)
+	}
+	if len(hook.Secret) != 64 {
+		t.Fatalf("expected a generated secret, got %q", hook.Secret)
+	}
+	for i := 1; i < maxSessionWebhooks; i++ {
+		if _, err := manager.AddSessionWebhook("1", WebhookOptions{URL: "http://example.com/hook"}); err != nil {
+			t.Fatalf("add webhook %d: %v", i, err)
+		}
+	}
+	if _, err := manager.AddSessionWebhook("1", WebhookOptions{URL: "http://example.com/hook"}); !errors.Is(err, ErrWebhookLimit) {
+		t.Fatalf("expected webhook limit, got %v", err)
+	}
+
+	if err := manager.RemoveSessionWebhook("1", hook.ID); err != nil {
+		t.Fatalf("remove webhook: %v", err)
+	}
+	if err := manager.RemoveSessionWebhook("1", hook.ID); !errors.Is(err, ErrWebhookNotFound) {
+		t.Fatalf("expected webhook not found, got %v", err)
+	}
+	if listed, _ := manager.SessionWebhooks("1"); len(listed) != maxSessionWebhooks-1 {
+		t.Fatalf("expected %d webhooks, got %d", maxSessionWebhooks-1, len(listed))
+	}
+}
+
+func waitForWebhookDelivery(t *testing.T, deliveries <-chan webhookDelivery) webhookDelivery {
+	t.Helper()
+	select {
+	case delivery := <-deliveries:
+		return delivery
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for webhook delivery")
+	}
+	return webhookDelivery{}
+}
@@ -19,9 +19,14 @@ func WatchFile(bus *event.Bus[Event], watch Watch, path string) (Handle, error)
 	}
 
 	handle, err := watch.Watch(path, func(event Event) {
+		eventType := EventTypeFileChanged
+		if event.Type == EventTypeResync || event.Type == EventTypeRenamed || event.Type == EventTypeOverflow {
+			eventType = event.Type
+		}
 		bus.Publish(Event{
-			Type:      EventTypeFileChanged,
+			Type:      eventType,
 			Path:      event.Path,
+			OldPath:   event.OldPath,
 			Op:        event.Op,
 			Timestamp: event.Timestamp,
 		})
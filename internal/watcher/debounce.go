@@ -54,6 +54,28 @@ func (debouncer *debouncer) pop(path string) (Event, bool) {
 	return entry.event, true
 }
 
+func (debouncer *debouncer) peek(path string) (Event, bool) {
+	if debouncer == nil {
+		return Event{}, false
+	}
+	entry, ok := debouncer.entries[path]
+	return entry.event, ok
+}
+
+func (debouncer *debouncer) cancel(path string) {
+	if debouncer == nil {
+		return
+	}
+	entry, ok := debouncer.entries[path]
+	if !ok {
+		return
+	}
+	if entry.timer != nil {
+		entry.timer.Stop()
+	}
+	delete(debouncer.entries, path)
+}
+
 func (debouncer *debouncer) stop() {
 	if debouncer == nil {
 		return
@@ -72,15 +94,26 @@ func (watcher *Watcher) handleEvent(event fsnotify.Event) {
 		watcher.mutex.Unlock()
 		return
 	}
+	now := time.Now()
+	oldPath := watcher.pairRenameLocked(event, now)
 	if !watcher.hasCallbacksLocked(event.Name) {
 		watcher.mutex.Unlock()
 		return
 	}
+	if watcher.suppressLocked() {
+		watcher.mutex.Unlock()
+		return
+	}
 
 	entry := Event{
 		Path:      event.Name,
 		Op:        event.Op,
-		Timestamp: time.Now().UTC(),
+		Timestamp: now.UTC(),
+	}
+	if oldPath != "" {
+		entry.Type = EventTypeRenamed
+		entry.OldPath = oldPath
+		watcher.dropPendingRenameLocked(oldPath, event.Name)
 	}
 	if watcher.debouncer != nil {
 		dropped := watcher.debouncer.schedule(event.Name, entry, watcher.flush)
@@ -106,6 +139,10 @@ func (watcher *Watcher) flush(path string) {
 		watcher.mutex.Unlock()
 		return
 	}
+	if watcher.suppressLocked() {
+		watcher.mutex.Unlock()
+		return
+	}
 	callbacks := watcher.callbacksForPathLocked(path)
 	watcher.mutex.Unlock()
 
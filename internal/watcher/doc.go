@@ -3,4 +3,16 @@
 // The Watcher API is safe for concurrent use and delivers best-effort events:
 // callers should assume events can be coalesced or dropped under load and use
 // callbacks to trigger higher-level refreshes rather than rely on exact ordering.
+//
+// Pause and Resume suspend delivery during bulk operations. Events seen while
+// paused are dropped, not queued; Resume(true) replaces them with a single
+// EventTypeResync event per callback.
+//
+// Moves are reported as one EventTypeRenamed event carrying both paths when
+// the OS correlates them; otherwise callers see the rename of the old path and
+// the create of the new one separately.
+//
+// When the OS event queue overflows, every callback receives one
+// EventTypeOverflow event for its watched path. Events were lost, so callers
+// must do a full refresh of that path rather than treat it as a single change.
 package watcher
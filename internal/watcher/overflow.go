@@ -0,0 +1,64 @@
+package watcher
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// isOverflow reports whether err is the kernel telling us its event queue
+// overflowed (IN_Q_OVERFLOW on Linux).
+func isOverflow(err error) bool {
+	return errors.Is(err, fsnotify.ErrEventOverflow)
+}
+
+// handleOverflow replaces the events the kernel dropped with one
+// EventTypeOverflow event per callback. The watches themselves are still
+// valid, so no restart is scheduled. While paused or settling the overflow is
+// recorded instead, and a resync is sent when suppression ends even if the
+// caller resumed without one: the lost events were not necessarily its own.
+func (watcher *Watcher) handleOverflow() {
+	count := atomic.AddUint64(&watcher.overflowCount, 1)
+
+	watcher.mutex.Lock()
+	if watcher.closed {
+		watcher.mutex.Unlock()
+		return
+	}
+	if watcher.suppressLocked() {
+		watcher.pauseResync = true
+		watcher.mutex.Unlock()
+		watcher.logWarn("watcher event queue overflowed while paused; resync pending", map[string]string{
+			"overflows": strconv.FormatUint(count, 10),
+		})
+		return
+	}
+	type overflowTarget struct {
+		path     string
+		callback func(Event)
+	}
+	var targets []overflowTarget
+	for path, entries := range watcher.callbacks {
+		for _, entry := range entries {
+			targets = append(targets, overflowTarget{path: path, callback: entry.callback})
+		}
+	}
+	watcher.mutex.Unlock()
+
+	watcher.logWarn("watcher event queue overflowed; consumers should resync", map[string]string{
+		"overflows": strconv.FormatUint(count, 10),
+		"callbacks": strconv.Itoa(len(targets)),
+	})
+
+	now := time.Now().UTC()
+	for _, target := range targets {
+		target.callback(Event{
+			Type:      EventTypeOverflow,
+			Path:      target.path,
+			Timestamp: now,
+		})
+	}
+}
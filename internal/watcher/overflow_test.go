@@ -0,0 +1,135 @@
+package watcher
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestOverflowDeliversEventWithoutRestart(t *testing.T) {
+	watcher, err := New()
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	dir := t.TempDir()
+	events := make(chan Event, 4)
+	if _, err := watcher.Watch(dir, func(event Event) {
+		events <- event
+	}); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	watcher.handleError(fmt.Errorf("inotify: %w", fsnotify.ErrEventOverflow))
+
+	select {
+	case event := <-events:
+		if event.Type != EventTypeOverflow || filepath.Clean(event.Path) != filepath.Clean(dir) {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected overflow event")
+	}
+
+	metrics := watcher.Metrics()
+	if metrics.Overflows != 1 || metrics.Errors != 0 {
+		t.Fatalf("expected one overflow and no errors, got %+v", metrics)
+	}
+	watcher.restartMutex.Lock()
+	timer := watcher.restartTimer
+	watcher.restartMutex.Unlock()
+	if timer != nil {
+		t.Fatal("expected overflow not to schedule a restart")
+	}
+}
+
+func TestOverflowWhilePausedBecomesResync(t *testing.T) {
+	watcher, err := New()
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	dir := t.TempDir()
+	events := make(chan Event, 4)
+	if _, err := watcher.Watch(dir, func(event Event) {
+		events <- event
+	}); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	watcher.Pause()
+	watcher.handleError(fsnotify.ErrEventOverflow)
+	watcher.Resume(true)
+
+	select {
+	case event := <-events:
+		if event.Type != EventTypeResync {
+			t.Fatalf("expected resync after paused overflow, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected resync event")
+	}
+}
+
+func TestOverflowWhilePausedForcesResync(t *testing.T) {
+	watcher, err := NewWithOptions(Options{Debounce: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	dir := t.TempDir()
+	events := make(chan Event, 4)
+	if _, err := watcher.Watch(dir, func(event Event) {
+		events <- event
+	}); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	watcher.Pause()
+	watcher.handleError(fsnotify.ErrEventOverflow)
+	watcher.Resume(false)
+
+	select {
+	case event := <-events:
+		if event.Type != EventTypeResync {
+			t.Fatalf("expected resync after paused overflow, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected resync event")
+	}
+}
+
+func TestOverflowWhileSettlingForcesResync(t *testing.T) {
+	watcher, err := NewWithOptions(Options{Debounce: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	dir := t.TempDir()
+	events := make(chan Event, 4)
+	if _, err := watcher.Watch(dir, func(event Event) {
+		events <- event
+	}); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	watcher.Pause()
+	watcher.Resume(false)
+	watcher.handleError(fsnotify.ErrEventOverflow)
+
+	select {
+	case event := <-events:
+		if event.Type != EventTypeResync {
+			t.Fatalf("expected resync after settling overflow, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected resync event")
+	}
+}
@@ -0,0 +1,148 @@
+package watcher
+
+import (
+	"strconv"
+	"time"
+)
+
+// Pause suspends event delivery until a matching Resume. Pauses nest: delivery
+// restarts only after every Pause has been resumed. Events observed while
+// paused, including debounced events already pending, are dropped rather than
+// queued; they are counted in Metrics.EventsSuppressed.
+func (watcher *Watcher) Pause() {
+	if watcher == nil {
+		return
+	}
+	watcher.mutex.Lock()
+	watcher.pauseDepth++
+	depth := watcher.pauseDepth
+	if depth == 1 {
+		if watcher.settleTimer != nil {
+			// Still settling from the previous Resume: carry its suppressed
+			// count and resync request into this pause.
+			watcher.settleTimer.Stop()
+			watcher.settleTimer = nil
+		} else {
+			watcher.pauseSuppressed = 0
+			watcher.pauseResync = false
+		}
+	}
+	watcher.mutex.Unlock()
+	if depth == 1 && watcher.logger != nil {
+		watcher.logger.Debug("watcher paused", withWatcherFields(nil))
+	}
+}
+
+// Resume ends one Pause. fsnotify reports events asynchronously, so events
+// keep being dropped for one debounce interval after the last Resume to cover
+// writes made just before it. When that settle window closes and resync was
+// requested, every registered callback receives a single EventTypeResync event
+// for its watched path if any events were suppressed during the pause or the
+// window, so consumers can rescan instead of relying on the dropped events.
+func (watcher *Watcher) Resume(resync bool) {
+	if watcher == nil {
+		return
+	}
+	watcher.mutex.Lock()
+	if watcher.pauseDepth == 0 || watcher.closed {
+		watcher.mutex.Unlock()
+		return
+	}
+	watcher.pauseDepth--
+	if watcher.pauseDepth > 0 {
+		watcher.mutex.Unlock()
+		return
+	}
+	suppressed := watcher.pauseSuppressed
+	watcher.pauseResync = watcher.pauseResync || resync
+	settle := time.Duration(0)
+	if watcher.debouncer != nil {
+		settle = watcher.debouncer.duration
+	}
+	watcher.settleGeneration++
+	generation := watcher.settleGeneration
+	watcher.settleTimer = time.AfterFunc(settle, func() {
+		watcher.endSettle(generation)
+	})
+	watcher.mutex.Unlock()
+
+	if watcher.logger != nil {
+		watcher.logger.Debug("watcher resumed", withWatcherFields(map[string]string{
+			"suppressed": strconv.FormatUint(suppressed, 10),
+			"resync":     strconv.FormatBool(resync),
+		}))
+	}
+}
+
+// endSettle closes the settle window opened by the Resume that produced
+// generation and sends the resync if one is owed.
+func (watcher *Watcher) endSettle(generation uint64) {
+	watcher.mutex.Lock()
+	if watcher.closed || watcher.pauseDepth > 0 || watcher.settleGeneration != generation {
+		watcher.mutex.Unlock()
+		return
+	}
+	watcher.settleTimer = nil
+	suppressed := watcher.pauseSuppressed
+	resync := watcher.pauseResync && suppressed > 0
+	watcher.pauseSuppressed = 0
+	watcher.pauseResync = false
+
+	type resyncTarget struct {
+		path     string
+		callback func(Event)
+	}
+	var targets []resyncTarget
+	if resync {
+		for path, entries := range watcher.callbacks {
+			for _, entry := range entries {
+				targets = append(targets, resyncTarget{path: path, callback: entry.callback})
+			}
+		}
+	}
+	watcher.mutex.Unlock()
+
+	if watcher.logger != nil {
+		watcher.logger.Debug("watcher settled", withWatcherFields(map[string]string{
+			"suppressed": strconv.FormatUint(suppressed, 10),
+			"resync":     strconv.FormatBool(resync),
+		}))
+	}
+
+	now := time.Now().UTC()
+	for _, target := range targets {
+		target.callback(Event{
+			Type:      EventTypeResync,
+			Path:      target.path,
+			Timestamp: now,
+		})
+	}
+}
+
+// Paused reports whether event delivery is currently suspended.
+func (watcher *Watcher) Paused() bool {
+	if watcher == nil {
+		return false
+	}
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+	return watcher.pauseDepth > 0
+}
+
+// WhilePaused runs fn with event delivery paused. It is meant for callers that
+// rewrite watched files themselves, so it resumes without a resync event.
+func (watcher *Watcher) WhilePaused(fn func() error) error {
+	watcher.Pause()
+	defer watcher.Resume(false)
+	return fn()
+}
+
+// suppressLocked records a dropped event when paused or settling. Callers must hold mutex.
+func (watcher *Watcher) suppressLocked() bool {
+	if watcher.pauseDepth == 0 && watcher.settleTimer == nil {
+		return false
+	}
+	watcher.pauseSuppressed++
+	watcher.eventsSuppressed++
+	return true
+}
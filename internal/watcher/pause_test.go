@@ -0,0 +1,187 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestWatcherPauseSuppressesAndResyncs(t *testing.T) {
+	watcher, err := NewWithOptions(Options{Debounce: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	path := filepath.Join(t.TempDir(), "watched.txt")
+	if err := os.WriteFile(path, []byte("initial"), 0600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	events := make(chan Event, 16)
+	handle, err := watcher.Watch(path, func(event Event) {
+		events <- event
+	})
+	if err != nil {
+		t.Fatalf("watch path: %v", err)
+	}
+	defer handle.Close()
+
+	watcher.Pause()
+	watcher.Pause()
+	if !watcher.Paused() {
+		t.Fatalf("expected watcher to be paused")
+	}
+	if err := os.WriteFile(path, []byte("update"), 0600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event while paused: %+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	watcher.Resume(true)
+	if !watcher.Paused() {
+		t.Fatalf("expected nested pause to keep watcher paused")
+	}
+	watcher.Resume(true)
+	if watcher.Paused() {
+		t.Fatalf("expected watcher to be resumed")
+	}
+
+	event, ok := waitForEvent(events)
+	if !ok {
+		t.Fatal("timed out waiting for resync event")
+	}
+	if event.Type != EventTypeResync || event.Path != path {
+		t.Fatalf("expected resync event for %q, got %+v", path, event)
+	}
+	if metrics := watcher.Metrics(); metrics.EventsSuppressed == 0 {
+		t.Fatalf("expected suppressed events to be counted")
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if err := os.WriteFile(path, []byte("again"), 0600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	event, ok = waitForEvent(events)
+	if !ok {
+		t.Fatal("timed out waiting for write event after resume")
+	}
+	if event.Type == EventTypeResync {
+		t.Fatalf("expected regular event after resume, got resync")
+	}
+}
+
+func TestWatcherWhilePausedSkipsResync(t *testing.T) {
+	watcher, err := NewWithOptions(Options{Debounce: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	path := filepath.Join(t.TempDir(), "watched.txt")
+	if err := os.WriteFile(path, []byte("initial"), 0600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	events := make(chan Event, 16)
+	handle, err := watcher.Watch(path, func(event Event) {
+		events <- event
+	})
+	if err != nil {
+		t.Fatalf("watch path: %v", err)
+	}
+	defer handle.Close()
+
+	err = watcher.WhilePaused(func() error {
+		if !watcher.Paused() {
+			t.Fatalf("expected watcher to be paused")
+		}
+		return os.WriteFile(path, []byte("rewrite"), 0600)
+	})
+	if err != nil {
+		t.Fatalf("while paused: %v", err)
+	}
+	if watcher.Paused() {
+		t.Fatalf("expected watcher to be resumed")
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event: %+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatcherResyncCoversSettleWindow(t *testing.T) {
+	watcher, err := NewWithOptions(Options{Debounce: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	path := filepath.Join(t.TempDir(), "watched.txt")
+	if err := os.WriteFile(path, []byte("initial"), 0600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	events := make(chan Event, 16)
+	handle, err := watcher.Watch(path, func(event Event) {
+		events <- event
+	})
+	if err != nil {
+		t.Fatalf("watch path: %v", err)
+	}
+	defer handle.Close()
+
+	watcher.Pause()
+	watcher.Resume(true)
+	watcher.handleEvent(fsnotify.Event{Name: path, Op: fsnotify.Write})
+
+	event, ok := waitForEvent(events)
+	if !ok {
+		t.Fatal("timed out waiting for resync event")
+	}
+	if event.Type != EventTypeResync || event.Path != path {
+		t.Fatalf("expected resync event for %q, got %+v", path, event)
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected extra event: %+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatcherResumeWithoutSuppressionSkipsResync(t *testing.T) {
+	watcher, err := NewWithOptions(Options{Debounce: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	path := filepath.Join(t.TempDir(), "watched.txt")
+	if err := os.WriteFile(path, []byte("initial"), 0600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	events := make(chan Event, 16)
+	handle, err := watcher.Watch(path, func(event Event) {
+		events <- event
+	})
+	if err != nil {
+		t.Fatalf("watch path: %v", err)
+	}
+	defer handle.Close()
+
+	watcher.Pause()
+	watcher.Resume(true)
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event: %+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -103,3 +103,33 @@ func TestWatchContextCancels(t *testing.T) {
 	case <-time.After(200 * time.Millisecond):
 	}
 }
+
+func TestWatcherWatchedPathsIncludesRecursiveDirs(t *testing.T) {
+	watcher, err := NewWithOptions(Options{WatchDir: true, WatchRecursive: true})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	dir := t.TempDir()
+	nestedDir := filepath.Join(dir, "a")
+	if err := os.MkdirAll(nestedDir, 0o755); err != nil {
+		t.Fatalf("create nested dir: %v", err)
+	}
+	handle, err := watcher.Watch(dir, func(Event) {})
+	if err != nil {
+		t.Fatalf("watch dir: %v", err)
+	}
+
+	paths := watcher.WatchedPaths()
+	if len(paths) != 2 || paths[0] != dir || paths[1] != nestedDir {
+		t.Fatalf("expected %q and %q, got %v", dir, nestedDir, paths)
+	}
+
+	if err := handle.Close(); err != nil {
+		t.Fatalf("close handle: %v", err)
+	}
+	if paths := watcher.WatchedPaths(); len(paths) != 0 {
+		t.Fatalf("expected no watched paths after close, got %v", paths)
+	}
+}
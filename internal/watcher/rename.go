@@ -0,0 +1,57 @@
+package watcher
+
+import (
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// renamePairWindow bounds how long after a Rename a Create may arrive and
+// still be taken as the other half of the same move.
+const renamePairWindow = 50 * time.Millisecond
+
+// pendingRename is the last Rename event seen, waiting for its Create.
+type pendingRename struct {
+	path string
+	at   time.Time
+}
+
+// pairRenameLocked correlates the two events fsnotify reports for a move: a
+// Rename for the old path followed directly by a Create for the new one
+// (inotify queues IN_MOVED_FROM and IN_MOVED_TO back to back, and Windows
+// reports the old and new names together). It returns the old path when
+// event completes such a pair. Any other event in between, or a Create later
+// than renamePairWindow, leaves the two unpaired. Callers must hold mutex.
+func (watcher *Watcher) pairRenameLocked(event fsnotify.Event, now time.Time) string {
+	previous := watcher.lastRename
+	watcher.lastRename = pendingRename{}
+	if event.Has(fsnotify.Rename) {
+		watcher.lastRename = pendingRename{path: event.Name, at: now}
+		return ""
+	}
+	if !event.Has(fsnotify.Create) || previous.path == "" || previous.path == event.Name {
+		return ""
+	}
+	if now.Sub(previous.at) > renamePairWindow {
+		return ""
+	}
+	return previous.path
+}
+
+// dropPendingRenameLocked discards the queued rename-away event for oldPath
+// when the same callbacks will receive the correlated EventTypeRenamed for
+// newPath, so they see one event instead of a delete/create pair.
+func (watcher *Watcher) dropPendingRenameLocked(oldPath, newPath string) {
+	if watcher.debouncer == nil {
+		return
+	}
+	pending, ok := watcher.debouncer.peek(oldPath)
+	if !ok || pending.Op != fsnotify.Rename {
+		return
+	}
+	if !slices.Equal(watcher.callbackIDsForPathLocked(oldPath), watcher.callbackIDsForPathLocked(newPath)) {
+		return
+	}
+	watcher.debouncer.cancel(oldPath)
+}
@@ -0,0 +1,99 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestPairRenameMatchesAdjacentCreate(t *testing.T) {
+	watcher := &Watcher{}
+	now := time.Now()
+	if from := watcher.pairRenameLocked(fsnotify.Event{Name: "/tmp/old.txt", Op: fsnotify.Rename}, now); from != "" {
+		t.Fatalf("expected rename alone to be unpaired, got %q", from)
+	}
+	if from := watcher.pairRenameLocked(fsnotify.Event{Name: "/tmp/new.txt", Op: fsnotify.Create}, now); from != "/tmp/old.txt" {
+		t.Fatalf("expected create to pair with rename, got %q", from)
+	}
+	if from := watcher.pairRenameLocked(fsnotify.Event{Name: "/tmp/other.txt", Op: fsnotify.Create}, now); from != "" {
+		t.Fatalf("expected a rename to pair only once, got %q", from)
+	}
+}
+
+func TestPairRenameIgnoresUncorrelatedEvents(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name  string
+		next  fsnotify.Event
+		delay time.Duration
+	}{
+		{name: "write in between", next: fsnotify.Event{Name: "/tmp/new.txt", Op: fsnotify.Write}},
+		{name: "same path", next: fsnotify.Event{Name: "/tmp/old.txt", Op: fsnotify.Create}},
+		{name: "too late", next: fsnotify.Event{Name: "/tmp/new.txt", Op: fsnotify.Create}, delay: renamePairWindow + time.Millisecond},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			watcher := &Watcher{}
+			watcher.pairRenameLocked(fsnotify.Event{Name: "/tmp/old.txt", Op: fsnotify.Rename}, now)
+			from := watcher.pairRenameLocked(tc.next, now.Add(tc.delay))
+			if tc.next.Op == fsnotify.Write {
+				from = watcher.pairRenameLocked(fsnotify.Event{Name: "/tmp/new.txt", Op: fsnotify.Create}, now)
+			}
+			if from != "" {
+				t.Fatalf("expected no rename source, got %q", from)
+			}
+		})
+	}
+	watcher := &Watcher{}
+	if from := watcher.pairRenameLocked(fsnotify.Event{Name: "/tmp/new.txt", Op: fsnotify.Create}, now); from != "" {
+		t.Fatalf("expected plain create to be unpaired, got %q", from)
+	}
+}
+
+func TestWatcherCorrelatesRenameInDirectory(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("rename correlation relies on inotify reporting both halves of a move in order")
+	}
+	watcher, err := NewWithOptions(Options{WatchDir: true, Debounce: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "old.txt")
+	newPath := filepath.Join(dir, "new.txt")
+	if err := os.WriteFile(oldPath, []byte("data"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	events := make(chan Event, 8)
+	handle, err := watcher.Watch(dir, func(event Event) {
+		events <- event
+	})
+	if err != nil {
+		t.Fatalf("watch dir: %v", err)
+	}
+	defer handle.Close()
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+
+	event, ok := waitForEvent(events)
+	if !ok {
+		t.Fatal("timed out waiting for rename event")
+	}
+	if event.Type != EventTypeRenamed || event.Path != newPath || event.OldPath != oldPath {
+		t.Fatalf("expected correlated rename %q -> %q, got %+v", oldPath, newPath, event)
+	}
+	select {
+	case extra := <-events:
+		t.Fatalf("expected a single event, also got %+v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
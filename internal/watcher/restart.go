@@ -11,6 +11,10 @@ func (watcher *Watcher) handleError(err error) {
 	if err == nil {
 		return
 	}
+	if isOverflow(err) {
+		watcher.handleOverflow()
+		return
+	}
 	atomic.AddUint64(&watcher.errorCount, 1)
 	watcher.logWarn("watcher error", map[string]string{
 		"error": err.Error(),
@@ -13,12 +13,24 @@ const (
 	EventTypeFileChanged      = "file-change"
 	EventTypeGitBranchChanged = "git-branch"
 	EventTypeWatchError       = "watch_error"
+	// EventTypeResync tells callbacks to rescan after a paused period.
+	EventTypeResync = "resync"
+	// EventTypeRenamed reports a move within watched paths; OldPath holds the
+	// source. Correlation is best-effort and only happens where the OS pairs
+	// both halves (inotify, ReadDirectoryChangesW); otherwise a move arrives
+	// as a rename of the old path followed by a create of the new one.
+	EventTypeRenamed = "file-rename"
+	// EventTypeOverflow reports that the OS dropped events because its queue
+	// overflowed. Path is the watched path, not a changed file; callers should
+	// treat it as "anything under Path may have changed" and do a full refresh.
+	EventTypeOverflow = "overflow"
 )
 
 // Event represents a single filesystem change.
 type Event struct {
 	Type      string
 	Path      string
+	OldPath   string
 	Op        fsnotify.Op
 	Timestamp time.Time
 }
@@ -53,7 +65,11 @@ type Metrics struct {
 	ActiveWatches   int
 	EventsDelivered uint64
 	EventsDropped   uint64
-	Errors          uint64
+	// EventsSuppressed counts events dropped while the watcher was paused.
+	EventsSuppressed uint64
+	Errors           uint64
+	// Overflows counts kernel event queue overflows.
+	Overflows       uint64
 	RestartAttempts int
 }
 
@@ -78,8 +94,16 @@ type Watcher struct {
 	eventsDelivered   uint64
 	eventsDropped     uint64
 	errorCount        uint64
+	overflowCount     uint64
 	errorHandler      func(error)
 	restartMutex      sync.Mutex
 	restartAttempts   int
 	restartTimer      *time.Timer
+	pauseDepth        int
+	pauseSuppressed   uint64
+	lastRename        pendingRename
+	pauseResync       bool
+	settleTimer       *time.Timer
+	settleGeneration  uint64
+	eventsSuppressed  uint64
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 )
@@ -214,21 +215,41 @@ func (watcher *Watcher) hasCallbacksLocked(path string) bool {
 }
 
 func (watcher *Watcher) callbacksForPathLocked(path string) []func(Event) {
+	entries := watcher.entriesForPathLocked(path)
+	if entries == nil {
+		return nil
+	}
+	callbacks := make([]func(Event), 0, len(entries))
+	for _, entry := range entries {
+		callbacks = append(callbacks, entry.callback)
+	}
+	return callbacks
+}
+
+// callbackIDsForPathLocked returns the sorted ids of the callbacks that
+// receive events for path.
+func (watcher *Watcher) callbackIDsForPathLocked(path string) []uint64 {
+	entries := watcher.entriesForPathLocked(path)
+	ids := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (watcher *Watcher) entriesForPathLocked(path string) []callbackEntry {
 	if watcher == nil {
 		return nil
 	}
 	if entries := watcher.callbacks[path]; len(entries) > 0 {
-		callbacks := make([]func(Event), 0, len(entries))
-		for _, entry := range entries {
-			callbacks = append(callbacks, entry.callback)
-		}
-		return callbacks
+		return entries
 	}
 	if !watcher.watchDirRecursive {
 		return nil
 	}
 
-	callbacks := []func(Event){}
+	matched := []callbackEntry{}
 	for watchPath, entries := range watcher.callbacks {
 		if !hasDirWatch(entries) {
 			continue
@@ -236,11 +257,9 @@ func (watcher *Watcher) callbacksForPathLocked(path string) []func(Event) {
 		if !isWithinPath(watchPath, path) {
 			continue
 		}
-		for _, entry := range entries {
-			callbacks = append(callbacks, entry.callback)
-		}
+		matched = append(matched, entries...)
 	}
-	return callbacks
+	return matched
 }
 
 func hasDirWatch(entries []callbackEntry) bool {
@@ -267,3 +286,26 @@ func isWithinPath(parent, child string) bool {
 	}
 	return true
 }
+
+// WatchedPaths returns the sorted paths registered with the underlying
+// watcher, both explicit watches and directories added by recursive walks.
+func (watcher *Watcher) WatchedPaths() []string {
+	if watcher == nil {
+		return nil
+	}
+	watcher.mutex.Lock()
+	paths := make([]string, 0, len(watcher.callbacks)+len(watcher.recursiveWatches))
+	for path, entries := range watcher.callbacks {
+		if len(entries) > 0 {
+			paths = append(paths, path)
+		}
+	}
+	for path, count := range watcher.recursiveWatches {
+		if count > 0 && len(watcher.callbacks[path]) == 0 {
+			paths = append(paths, path)
+		}
+	}
+	watcher.mutex.Unlock()
+	slices.Sort(paths)
+	return paths
+}
@@ -86,6 +86,10 @@ func (watcher *Watcher) Close() error {
 		return nil
 	}
 	watcher.closed = true
+	if watcher.settleTimer != nil {
+		watcher.settleTimer.Stop()
+		watcher.settleTimer = nil
+	}
 	if watcher.debouncer != nil {
 		watcher.debouncer.stop()
 		watcher.debouncer = nil
@@ -190,15 +194,18 @@ func (watcher *Watcher) Metrics() Metrics {
 	}
 	watcher.mutex.Lock()
 	active := watcher.activeWatches
+	suppressed := watcher.eventsSuppressed
 	watcher.mutex.Unlock()
 	watcher.restartMutex.Lock()
 	restartAttempts := watcher.restartAttempts
 	watcher.restartMutex.Unlock()
 	return Metrics{
-		ActiveWatches:   active,
-		EventsDelivered: atomic.LoadUint64(&watcher.eventsDelivered),
-		EventsDropped:   atomic.LoadUint64(&watcher.eventsDropped),
-		Errors:          atomic.LoadUint64(&watcher.errorCount),
-		RestartAttempts: restartAttempts,
+		ActiveWatches:    active,
+		EventsDelivered:  atomic.LoadUint64(&watcher.eventsDelivered),
+		EventsDropped:    atomic.LoadUint64(&watcher.eventsDropped),
+		EventsSuppressed: suppressed,
+		Errors:           atomic.LoadUint64(&watcher.errorCount),
+		Overflows:        atomic.LoadUint64(&watcher.overflowCount),
+		RestartAttempts:  restartAttempts,
 	}
 }